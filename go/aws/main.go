@@ -10,17 +10,26 @@ func main() {
 
 	// Add subnets command with nested sub-commands
 	app.SubCommand("subnets", aws.SubnetsRouter,
-		gofr.AddDescription("Manage AWS subnets - list, delete, or check dependencies"),
+		gofr.AddDescription("Manage AWS subnets - list, delete, check dependencies, or prune empty subnets"),
 		gofr.AddHelp("Usage: aws subnets [COMMAND]\n"+
 			"Commands:\n"+
-			"  list               List all subnets in a VPC (default)\n"+
+			"  list               List all subnets in a VPC, or in every VPC with --all (default)\n"+
 			"  delete             Delete a subnet by ID\n"+
-			"  check-dependencies Check what resources are preventing subnet deletion\n\n"+
+			"  check-dependencies Check what resources are preventing subnet deletion\n"+
+			"  move-enis          Report the network interfaces in a subnet by owner, with how to move them\n"+
+			"  prune              Find and delete subnets with no dependencies\n\n"+
 			"Examples:\n"+
 			"  aws subnets --vpc vpc-12345678\n"+
 			"  aws subnets list --vpc vpc-12345678\n"+
+			"  aws subnets list --vpc vpc-12345678 --state available\n"+
+			"  aws subnets list --all\n"+
 			"  aws subnets delete --subnet-id subnet-12345678\n"+
-			"  aws subnets check-dependencies --subnet-id subnet-12345678"),
+			"  aws subnets delete --subnet-id subnet-12345678 --force --detach-dependencies\n"+
+			"  aws subnets check-dependencies --subnet-id subnet-12345678\n"+
+			"  aws subnets check-dependencies --subnet-id subnet-12345678 --explain\n"+
+			"  aws subnets move-enis --subnet-id subnet-12345678\n"+
+			"  aws subnets prune --vpc vpc-12345678\n"+
+			"  aws subnets --vpc vpc-12345678 --verbose"),
 	)
 
 	// Add nlb command with nested sub-commands
@@ -29,18 +38,38 @@ func main() {
 		gofr.AddHelp("Usage: aws nlb [COMMAND]\n"+
 			"Commands:\n"+
 			"  list               List all Network Load Balancers in a VPC (default)\n"+
+			"  describe           Show detailed information for a single NLB\n"+
 			"  add-subnet         Add subnets from a zone to NLBs in a VPC\n"+
 			"  remove-subnet      Remove a subnet from NLBs in a VPC and zone\n"+
-			"  check-associations Check for service associations that might prevent subnet removal\n\n"+
+			"  check-associations Check for service associations that might prevent subnet removal\n"+
+			"  check-zones        Flag NLBs that are missing availability zones other NLBs in the VPC cover\n\n"+
 			"Examples:\n"+
 			"  aws nlb --vpc vpc-12345678\n"+
 			"  aws nlb list --vpc vpc-12345678\n"+
 			"  aws nlb list --vpc vpc-12345678 --zone us-east-1a\n"+
+			"  aws nlb list --vpc vpc-12345678 --state active\n"+
 			"  aws nlb list --vpc vpc-12345678 --sort state\n"+
+			"  aws nlb list --vpc vpc-12345678 --with-details\n"+
 			"  aws nlb add-subnet --vpc vpc-12345678 --zone us-east-1b\n"+
+			"  aws nlb describe --vpc vpc-12345678 --nlb-name my-nlb\n"+
 			"  aws nlb check-associations --vpc vpc-12345678\n"+
+			"  aws nlb check-zones --vpc vpc-12345678\n"+
 			"  aws nlb remove-subnet --vpc vpc-12345678 --zone us-east-1a\n"+
-			"  aws nlb remove-subnet --vpc vpc-12345678 --zone us-east-1a --nlb-name my-nlb"),
+			"  aws nlb remove-subnet --vpc vpc-12345678 --zone us-east-1a --nlb-name my-nlb\n"+
+			"  aws nlb check-associations --vpc vpc-12345678 --verbose"),
+	)
+
+	// Add alb command with nested sub-commands
+	app.SubCommand("alb", aws.ALBRouter,
+		gofr.AddDescription("List AWS Application Load Balancers"),
+		gofr.AddHelp("Usage: aws alb [COMMAND]\n"+
+			"Commands:\n"+
+			"  list               List all Application Load Balancers in a VPC (default)\n\n"+
+			"Examples:\n"+
+			"  aws alb --vpc vpc-12345678\n"+
+			"  aws alb list --vpc vpc-12345678\n"+
+			"  aws alb list --vpc vpc-12345678 --zone us-east-1a\n"+
+			"  aws alb list --vpc vpc-12345678 --with-details"),
 	)
 
 	// Add ecr command with nested sub-commands
@@ -48,7 +77,14 @@ func main() {
 		gofr.AddDescription("Manage AWS ECR repositories - list image versions and tags"),
 		gofr.AddHelp("Usage: aws ecr [COMMAND]\n"+
 			"Commands:\n"+
-			"  list               List all image versions in an ECR repository (default)\n\n"+
+			"  list               List all image versions in an ECR repository (default)\n"+
+			"  repos              List repositories with their tagged and untagged image counts\n"+
+			"  untag              Remove a tag from an image without deleting the image\n"+
+			"  delete             Delete images by tag or digest\n"+
+			"  prune              Delete untagged images, keeping the newest N per repository\n"+
+			"  scan               Summarize an image's vulnerability scan findings by severity\n"+
+			"  compare            Check whether two tags point at the same image digest\n"+
+			"  get-login          Print the registry password for docker login\n\n"+
 			"Examples:\n"+
 			"  aws ecr --repository my-repo\n"+
 			"  aws ecr list --repository my-repo\n"+
@@ -57,10 +93,24 @@ func main() {
 			"  aws ecr list --repository my-repo --sort size\n"+
 			"  aws ecr --all\n"+
 			"  aws ecr list --all --tag latest\n"+
+			"  aws ecr --all --repository-prefix team-a/\n"+
+			"  aws ecr --all --repository-regex '^team-(a|b)/service-.*$'\n"+
 			"  aws ecr --repository my-repo --older-than latest\n"+
 			"  aws ecr --all --older-than v1.0\n"+
 			"  aws ecr --repository my-repo --output yaml\n"+
-			"  aws ecr --all --output yaml"),
+			"  aws ecr --all --output yaml\n"+
+			"  aws ecr --all --verbose\n"+
+			"  aws ecr repos\n"+
+			"  aws ecr repos --repository-prefix team-a/\n"+
+			"  aws ecr untag --repository my-repo --tag old-release\n"+
+			"  aws ecr delete --repository my-repo --tag old-release --digest sha256:abc123...\n"+
+			"  aws ecr prune --repository my-repo --keep-last 5 --dry-run\n"+
+			"  aws ecr prune --all --keep-last 5 --force\n"+
+			"  aws ecr scan --repository my-repo --tag v1.0\n"+
+			"  aws ecr compare --repository my-repo --tag-a staging --tag-b prod\n"+
+			"  aws ecr compare --repository staging/app --tag-a v1.2.0 --repo-b prod/app --tag-b v1.2.0\n"+
+			"  aws ecr get-login | docker login --username AWS --password-stdin 123456789012.dkr.ecr.us-east-1.amazonaws.com\n"+
+			"  aws ecr get-login --registry-id 210987654321 --print-command"),
 	)
 
 	app.Run()
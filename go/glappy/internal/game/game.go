@@ -30,8 +30,76 @@ const (
 	PipeGapSize   = 100
 	PipeSpeed     = 5
 	PipeSpawnDist = 300
+
+	// CountdownFrames is the 3-2-1 countdown before play starts or restarts, in frames (60 per second)
+	CountdownFrames = 180
+
+	// Minimum scores for each medal
+	BronzeMedalScore   = 10
+	SilverMedalScore   = 20
+	GoldMedalScore     = 30
+	PlatinumMedalScore = 40
 )
 
+// Medal is the award earned for a final score
+type Medal int
+
+const (
+	MedalNone Medal = iota
+	MedalBronze
+	MedalSilver
+	MedalGold
+	MedalPlatinum
+)
+
+// MedalForScore returns the highest medal whose threshold the score reaches
+func MedalForScore(score int) Medal {
+	switch {
+	case score >= PlatinumMedalScore:
+		return MedalPlatinum
+	case score >= GoldMedalScore:
+		return MedalGold
+	case score >= SilverMedalScore:
+		return MedalSilver
+	case score >= BronzeMedalScore:
+		return MedalBronze
+	default:
+		return MedalNone
+	}
+}
+
+// String returns the medal name
+func (m Medal) String() string {
+	switch m {
+	case MedalBronze:
+		return "Bronze"
+	case MedalSilver:
+		return "Silver"
+	case MedalGold:
+		return "Gold"
+	case MedalPlatinum:
+		return "Platinum"
+	default:
+		return "None"
+	}
+}
+
+// Color returns the color the medal is drawn with
+func (m Medal) Color() color.RGBA {
+	switch m {
+	case MedalBronze:
+		return color.RGBA{205, 127, 50, 255}
+	case MedalSilver:
+		return color.RGBA{192, 192, 192, 255}
+	case MedalGold:
+		return color.RGBA{255, 215, 0, 255}
+	case MedalPlatinum:
+		return color.RGBA{229, 228, 226, 255}
+	default:
+		return color.RGBA{0, 0, 0, 0}
+	}
+}
+
 // Pipe represents a pipe obstacle
 type Pipe struct {
 	X       float64
@@ -61,7 +129,12 @@ func (p *Pipe) Speed() float64 {
 
 // Update moves the pipe to the left
 func (p *Pipe) Update() {
-	p.X -= p.speed
+	p.Move(1)
+}
+
+// Move moves the pipe to the left at factor times its speed
+func (p *Pipe) Move(factor float64) {
+	p.X -= p.speed * factor
 }
 
 // GetTopRect returns the top pipe's collision rectangle
@@ -98,6 +171,25 @@ type GameState struct {
 	Score     int
 	GameOver  bool
 	LastSpawn float64
+	// Medal is the medal earned by the last finished game
+	Medal Medal
+	// HighScore and BestMedal carry over between restarts
+	HighScore int
+	BestMedal Medal
+
+	// PowerUpsEnabled spawns occasional power-ups in pipe gaps
+	PowerUpsEnabled bool
+	PowerUps        []*PowerUp
+	// Shield is set while a collected shield is waiting to absorb a collision
+	Shield bool
+	// ShieldGrace counts the frames of invulnerability left after the shield absorbed a hit,
+	// long enough for the bird to clear the pipe it hit
+	ShieldGrace int
+	// SlowMotion counts the frames of slow motion left
+	SlowMotion int
+	// Countdown counts the frames left before play starts; the bird and pipes are frozen
+	// until it reaches zero
+	Countdown int
 }
 
 // NewGameState creates a new game state instance
@@ -108,6 +200,7 @@ func NewGameState() *GameState {
 		Score:     0,
 		GameOver:  false,
 		LastSpawn: 0,
+		Countdown: CountdownFrames,
 	}
 }
 
@@ -118,6 +211,87 @@ func (g *GameState) Restart() {
 	g.Score = 0
 	g.GameOver = false
 	g.LastSpawn = 0
+	g.Medal = MedalNone
+	g.PowerUps = nil
+	g.Shield = false
+	g.ShieldGrace = 0
+	g.SlowMotion = 0
+	g.Countdown = CountdownFrames
+}
+
+// CountingDown reports whether the countdown before play is still running
+func (g *GameState) CountingDown() bool {
+	return g.Countdown > 0
+}
+
+// CountdownNumber returns the number to show during the countdown: 3, 2, then 1
+func (g *GameState) CountdownNumber() int {
+	return (g.Countdown + 59) / 60
+}
+
+// TickCountdown counts the countdown down by one frame
+func (g *GameState) TickCountdown() {
+	if g.Countdown > 0 {
+		g.Countdown--
+	}
+}
+
+// CollectPowerUp applies the effect of a collected power-up
+func (g *GameState) CollectPowerUp(p *PowerUp) {
+	switch p.Kind {
+	case PowerUpShield:
+		g.Shield = true
+	case PowerUpSlowMotion:
+		g.SlowMotion = SlowMotionFrames
+	}
+}
+
+// HandlePipeCollision resolves a collision with a pipe: a shield absorbs it and grants a
+// short grace period, otherwise the game ends
+func (g *GameState) HandlePipeCollision() {
+	if g.ShieldGrace > 0 {
+		return
+	}
+	if g.Shield {
+		g.Shield = false
+		g.ShieldGrace = ShieldGraceFrames
+		return
+	}
+	g.EndGame()
+}
+
+// SpeedFactor returns the factor applied to pipe and power-up speed by active effects
+func (g *GameState) SpeedFactor() float64 {
+	if g.SlowMotion > 0 {
+		return SlowMotionFactor
+	}
+	return 1
+}
+
+// TickEffects counts down the timed power-up effects by one frame
+func (g *GameState) TickEffects() {
+	if g.SlowMotion > 0 {
+		g.SlowMotion--
+	}
+	if g.ShieldGrace > 0 {
+		g.ShieldGrace--
+	}
+}
+
+// EndGame marks the game as over, awards the medal for the final score and updates the
+// high score and best medal
+func (g *GameState) EndGame() {
+	if g.GameOver {
+		return
+	}
+	g.GameOver = true
+	g.Medal = MedalForScore(g.Score)
+	if g.Score > g.HighScore {
+		g.HighScore = g.Score
+	}
+	if g.Medal > g.BestMedal {
+		g.BestMedal = g.Medal
+	}
 }
 
 // Game represents the main game instance
@@ -137,17 +311,23 @@ func NewGame() *Game {
 	}
 }
 
-// spawnPipe creates a new pipe at the right edge of the screen
+// spawnPipe creates a new pipe at the right edge of the screen, occasionally with a power-up
+// in its gap when power-ups are enabled
 func (g *Game) spawnPipe() {
 	gapY := float64(rand.Intn(ScreenHeight-300) + 150)
 	g.Pipes = append(g.Pipes, NewPipe(float64(ScreenWidth), gapY))
 	g.LastSpawn = float64(ScreenWidth)
+
+	if g.PowerUpsEnabled && rand.Float64() < PowerUpChance {
+		kind := PowerUpKind(rand.Intn(2))
+		g.PowerUps = append(g.PowerUps, NewPowerUp(float64(ScreenWidth)+PipeWidth/2, gapY, kind))
+	}
 }
 
 // Update updates the game state
 func (g *Game) Update() error {
-	// Handle input
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) && !g.GameOver {
+	// Handle input; the bird ignores jumps until the countdown ends
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) && !g.GameOver && !g.CountingDown() {
 		g.Bird.Jump()
 	}
 
@@ -163,12 +343,20 @@ func (g *Game) Update() error {
 		return nil
 	}
 
-	// Update bird
+	// Freeze the bird and pipes while counting down
+	if g.CountingDown() {
+		g.TickCountdown()
+		return nil
+	}
+
+	// Update bird and count down power-up effects
 	g.Bird.Update()
+	g.TickEffects()
+	speedFactor := g.SpeedFactor()
 
 	// Check if bird hits ground or ceiling
 	if g.Bird.Y > ScreenHeight || g.Bird.Y < 0 {
-		g.GameOver = true
+		g.EndGame()
 	}
 
 	// Spawn new pipes
@@ -179,16 +367,16 @@ func (g *Game) Update() error {
 	// Update pipes and check collisions
 	for i := len(g.Pipes) - 1; i >= 0; i-- {
 		pipe := g.Pipes[i]
-		pipe.Update()
+		pipe.Move(speedFactor)
 
 		// Check collision with bird
 		bx, by, bw, bh := g.Bird.GetRect()
 		topX, topY, topW, topH := pipe.GetTopRect()
 		bottomX, bottomY, bottomW, bottomH := pipe.GetBottomRect()
 
-		if (bx < topX+topW && bx+bw > topX && by < topY+topH && by+bh > topY) ||
-			(bx < bottomX+bottomW && bx+bw > bottomX && by < bottomY+bottomH && by+bh > bottomY) {
-			g.GameOver = true
+		if rectsOverlap(bx, by, bw, bh, topX, topY, topW, topH) ||
+			rectsOverlap(bx, by, bw, bh, bottomX, bottomY, bottomW, bottomH) {
+			g.HandlePipeCollision()
 		}
 
 		// Remove pipes that are off screen and increment score
@@ -201,6 +389,24 @@ func (g *Game) Update() error {
 		}
 	}
 
+	// Update power-ups and collect those the bird touches
+	for i := len(g.PowerUps) - 1; i >= 0; i-- {
+		powerUp := g.PowerUps[i]
+		powerUp.Move(speedFactor)
+
+		bx, by, bw, bh := g.Bird.GetRect()
+		px, py, pw, ph := powerUp.GetRect()
+		if rectsOverlap(bx, by, bw, bh, px, py, pw, ph) {
+			g.CollectPowerUp(powerUp)
+			g.PowerUps = append(g.PowerUps[:i], g.PowerUps[i+1:]...)
+			continue
+		}
+
+		if powerUp.X+float64(powerUp.Size) < 0 {
+			g.PowerUps = append(g.PowerUps[:i], g.PowerUps[i+1:]...)
+		}
+	}
+
 	return nil
 }
 
@@ -214,18 +420,57 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		pipe.Draw(screen)
 	}
 
-	// Draw bird
+	// Draw power-ups
+	for _, powerUp := range g.PowerUps {
+		powerUp.Draw(screen)
+	}
+
+	// Draw bird, ringed while a shield is held or absorbing a hit
 	g.Bird.Draw(screen)
+	if g.Shield || g.ShieldGrace > 0 {
+		vector.StrokeCircle(screen, float32(g.Bird.X), float32(g.Bird.Y),
+			float32(g.Bird.Size/2+6), 3, PowerUpShield.Color(), true)
+	}
 
 	// Draw score
 	scoreText := fmt.Sprintf("Score: %d", g.Score)
 	text.Draw(screen, scoreText, g.font, 10, 30, color.RGBA{0, 0, 0, 255})
 
+	// Draw active power-up effects under the score
+	if g.Shield {
+		text.Draw(screen, PowerUpShield.String(), g.font, 10, 50, PowerUpShield.Color())
+	}
+	if g.SlowMotion > 0 {
+		slowText := fmt.Sprintf("%s: %ds", PowerUpSlowMotion, (g.SlowMotion+59)/60)
+		text.Draw(screen, slowText, g.font, 10, 70, PowerUpSlowMotion.Color())
+	}
+
+	// Draw the countdown number over the frozen scene
+	if g.CountingDown() {
+		countdownText := fmt.Sprintf("%d", g.CountdownNumber())
+		text.Draw(screen, countdownText, g.font, ScreenWidth/2-3, ScreenHeight/2-40,
+			color.RGBA{255, 255, 255, 255})
+		text.Draw(screen, "Get ready!", g.font, ScreenWidth/2-35, ScreenHeight/2-20,
+			color.RGBA{0, 0, 0, 255})
+	}
+
 	// Draw game over screen
 	if g.GameOver {
 		gameOverText := "GAME OVER! Press R to restart"
 		text.Draw(screen, gameOverText, g.font, ScreenWidth/2-100, ScreenHeight/2,
 			color.RGBA{255, 0, 0, 255})
+
+		// Draw final score with the medal earned next to it
+		finalText := fmt.Sprintf("Final Score: %d  Medal: %s", g.Score, g.Medal)
+		text.Draw(screen, finalText, g.font, ScreenWidth/2-100, ScreenHeight/2+25,
+			color.RGBA{0, 0, 0, 255})
+		if g.Medal != MedalNone {
+			vector.DrawFilledCircle(screen, ScreenWidth/2-115, ScreenHeight/2+20, 8, g.Medal.Color(), true)
+		}
+
+		bestText := fmt.Sprintf("High Score: %d  Best Medal: %s", g.HighScore, g.BestMedal)
+		text.Draw(screen, bestText, g.font, ScreenWidth/2-100, ScreenHeight/2+50,
+			color.RGBA{0, 0, 0, 255})
 	}
 }
 
@@ -234,13 +479,23 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return ScreenWidth, ScreenHeight
 }
 
+// Options configures optional gameplay features
+type Options struct {
+	// PowerUps spawns occasional shield and slow-motion power-ups
+	PowerUps bool
+}
+
 // Run starts the game
-func Run() {
+func Run(opts Options) {
 	fmt.Println("🐦 Starting Glappy Bird Game!")
 	fmt.Println("Controls:")
 	fmt.Println("  SPACE - Jump")
 	fmt.Println("  R - Restart (when game over)")
 	fmt.Println("  ESC - Quit")
+	fmt.Println("Each round starts after a 3-2-1 countdown")
+	if opts.PowerUps {
+		fmt.Println("Power-ups: blue shield absorbs one hit, purple slows the pipes")
+	}
 	fmt.Println()
 
 	// Set window properties
@@ -250,6 +505,7 @@ func Run() {
 
 	// Create and run game
 	game := NewGame()
+	game.PowerUpsEnabled = opts.PowerUps
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
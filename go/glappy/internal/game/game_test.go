@@ -162,6 +162,36 @@ func TestGameStateInitialization(t *testing.T) {
 	if state.LastSpawn != 0 {
 		t.Errorf("Expected initial LastSpawn to be 0, got %f", state.LastSpawn)
 	}
+	if !state.CountingDown() {
+		t.Error("Game should start with a countdown")
+	}
+}
+
+func TestGameStateCountdown(t *testing.T) {
+	state := NewGameState()
+
+	var numbers []int
+	frames := 0
+	for state.CountingDown() {
+		if n := state.CountdownNumber(); len(numbers) == 0 || numbers[len(numbers)-1] != n {
+			numbers = append(numbers, n)
+		}
+		state.TickCountdown()
+		frames++
+	}
+
+	if frames != CountdownFrames {
+		t.Errorf("Expected countdown to last %d frames, got %d", CountdownFrames, frames)
+	}
+	if len(numbers) != 3 || numbers[0] != 3 || numbers[1] != 2 || numbers[2] != 1 {
+		t.Errorf("Expected countdown numbers [3 2 1], got %v", numbers)
+	}
+
+	// Ticking after the countdown ends keeps it at zero
+	state.TickCountdown()
+	if state.Countdown != 0 {
+		t.Errorf("Expected countdown to stay at 0, got %d", state.Countdown)
+	}
 }
 
 func TestGameStateRestart(t *testing.T) {
@@ -189,6 +219,9 @@ func TestGameStateRestart(t *testing.T) {
 	if len(state.Pipes) != 0 {
 		t.Errorf("Expected pipes to be empty after restart, got %d", len(state.Pipes))
 	}
+	if state.Countdown != CountdownFrames {
+		t.Errorf("Expected countdown to be %d after restart, got %d", CountdownFrames, state.Countdown)
+	}
 	if state.Bird.X != BirdStartX {
 		t.Errorf("Expected bird X to be %f after restart, got %f", float64(BirdStartX), state.Bird.X)
 	}
@@ -237,3 +270,63 @@ func TestGameInitialization(t *testing.T) {
 	}
 	// Note: font field is unexported, so we can't test it directly
 }
+
+func TestMedalForScore(t *testing.T) {
+	tests := []struct {
+		score int
+		want  Medal
+	}{
+		{0, MedalNone},
+		{BronzeMedalScore - 1, MedalNone},
+		{BronzeMedalScore, MedalBronze},
+		{SilverMedalScore, MedalSilver},
+		{GoldMedalScore - 1, MedalSilver},
+		{GoldMedalScore, MedalGold},
+		{PlatinumMedalScore, MedalPlatinum},
+		{PlatinumMedalScore * 10, MedalPlatinum},
+	}
+
+	for _, tt := range tests {
+		if got := MedalForScore(tt.score); got != tt.want {
+			t.Errorf("MedalForScore(%d) = %s, want %s", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestGameStateEndGame(t *testing.T) {
+	state := NewGameState()
+
+	state.Score = SilverMedalScore
+	state.EndGame()
+
+	if !state.GameOver {
+		t.Error("Game should be over after EndGame")
+	}
+	if state.Medal != MedalSilver {
+		t.Errorf("Expected medal to be %s, got %s", MedalSilver, state.Medal)
+	}
+	if state.HighScore != SilverMedalScore {
+		t.Errorf("Expected high score to be %d, got %d", SilverMedalScore, state.HighScore)
+	}
+	if state.BestMedal != MedalSilver {
+		t.Errorf("Expected best medal to be %s, got %s", MedalSilver, state.BestMedal)
+	}
+
+	// A worse game keeps the high score and best medal
+	state.Restart()
+	if state.Medal != MedalNone {
+		t.Errorf("Expected medal to be reset after restart, got %s", state.Medal)
+	}
+	state.Score = BronzeMedalScore
+	state.EndGame()
+
+	if state.Medal != MedalBronze {
+		t.Errorf("Expected medal to be %s, got %s", MedalBronze, state.Medal)
+	}
+	if state.HighScore != SilverMedalScore {
+		t.Errorf("Expected high score to stay %d, got %d", SilverMedalScore, state.HighScore)
+	}
+	if state.BestMedal != MedalSilver {
+		t.Errorf("Expected best medal to stay %s, got %s", MedalSilver, state.BestMedal)
+	}
+}
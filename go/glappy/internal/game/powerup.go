@@ -0,0 +1,104 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// Power-up properties
+	PowerUpSize = 20
+	// PowerUpChance is the probability that a new pipe carries a power-up in its gap
+	PowerUpChance = 0.15
+
+	// Effect durations in frames (60 per second)
+	SlowMotionFrames  = 300
+	ShieldGraceFrames = 60
+
+	// SlowMotionFactor scales pipe speed while slow motion is active
+	SlowMotionFactor = 0.5
+)
+
+// PowerUpKind is the effect a power-up grants when collected
+type PowerUpKind int
+
+const (
+	// PowerUpShield ignores the next collision with a pipe
+	PowerUpShield PowerUpKind = iota
+	// PowerUpSlowMotion slows the pipes down for a few seconds
+	PowerUpSlowMotion
+)
+
+// String returns the power-up name
+func (k PowerUpKind) String() string {
+	switch k {
+	case PowerUpShield:
+		return "Shield"
+	case PowerUpSlowMotion:
+		return "Slow Motion"
+	default:
+		return "Unknown"
+	}
+}
+
+// Color returns the color the power-up is drawn with
+func (k PowerUpKind) Color() color.RGBA {
+	switch k {
+	case PowerUpShield:
+		return color.RGBA{65, 105, 225, 255}
+	case PowerUpSlowMotion:
+		return color.RGBA{186, 85, 211, 255}
+	default:
+		return color.RGBA{255, 255, 255, 255}
+	}
+}
+
+// PowerUp represents a collectible that moves with the pipes
+type PowerUp struct {
+	X, Y  float64
+	Kind  PowerUpKind
+	Size  int
+	speed float64
+}
+
+// NewPowerUp creates a new power-up centered at the specified position
+func NewPowerUp(x, y float64, kind PowerUpKind) *PowerUp {
+	return &PowerUp{
+		X:     x,
+		Y:     y,
+		Kind:  kind,
+		Size:  PowerUpSize,
+		speed: PipeSpeed,
+	}
+}
+
+// Update moves the power-up to the left
+func (p *PowerUp) Update() {
+	p.Move(1)
+}
+
+// Move moves the power-up to the left at factor times its speed
+func (p *PowerUp) Move(factor float64) {
+	p.X -= p.speed * factor
+}
+
+// GetRect returns the power-up's collision rectangle
+func (p *PowerUp) GetRect() (x, y, width, height float64) {
+	return p.X - float64(p.Size/2), p.Y - float64(p.Size/2),
+		float64(p.Size), float64(p.Size)
+}
+
+// Draw draws the power-up on the screen
+func (p *PowerUp) Draw(screen *ebiten.Image) {
+	vector.DrawFilledCircle(screen, float32(p.X), float32(p.Y),
+		float32(p.Size/2), p.Kind.Color(), true)
+	vector.StrokeCircle(screen, float32(p.X), float32(p.Y),
+		float32(p.Size/2), 2, color.RGBA{255, 255, 255, 255}, true)
+}
+
+// rectsOverlap reports whether two rectangles intersect
+func rectsOverlap(ax, ay, aw, ah, bx, by, bw, bh float64) bool {
+	return ax < bx+bw && ax+aw > bx && ay < by+bh && ay+ah > by
+}
@@ -0,0 +1,91 @@
+package game
+
+import "testing"
+
+func TestPowerUpGetRect(t *testing.T) {
+	p := NewPowerUp(100, 200, PowerUpShield)
+	x, y, width, height := p.GetRect()
+
+	if x != 100-PowerUpSize/2 || y != 200-PowerUpSize/2 {
+		t.Errorf("Expected rect origin (%d, %d), got (%f, %f)", 100-PowerUpSize/2, 200-PowerUpSize/2, x, y)
+	}
+	if width != PowerUpSize || height != PowerUpSize {
+		t.Errorf("Expected rect size %d, got %fx%f", PowerUpSize, width, height)
+	}
+}
+
+func TestPowerUpMove(t *testing.T) {
+	p := NewPowerUp(100, 200, PowerUpSlowMotion)
+	p.Update()
+	if p.X != 100-PipeSpeed {
+		t.Errorf("Expected power-up X to be %d after update, got %f", 100-PipeSpeed, p.X)
+	}
+
+	p.Move(SlowMotionFactor)
+	if want := 100 - PipeSpeed - PipeSpeed*SlowMotionFactor; p.X != want {
+		t.Errorf("Expected power-up X to be %f after slow move, got %f", want, p.X)
+	}
+}
+
+func TestShieldAbsorbsOneCollision(t *testing.T) {
+	state := NewGameState()
+	state.CollectPowerUp(NewPowerUp(0, 0, PowerUpShield))
+
+	state.HandlePipeCollision()
+	if state.GameOver {
+		t.Fatal("Shield should absorb the first collision")
+	}
+	if state.Shield {
+		t.Error("Shield should be used up by the collision")
+	}
+
+	// Still overlapping the same pipe during the grace period
+	state.HandlePipeCollision()
+	if state.GameOver {
+		t.Fatal("Collisions during the grace period should be ignored")
+	}
+
+	for i := 0; i < ShieldGraceFrames; i++ {
+		state.TickEffects()
+	}
+	state.HandlePipeCollision()
+	if !state.GameOver {
+		t.Error("Collision after the grace period should end the game")
+	}
+}
+
+func TestSlowMotionExpires(t *testing.T) {
+	state := NewGameState()
+	if state.SpeedFactor() != 1 {
+		t.Errorf("Expected speed factor 1 without effects, got %f", state.SpeedFactor())
+	}
+
+	state.CollectPowerUp(NewPowerUp(0, 0, PowerUpSlowMotion))
+	if state.SpeedFactor() != SlowMotionFactor {
+		t.Errorf("Expected speed factor %f during slow motion, got %f", SlowMotionFactor, state.SpeedFactor())
+	}
+
+	for i := 0; i < SlowMotionFrames; i++ {
+		state.TickEffects()
+	}
+	if state.SpeedFactor() != 1 {
+		t.Errorf("Expected speed factor 1 after slow motion expires, got %f", state.SpeedFactor())
+	}
+}
+
+func TestRestartClearsPowerUps(t *testing.T) {
+	state := NewGameState()
+	state.PowerUpsEnabled = true
+	state.PowerUps = append(state.PowerUps, NewPowerUp(100, 200, PowerUpShield))
+	state.Shield = true
+	state.SlowMotion = SlowMotionFrames
+
+	state.Restart()
+
+	if len(state.PowerUps) != 0 || state.Shield || state.SlowMotion != 0 || state.ShieldGrace != 0 {
+		t.Error("Restart should clear power-ups and active effects")
+	}
+	if !state.PowerUpsEnabled {
+		t.Error("Restart should keep power-ups enabled")
+	}
+}
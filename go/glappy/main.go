@@ -1,9 +1,14 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/pischarti/nix/go/glappy/internal/game"
 )
 
 func main() {
-	game.Run()
+	powerUps := flag.Bool("powerups", false, "spawn occasional shield and slow-motion power-ups")
+	flag.Parse()
+
+	game.Run(game.Options{PowerUps: *powerUps})
 }
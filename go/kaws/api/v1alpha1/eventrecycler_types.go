@@ -49,8 +49,29 @@ type EventRecyclerStatus struct {
 
 	// EventCounts tracks event counts per node group
 	EventCounts map[string]int `json:"eventCounts,omitempty"`
+
+	// WouldRecycle lists the node groups at or over the threshold in the last dry-run check,
+	// leaving out any deferred by the operator's per-cycle recycle limit
+	WouldRecycle []string `json:"wouldRecycle,omitempty"`
+
+	// Conditions report the latest observations of the EventRecycler: Ready, Degraded and
+	// Recycling
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// Condition types set on EventRecycler status
+const (
+	// ConditionReady is True when the last event check succeeded
+	ConditionReady = "Ready"
+	// ConditionDegraded is True when the last event check or a recycle of the last check failed
+	ConditionDegraded = "Degraded"
+	// ConditionRecycling is True while a node group is being recycled
+	ConditionRecycling = "Recycling"
+)
+
 // RecycleHistoryEntry represents a single recycle operation
 type RecycleHistoryEntry struct {
 	// NodeGroup is the name of the recycled node group
@@ -69,6 +90,11 @@ type RecycleHistoryEntry struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+// +kubebuilder:printcolumn:name="Recycling",type=string,JSONPath=`.status.conditions[?(@.type=="Recycling")].status`
+// +kubebuilder:printcolumn:name="Last Check",type=date,JSONPath=`.status.lastCheckTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // EventRecycler is the Schema for the eventrecyclers API
 type EventRecycler struct {
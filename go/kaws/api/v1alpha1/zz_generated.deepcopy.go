@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -114,6 +115,18 @@ func (in *EventRecyclerStatus) DeepCopyInto(out *EventRecyclerStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.WouldRecycle != nil {
+		in, out := &in.WouldRecycle, &out.WouldRecycle
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecyclerStatus.
@@ -0,0 +1,129 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/jedib0t/go-pretty/v6/table"
+	awspkg "github.com/pischarti/nix/pkg/aws"
+	"github.com/pischarti/nix/pkg/print"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewListCmd creates the list subcommand, which discovers the node groups that can be recycled
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List EKS node groups backed by Auto Scaling Groups",
+		Long: `List the Auto Scaling Groups tagged as EKS managed or eksctl node groups, with their
+cluster, Min/Max/Desired sizes and instance count. The ASG column is the name the
+recycle command takes.`,
+		Args: cobra.NoArgs,
+		RunE: runList,
+		Example: `  # List every node group in the region
+  kaws aws ngs list
+
+  # Only the node groups of one cluster, largest first
+  kaws aws ngs list --cluster my-cluster --sort desired
+
+  # Recycle every node group of a cluster
+  kaws aws ngs list --cluster my-cluster --no-headers | awk '{print $1}' | xargs kaws aws ngs recycle`,
+	}
+
+	cmd.Flags().StringP("region", "r", "", "AWS region (default: from AWS config)")
+	cmd.Flags().StringP("cluster", "c", "", "only list node groups of this EKS cluster")
+	cmd.Flags().String("sort", awspkg.NodeGroupSortName, "sort by: name, cluster, desired (largest first) or instances (most first)")
+	cmd.Flags().String("color", print.ColorAuto, "color table output: auto, always, never")
+	cmd.Flags().Bool("no-headers", false, "print table rows without headers or borders")
+
+	return cmd
+}
+
+// runList executes the node group list command
+func runList(cmd *cobra.Command, args []string) error {
+	verbose := viper.GetBool("verbose")
+	region, _ := cmd.Flags().GetString("region")
+	clusterName, _ := cmd.Flags().GetString("cluster")
+	sortBy, _ := cmd.Flags().GetString("sort")
+	colorMode, _ := cmd.Flags().GetString("color")
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+
+	color, err := print.ResolveColor(colorMode)
+	if err != nil {
+		return err
+	}
+	// Reject an unknown --sort before calling AWS
+	if err := awspkg.SortASGNodeGroups(nil, sortBy); err != nil {
+		return err
+	}
+
+	if verbose {
+		if clusterName != "" {
+			fmt.Printf("Listing node groups of cluster: %s\n", clusterName)
+		} else {
+			fmt.Println("Listing node groups of all clusters")
+		}
+	}
+
+	// Load AWS config
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, func(opts *config.LoadOptions) error {
+		if region != "" {
+			opts.Region = region
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	nodeGroups, err := awspkg.ListASGNodeGroups(ctx, autoscaling.NewFromConfig(cfg), clusterName)
+	if err != nil {
+		return err
+	}
+	if err := awspkg.SortASGNodeGroups(nodeGroups, sortBy); err != nil {
+		return err
+	}
+
+	if len(nodeGroups) == 0 {
+		if !noHeaders {
+			fmt.Println("No node groups found")
+		}
+		return nil
+	}
+
+	displayNodeGroupsTable(os.Stdout, nodeGroups, color, noHeaders)
+	return nil
+}
+
+// displayNodeGroupsTable writes the node groups as a table. With noHeaders it writes only the
+// data rows, without the header or borders.
+func displayNodeGroupsTable(w io.Writer, nodeGroups []awspkg.ASGNodeGroup, color, noHeaders bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	if noHeaders {
+		print.SetNoHeadersStyle(t)
+	} else {
+		print.SetColoredStyle(t, color)
+		t.AppendHeader(table.Row{"ASG", "Node Group", "Cluster", "Min", "Max", "Desired", "Instances"})
+	}
+
+	for _, ng := range nodeGroups {
+		t.AppendRow(table.Row{
+			ng.ASGName,
+			ng.NodeGroupName,
+			ng.ClusterName,
+			ng.MinSize,
+			ng.MaxSize,
+			ng.DesiredSize,
+			ng.InstanceCount,
+		})
+	}
+
+	t.Render()
+}
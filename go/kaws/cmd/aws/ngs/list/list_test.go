@@ -0,0 +1,23 @@
+package list
+
+import (
+	"bytes"
+	"testing"
+
+	awspkg "github.com/pischarti/nix/pkg/aws"
+)
+
+func TestDisplayNodeGroupsTableNoHeaders(t *testing.T) {
+	nodeGroups := []awspkg.ASGNodeGroup{
+		{ASGName: "eks-workers-1-abc", NodeGroupName: "workers-1", ClusterName: "prod", MinSize: 1, MaxSize: 5, DesiredSize: 3, InstanceCount: 3},
+		{ASGName: "eks-batch-def", NodeGroupName: "batch", ClusterName: "prod", MaxSize: 10},
+	}
+
+	var out bytes.Buffer
+	displayNodeGroupsTable(&out, nodeGroups, false, true)
+
+	want := "eks-workers-1-abc workers-1 prod 1  5 3 3\neks-batch-def     batch     prod 0 10 0 0\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
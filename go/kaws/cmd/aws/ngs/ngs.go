@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pischarti/nix/go/kaws/cmd/aws/ngs/list"
 	"github.com/pischarti/nix/go/kaws/cmd/aws/ngs/recycle"
 	awspkg "github.com/pischarti/nix/pkg/aws"
 	"github.com/spf13/cobra"
@@ -30,6 +31,9 @@ func NewNgsCmd() *cobra.Command {
   # Find node groups for instances with custom region
   kaws aws ngs i-1234567890abcdef0 --region us-west-2
   
+  # List the node groups of a cluster
+  kaws aws ngs list --cluster my-cluster
+
   # Recycle a node group
   kaws aws ngs recycle ng-workers-1`,
 	}
@@ -39,6 +43,7 @@ func NewNgsCmd() *cobra.Command {
 	cmd.Flags().StringP("cluster", "c", "", "EKS cluster name (if not specified, searches all clusters)")
 
 	// Add subcommands
+	cmd.AddCommand(list.NewListCmd())
 	cmd.AddCommand(recycle.NewRecycleCmd())
 
 	return cmd
@@ -110,6 +115,7 @@ func displayNodeGroupsTable(nodeGroups []awspkg.NodeGroupInfo) {
 		"Instance ID",
 		"Cluster",
 		"Node Group",
+		"Type",
 		"Instance Type",
 	})
 
@@ -118,6 +124,7 @@ func displayNodeGroupsTable(nodeGroups []awspkg.NodeGroupInfo) {
 			ng.InstanceID,
 			ng.ClusterName,
 			ng.NodeGroupName,
+			ng.NodeGroupType,
 			ng.InstanceType,
 		})
 	}
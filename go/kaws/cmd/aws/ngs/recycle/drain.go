@@ -0,0 +1,76 @@
+package recycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pischarti/nix/pkg/k8s"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DrainConfig controls the drain of a node group's nodes before it is scaled to zero
+type DrainConfig struct {
+	Clientset kubernetes.Interface
+	Options   k8s.DrainOptions
+}
+
+// drainNodeGroup cordons and drains the nodes backed by instanceIDs, within one timeout for
+// all of them. Pods that could not be evicted, typically because a PodDisruptionBudget kept
+// refusing, abort the recycle so that scaling to zero does not take them down regardless.
+func drainNodeGroup(ctx context.Context, out io.Writer, drain *DrainConfig, instanceIDs []string, verbose bool) error {
+	fmt.Fprintln(out, "  Draining nodes...")
+
+	nodeNames, err := k8s.NodeNamesForInstances(ctx, drain.Clientset, instanceIDs)
+	if err != nil {
+		return fmt.Errorf("drain failed: %w", err)
+	}
+
+	// --drain-timeout bounds the drain of the whole node group, not of each node
+	opts := drain.Options
+	if opts.Timeout <= 0 {
+		opts.Timeout = k8s.DefaultDrainTimeout
+	}
+	opts.Deadline = time.Now().Add(opts.Timeout)
+
+	var failed k8s.DrainResults
+	for _, nodeName := range nodeNames {
+		results, err := k8s.DrainNode(ctx, drain.Clientset, nodeName, opts)
+		if err != nil {
+			return fmt.Errorf("drain failed: %w", err)
+		}
+
+		fmt.Fprintf(out, "  %s: %d evicted, %d skipped, %d failed\n", nodeName,
+			results.Count(k8s.PodEvicted), results.Count(k8s.PodSkipped), results.Count(k8s.PodFailed))
+		if verbose {
+			for _, result := range results {
+				if result.Status == k8s.PodSkipped {
+					fmt.Fprintf(out, "    skipped %s/%s: %s\n", result.Namespace, result.Name, result.Reason)
+				}
+			}
+		}
+		failed = append(failed, results.Failed()...)
+	}
+
+	if len(failed) > 0 {
+		for _, result := range failed {
+			fmt.Fprintf(out, "  ✗ %v\n", result.Err)
+		}
+		return fmt.Errorf("%d pod(s) could not be evicted; nodes are left cordoned (uncordon with kubectl uncordon)", len(failed))
+	}
+
+	fmt.Fprintf(out, "  ✓ Drained %d node(s)\n", len(nodeNames))
+	return nil
+}
+
+// newDrainConfig builds the drain configuration from the --drain-* flag values
+func newDrainConfig(clientset kubernetes.Interface, gracePeriod, timeout time.Duration) *DrainConfig {
+	return &DrainConfig{
+		Clientset: clientset,
+		Options: k8s.DrainOptions{
+			GracePeriod: gracePeriod,
+			Timeout:     timeout,
+		},
+	}
+}
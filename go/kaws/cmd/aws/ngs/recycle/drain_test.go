@@ -0,0 +1,69 @@
+package recycle
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDrainNodeGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocked bool
+		wantErr bool
+	}{
+		{name: "all pods evicted"},
+		{name: "disruption budget blocks eviction", blocked: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-111"}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-222"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+			)
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				eviction, ok := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+				if !ok {
+					return false, nil, nil
+				}
+				if tt.blocked {
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+				return true, nil, clientset.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+			})
+
+			drain := newDrainConfig(clientset, 0, time.Millisecond)
+			var out bytes.Buffer
+			err := drainNodeGroup(context.Background(), &out, drain, []string{"i-111"}, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("drainNodeGroup() error = %v, wantErr %v\n%s", err, tt.wantErr, out.String())
+			}
+
+			node, _ := clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+			if !node.Spec.Unschedulable {
+				t.Error("node-1 was not cordoned")
+			}
+			other, _ := clientset.CoreV1().Nodes().Get(context.Background(), "node-2", metav1.GetOptions{})
+			if other.Spec.Unschedulable {
+				t.Error("node-2 is outside the node group but was cordoned")
+			}
+			if !tt.wantErr && !strings.Contains(out.String(), "Drained 1 node(s)") {
+				t.Errorf("output = %q, want a drained summary", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package recycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/smithy-go"
+)
+
+// Strategies for handling ASG termination lifecycle hooks, selected with --lifecycle-hooks
+const (
+	// LifecycleHooksWarn reports termination hooks and lets them run their course
+	LifecycleHooksWarn = "warn"
+	// LifecycleHooksComplete completes the pending lifecycle action of each terminating instance
+	LifecycleHooksComplete = "complete"
+)
+
+// terminatingTransition is the lifecycle transition of hooks that hold instances in Terminating:Wait
+const terminatingTransition = "autoscaling:EC2_INSTANCE_TERMINATING"
+
+// parseLifecycleHooksStrategy validates the --lifecycle-hooks value
+func parseLifecycleHooksStrategy(value string) (string, error) {
+	switch value {
+	case LifecycleHooksWarn, LifecycleHooksComplete:
+		return value, nil
+	default:
+		return "", fmt.Errorf("unsupported --lifecycle-hooks value: %s (supported: %s, %s)", value, LifecycleHooksWarn, LifecycleHooksComplete)
+	}
+}
+
+// terminationHooks returns the hooks that run when an instance terminates
+func terminationHooks(hooks []asgtypes.LifecycleHook) []asgtypes.LifecycleHook {
+	var terminating []asgtypes.LifecycleHook
+	for _, hook := range hooks {
+		if aws.ToString(hook.LifecycleTransition) == terminatingTransition {
+			terminating = append(terminating, hook)
+		}
+	}
+	return terminating
+}
+
+// describeLifecycleHook returns the hook name with how long it can hold an instance
+func describeLifecycleHook(hook asgtypes.LifecycleHook) string {
+	return fmt.Sprintf("%s (heartbeat timeout %ds, default result %s)",
+		aws.ToString(hook.LifecycleHookName), aws.ToInt32(hook.HeartbeatTimeout), aws.ToString(hook.DefaultResult))
+}
+
+// checkLifecycleHooks looks up the group's termination lifecycle hooks. Instances stay in
+// Terminating:Wait until each hook is completed or its heartbeat times out, so with the warn
+// strategy the hooks are reported as a likely cause of a slow or timed-out termination.
+// Failing to list the hooks only warns, since the recycle can proceed without them.
+func checkLifecycleHooks(ctx context.Context, out io.Writer, client *autoscaling.Client, asgName, strategy string) []asgtypes.LifecycleHook {
+	result, err := client.DescribeLifecycleHooks(ctx, &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(asgName),
+	})
+	if err != nil {
+		fmt.Fprintf(out, "  Warning: failed to describe lifecycle hooks: %v\n", err)
+		return nil
+	}
+
+	hooks := terminationHooks(result.LifecycleHooks)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(hooks))
+	for _, hook := range hooks {
+		names = append(names, describeLifecycleHook(hook))
+	}
+
+	if strategy == LifecycleHooksComplete {
+		fmt.Fprintf(out, "  Termination lifecycle hooks will be completed automatically: %s\n", strings.Join(names, ", "))
+		return hooks
+	}
+
+	fmt.Fprintf(out, "  ⚠️  Termination lifecycle hooks may hold instances in Terminating:Wait until they are completed or time out: %s\n", strings.Join(names, ", "))
+	fmt.Fprintln(out, "  Use --lifecycle-hooks complete to complete them automatically")
+	return hooks
+}
+
+// instancesAwaitingTermination returns the group's instances held by a termination hook
+func instancesAwaitingTermination(instances []asgtypes.Instance) []string {
+	var waiting []string
+	for _, instance := range instances {
+		if instance.LifecycleState == asgtypes.LifecycleStateTerminatingWait && instance.InstanceId != nil {
+			waiting = append(waiting, *instance.InstanceId)
+		}
+	}
+	return waiting
+}
+
+// lifecycleAction identifies the pending action of one termination hook on one instance
+type lifecycleAction struct {
+	instanceID string
+	hookName   string
+}
+
+// isNoActiveLifecycleAction reports whether CompleteLifecycleAction failed because the
+// action is no longer pending, e.g. it was completed already or its heartbeat timed out
+func isNoActiveLifecycleAction(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+		strings.Contains(apiErr.ErrorMessage(), "No active Lifecycle Action found")
+}
+
+// newLifecycleActionCompleter returns a poll callback that completes every termination hook
+// with CONTINUE for instances in Terminating:Wait. Each hook is completed once per instance;
+// failures are retried on the next poll, and an action that is no longer pending counts as
+// completed, since AWS rejects completing it again.
+func newLifecycleActionCompleter(out io.Writer, client *autoscaling.Client, asgName string, hooks []asgtypes.LifecycleHook, verbose bool) func(context.Context) {
+	completed := make(map[lifecycleAction]bool)
+
+	return func(ctx context.Context) {
+		result, err := client.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []string{asgName},
+		})
+		if err != nil || len(result.AutoScalingGroups) == 0 {
+			if verbose {
+				fmt.Fprintf(out, "  Warning: failed to describe ASG for lifecycle hooks: %v\n", err)
+			}
+			return
+		}
+
+		for _, instanceID := range instancesAwaitingTermination(result.AutoScalingGroups[0].Instances) {
+			for _, hook := range hooks {
+				action := lifecycleAction{instanceID: instanceID, hookName: aws.ToString(hook.LifecycleHookName)}
+				if completed[action] {
+					continue
+				}
+
+				_, err := client.CompleteLifecycleAction(ctx, &autoscaling.CompleteLifecycleActionInput{
+					AutoScalingGroupName:  aws.String(asgName),
+					LifecycleHookName:     hook.LifecycleHookName,
+					InstanceId:            aws.String(instanceID),
+					LifecycleActionResult: aws.String("CONTINUE"),
+				})
+				if err != nil && !isNoActiveLifecycleAction(err) {
+					if verbose {
+						fmt.Fprintf(out, "  Warning: failed to complete lifecycle hook %s for %s: %v\n", action.hookName, instanceID, err)
+					}
+					continue
+				}
+
+				completed[action] = true
+				if verbose {
+					fmt.Fprintf(out, "  Completed lifecycle hook %s for %s\n", action.hookName, instanceID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package recycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+func TestParseLifecycleHooksStrategy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "warn", want: LifecycleHooksWarn},
+		{value: "complete", want: LifecycleHooksComplete},
+		{value: "ignore", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLifecycleHooksStrategy(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLifecycleHooksStrategy(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLifecycleHooksStrategy(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminationHooks(t *testing.T) {
+	hooks := []asgtypes.LifecycleHook{
+		{LifecycleHookName: aws.String("drain"), LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_TERMINATING")},
+		{LifecycleHookName: aws.String("bootstrap"), LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_LAUNCHING")},
+	}
+
+	got := terminationHooks(hooks)
+	if len(got) != 1 || aws.ToString(got[0].LifecycleHookName) != "drain" {
+		t.Errorf("terminationHooks() = %+v, want only the drain hook", got)
+	}
+}
+
+func TestInstancesAwaitingTermination(t *testing.T) {
+	instances := []asgtypes.Instance{
+		{InstanceId: aws.String("i-1"), LifecycleState: asgtypes.LifecycleStateTerminatingWait},
+		{InstanceId: aws.String("i-2"), LifecycleState: asgtypes.LifecycleStateTerminating},
+		{InstanceId: aws.String("i-3"), LifecycleState: asgtypes.LifecycleStateInService},
+		{InstanceId: aws.String("i-4"), LifecycleState: asgtypes.LifecycleStateTerminatingWait},
+	}
+
+	got := instancesAwaitingTermination(instances)
+	want := []string{"i-1", "i-4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("instancesAwaitingTermination() = %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleActionCompleter(t *testing.T) {
+	// i-1 waits on three hooks: drain completes, flaky fails once, and gone has no
+	// pending action left, which AWS reports as a validation error
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		switch r.Form.Get("Action") {
+		case "DescribeAutoScalingGroups":
+			fmt.Fprint(w, `<DescribeAutoScalingGroupsResponse><DescribeAutoScalingGroupsResult><AutoScalingGroups><member>`+
+				`<AutoScalingGroupName>ng-workers-1</AutoScalingGroupName><Instances><member>`+
+				`<InstanceId>i-1</InstanceId><LifecycleState>Terminating:Wait</LifecycleState>`+
+				`</member></Instances></member></AutoScalingGroups></DescribeAutoScalingGroupsResult></DescribeAutoScalingGroupsResponse>`)
+		case "CompleteLifecycleAction":
+			hook := r.Form.Get("LifecycleHookName")
+			mu.Lock()
+			calls[hook]++
+			n := calls[hook]
+			mu.Unlock()
+
+			switch {
+			case hook == "flaky" && n == 1:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error></ErrorResponse>`)
+			case hook == "gone":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>ValidationError</Code>`+
+					`<Message>No active Lifecycle Action found with instance ID i-1</Message></Error></ErrorResponse>`)
+			default:
+				fmt.Fprint(w, `<CompleteLifecycleActionResponse><CompleteLifecycleActionResult/></CompleteLifecycleActionResponse>`)
+			}
+		default:
+			t.Errorf("unexpected action %q", r.Form.Get("Action"))
+		}
+	}))
+	defer server.Close()
+
+	client := autoscaling.New(autoscaling.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(server.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+	hooks := []asgtypes.LifecycleHook{
+		{LifecycleHookName: aws.String("drain")},
+		{LifecycleHookName: aws.String("flaky")},
+		{LifecycleHookName: aws.String("gone")},
+	}
+
+	complete := newLifecycleActionCompleter(io.Discard, client, "ng-workers-1", hooks, false)
+	for range 3 {
+		complete(context.Background())
+	}
+
+	want := map[string]int{"drain": 1, "flaky": 2, "gone": 1}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("CompleteLifecycleAction calls per hook = %v, want %v", calls, want)
+	}
+}
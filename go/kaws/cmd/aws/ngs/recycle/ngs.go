@@ -2,15 +2,28 @@ package recycle
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awspkg "github.com/pischarti/nix/pkg/aws"
+	"github.com/pischarti/nix/pkg/k8s"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
 )
 
 // ASGConfig stores the original Auto Scaling Group configuration
@@ -21,6 +34,87 @@ type ASGConfig struct {
 	DesiredSize int32
 }
 
+// PreCheckConfig controls the cluster headroom check run before a node group is scaled to zero
+type PreCheckConfig struct {
+	Clientset kubernetes.Interface
+	Threshold float64
+	WarnOnly  bool
+	Timeout   time.Duration
+}
+
+// Waiter phases reported in ProgressEvent
+const (
+	PhaseTerminating = "terminating"
+	PhaseLaunching   = "launching"
+)
+
+// ProgressEvent is a machine-readable snapshot of one waiter poll. With --output json each
+// event is written to stdout as a single JSON line.
+type ProgressEvent struct {
+	NodeGroup      string         `json:"nodeGroup"`
+	Phase          string         `json:"phase"`
+	ElapsedSeconds float64        `json:"elapsedSeconds"`
+	StateCount     map[string]int `json:"stateCount"`
+	// Instances is the number of instances the waiter is tracking
+	Instances int `json:"instances"`
+	// Ready counts instances in a target state: shutting-down/terminated while terminating,
+	// pending/running while launching
+	Ready    int  `json:"ready"`
+	Expected int  `json:"expected"`
+	Done     bool `json:"done"`
+}
+
+// Defaults for how often a recycle polls and how long it waits for each phase, shared by the
+// recycle command and the operator
+const (
+	DefaultPollInterval = 15 * time.Second
+	DefaultTimeout      = 20 * time.Minute
+)
+
+// finalStateTimeout bounds the lookup of a node group's state after an interrupted recycle,
+// which runs on a fresh context since the recycle's own is cancelled
+const finalStateTimeout = 10 * time.Second
+
+// errNodeGroupAtZero is returned by recycleNodeGroup when --skip-zero skips a node group
+// whose desired size is already zero
+var errNodeGroupAtZero = errors.New("node group desired size is already 0")
+
+// recycleOptions controls how a node group recycle waits and reports progress
+type recycleOptions struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	// SkipZero skips node groups that are already scaled to zero instead of recycling them
+	SkipZero bool
+	// RefuseBelow refuses a recycle of a node group when it is above zero, since a recycle
+	// always scales the node group to zero; it guards node groups that must never be emptied
+	RefuseBelow int32
+	// PreCheck, when set, verifies cluster headroom before the group is scaled down
+	PreCheck *PreCheckConfig
+	// Drain, when set, cordons and drains the group's nodes before it is scaled down
+	Drain *DrainConfig
+	// LifecycleHooks is the strategy for termination lifecycle hooks: warn or complete
+	LifecycleHooks string
+	Verbose        bool
+	// Out receives human-readable progress; it is stderr with --output json so that stdout
+	// only carries JSON lines
+	Out io.Writer
+	// OnProgress, when set, receives every waiter poll in place of the dot/verbose output
+	OnProgress func(ProgressEvent)
+	// ReportFailures reports the configuration for --restore after any failed recycle, not
+	// only an interrupted one, for callers that cannot show the failure to the user
+	ReportFailures bool
+	// beforeTerminatePoll, when set, runs before each poll while waiting for termination
+	beforeTerminatePoll func(context.Context)
+}
+
+// newJSONProgressWriter returns a progress callback that writes each event as a JSON line
+func newJSONProgressWriter(w io.Writer) func(ProgressEvent) {
+	encoder := json.NewEncoder(w)
+	return func(event ProgressEvent) {
+		_ = encoder.Encode(event)
+	}
+}
+
 // NewRecycleCmd creates the recycle subcommand
 func NewRecycleCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -39,12 +133,46 @@ then scale back up to original values and wait for new instances to start.`,
   kaws aws ngs recycle ng-workers-1 --region us-west-2
   
   # With custom polling interval
-  kaws aws ngs recycle ng-workers-1 --poll-interval 10s`,
+  kaws aws ngs recycle ng-workers-1 --poll-interval 10s
+
+  # Refuse to recycle unless other nodes can absorb the pods with 20% to spare
+  kaws aws ngs recycle ng-workers-1 --pre-check --headroom-threshold 1.2
+
+  # Emit one JSON line per poll for a wrapping orchestrator
+  kaws aws ngs recycle ng-workers-1 --output json
+
+  # Leave node groups that were deliberately scaled to zero alone
+  kaws aws ngs recycle ng-workers-1 ng-batch --skip-zero
+
+  # Complete drain lifecycle hooks instead of waiting for them to time out
+  kaws aws ngs recycle ng-workers-1 --lifecycle-hooks complete
+
+  # Evict pods through the eviction API, respecting PodDisruptionBudgets, before scaling down
+  kaws aws ngs recycle ng-workers-1 --drain --drain-timeout 10m
+
+  # Refuse to empty node groups that must always keep a node
+  kaws aws ngs recycle ng-system --refuse-below 1
+
+  # Restore a node group left scaled down by an interrupted recycle to Min=1, Max=5, Desired=3
+  kaws aws ngs recycle ng-workers-1 --restore 1:5:3`,
 	}
 
 	cmd.Flags().StringP("region", "r", "", "AWS region (default: from AWS config)")
-	cmd.Flags().DurationP("poll-interval", "p", 15*time.Second, "polling interval for status checks")
-	cmd.Flags().Duration("timeout", 20*time.Minute, "maximum time to wait for recycle to complete")
+	cmd.Flags().DurationP("poll-interval", "p", DefaultPollInterval, "polling interval for status checks")
+	cmd.Flags().Duration("timeout", DefaultTimeout, "maximum time to wait for recycle to complete")
+	cmd.Flags().Bool("pre-check", false, "verify other nodes have capacity for the node group's pods before scaling to zero")
+	cmd.Flags().Float64("headroom-threshold", 1.0, "required ratio of free capacity to displaced pod requests for --pre-check")
+	cmd.Flags().Bool("pre-check-warn-only", false, "warn instead of aborting when --pre-check finds insufficient headroom")
+	cmd.Flags().Bool("skip-zero", false, "skip node groups whose desired size is already 0 instead of recycling them")
+	cmd.Flags().Int32("refuse-below", 0, "refuse to scale a node group's desired capacity below this value; a recycle scales to zero, so any value above zero refuses it")
+	cmd.Flags().Int32("floor", 0, "alias of --refuse-below")
+	_ = cmd.Flags().MarkDeprecated("floor", "use --refuse-below instead")
+	cmd.Flags().String("restore", "", "instead of recycling, scale the node groups to MIN:MAX:DESIRED and verify, e.g. after an interrupted recycle")
+	cmd.Flags().String("lifecycle-hooks", LifecycleHooksWarn, "termination lifecycle hook strategy: warn (report hooks that can stall termination) or complete (complete them with CONTINUE)")
+	cmd.Flags().Bool("drain", false, "cordon the node group's nodes and evict their pods, respecting PodDisruptionBudgets, before scaling to zero")
+	cmd.Flags().Duration("drain-grace-period", 0, "termination grace period for evicted pods (0 uses each pod's own)")
+	cmd.Flags().Duration("drain-timeout", k8s.DefaultDrainTimeout, "how long the drain of the node group may keep retrying evictions blocked by PodDisruptionBudgets and wait for evicted pods to terminate")
+	cmd.Flags().StringP("output", "o", "text", "progress output format: text or json (JSON lines on stdout, messages on stderr)")
 
 	return cmd
 }
@@ -55,6 +183,68 @@ func runRecycle(cmd *cobra.Command, args []string) error {
 	region, _ := cmd.Flags().GetString("region")
 	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
 	timeout, _ := cmd.Flags().GetDuration("timeout")
+	preCheck, _ := cmd.Flags().GetBool("pre-check")
+	headroomThreshold, _ := cmd.Flags().GetFloat64("headroom-threshold")
+	preCheckWarnOnly, _ := cmd.Flags().GetBool("pre-check-warn-only")
+	outputFormat, _ := cmd.Flags().GetString("output")
+	skipZero, _ := cmd.Flags().GetBool("skip-zero")
+	refuseBelow, _ := cmd.Flags().GetInt32("refuse-below")
+	if cmd.Flags().Changed("floor") {
+		if cmd.Flags().Changed("refuse-below") {
+			return fmt.Errorf("--floor is an alias of --refuse-below; set only one of them")
+		}
+		refuseBelow, _ = cmd.Flags().GetInt32("floor")
+	}
+	restoreValue, _ := cmd.Flags().GetString("restore")
+	lifecycleHooksValue, _ := cmd.Flags().GetString("lifecycle-hooks")
+	drain, _ := cmd.Flags().GetBool("drain")
+	drainGracePeriod, _ := cmd.Flags().GetDuration("drain-grace-period")
+	drainTimeout, _ := cmd.Flags().GetDuration("drain-timeout")
+
+	if headroomThreshold <= 0 {
+		return fmt.Errorf("--headroom-threshold must be greater than zero")
+	}
+	if drainGracePeriod < 0 || drainTimeout < 0 {
+		return fmt.Errorf("--drain-grace-period and --drain-timeout must not be negative")
+	}
+	if refuseBelow < 0 {
+		return fmt.Errorf("--refuse-below must not be negative")
+	}
+	lifecycleHooks, err := parseLifecycleHooksStrategy(lifecycleHooksValue)
+	if err != nil {
+		return err
+	}
+	var restore *ASGConfig
+	if restoreValue != "" {
+		if restore, err = parseRestoreConfig(restoreValue); err != nil {
+			return err
+		}
+		if preCheck || drain {
+			return fmt.Errorf("--restore cannot be combined with --pre-check or --drain")
+		}
+		if err := checkRefuseBelow(restore.DesiredSize, refuseBelow); err != nil {
+			return err
+		}
+	}
+
+	opts := recycleOptions{
+		PollInterval:   pollInterval,
+		Timeout:        timeout,
+		SkipZero:       skipZero,
+		RefuseBelow:    refuseBelow,
+		LifecycleHooks: lifecycleHooks,
+		Verbose:        verbose,
+		Out:            os.Stdout,
+	}
+	switch outputFormat {
+	case "text":
+	case "json":
+		opts.Out = os.Stderr
+		opts.OnProgress = newJSONProgressWriter(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: text, json)", outputFormat)
+	}
+	out := opts.Out
 
 	// Get node group names from args
 	nodeGroupNames := args
@@ -63,13 +253,39 @@ func runRecycle(cmd *cobra.Command, args []string) error {
 	}
 
 	if verbose {
-		fmt.Printf("Recycling %d node group(s)\n", len(nodeGroupNames))
-		fmt.Printf("Poll interval: %s\n", pollInterval)
-		fmt.Printf("Timeout: %s\n", timeout)
+		fmt.Fprintf(out, "Recycling %d node group(s)\n", len(nodeGroupNames))
+		fmt.Fprintf(out, "Poll interval: %s\n", pollInterval)
+		fmt.Fprintf(out, "Timeout: %s\n", timeout)
+		if preCheck {
+			fmt.Fprintf(out, "Pre-check headroom threshold: %.2f\n", headroomThreshold)
+		}
+	}
+
+	// Connect to the cluster only when the headroom pre-check or a drain is requested
+	if preCheck || drain {
+		client, err := k8s.NewClient()
+		if err != nil {
+			return fmt.Errorf("--pre-check and --drain require cluster access: %w", err)
+		}
+		if preCheck {
+			opts.PreCheck = &PreCheckConfig{
+				Clientset: client.Clientset,
+				Threshold: headroomThreshold,
+				WarnOnly:  preCheckWarnOnly,
+				Timeout:   k8s.DefaultTimeout,
+			}
+		}
+		if drain {
+			opts.Drain = newDrainConfig(client.Clientset, drainGracePeriod, drainTimeout)
+		}
 	}
 
+	// Cancel on Ctrl+C or SIGTERM; the waiters stop at the next poll and the interrupted
+	// recycle reports the configuration to restore
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load AWS config
-	ctx := context.Background()
 	cfg, err := config.LoadDefaultConfig(ctx, func(opts *config.LoadOptions) error {
 		if region != "" {
 			opts.Region = region
@@ -84,66 +300,289 @@ func runRecycle(cmd *cobra.Command, args []string) error {
 	asgClient := autoscaling.NewFromConfig(cfg)
 	ec2Client := ec2.NewFromConfig(cfg)
 
+	if restore != nil {
+		for _, ngName := range nodeGroupNames {
+			fmt.Fprintf(out, "\n=== Restoring node group: %s ===\n", ngName)
+			target := *restore
+			target.Name = ngName
+			if err := restoreASG(ctx, out, asgClient, &target); err != nil {
+				return fmt.Errorf("failed to restore node group %s: %w", ngName, err)
+			}
+		}
+		return nil
+	}
+
 	// Process each node group
 	for _, ngName := range nodeGroupNames {
-		fmt.Printf("\n=== Recycling node group: %s ===\n", ngName)
+		fmt.Fprintf(out, "\n=== Recycling node group: %s ===\n", ngName)
 
-		if err := recycleNodeGroup(ctx, asgClient, ec2Client, ngName, pollInterval, timeout, verbose); err != nil {
+		err := recycleNodeGroup(ctx, asgClient, ec2Client, ngName, opts)
+		if errors.Is(err, errNodeGroupAtZero) {
+			fmt.Fprintf(out, "⏭  Skipped node group: %s\n", ngName)
+			continue
+		}
+		if err != nil {
 			return fmt.Errorf("failed to recycle node group %s: %w", ngName, err)
 		}
 
-		fmt.Printf("✓ Successfully recycled node group: %s\n", ngName)
+		fmt.Fprintf(out, "✓ Successfully recycled node group: %s\n", ngName)
 	}
 
 	return nil
 }
 
-// recycleNodeGroup performs the full recycle operation for a single node group
-func recycleNodeGroup(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, ngName string, pollInterval, timeout time.Duration, verbose bool) error {
+// RecycleNodeGroup scales the Auto Scaling Group asgName to zero, waits for its instances to
+// terminate, then restores its original size and waits for the new instances to start.
+// Progress is written to stdout, and termination lifecycle hooks are only reported. When the
+// recycle fails or ctx is cancelled part way, the original size is reported for --restore.
+func RecycleNodeGroup(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, asgName string, pollInterval, timeout time.Duration) error {
+	return recycleNodeGroup(ctx, asgClient, ec2Client, asgName, recycleOptions{
+		PollInterval:   pollInterval,
+		Timeout:        timeout,
+		LifecycleHooks: LifecycleHooksWarn,
+		Out:            os.Stdout,
+		ReportFailures: true,
+	})
+}
+
+// RecycleEventNodeGroup recycles the node group named by Kubernetes events. Events name the
+// node group, not its Auto Scaling Group, so the ASGs tagged with the node group's name are
+// looked up and each is recycled with RecycleNodeGroup.
+func RecycleEventNodeGroup(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, nodeGroupName string, pollInterval, timeout time.Duration) error {
+	nodeGroups, err := awspkg.ListASGNodeGroups(ctx, asgClient, "")
+	if err != nil {
+		return err
+	}
+	asgNames, err := asgNamesForNodeGroup(nodeGroups, nodeGroupName)
+	if err != nil {
+		return err
+	}
+	for _, asgName := range asgNames {
+		fmt.Printf("\n=== Recycling node group: %s (Auto Scaling Group %s) ===\n", nodeGroupName, asgName)
+		if err := RecycleNodeGroup(ctx, asgClient, ec2Client, asgName, pollInterval, timeout); err != nil {
+			return fmt.Errorf("failed to recycle Auto Scaling Group %s: %w", asgName, err)
+		}
+	}
+	return nil
+}
+
+// asgNamesForNodeGroup returns the Auto Scaling Groups backing the named node group. It
+// refuses a name shared by node groups of several clusters, since the events do not say
+// which of them they came from.
+func asgNamesForNodeGroup(nodeGroups []awspkg.ASGNodeGroup, name string) ([]string, error) {
+	var asgNames, clusters []string
+	for _, nodeGroup := range nodeGroups {
+		if nodeGroup.NodeGroupName != name {
+			continue
+		}
+		asgNames = append(asgNames, nodeGroup.ASGName)
+		if !slices.Contains(clusters, nodeGroup.ClusterName) {
+			clusters = append(clusters, nodeGroup.ClusterName)
+		}
+	}
+
+	if len(asgNames) == 0 {
+		return nil, fmt.Errorf("no Auto Scaling Group is tagged with node group %s", name)
+	}
+	if len(clusters) > 1 {
+		sort.Strings(clusters)
+		return nil, fmt.Errorf("node group %s exists in several clusters (%s); recycle its Auto Scaling Group with 'kaws aws ngs recycle'", name, strings.Join(clusters, ", "))
+	}
+	sort.Strings(asgNames)
+	return asgNames, nil
+}
+
+// recycleNodeGroup performs the full recycle operation for a single node group. When the
+// context is cancelled part way, or any step fails with opts.ReportFailures, the captured
+// configuration is reported for --restore.
+func recycleNodeGroup(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, ngName string, opts recycleOptions) (err error) {
+	out := opts.Out
+
 	// Step 1: Get current ASG configuration
-	fmt.Println("\n[1/5] Getting current node group configuration...")
+	fmt.Fprintln(out, "\n[1/5] Getting current node group configuration...")
 	originalConfig, instanceIDs, err := getASGConfig(ctx, asgClient, ngName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("  Current config: Min=%d, Max=%d, Desired=%d\n", originalConfig.MinSize, originalConfig.MaxSize, originalConfig.DesiredSize)
-	fmt.Printf("  Current instances: %d\n", len(instanceIDs))
+	defer func() {
+		switch {
+		case err == nil || errors.Is(err, errNodeGroupAtZero):
+		case ctx.Err() != nil:
+			reportUnfinishedRecycle(out, asgClient, originalConfig, "interrupted")
+			err = fmt.Errorf("recycle interrupted: %w", err)
+		case opts.ReportFailures:
+			reportUnfinishedRecycle(out, asgClient, originalConfig, "failed")
+		}
+	}()
+
+	fmt.Fprintf(out, "  Current config: Min=%d, Max=%d, Desired=%d\n", originalConfig.MinSize, originalConfig.MaxSize, originalConfig.DesiredSize)
+	fmt.Fprintf(out, "  Current instances: %d\n", len(instanceIDs))
+
+	if err := checkIdleNodeGroup(out, originalConfig, opts.SkipZero); err != nil {
+		return err
+	}
+
+	// A recycle scales to zero, so refuse before anything is drained or scaled
+	if err := checkRefuseBelow(0, opts.RefuseBelow); err != nil {
+		return err
+	}
+
+	if opts.PreCheck != nil {
+		if err := checkHeadroom(ctx, out, opts.PreCheck, instanceIDs); err != nil {
+			return err
+		}
+	}
+
+	if opts.Drain != nil {
+		if err := drainNodeGroup(ctx, out, opts.Drain, instanceIDs, opts.Verbose); err != nil {
+			return err
+		}
+	}
+
+	hooks := checkLifecycleHooks(ctx, out, asgClient, ngName, opts.LifecycleHooks)
+	if len(hooks) > 0 && opts.LifecycleHooks == LifecycleHooksComplete {
+		opts.beforeTerminatePoll = newLifecycleActionCompleter(out, asgClient, ngName, hooks, opts.Verbose)
+	}
 
 	// Step 2: Scale down to zero
-	fmt.Println("\n[2/5] Scaling down to zero...")
-	if err := scaleASG(ctx, asgClient, ngName, 0, 0, 0); err != nil {
+	fmt.Fprintln(out, "\n[2/5] Scaling down to zero...")
+	if err := scaleASG(ctx, out, asgClient, ngName, 0, 0, 0); err != nil {
 		return err
 	}
 
 	// Step 3: Wait for instances to terminate
-	fmt.Println("\n[3/5] Waiting for instances to terminate...")
-	if err := waitForInstanceStates(ctx, ec2Client, instanceIDs, []ec2types.InstanceStateName{
+	fmt.Fprintln(out, "\n[3/5] Waiting for instances to terminate...")
+	if err := waitForInstanceStates(ctx, ec2Client, ngName, instanceIDs, []ec2types.InstanceStateName{
 		ec2types.InstanceStateNameShuttingDown,
 		ec2types.InstanceStateNameTerminated,
-	}, pollInterval, timeout, verbose); err != nil {
+	}, opts); err != nil {
+		if len(hooks) > 0 && opts.LifecycleHooks == LifecycleHooksWarn {
+			return fmt.Errorf("%w (termination lifecycle hooks may be holding instances; use --lifecycle-hooks complete)", err)
+		}
 		return err
 	}
 
-	fmt.Println("  All instances terminated")
+	fmt.Fprintln(out, "  All instances terminated")
 
 	// Step 4: Scale back up to original values
-	fmt.Println("\n[4/5] Scaling back up to original configuration...")
-	if err := scaleASG(ctx, asgClient, ngName, originalConfig.MinSize, originalConfig.MaxSize, originalConfig.DesiredSize); err != nil {
+	fmt.Fprintln(out, "\n[4/5] Scaling back up to original configuration...")
+	if err := restoreASG(ctx, out, asgClient, originalConfig); err != nil {
 		return err
 	}
 
 	// Step 5: Wait for new instances to start (pending state)
-	fmt.Println("\n[5/5] Waiting for new instances to start...")
-	if err := waitForNewInstances(ctx, asgClient, ec2Client, ngName, int(originalConfig.DesiredSize), pollInterval, timeout, verbose); err != nil {
+	fmt.Fprintln(out, "\n[5/5] Waiting for new instances to start...")
+	if err := waitForNewInstances(ctx, asgClient, ec2Client, ngName, int(originalConfig.DesiredSize), opts); err != nil {
 		return err
 	}
 
-	fmt.Println("  All new instances starting")
+	fmt.Fprintln(out, "  All new instances starting")
 
 	return nil
 }
 
+// reportUnfinishedRecycle prints the node group's configuration before the recycle and,
+// when it can still be read, its current one, with the command that restores it
+func reportUnfinishedRecycle(out io.Writer, client *autoscaling.Client, original *ASGConfig, outcome string) {
+	ctx, cancel := context.WithTimeout(context.Background(), finalStateTimeout)
+	defer cancel()
+
+	current, _, err := getASGConfig(ctx, client, original.Name)
+	writeUnfinishedRecycleReport(out, original, current, err, outcome)
+}
+
+// writeUnfinishedRecycleReport writes the final-state report of a recycle that was interrupted
+// or failed, as outcome says; current is nil when lookupErr explains why it could not be read
+func writeUnfinishedRecycleReport(out io.Writer, original, current *ASGConfig, lookupErr error, outcome string) {
+	fmt.Fprintf(out, "\n⚠️  Recycle %s\n", outcome)
+	fmt.Fprintf(out, "  Original config: Min=%d, Max=%d, Desired=%d\n", original.MinSize, original.MaxSize, original.DesiredSize)
+	if lookupErr != nil {
+		fmt.Fprintf(out, "  Current config: unknown (%v)\n", lookupErr)
+	} else {
+		fmt.Fprintf(out, "  Current config: Min=%d, Max=%d, Desired=%d\n", current.MinSize, current.MaxSize, current.DesiredSize)
+		if verifyASGConfig(original, current) == nil {
+			fmt.Fprintln(out, "  The node group size is unchanged; nodes drained by --drain stay cordoned until uncordoned")
+			return
+		}
+	}
+	fmt.Fprintln(out, "  The node group may need manual restoration:")
+	fmt.Fprintf(out, "    kaws aws ngs recycle %s --restore %s\n", original.Name, formatRestoreConfig(original))
+}
+
+// formatRestoreConfig returns the --restore value for the configuration
+func formatRestoreConfig(config *ASGConfig) string {
+	return fmt.Sprintf("%d:%d:%d", config.MinSize, config.MaxSize, config.DesiredSize)
+}
+
+// parseRestoreConfig parses a --restore MIN:MAX:DESIRED value
+func parseRestoreConfig(value string) (*ASGConfig, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid --restore %q: expected MIN:MAX:DESIRED", value)
+	}
+
+	sizes := make([]int32, len(parts))
+	for i, part := range parts {
+		size, err := strconv.ParseInt(part, 10, 32)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid --restore %q: sizes must be non-negative integers", value)
+		}
+		sizes[i] = int32(size)
+	}
+
+	config := &ASGConfig{MinSize: sizes[0], MaxSize: sizes[1], DesiredSize: sizes[2]}
+	if config.MinSize > config.DesiredSize || config.DesiredSize > config.MaxSize {
+		return nil, fmt.Errorf("invalid --restore %q: sizes must satisfy MIN <= DESIRED <= MAX", value)
+	}
+	return config, nil
+}
+
+// checkHeadroom verifies that nodes outside the group can absorb its pods. Insufficient
+// headroom aborts the recycle unless the check is configured to only warn.
+func checkHeadroom(ctx context.Context, out io.Writer, preCheck *PreCheckConfig, instanceIDs []string) error {
+	fmt.Fprintln(out, "  Checking cluster headroom...")
+
+	checkCtx, cancel := context.WithTimeout(ctx, preCheck.Timeout)
+	defer cancel()
+
+	report, err := k8s.CheckHeadroom(checkCtx, preCheck.Clientset, instanceIDs)
+	if err != nil {
+		return fmt.Errorf("headroom pre-check failed: %w", k8s.WrapTimeoutError(checkCtx, err, preCheck.Timeout))
+	}
+
+	fmt.Fprintf(out, "  Headroom: %s\n", report)
+
+	if report.Sufficient(preCheck.Threshold) {
+		fmt.Fprintf(out, "  ✓ Sufficient headroom (threshold %.2f)\n", preCheck.Threshold)
+		return nil
+	}
+
+	if preCheck.WarnOnly {
+		fmt.Fprintf(out, "  ⚠️  Insufficient headroom (threshold %.2f); continuing because of --pre-check-warn-only\n", preCheck.Threshold)
+		return nil
+	}
+
+	return fmt.Errorf("insufficient headroom to recycle: %s (threshold %.2f)", report, preCheck.Threshold)
+}
+
+// checkIdleNodeGroup handles a node group whose desired size is already zero. Such a group
+// was most likely scaled down on purpose, and scaling it "back up" to zero recycles nothing,
+// so it returns errNodeGroupAtZero with skipZero and otherwise only warns.
+func checkIdleNodeGroup(out io.Writer, config *ASGConfig, skipZero bool) error {
+	if config.DesiredSize != 0 {
+		return nil
+	}
+
+	if skipZero {
+		fmt.Fprintln(out, "  Desired size is already 0; skipping because of --skip-zero")
+		return errNodeGroupAtZero
+	}
+
+	fmt.Fprintln(out, "  ⚠️  Desired size is already 0, so no new instances will be started (use --skip-zero to skip idle node groups)")
+	return nil
+}
+
 // getASGConfig retrieves the current ASG configuration and instance IDs
 func getASGConfig(ctx context.Context, client *autoscaling.Client, asgName string) (*ASGConfig, []string, error) {
 	input := &autoscaling.DescribeAutoScalingGroupsInput{
@@ -179,8 +618,16 @@ func getASGConfig(ctx context.Context, client *autoscaling.Client, asgName strin
 	return config, instanceIDs, nil
 }
 
+// checkRefuseBelow refuses a desired capacity below the value of --refuse-below
+func checkRefuseBelow(desired, refuseBelow int32) error {
+	if desired < refuseBelow {
+		return fmt.Errorf("refusing to scale to desired capacity %d, below %d set with --refuse-below", desired, refuseBelow)
+	}
+	return nil
+}
+
 // scaleASG updates the ASG size
-func scaleASG(ctx context.Context, client *autoscaling.Client, asgName string, min, max, desired int32) error {
+func scaleASG(ctx context.Context, out io.Writer, client *autoscaling.Client, asgName string, min, max, desired int32) error {
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: &asgName,
 		MinSize:              &min,
@@ -193,27 +640,64 @@ func scaleASG(ctx context.Context, client *autoscaling.Client, asgName string, m
 		return fmt.Errorf("failed to update ASG: %w", err)
 	}
 
-	fmt.Printf("  Scaled to Min=%d, Max=%d, Desired=%d\n", min, max, desired)
+	fmt.Fprintf(out, "  Scaled to Min=%d, Max=%d, Desired=%d\n", min, max, desired)
 	return nil
 }
 
+// restoreASG scales the ASG back to its captured configuration and verifies that
+// DescribeAutoScalingGroups reports exactly that configuration afterwards
+func restoreASG(ctx context.Context, out io.Writer, client *autoscaling.Client, original *ASGConfig) error {
+	if err := scaleASG(ctx, out, client, original.Name, original.MinSize, original.MaxSize, original.DesiredSize); err != nil {
+		return err
+	}
+
+	restored, _, err := getASGConfig(ctx, client, original.Name)
+	if err != nil {
+		return fmt.Errorf("failed to verify restored configuration: %w", err)
+	}
+	if err := verifyASGConfig(original, restored); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "  ✓ Verified restored configuration")
+	return nil
+}
+
+// verifyASGConfig reports a mismatch between the expected and actual ASG sizes
+func verifyASGConfig(expected, actual *ASGConfig) error {
+	if actual.MinSize == expected.MinSize && actual.MaxSize == expected.MaxSize && actual.DesiredSize == expected.DesiredSize {
+		return nil
+	}
+	return fmt.Errorf("node group %s was left at Min=%d, Max=%d, Desired=%d; expected Min=%d, Max=%d, Desired=%d",
+		expected.Name, actual.MinSize, actual.MaxSize, actual.DesiredSize, expected.MinSize, expected.MaxSize, expected.DesiredSize)
+}
+
 // waitForInstanceStates waits for all instances to reach one of the specified states
-func waitForInstanceStates(ctx context.Context, client *ec2.Client, instanceIDs []string, targetStates []ec2types.InstanceStateName, pollInterval, timeout time.Duration, verbose bool) error {
+func waitForInstanceStates(ctx context.Context, client *ec2.Client, ngName string, instanceIDs []string, targetStates []ec2types.InstanceStateName, opts recycleOptions) error {
 	if len(instanceIDs) == 0 {
 		return nil
 	}
 
+	out := opts.Out
 	startTime := time.Now()
-	ticker := time.NewTicker(pollInterval)
+	ticker := time.NewTicker(opts.PollInterval)
 	defer ticker.Stop()
 
+	// One timer for the whole wait; a timer started in the select would restart every poll
+	timeout := time.NewTimer(opts.Timeout)
+	defer timeout.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(timeout):
+		case <-timeout.C:
 			return fmt.Errorf("timeout waiting for instances to reach target state")
 		case <-ticker.C:
+			if opts.beforeTerminatePoll != nil {
+				opts.beforeTerminatePoll(ctx)
+			}
+
 			// Check instance states
 			input := &ec2.DescribeInstancesInput{
 				InstanceIds: instanceIDs,
@@ -221,43 +705,54 @@ func waitForInstanceStates(ctx context.Context, client *ec2.Client, instanceIDs
 
 			result, err := client.DescribeInstances(ctx, input)
 			if err != nil {
-				if verbose {
-					fmt.Printf("  Warning: failed to describe instances: %v\n", err)
+				if opts.Verbose {
+					fmt.Fprintf(out, "  Warning: failed to describe instances: %v\n", err)
 				}
 				continue
 			}
 
-			allInTargetState := true
+			readyCount := 0
+			instanceCount := 0
 			stateCount := make(map[string]int)
 
 			for _, reservation := range result.Reservations {
 				for _, instance := range reservation.Instances {
 					stateName := instance.State.Name
 					stateCount[string(stateName)]++
+					instanceCount++
 
-					inTargetState := false
 					for _, targetState := range targetStates {
 						if stateName == targetState {
-							inTargetState = true
+							readyCount++
 							break
 						}
 					}
-
-					if !inTargetState {
-						allInTargetState = false
-					}
 				}
 			}
 
-			if verbose {
-				fmt.Printf("  [%s] Instance states: %v\n", time.Since(startTime).Round(time.Second), stateCount)
-			} else {
-				fmt.Print(".")
+			allInTargetState := readyCount == instanceCount
+
+			switch {
+			case opts.OnProgress != nil:
+				opts.OnProgress(ProgressEvent{
+					NodeGroup:      ngName,
+					Phase:          PhaseTerminating,
+					ElapsedSeconds: time.Since(startTime).Round(time.Second).Seconds(),
+					StateCount:     stateCount,
+					Instances:      instanceCount,
+					Ready:          readyCount,
+					Expected:       len(instanceIDs),
+					Done:           allInTargetState,
+				})
+			case opts.Verbose:
+				fmt.Fprintf(out, "  [%s] Instance states: %v\n", time.Since(startTime).Round(time.Second), stateCount)
+			default:
+				fmt.Fprint(out, ".")
 			}
 
 			if allInTargetState {
-				if !verbose {
-					fmt.Println()
+				if !opts.Verbose && opts.OnProgress == nil {
+					fmt.Fprintln(out)
 				}
 				return nil
 			}
@@ -266,16 +761,42 @@ func waitForInstanceStates(ctx context.Context, client *ec2.Client, instanceIDs
 }
 
 // waitForNewInstances waits for new instances to appear and reach pending state
-func waitForNewInstances(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, asgName string, expectedCount int, pollInterval, timeout time.Duration, verbose bool) error {
+func waitForNewInstances(ctx context.Context, asgClient *autoscaling.Client, ec2Client *ec2.Client, asgName string, expectedCount int, opts recycleOptions) error {
+	out := opts.Out
 	startTime := time.Now()
-	ticker := time.NewTicker(pollInterval)
+	ticker := time.NewTicker(opts.PollInterval)
 	defer ticker.Stop()
 
+	// report emits a poll result through the progress callback, or as verbose text or a dot
+	report := func(stateCount map[string]int, instanceCount, pendingCount int, verboseLine string) {
+		switch {
+		case opts.OnProgress != nil:
+			opts.OnProgress(ProgressEvent{
+				NodeGroup:      asgName,
+				Phase:          PhaseLaunching,
+				ElapsedSeconds: time.Since(startTime).Round(time.Second).Seconds(),
+				StateCount:     stateCount,
+				Instances:      instanceCount,
+				Ready:          pendingCount,
+				Expected:       expectedCount,
+				Done:           instanceCount >= expectedCount && pendingCount >= expectedCount,
+			})
+		case opts.Verbose:
+			fmt.Fprintln(out, verboseLine)
+		default:
+			fmt.Fprint(out, ".")
+		}
+	}
+
+	// One timer for the whole wait; a timer started in the select would restart every poll
+	timeout := time.NewTimer(opts.Timeout)
+	defer timeout.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(timeout):
+		case <-timeout.C:
 			return fmt.Errorf("timeout waiting for new instances")
 		case <-ticker.C:
 			// Get current ASG instances
@@ -285,8 +806,8 @@ func waitForNewInstances(ctx context.Context, asgClient *autoscaling.Client, ec2
 
 			result, err := asgClient.DescribeAutoScalingGroups(ctx, input)
 			if err != nil {
-				if verbose {
-					fmt.Printf("  Warning: failed to describe ASG: %v\n", err)
+				if opts.Verbose {
+					fmt.Fprintf(out, "  Warning: failed to describe ASG: %v\n", err)
 				}
 				continue
 			}
@@ -328,31 +849,23 @@ func waitForNewInstances(ctx context.Context, asgClient *autoscaling.Client, ec2
 							}
 						}
 
-						if verbose {
-							fmt.Printf("  [%s] Instances: %d/%d, States: %v\n",
-								time.Since(startTime).Round(time.Second),
-								pendingCount, expectedCount, stateCount)
-						} else {
-							fmt.Print(".")
-						}
+						report(stateCount, currentInstanceCount, pendingCount, fmt.Sprintf("  [%s] Instances: %d/%d, States: %v",
+							time.Since(startTime).Round(time.Second),
+							pendingCount, expectedCount, stateCount))
 
 						if pendingCount >= expectedCount {
-							if !verbose {
-								fmt.Println()
+							if !opts.Verbose && opts.OnProgress == nil {
+								fmt.Fprintln(out)
 							}
-							fmt.Printf("  %d instances are now starting (pending/running)\n", pendingCount)
+							fmt.Fprintf(out, "  %d instances are now starting (pending/running)\n", pendingCount)
 							return nil
 						}
 					}
 				}
 			} else {
-				if verbose {
-					fmt.Printf("  [%s] Waiting for instances to appear: %d/%d\n",
-						time.Since(startTime).Round(time.Second),
-						currentInstanceCount, expectedCount)
-				} else {
-					fmt.Print(".")
-				}
+				report(map[string]int{}, currentInstanceCount, 0, fmt.Sprintf("  [%s] Waiting for instances to appear: %d/%d",
+					time.Since(startTime).Round(time.Second),
+					currentInstanceCount, expectedCount))
 			}
 		}
 	}
@@ -0,0 +1,327 @@
+package recycle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awspkg "github.com/pischarti/nix/pkg/aws"
+)
+
+func TestJSONProgressWriter(t *testing.T) {
+	var out bytes.Buffer
+	onProgress := newJSONProgressWriter(&out)
+
+	onProgress(ProgressEvent{
+		NodeGroup:      "ng-workers-1",
+		Phase:          PhaseTerminating,
+		ElapsedSeconds: 15,
+		StateCount:     map[string]int{"shutting-down": 3, "terminated": 2},
+		Instances:      5,
+		Ready:          5,
+		Expected:       5,
+		Done:           true,
+	})
+	onProgress(ProgressEvent{
+		NodeGroup:      "ng-workers-1",
+		Phase:          PhaseLaunching,
+		ElapsedSeconds: 30,
+		StateCount:     map[string]int{},
+		Instances:      2,
+		Expected:       5,
+	})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), out.String())
+	}
+
+	want := `{"nodeGroup":"ng-workers-1","phase":"terminating","elapsedSeconds":15,"stateCount":{"shutting-down":3,"terminated":2},"instances":5,"ready":5,"expected":5,"done":true}`
+	if lines[0] != want {
+		t.Errorf("first line = %s\nwant %s", lines[0], want)
+	}
+
+	var event ProgressEvent
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatalf("second line is not valid JSON: %v", err)
+	}
+	if event.Phase != PhaseLaunching || event.Instances != 2 || event.Done {
+		t.Errorf("second event = %+v, want launching with 2 instances and not done", event)
+	}
+	if event.StateCount == nil {
+		t.Error("stateCount should be encoded as an empty object, not null")
+	}
+}
+
+func TestCheckIdleNodeGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		desired     int32
+		skipZero    bool
+		wantErr     error
+		wantWarning bool
+	}{
+		{name: "running node group", desired: 3, skipZero: true},
+		{name: "idle node group with skip-zero", desired: 0, skipZero: true, wantErr: errNodeGroupAtZero},
+		{name: "idle node group warns by default", desired: 0, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := checkIdleNodeGroup(&out, &ASGConfig{Name: "ng-batch", MaxSize: 5, DesiredSize: tt.desired}, tt.skipZero)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkIdleNodeGroup() error = %v, want %v", err, tt.wantErr)
+			}
+			if hasWarning := strings.Contains(out.String(), "⚠️"); hasWarning != tt.wantWarning {
+				t.Errorf("checkIdleNodeGroup() warning = %v, want %v; output:\n%s", hasWarning, tt.wantWarning, out.String())
+			}
+		})
+	}
+}
+
+func TestCheckRefuseBelow(t *testing.T) {
+	tests := []struct {
+		name        string
+		desired     int32
+		refuseBelow int32
+		wantErr     bool
+	}{
+		{name: "default allows zero", desired: 0, refuseBelow: 0},
+		{name: "at the limit", desired: 1, refuseBelow: 1},
+		{name: "below the limit", desired: 0, refuseBelow: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRefuseBelow(tt.desired, tt.refuseBelow); (err != nil) != tt.wantErr {
+				t.Errorf("checkRefuseBelow(%d, %d) error = %v, wantErr %v", tt.desired, tt.refuseBelow, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFloorAliasesRefuseBelow(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "floor refuses a restore below it", args: []string{"--floor", "2", "--restore", "0:3:1"}, wantErr: "below 2"},
+		{name: "floor and refuse-below together", args: []string{"--floor", "1", "--refuse-below", "1"}, wantErr: "set only one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRecycleCmd()
+			cmd.SetArgs(append([]string{"ng-system"}, tt.args...))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyASGConfig(t *testing.T) {
+	expected := &ASGConfig{Name: "ng-workers-1", MinSize: 1, MaxSize: 5, DesiredSize: 3}
+
+	if err := verifyASGConfig(expected, &ASGConfig{Name: "ng-workers-1", MinSize: 1, MaxSize: 5, DesiredSize: 3}); err != nil {
+		t.Errorf("verifyASGConfig() of a matching config error = %v", err)
+	}
+
+	err := verifyASGConfig(expected, &ASGConfig{Name: "ng-workers-1", MinSize: 0, MaxSize: 5, DesiredSize: 3})
+	if err == nil {
+		t.Fatal("verifyASGConfig() of a mismatched config succeeded, want an error")
+	}
+	if !strings.Contains(err.Error(), "left at Min=0, Max=5, Desired=3") {
+		t.Errorf("verifyASGConfig() error = %v, want it to report the actual sizes", err)
+	}
+}
+
+func TestParseRestoreConfig(t *testing.T) {
+	config, err := parseRestoreConfig("1:5:3")
+	if err != nil {
+		t.Fatalf("parseRestoreConfig() error = %v", err)
+	}
+	if config.MinSize != 1 || config.MaxSize != 5 || config.DesiredSize != 3 {
+		t.Errorf("parseRestoreConfig() = %+v, want Min=1, Max=5, Desired=3", config)
+	}
+	if got := formatRestoreConfig(config); got != "1:5:3" {
+		t.Errorf("formatRestoreConfig() = %q, want 1:5:3", got)
+	}
+
+	for _, value := range []string{"", "1:5", "1:5:3:2", "a:5:3", "-1:5:3", "4:5:3", "1:2:3"} {
+		if _, err := parseRestoreConfig(value); err == nil {
+			t.Errorf("parseRestoreConfig(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestWriteUnfinishedRecycleReport(t *testing.T) {
+	original := &ASGConfig{Name: "ng-workers-1", MinSize: 1, MaxSize: 5, DesiredSize: 3}
+
+	tests := []struct {
+		name      string
+		current   *ASGConfig
+		lookupErr error
+		outcome   string
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:    "scaled down",
+			outcome: "interrupted",
+			current: &ASGConfig{Name: "ng-workers-1"},
+			want: []string{
+				"Recycle interrupted",
+				"Original config: Min=1, Max=5, Desired=3",
+				"Current config: Min=0, Max=0, Desired=0",
+				"kaws aws ngs recycle ng-workers-1 --restore 1:5:3",
+			},
+		},
+		{
+			name:    "failed while scaled down",
+			outcome: "failed",
+			current: &ASGConfig{Name: "ng-workers-1"},
+			want: []string{
+				"Recycle failed",
+				"kaws aws ngs recycle ng-workers-1 --restore 1:5:3",
+			},
+		},
+		{
+			name:      "current state unknown",
+			outcome:   "interrupted",
+			lookupErr: errors.New("request timed out"),
+			want: []string{
+				"Current config: unknown (request timed out)",
+				"--restore 1:5:3",
+			},
+		},
+		{
+			name:    "unchanged",
+			outcome: "failed",
+			current: &ASGConfig{Name: "ng-workers-1", MinSize: 1, MaxSize: 5, DesiredSize: 3},
+			want:    []string{"size is unchanged"},
+			notWant: []string{"--restore"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writeUnfinishedRecycleReport(&out, original, tt.current, tt.lookupErr, tt.outcome)
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("report missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("report unexpectedly contains %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestASGNamesForNodeGroup(t *testing.T) {
+	nodeGroups := []awspkg.ASGNodeGroup{
+		{ASGName: "eks-ng-workers-b", NodeGroupName: "ng-workers", ClusterName: "prod"},
+		{ASGName: "eks-ng-workers-a", NodeGroupName: "ng-workers", ClusterName: "prod"},
+		{ASGName: "eks-ng-system", NodeGroupName: "ng-system", ClusterName: "prod"},
+		{ASGName: "eks-default-prod", NodeGroupName: "default", ClusterName: "prod"},
+		{ASGName: "eks-default-staging", NodeGroupName: "default", ClusterName: "staging"},
+	}
+
+	tests := []struct {
+		name      string
+		nodeGroup string
+		want      []string
+		wantErr   string
+	}{
+		{name: "single ASG", nodeGroup: "ng-system", want: []string{"eks-ng-system"}},
+		{name: "several ASGs in one cluster", nodeGroup: "ng-workers", want: []string{"eks-ng-workers-a", "eks-ng-workers-b"}},
+		{name: "unknown node group", nodeGroup: "ng-gone", wantErr: "no Auto Scaling Group"},
+		{name: "name shared by clusters", nodeGroup: "default", wantErr: "several clusters (prod, staging)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := asgNamesForNodeGroup(nodeGroups, tt.nodeGroup)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("asgNamesForNodeGroup() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("asgNamesForNodeGroup() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("asgNamesForNodeGroup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitersTimeOut(t *testing.T) {
+	// Every AWS call fails, so the waiters keep polling until their timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	ec2Client := ec2.New(ec2.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(server.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+	asgClient := autoscaling.New(autoscaling.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(server.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+	opts := recycleOptions{PollInterval: time.Millisecond, Timeout: 50 * time.Millisecond, Out: io.Discard}
+
+	waiters := map[string]func(ctx context.Context) error{
+		"instance states": func(ctx context.Context) error {
+			return waitForInstanceStates(ctx, ec2Client, "ng-workers-1", []string{"i-111"},
+				[]ec2types.InstanceStateName{ec2types.InstanceStateNameTerminated}, opts)
+		},
+		"new instances": func(ctx context.Context) error {
+			return waitForNewInstances(ctx, asgClient, ec2Client, "ng-workers-1", 1, opts)
+		},
+	}
+	for name, wait := range waiters {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := wait(ctx)
+			if err == nil || !strings.Contains(err.Error(), "timeout") {
+				t.Fatalf("waiter error = %v, want a timeout", err)
+			}
+			if ctx.Err() != nil {
+				t.Fatal("waiter ran until the test deadline instead of its own timeout")
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pischarti/nix/pkg/k8s"
+	pkgoperator "github.com/pischarti/nix/pkg/operator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// describeInstancesBatchSize bounds the instance IDs in one DescribeInstances filter
+const describeInstancesBatchSize = 200
+
+// NewDoctorCmd creates the doctor command, a read-only sweep for node problems
+func NewDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the cluster for node problems without changing anything",
+		Long: `Run a read-only health sweep that mirrors the operator's detection: find recent events
+matching the search terms, check that every node maps to an EC2 instance with a node group
+tag, and count the matching events per node group against the threshold. The report ends
+with what to do about each problem found.
+
+The command exits non-zero when it finds a problem, so it can gate scripts.`,
+		Args: cobra.NoArgs,
+		RunE: runDoctor,
+		Example: `  # Check for sandbox image failures in the last hour
+  kaws doctor
+
+  # Look further back, with a lower threshold
+  kaws doctor --since 6h --threshold 2
+
+  # Check for several error patterns in one namespace
+  kaws doctor --search "failed to get sandbox image" --search "ImagePullBackOff" --namespace default`,
+	}
+
+	cmd.Flags().StringSlice("search", []string{"failed to get sandbox image"}, "search terms to check for (can specify multiple)")
+	cmd.Flags().Int("threshold", 5, "number of events on a node group that the operator would recycle at")
+	cmd.Flags().Duration("since", time.Hour, "only count events last seen within this window")
+	cmd.Flags().Duration("timeout", k8s.DefaultTimeout, "deadline for cluster calls (e.g. 10s, 2m)")
+	cmd.Flags().Int("list-attempts", k8s.DefaultListAttempts, "maximum attempts for listing events on transient API server errors")
+	cmd.Flags().StringP("region", "r", "", "AWS region (default: from AWS config)")
+
+	return cmd
+}
+
+// runDoctor executes the doctor command
+func runDoctor(cmd *cobra.Command, args []string) error {
+	verbose := viper.GetBool("verbose")
+	namespace := viper.GetString("namespace")
+
+	searchTerms, _ := cmd.Flags().GetStringSlice("search")
+	threshold, _ := cmd.Flags().GetInt("threshold")
+	since, _ := cmd.Flags().GetDuration("since")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	listAttempts, _ := cmd.Flags().GetInt("list-attempts")
+	region, _ := cmd.Flags().GetString("region")
+
+	if len(searchTerms) == 0 {
+		return fmt.Errorf("at least one --search term is required")
+	}
+	if threshold < 1 {
+		return fmt.Errorf("--threshold must be at least 1")
+	}
+	if since <= 0 {
+		return fmt.Errorf("--since must be greater than zero")
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+	if listAttempts < 1 {
+		return fmt.Errorf("--list-attempts must be at least 1")
+	}
+
+	client, err := k8s.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Bound all cluster calls so an unreachable API server cannot hang the command
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if verbose {
+		fmt.Printf("Querying events and nodes (timeout %s)\n", timeout)
+	}
+
+	events, err := client.QueryEvents(ctx, k8s.EventQueryOptions{
+		Namespace:    namespace,
+		ListAttempts: listAttempts,
+	})
+	if err != nil {
+		return k8s.WrapTimeoutError(ctx, err, timeout)
+	}
+	matching := matchingEvents(events, searchTerms, time.Now().Add(-since))
+
+	enriched, err := client.EnrichEventsWithNodeInfo(ctx, matching, false)
+	if err != nil {
+		return fmt.Errorf("failed to look up the nodes of matching events: %w", k8s.WrapTimeoutError(ctx, err, timeout))
+	}
+
+	nodes, err := client.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", k8s.WrapTimeoutError(ctx, err, timeout))
+	}
+
+	// Load AWS config
+	cfg, err := config.LoadDefaultConfig(ctx, func(opts *config.LoadOptions) error {
+		if region != "" {
+			opts.Region = region
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	instanceIDs := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if id := pkgoperator.ExtractInstanceIDFromProviderID(node.Spec.ProviderID); id != "N/A" {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	instances, err := describeNodeGroups(ctx, ec2.NewFromConfig(cfg), instanceIDs)
+	if err != nil {
+		return err
+	}
+
+	r := buildReport(searchTerms, since, threshold, enriched, mapNodes(nodes.Items, instances))
+	if problems := printReport(os.Stdout, r); problems > 0 {
+		return fmt.Errorf("doctor found %d problem(s)", problems)
+	}
+	return nil
+}
+
+// matchingEvents returns the events matching any search term that were last seen at or after
+// cutoff. An event matching several terms is returned once.
+func matchingEvents(events []corev1.Event, searchTerms []string, cutoff time.Time) []corev1.Event {
+	seen := make(map[string]bool)
+	var matching []corev1.Event
+	for _, searchTerm := range searchTerms {
+		for _, event := range k8s.FilterEvents(events, searchTerm) {
+			key := event.Namespace + "/" + event.Name
+			if seen[key] || lastSeen(event).Before(cutoff) {
+				continue
+			}
+			seen[key] = true
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// lastSeen returns when an event last occurred, falling back from the last timestamp to the
+// event time and the creation time
+func lastSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// instanceNodeGroup is the node group DescribeInstances reported for one instance; ok is false
+// when the instance has no node group tag
+type instanceNodeGroup struct {
+	nodeGroup k8s.NodeGroup
+	ok        bool
+}
+
+// describeNodeGroups looks up the node group tags of the given instances. Instances that do
+// not exist are left out of the result rather than failing the call.
+func describeNodeGroups(ctx context.Context, ec2Client *ec2.Client, instanceIDs []string) (map[string]instanceNodeGroup, error) {
+	found := make(map[string]instanceNodeGroup, len(instanceIDs))
+	for start := 0; start < len(instanceIDs); start += describeInstancesBatchSize {
+		end := min(start+describeInstancesBatchSize, len(instanceIDs))
+
+		// An instance-id filter skips unknown IDs, where InstanceIds would fail the whole call
+		paginator := ec2.NewDescribeInstancesPaginator(ec2Client, &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: instanceIDs[start:end]}},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe node instances: %w", err)
+			}
+			for _, reservation := range page.Reservations {
+				for _, instance := range reservation.Instances {
+					ng, ok := k8s.NodeGroupFromEC2Tags(instance.Tags)
+					found[aws.ToString(instance.InstanceId)] = instanceNodeGroup{nodeGroup: ng, ok: ok}
+				}
+			}
+		}
+	}
+	return found, nil
+}
+
+// nodeMapping is how one node resolved to a node group; Problem is empty when it did
+type nodeMapping struct {
+	NodeName   string
+	InstanceID string
+	NodeGroup  k8s.NodeGroup
+	Problem    string
+}
+
+// mapNodes resolves each node to its node group through its provider ID and the described
+// instances, recording why it could not be resolved otherwise
+func mapNodes(nodes []corev1.Node, instances map[string]instanceNodeGroup) []nodeMapping {
+	mappings := make([]nodeMapping, 0, len(nodes))
+	for _, node := range nodes {
+		m := nodeMapping{NodeName: node.Name}
+		m.InstanceID = pkgoperator.ExtractInstanceIDFromProviderID(node.Spec.ProviderID)
+		instance, found := instances[m.InstanceID]
+		switch {
+		case m.InstanceID == "N/A":
+			m.Problem = "no EC2 instance ID in the provider ID"
+		case !found:
+			m.Problem = "instance not found in EC2"
+		case !instance.ok:
+			m.Problem = "instance has no node group tag"
+		default:
+			m.NodeGroup = instance.nodeGroup
+		}
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].NodeName < mappings[j].NodeName })
+	return mappings
+}
+
+// nodeGroupCount is the number of matching events on one node group
+type nodeGroupCount struct {
+	NodeGroup k8s.NodeGroup
+	Events    int
+}
+
+// report is the outcome of a doctor sweep
+type report struct {
+	SearchTerms    []string
+	Since          time.Duration
+	Threshold      int
+	MatchingEvents int
+	// UnmappedEvents counts matching events whose node or node group could not be resolved
+	UnmappedEvents int
+	Nodes          []nodeMapping
+	// NodeGroups is sorted by event count, highest first
+	NodeGroups []nodeGroupCount
+}
+
+// buildReport counts the matching events per node group. Pod events are attributed through
+// the pod's node and Node events through the node itself; events on other objects, or on
+// nodes without a node group, count as unmapped.
+func buildReport(searchTerms []string, since time.Duration, threshold int, events []k8s.EventWithNode, nodes []nodeMapping) report {
+	byNode := make(map[string]nodeMapping, len(nodes))
+	for _, m := range nodes {
+		byNode[m.NodeName] = m
+	}
+
+	r := report{
+		SearchTerms:    searchTerms,
+		Since:          since,
+		Threshold:      threshold,
+		MatchingEvents: len(events),
+		Nodes:          nodes,
+	}
+
+	counts := make(map[k8s.NodeGroup]int)
+	for _, event := range events {
+		nodeName := event.NodeName
+		if event.Event.InvolvedObject.Kind == k8s.KindNode {
+			nodeName = event.Event.InvolvedObject.Name
+		}
+		m, ok := byNode[nodeName]
+		if !ok || m.Problem != "" {
+			r.UnmappedEvents++
+			continue
+		}
+		counts[m.NodeGroup]++
+	}
+
+	for ng, count := range counts {
+		r.NodeGroups = append(r.NodeGroups, nodeGroupCount{NodeGroup: ng, Events: count})
+	}
+	sort.Slice(r.NodeGroups, func(i, j int) bool {
+		if r.NodeGroups[i].Events != r.NodeGroups[j].Events {
+			return r.NodeGroups[i].Events > r.NodeGroups[j].Events
+		}
+		return r.NodeGroups[i].NodeGroup.String() < r.NodeGroups[j].NodeGroup.String()
+	})
+
+	return r
+}
+
+// printReport writes the report with a suggested action for each problem and returns the
+// number of problems found: unresolvable nodes and node groups at or over the threshold
+func printReport(w io.Writer, r report) int {
+	problems := 0
+
+	fmt.Fprintln(w, "Events")
+	if r.MatchingEvents == 0 {
+		fmt.Fprintf(w, "  ✓ No events in the last %s matching %q\n", r.Since, r.SearchTerms)
+	} else {
+		fmt.Fprintf(w, "  ⚠️  %d event(s) in the last %s matching %q\n", r.MatchingEvents, r.Since, r.SearchTerms)
+		if r.UnmappedEvents > 0 {
+			fmt.Fprintf(w, "     %d of them could not be attributed to a node group\n", r.UnmappedEvents)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Node mapping")
+	unresolved := 0
+	for _, m := range r.Nodes {
+		if m.Problem == "" {
+			continue
+		}
+		unresolved++
+		fmt.Fprintf(w, "  ⚠️  %s (%s): %s\n", m.NodeName, m.InstanceID, m.Problem)
+	}
+	if unresolved == 0 {
+		fmt.Fprintf(w, "  ✓ All %d node(s) resolve to a node group\n", len(r.Nodes))
+	} else {
+		fmt.Fprintf(w, "  %d of %d node(s) cannot be resolved; the operator cannot attribute their events or recycle them\n", unresolved, len(r.Nodes))
+		problems += unresolved
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Node groups (threshold: %d)\n", r.Threshold)
+	if len(r.NodeGroups) == 0 {
+		fmt.Fprintln(w, "  ✓ No matching events on any node group")
+	}
+	for _, ng := range r.NodeGroups {
+		if ng.Events < r.Threshold {
+			fmt.Fprintf(w, "  %s: %d event(s)\n", ng.NodeGroup, ng.Events)
+			continue
+		}
+		problems++
+		fmt.Fprintf(w, "  ⚠️  %s: %d event(s), the operator would recycle it\n", ng.NodeGroup, ng.Events)
+		if ng.NodeGroup.Kind == k8s.NodeGroupKindKarpenter {
+			fmt.Fprintf(w, "     Recycle it by deleting its nodes: kubectl delete nodes -l %s=%s\n", k8s.TagKarpenterNodePool, ng.NodeGroup.Name)
+		} else {
+			fmt.Fprintf(w, "     Find its ASG with 'kaws aws ngs list', then run 'kaws aws ngs recycle <asg>'\n")
+		}
+	}
+
+	fmt.Fprintln(w)
+	if problems == 0 {
+		fmt.Fprintln(w, "✓ No node problems found")
+	} else {
+		fmt.Fprintf(w, "⚠️  Found %d problem(s)\n", problems)
+	}
+	return problems
+}
@@ -0,0 +1,174 @@
+package doctor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pischarti/nix/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchingEvents(t *testing.T) {
+	now := time.Now()
+	event := func(name, message string, age time.Duration) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Namespace: "default", Name: name},
+			Message:       message,
+			LastTimestamp: metav1.NewTime(now.Add(-age)),
+		}
+	}
+	events := []corev1.Event{
+		event("recent", "failed to get sandbox image", time.Minute),
+		event("old", "failed to get sandbox image", 2*time.Hour),
+		event("both", "failed to get sandbox image: ImagePullBackOff", time.Minute),
+		event("other", "Started container", time.Minute),
+	}
+
+	got := matchingEvents(events, []string{"sandbox image", "ImagePullBackOff"}, now.Add(-time.Hour))
+
+	var names []string
+	for _, e := range got {
+		names = append(names, e.Name)
+	}
+	if strings.Join(names, ",") != "recent,both" {
+		t.Errorf("matchingEvents() = %v, want [recent both]", names)
+	}
+}
+
+func TestMapNodes(t *testing.T) {
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-333"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-111"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1b/i-222"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-d"}},
+	}
+	instances := map[string]instanceNodeGroup{
+		"i-111": {nodeGroup: k8s.NodeGroup{Name: "workers", Kind: k8s.NodeGroupKindASG}, ok: true},
+		"i-222": {},
+	}
+
+	got := mapNodes(nodes, instances)
+
+	want := []nodeMapping{
+		{NodeName: "node-a", InstanceID: "i-111", NodeGroup: k8s.NodeGroup{Name: "workers", Kind: k8s.NodeGroupKindASG}},
+		{NodeName: "node-b", InstanceID: "i-222", Problem: "instance has no node group tag"},
+		{NodeName: "node-c", InstanceID: "i-333", Problem: "instance not found in EC2"},
+		{NodeName: "node-d", InstanceID: "N/A", Problem: "no EC2 instance ID in the provider ID"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mapNodes() returned %d mappings, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mapping %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	workers := k8s.NodeGroup{Name: "workers", Kind: k8s.NodeGroupKindASG}
+	batch := k8s.NodeGroup{Name: "batch", Kind: k8s.NodeGroupKindKarpenter}
+	nodes := []nodeMapping{
+		{NodeName: "node-a", InstanceID: "i-111", NodeGroup: workers},
+		{NodeName: "node-b", InstanceID: "i-222", NodeGroup: batch},
+		{NodeName: "node-c", InstanceID: "N/A", Problem: "no EC2 instance ID in the provider ID"},
+	}
+	podEvent := func(nodeName string) k8s.EventWithNode {
+		return k8s.EventWithNode{Event: corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: k8s.KindPod}}, NodeName: nodeName}
+	}
+	events := []k8s.EventWithNode{
+		podEvent("node-a"),
+		podEvent("node-a"),
+		podEvent("node-b"),
+		podEvent("node-c"),
+		podEvent("N/A"),
+		{Event: corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: k8s.KindNode, Name: "node-b"}}},
+		{Event: corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: k8s.KindDeployment, Name: "web"}}},
+	}
+
+	r := buildReport([]string{"sandbox"}, time.Hour, 2, events, nodes)
+
+	if r.MatchingEvents != 7 {
+		t.Errorf("MatchingEvents = %d, want 7", r.MatchingEvents)
+	}
+	if r.UnmappedEvents != 3 {
+		t.Errorf("UnmappedEvents = %d, want 3", r.UnmappedEvents)
+	}
+	want := []nodeGroupCount{{NodeGroup: batch, Events: 2}, {NodeGroup: workers, Events: 2}}
+	if len(r.NodeGroups) != len(want) {
+		t.Fatalf("NodeGroups = %+v, want %+v", r.NodeGroups, want)
+	}
+	for i := range want {
+		if r.NodeGroups[i] != want[i] {
+			t.Errorf("NodeGroups[%d] = %+v, want %+v", i, r.NodeGroups[i], want[i])
+		}
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	tests := []struct {
+		name         string
+		report       report
+		wantProblems int
+		wantContains []string
+	}{
+		{
+			name: "healthy",
+			report: report{
+				SearchTerms: []string{"sandbox"},
+				Since:       time.Hour,
+				Threshold:   5,
+				Nodes:       []nodeMapping{{NodeName: "node-a", InstanceID: "i-111"}},
+			},
+			wantProblems: 0,
+			wantContains: []string{"✓ No events in the last 1h0m0s", "✓ All 1 node(s) resolve", "✓ No node problems found"},
+		},
+		{
+			name: "unresolved node and node groups over threshold",
+			report: report{
+				SearchTerms:    []string{"sandbox"},
+				Since:          time.Hour,
+				Threshold:      2,
+				MatchingEvents: 6,
+				UnmappedEvents: 1,
+				Nodes: []nodeMapping{
+					{NodeName: "node-a", InstanceID: "i-111"},
+					{NodeName: "node-c", InstanceID: "N/A", Problem: "no EC2 instance ID in the provider ID"},
+				},
+				NodeGroups: []nodeGroupCount{
+					{NodeGroup: k8s.NodeGroup{Name: "workers", Kind: k8s.NodeGroupKindASG}, Events: 3},
+					{NodeGroup: k8s.NodeGroup{Name: "batch", Kind: k8s.NodeGroupKindKarpenter}, Events: 2},
+					{NodeGroup: k8s.NodeGroup{Name: "quiet", Kind: k8s.NodeGroupKindASG}, Events: 1},
+				},
+			},
+			wantProblems: 3,
+			wantContains: []string{
+				"6 event(s) in the last 1h0m0s",
+				"1 of them could not be attributed",
+				"node-c (N/A): no EC2 instance ID in the provider ID",
+				"workers: 3 event(s), the operator would recycle it",
+				"kaws aws ngs recycle <asg>",
+				"kubectl delete nodes -l karpenter.sh/nodepool=batch",
+				"  quiet: 1 event(s)\n",
+				"Found 3 problem(s)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := printReport(&out, tt.report); got != tt.wantProblems {
+				t.Errorf("printReport() = %d problems, want %d", got, tt.wantProblems)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
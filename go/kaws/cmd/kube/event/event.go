@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pischarti/nix/pkg/k8s"
 	"github.com/pischarti/nix/pkg/print"
@@ -16,25 +19,53 @@ func NewEventCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "event",
 		Short: "Query and filter Kubernetes events",
-		Long:  `Query Kubernetes events across all namespaces (or a specific namespace) and filter by message content`,
-		RunE:  runEvent,
+		Long: `Query Kubernetes events across all namespaces (or a specific namespace) and filter by message or reason content.
+Repeat --search to show events matching any of several terms.`,
+		RunE: runEvent,
 		Example: `  # Filter events containing "failed to get sandbox image"
   kaws kube event --search "failed to get sandbox image"
   
+  # Filter events matching any of several terms, like the operator's search terms
+  kaws kube event --search "failed to get sandbox image" --search "ImagePullBackOff"
+
   # Filter events in a specific namespace
   kaws kube event --search "ImagePullBackOff" --namespace default
   
   # Output in YAML format
   kaws kube event --search "error" --output yaml
+
+  # Output the matching events as JSON for scripting
+  kaws kube event --search "BackOff" --output json | jq '.[].involvedObject.name'
   
   # Include EC2 instance IDs
-  kaws kube event --search "failed to get sandbox image" --show-instance-id`,
+  kaws kube event --search "failed to get sandbox image" --show-instance-id
+  
+  # Fail fast if the API server is unreachable
+  kaws kube event --search "error" --timeout 10s
+
+  # Show only the 20 most recent matching events
+  kaws kube event --search "BackOff" --tail 20
+
+  # Only consider events last seen within the past hour
+  kaws kube event --search "failed to get sandbox image" --since 1h
+
+  # Match the event reason instead of the message
+  kaws kube event --search FailedCreatePodSandBox --match-field reason
+
+  # Print selected fields, like kubectl's custom-columns output
+  kaws kube event --search "error" --custom-columns NAMESPACE:.metadata.namespace,REASON:.reason,MSG:.message`,
 	}
 
 	// Add event-specific flags
-	cmd.Flags().StringP("search", "s", "", "search term to filter events (required)")
-	cmd.Flags().StringP("output", "o", "table", "output format: table or yaml")
+	cmd.Flags().StringArrayP("search", "s", nil, "search term to filter events; repeat to match any of several terms (required)")
+	cmd.Flags().StringP("output", "o", "table", "output format: table, yaml or json")
 	cmd.Flags().Bool("show-instance-id", false, "include EC2 instance IDs from node labels")
+	cmd.Flags().String("custom-columns", "", "print the given event fields as columns, e.g. NAMESPACE:.metadata.namespace,REASON:.reason,MSG:.message")
+	cmd.Flags().String("match-field", string(k8s.MatchMessage), "event field the search term is matched against: "+strings.Join(k8s.SupportedMatchFields, ", "))
+	cmd.Flags().Int("tail", 0, "show only the N most recent matching events (0 shows all)")
+	cmd.Flags().Duration("since", 0, "only show events last seen within this window, e.g. 30m or 1h (0 shows all)")
+	cmd.Flags().Duration("timeout", k8s.DefaultTimeout, "deadline for cluster calls (e.g. 10s, 2m)")
+	cmd.Flags().Int("list-attempts", k8s.DefaultListAttempts, "maximum attempts for listing events on transient API server errors")
 	cmd.MarkFlagRequired("search")
 
 	return cmd
@@ -46,11 +77,17 @@ func runEvent(cmd *cobra.Command, args []string) error {
 	verbose := viper.GetBool("verbose")
 	namespace := viper.GetString("namespace")
 
-	// Get search term from flag
-	searchTerm, err := cmd.Flags().GetString("search")
+	// Get search terms from flag
+	searchTerms, err := cmd.Flags().GetStringArray("search")
 	if err != nil {
 		return fmt.Errorf("failed to get search flag: %w", err)
 	}
+	for _, term := range searchTerms {
+		if term == "" {
+			return fmt.Errorf("--search terms must not be empty")
+		}
+	}
+	searchDesc := describeSearchTerms(searchTerms)
 
 	// Get output format from flag
 	outputFormat, err := cmd.Flags().GetString("output")
@@ -64,6 +101,73 @@ func runEvent(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get show-instance-id flag: %w", err)
 	}
 
+	// Get timeout flag
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("failed to get timeout flag: %w", err)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+
+	// Get custom-columns flag
+	customColumnsSpec, err := cmd.Flags().GetString("custom-columns")
+	if err != nil {
+		return fmt.Errorf("failed to get custom-columns flag: %w", err)
+	}
+	var customColumns []print.CustomColumn
+	if cmd.Flags().Changed("custom-columns") {
+		if cmd.Flags().Changed("output") {
+			return fmt.Errorf("--custom-columns cannot be combined with --output")
+		}
+		customColumns, err = print.ParseCustomColumns(customColumnsSpec)
+		if err != nil {
+			return fmt.Errorf("invalid --custom-columns: %w", err)
+		}
+	}
+
+	// Get match-field flag
+	matchFieldFlag, err := cmd.Flags().GetString("match-field")
+	if err != nil {
+		return fmt.Errorf("failed to get match-field flag: %w", err)
+	}
+	matchField, err := k8s.ParseEventMatchField(matchFieldFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --match-field: %w", err)
+	}
+
+	// Get tail flag
+	tail, err := cmd.Flags().GetInt("tail")
+	if err != nil {
+		return fmt.Errorf("failed to get tail flag: %w", err)
+	}
+	if tail < 0 {
+		return fmt.Errorf("--tail must not be negative")
+	}
+
+	// Get since flag
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return fmt.Errorf("failed to get since flag: %w", err)
+	}
+	if since < 0 {
+		return fmt.Errorf("--since must not be negative")
+	}
+	// window describes the --since window in messages, e.g. " in the last 1h0m0s"
+	window := ""
+	if since > 0 {
+		window = fmt.Sprintf(" in the last %s", since)
+	}
+
+	// Get list-attempts flag
+	listAttempts, err := cmd.Flags().GetInt("list-attempts")
+	if err != nil {
+		return fmt.Errorf("failed to get list-attempts flag: %w", err)
+	}
+	if listAttempts < 1 {
+		return fmt.Errorf("--list-attempts must be at least 1")
+	}
+
 	// Get Kubernetes client
 	client, err := k8s.NewClient()
 	if err != nil {
@@ -76,42 +180,82 @@ func runEvent(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Println("Querying events in all namespaces")
 		}
-		fmt.Printf("Filtering for events containing: %q\n", searchTerm)
+		fmt.Printf("Filtering for events whose %s contains: %s\n", matchField, searchDesc)
+		if since > 0 {
+			fmt.Printf("Filtering for events last seen%s\n", window)
+		}
 	}
 
+	// Bound all cluster calls so an unreachable API server cannot hang the command
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
 	// Query events using the common k8s package
-	events, err := client.QueryEvents(context.Background(), k8s.EventQueryOptions{
-		Namespace: namespace,
+	events, err := client.QueryEvents(ctx, k8s.EventQueryOptions{
+		Namespace:    namespace,
+		ListAttempts: listAttempts,
 	})
 	if err != nil {
-		return err
+		return k8s.WrapTimeoutError(ctx, err, timeout)
 	}
 
-	// Filter events matching the search term
-	matchingEvents := k8s.FilterEvents(events, searchTerm)
+	// Filter events matching any search term
+	matchingEvents := k8s.FilterEventsByTerms(events, searchTerms, matchField)
+
+	// Keep only the events last seen within the --since window
+	if since > 0 {
+		matchingEvents = k8s.FilterEventsSince(matchingEvents, time.Now().Add(-since))
+	}
 
 	// Display results
 	if len(matchingEvents) == 0 {
-		fmt.Printf("No events found matching %q\n", searchTerm)
+		if outputFormat == "json" && customColumns == nil {
+			// Scripts still get a JSON array; the message goes to stderr
+			fmt.Fprintf(os.Stderr, "No events found matching %s%s\n", searchDesc, window)
+			return print.EventsJSON(os.Stdout, matchingEvents)
+		}
+		fmt.Printf("No events found matching %s%s\n", searchDesc, window)
 		return nil
 	}
 
+	// Keep only the most recent events when --tail is set, reporting how many were dropped
+	totalMatching := len(matchingEvents)
+	if tail > 0 {
+		matchingEvents = k8s.TailEvents(matchingEvents, tail)
+	}
+	summary := fmt.Sprintf("Found %d event(s) matching %s%s:\n\n", totalMatching, searchDesc, window)
+	if len(matchingEvents) < totalMatching {
+		summary = fmt.Sprintf("Showing %d of %d event(s) matching %s%s (most recent first):\n\n",
+			len(matchingEvents), totalMatching, searchDesc, window)
+		if customColumns != nil || outputFormat != "table" {
+			// Keep machine-readable output clean
+			fmt.Fprintf(os.Stderr, "Showing %d of %d event(s) matching %s%s\n", len(matchingEvents), totalMatching, searchDesc, window)
+		}
+	}
+
+	// Custom columns read event fields only, so they need no node information
+	if customColumns != nil {
+		return print.EventsCustomColumns(os.Stdout, matchingEvents, customColumns)
+	}
+
 	// Enrich events with node information (and optionally EC2 instance IDs)
-	enrichedEvents, err := client.EnrichEventsWithNodeInfo(context.Background(), matchingEvents, showInstanceID)
+	enrichedEvents, err := client.EnrichEventsWithNodeInfo(ctx, matchingEvents, showInstanceID)
 	if err != nil {
 		// If we can't get node info, fall back to basic display
 		if verbose {
-			fmt.Fprintf(os.Stderr, "Warning: Could not fetch node information: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: Could not fetch node information: %v\n", k8s.WrapTimeoutError(ctx, err, timeout))
 		}
 		switch outputFormat {
 		case "yaml":
 			return print.EventsYAML(matchingEvents)
+		case "json":
+			return print.EventsJSON(os.Stdout, matchingEvents)
 		case "table":
-			fmt.Printf("Found %d event(s) matching %q:\n\n", len(matchingEvents), searchTerm)
+			fmt.Print(summary)
 			print.EventsTable(matchingEvents)
 			return nil
 		default:
-			return fmt.Errorf("unsupported output format: %s (supported: table, yaml)", outputFormat)
+			return fmt.Errorf("unsupported output format: %s (supported: table, yaml, json)", outputFormat)
 		}
 	}
 
@@ -119,11 +263,22 @@ func runEvent(cmd *cobra.Command, args []string) error {
 	switch outputFormat {
 	case "yaml":
 		return print.EventsYAML(matchingEvents)
+	case "json":
+		return print.EventsJSON(os.Stdout, matchingEvents)
 	case "table":
-		fmt.Printf("Found %d event(s) matching %q:\n\n", len(matchingEvents), searchTerm)
+		fmt.Print(summary)
 		print.EventsTableWithNodes(enrichedEvents)
 		return nil
 	default:
-		return fmt.Errorf("unsupported output format: %s (supported: table, yaml)", outputFormat)
+		return fmt.Errorf("unsupported output format: %s (supported: table, yaml, json)", outputFormat)
+	}
+}
+
+// describeSearchTerms quotes the search terms for messages, joining several with "or"
+func describeSearchTerms(searchTerms []string) string {
+	quoted := make([]string, len(searchTerms))
+	for i, term := range searchTerms {
+		quoted[i] = strconv.Quote(term)
 	}
+	return strings.Join(quoted, " or ")
 }
@@ -201,3 +201,22 @@ func TestFilterEvents_RealWorldExample(t *testing.T) {
 		}
 	}
 }
+
+func TestDescribeSearchTerms(t *testing.T) {
+	tests := []struct {
+		name        string
+		searchTerms []string
+		want        string
+	}{
+		{name: "single term", searchTerms: []string{"failed to get sandbox image"}, want: `"failed to get sandbox image"`},
+		{name: "several terms", searchTerms: []string{"BackOff", "ImagePullBackOff"}, want: `"BackOff" or "ImagePullBackOff"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeSearchTerms(tt.searchTerms); got != tt.want {
+				t.Errorf("describeSearchTerms() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,16 +13,19 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	kawsv1alpha1 "github.com/pischarti/nix/go/kaws/api/v1alpha1"
+	"github.com/pischarti/nix/go/kaws/cmd/aws/ngs/recycle"
 	"github.com/pischarti/nix/go/kaws/controllers"
 	"github.com/pischarti/nix/pkg/k8s"
 	pkgoperator "github.com/pischarti/nix/pkg/operator"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
@@ -33,7 +37,11 @@ func NewOperatorCmd() *cobra.Command {
 		Long: `Run kaws in operator mode to continuously watch for error events and automatically recycle problematic node groups.
 
 The operator watches for specified error patterns (e.g., "failed to get sandbox image"), identifies the affected node groups, 
-and automatically recycles them to resolve the issues.`,
+and automatically recycles them to resolve the issues.
+
+In standalone mode, sending the operator SIGHUP re-reads the config file and applies its
+watch_interval, search_terms and threshold without a restart. Settings given as flags on the
+command line keep their flag values, and an invalid file leaves the running configuration in place.`,
 		RunE: runOperator,
 		Example: `  # Run operator with default settings
   kaws operator
@@ -49,37 +57,189 @@ and automatically recycles them to resolve the issues.`,
   
   # With custom event threshold
   kaws operator --threshold 3
+
+  # Recycle at most one node group per check, deferring the rest
+  kaws operator --max-recycles-per-cycle 1
   
+  # Ignore events that already existed before this restart
+  kaws operator --reset-dedup-on-start=false
+
+  # Also count Normal events towards the threshold
+  kaws operator --event-type all
+
+  # Only watch and recycle specific node groups
+  kaws operator --node-group ng-risky --node-group ng-batch
+
+  # Log the top namespaces and reasons of matching events on every check
+  kaws operator --detail
+
+  # Match search terms against the stable event reason instead of the message
+  kaws operator --search FailedCreatePodSandBox --match-field reason
+
+  # Run a single check from a CronJob or CI step; the exit status reports the outcome
+  kaws operator --once --dry-run
+
+  # Load settings from the operator section of a config file; flags still override it
+  kaws operator --config ~/.kaws-operator.yaml --dry-run
+
+  # Apply an edited watch_interval, search_terms or threshold from the config file
+  kill -HUP <operator pid>
+
   # Use CRD-based configuration
-  kaws operator --use-crd`,
+  kaws operator --use-crd
+  
+  # Run in a restricted namespace without kube-system lease permissions
+  kaws operator --use-crd --leader-election-namespace kaws-system`,
 	}
 
 	cmd.Flags().Duration("watch-interval", 60*time.Second, "interval between event checks")
 	cmd.Flags().StringSlice("search", []string{"failed to get sandbox image"}, "search terms to watch for (can specify multiple)")
 	cmd.Flags().Int("threshold", 5, "number of events before triggering recycle")
+	cmd.Flags().Int("max-recycles-per-cycle", 0, "recycle at most this many node groups per check, deferring the rest to later checks (0 for no limit)")
+	cmd.Flags().Duration("recycle-cooldown", 30*time.Minute, "minimum time between two recycles of the same node group (0 to disable; standalone mode only)")
+	cmd.Flags().String("event-type", corev1.EventTypeWarning, "only count events of this type: Warning, Normal, or all")
 	cmd.Flags().Bool("dry-run", false, "log actions without actually recycling node groups")
+	cmd.Flags().Bool("once", false, "run a single check and exit: 0 when no node group reached the threshold, 2 when all that did were recycled, 3 otherwise (standalone mode only)")
+	cmd.Flags().String("match-field", string(k8s.MatchMessage), "event field the search terms are matched against: "+strings.Join(k8s.SupportedMatchFields, ", "))
+	cmd.Flags().Bool("detail", false, "log the top namespaces and reasons of matching events on every check (implied by --verbose)")
+	cmd.Flags().Duration("timeout", k8s.DefaultTimeout, "deadline for each event query against the cluster")
+	cmd.Flags().Int("list-attempts", k8s.DefaultListAttempts, "maximum attempts for each event list on transient API server errors")
 	cmd.Flags().StringP("region", "r", "", "AWS region (default: from AWS config)")
+	cmd.Flags().Bool("reset-dedup-on-start", true, "treat every existing matching event as new after a restart or leader failover; set to false to mark existing events as already processed")
+	cmd.Flags().StringSlice("watch-resource", k8s.DefaultWatchResources, "involved object kinds whose events are mapped to node groups: "+strings.Join(k8s.SupportedWatchResources, ", ")+" (can specify multiple)")
+	cmd.Flags().StringSlice("node-group", nil, "only detect and recycle these node groups (can specify multiple; standalone mode only)")
 	cmd.Flags().Bool("use-crd", false, "use EventRecycler CRD for configuration (requires CRD installed)")
+	cmd.Flags().Bool("record-events", false, "record Kubernetes Events on the EventRecycler for recycle actions (CRD mode only)")
+	cmd.Flags().String("leader-election-namespace", "kube-system", "namespace for the leader election lease (CRD mode only)")
+	cmd.Flags().Duration("lease-duration", 15*time.Second, "how long non-leaders wait before trying to acquire leadership (CRD mode only)")
+	cmd.Flags().Duration("renew-deadline", 10*time.Second, "how long the leader retries refreshing leadership before giving up (CRD mode only)")
+	cmd.Flags().Duration("retry-period", 2*time.Second, "how long clients wait between leader election attempts (CRD mode only)")
+	cmd.Flags().Duration("sync-period", 10*time.Minute, "how often the informer cache re-lists watched resources (CRD mode only)")
+	cmd.Flags().String("health-probe-addr", ":8081", "address the /healthz and /readyz probe endpoints bind to, or 0 to disable them (CRD mode only)")
 
 	return cmd
 }
 
+// operatorConfigKeys maps the config file keys of the "operator" section to the flags they
+// provide defaults for
+var operatorConfigKeys = map[string]string{
+	"operator.watch_interval":         "watch-interval",
+	"operator.search_terms":           "search",
+	"operator.threshold":              "threshold",
+	"operator.event_type":             "event-type",
+	"operator.dry_run":                "dry-run",
+	"operator.timeout":                "timeout",
+	"operator.list_attempts":          "list-attempts",
+	"operator.region":                 "region",
+	"operator.node_groups":            "node-group",
+	"operator.watch_resources":        "watch-resource",
+	"operator.max_recycles_per_cycle": "max-recycles-per-cycle",
+	"operator.recycle_cooldown":       "recycle-cooldown",
+	"operator.detail":                 "detail",
+	"operator.match_field":            "match-field",
+}
+
+// bindOperatorConfig binds each operator flag to its config file key, so a value from the
+// file (loaded with the global --config) applies unless the flag is set on the command line
+func bindOperatorConfig(v *viper.Viper, cmd *cobra.Command) error {
+	for key, flag := range operatorConfigKeys {
+		if err := v.BindPFlag(key, cmd.Flags().Lookup(flag)); err != nil {
+			return fmt.Errorf("failed to bind --%s to %s: %w", flag, key, err)
+		}
+	}
+	return nil
+}
+
 // runOperator executes the operator command
 func runOperator(cmd *cobra.Command, args []string) error {
 	verbose := viper.GetBool("verbose")
-	watchInterval, _ := cmd.Flags().GetDuration("watch-interval")
-	searchTerms, _ := cmd.Flags().GetStringSlice("search")
-	threshold, _ := cmd.Flags().GetInt("threshold")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-	region, _ := cmd.Flags().GetString("region")
+
+	// Settings shared with the config file: flags win, then the file, then flag defaults
+	if err := bindOperatorConfig(viper.GetViper(), cmd); err != nil {
+		return err
+	}
+	watchInterval := viper.GetDuration("operator.watch_interval")
+	searchTerms := viper.GetStringSlice("operator.search_terms")
+	threshold := viper.GetInt("operator.threshold")
+	eventTypeFlag := viper.GetString("operator.event_type")
+	dryRun := viper.GetBool("operator.dry_run")
+	timeout := viper.GetDuration("operator.timeout")
+	listAttempts := viper.GetInt("operator.list_attempts")
+	region := viper.GetString("operator.region")
+	nodeGroups := viper.GetStringSlice("operator.node_groups")
+	watchResourcesFlag := viper.GetStringSlice("operator.watch_resources")
+	maxRecyclesPerCycle := viper.GetInt("operator.max_recycles_per_cycle")
+	recycleCooldown := viper.GetDuration("operator.recycle_cooldown")
+	detail := viper.GetBool("operator.detail") || verbose
+	matchFieldFlag := viper.GetString("operator.match_field")
+
+	resetDedupOnStart, _ := cmd.Flags().GetBool("reset-dedup-on-start")
 	useCRD, _ := cmd.Flags().GetBool("use-crd")
+	once, _ := cmd.Flags().GetBool("once")
+	recordEvents, _ := cmd.Flags().GetBool("record-events")
+	leaderElectionNamespace, _ := cmd.Flags().GetString("leader-election-namespace")
+	leaseDuration, _ := cmd.Flags().GetDuration("lease-duration")
+	renewDeadline, _ := cmd.Flags().GetDuration("renew-deadline")
+	retryPeriod, _ := cmd.Flags().GetDuration("retry-period")
+	syncPeriod, _ := cmd.Flags().GetDuration("sync-period")
+	healthProbeAddr, _ := cmd.Flags().GetString("health-probe-addr")
+
+	if listAttempts < 1 {
+		return fmt.Errorf("--list-attempts must be at least 1")
+	}
+	if maxRecyclesPerCycle < 0 {
+		return fmt.Errorf("--max-recycles-per-cycle must not be negative")
+	}
+	if recycleCooldown < 0 {
+		return fmt.Errorf("--recycle-cooldown must not be negative")
+	}
+	eventType, err := parseEventType(eventTypeFlag)
+	if err != nil {
+		return err
+	}
+	watchResources, err := k8s.ParseWatchResources(watchResourcesFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --watch-resource: %w", err)
+	}
+	matchField, err := k8s.ParseEventMatchField(matchFieldFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --match-field: %w", err)
+	}
+	if useCRD && len(nodeGroups) > 0 {
+		return fmt.Errorf("--node-group is only supported in standalone mode")
+	}
+	if useCRD && once {
+		return fmt.Errorf("--once is only supported in standalone mode")
+	}
 
 	fmt.Println("🚀 Starting kaws operator...")
 	fmt.Printf("   Mode: %s\n", map[bool]string{true: "CRD-based", false: "Standalone"}[useCRD])
-	fmt.Printf("   Watch interval: %s\n", watchInterval)
+	if once {
+		fmt.Println("   Run: single check (--once)")
+	} else {
+		fmt.Printf("   Watch interval: %s\n", watchInterval)
+	}
 	fmt.Printf("   Search terms: %v\n", searchTerms)
+	fmt.Printf("   Match field: %s\n", matchField)
 	fmt.Printf("   Event threshold: %d\n", threshold)
+	if maxRecyclesPerCycle > 0 {
+		fmt.Printf("   Max recycles per cycle: %d\n", maxRecyclesPerCycle)
+	} else {
+		fmt.Println("   Max recycles per cycle: unlimited")
+	}
+	if !useCRD {
+		fmt.Printf("   Recycle cooldown: %s\n", recycleCooldown)
+	}
+	fmt.Printf("   Event type: %s\n", eventTypeFlag)
+	fmt.Printf("   Watched resources: %v\n", watchResources)
 	fmt.Printf("   Dry run: %v\n", dryRun)
+	fmt.Printf("   Event breakdown: %v\n", detail)
+	if len(nodeGroups) > 0 {
+		fmt.Printf("   Node groups: %v\n", nodeGroups)
+	}
+	fmt.Printf("   Dedup on start: %s\n", map[bool]string{true: "reset (existing events are treated as new)", false: "skip existing events"}[resetDedupOnStart])
+	fmt.Printf("   Query timeout: %s\n", timeout)
+	fmt.Printf("   List attempts: %d\n", listAttempts)
 	if region != "" {
 		fmt.Printf("   AWS region: %s\n", region)
 	}
@@ -89,16 +249,45 @@ func runOperator(cmd *cobra.Command, args []string) error {
 		fmt.Println("📋 CRD-based mode with informers (race-condition safe)")
 		fmt.Println("   Using controller-runtime with cached informers for efficient event watching")
 		fmt.Println()
-		return runCRDOperator(region, verbose)
+		return runCRDOperator(crdOperatorOptions{
+			Region:                  region,
+			RecordEvents:            recordEvents,
+			ListAttempts:            listAttempts,
+			IgnoreExistingEvents:    !resetDedupOnStart,
+			EventType:               eventType,
+			WatchResources:          watchResources,
+			MaxRecyclesPerCycle:     maxRecyclesPerCycle,
+			Detail:                  detail,
+			MatchField:              matchField,
+			DryRun:                  dryRun,
+			LeaderElectionNamespace: leaderElectionNamespace,
+			LeaseDuration:           leaseDuration,
+			RenewDeadline:           renewDeadline,
+			RetryPeriod:             retryPeriod,
+			SyncPeriod:              syncPeriod,
+			HealthProbeAddr:         healthProbeAddr,
+		}, verbose)
 	}
 
 	// Create operator config
 	opConfig := &pkgoperator.OperatorConfig{
-		WatchInterval:    watchInterval,
-		SearchTerms:      searchTerms,
-		RecycleThreshold: threshold,
-		DryRun:           dryRun,
-		ProcessedEvents:  make(map[string]time.Time),
+		WatchInterval:       watchInterval,
+		SearchTerms:         searchTerms,
+		RecycleThreshold:    threshold,
+		DryRun:              dryRun,
+		QueryTimeout:        timeout,
+		ListAttempts:        listAttempts,
+		NodeGroups:          nodeGroups,
+		EventType:           eventType,
+		WatchResources:      watchResources,
+		MaxRecyclesPerCycle: maxRecyclesPerCycle,
+		RecycleCooldown:     recycleCooldown,
+		Detail:              detail,
+		MatchField:          matchField,
+		ProcessedEvents:     make(map[string]time.Time),
+	}
+	if err := opConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid operator configuration: %w", err)
 	}
 
 	// Create Kubernetes client
@@ -107,8 +296,11 @@ func runOperator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
+	// SIGINT and SIGTERM cancel ctx, stopping a running recycle as well as the watch loop
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// Create AWS clients
-	ctx := context.Background()
 	awsCfg, err := config.LoadDefaultConfig(ctx, func(opts *config.LoadOptions) error {
 		if region != "" {
 			opts.Region = region
@@ -121,38 +313,183 @@ func runOperator(cmd *cobra.Command, args []string) error {
 
 	ec2Client := ec2.NewFromConfig(awsCfg)
 	asgClient := autoscaling.NewFromConfig(awsCfg)
+	opConfig.Recycle = newNodeGroupRecycler(asgClient, ec2Client)
 
-	// Setup signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if once {
+		// A single check: events that existed before are exactly what a one-shot run is for,
+		// so --reset-dedup-on-start=false is ignored
+		result, err := pkgoperator.CheckAndRecycle(ctx, k8sClient, ec2Client, asgClient, opConfig, verbose)
+		if err != nil {
+			return fmt.Errorf("check failed: %w", err)
+		}
+		// The outcome is an exit status, not a usage error
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		return onceOutcome(result)
+	}
+
+	// Setup signal handling for config reloads; shutdown signals cancel ctx
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
 
 	// Run operator loop
 	ticker := time.NewTicker(watchInterval)
 	defer ticker.Stop()
 
-	fmt.Println("✓ Operator is running. Press Ctrl+C to stop.")
+	fmt.Println("✓ Operator is running. Press Ctrl+C to stop, or send SIGHUP to reload the config file.")
 	fmt.Println()
 
+	// Without persisted dedup state, skipping history means marking what exists now as handled
+	if !resetDedupOnStart {
+		marked, err := pkgoperator.MarkExistingEventsProcessed(ctx, k8sClient, opConfig)
+		if err != nil {
+			return fmt.Errorf("failed to mark existing events as processed: %w", err)
+		}
+		fmt.Printf("✓ Marked %d existing matching event(s) as already processed\n", marked)
+	}
+
 	// Run first check immediately
-	if err := pkgoperator.CheckAndRecycle(ctx, k8sClient, ec2Client, asgClient, opConfig, verbose); err != nil {
+	if _, err := pkgoperator.CheckAndRecycle(ctx, k8sClient, ec2Client, asgClient, opConfig, verbose); err != nil {
 		fmt.Fprintf(os.Stderr, "⚠️  Error during check: %v\n", err)
 	}
 
 	for {
 		select {
-		case <-sigChan:
+		case <-ctx.Done():
 			fmt.Println("\n🛑 Shutting down operator...")
 			return nil
+		case <-reloadChan:
+			if err := reloadOperatorConfig(viper.GetViper(), opConfig); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Config not reloaded, keeping the current settings: %v\n", err)
+				continue
+			}
+			ticker.Reset(opConfig.WatchInterval)
+			fmt.Printf("🔄 Reloaded config: watch interval %s, search terms %v, threshold %d\n",
+				opConfig.WatchInterval, opConfig.SearchTerms, opConfig.RecycleThreshold)
 		case <-ticker.C:
-			if err := pkgoperator.CheckAndRecycle(ctx, k8sClient, ec2Client, asgClient, opConfig, verbose); err != nil {
+			if _, err := pkgoperator.CheckAndRecycle(ctx, k8sClient, ec2Client, asgClient, opConfig, verbose); err != nil {
 				fmt.Fprintf(os.Stderr, "⚠️  Error during check: %v\n", err)
 			}
 		}
 	}
 }
 
+// reloadOperatorConfig re-reads the config file and applies its watch interval, search terms
+// and threshold to opConfig. Flags set on the command line still take precedence, as they are
+// bound to the same keys. opConfig is left unchanged when the file cannot be read or the
+// resulting configuration is invalid.
+func reloadOperatorConfig(v *viper.Viper, opConfig *pkgoperator.OperatorConfig) error {
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	reloaded := *opConfig
+	reloaded.WatchInterval = v.GetDuration("operator.watch_interval")
+	reloaded.SearchTerms = v.GetStringSlice("operator.search_terms")
+	reloaded.RecycleThreshold = v.GetInt("operator.threshold")
+	if err := reloaded.Validate(); err != nil {
+		return fmt.Errorf("invalid operator configuration: %w", err)
+	}
+
+	opConfig.WatchInterval = reloaded.WatchInterval
+	opConfig.SearchTerms = reloaded.SearchTerms
+	opConfig.RecycleThreshold = reloaded.RecycleThreshold
+	return nil
+}
+
+// Exit statuses of a --once check; 1 remains the status of a failed check
+const (
+	OnceExitRecycled    = 2
+	OnceExitNotRecycled = 3
+)
+
+// ExitError ends the command with a specific process exit status. Its message, when not
+// empty, is printed to stderr by main.
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+// Error returns the message
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+// ExitCode returns the process exit status
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// onceOutcome prints the outcome of a --once check and returns nil when no node group
+// reached the threshold, or an ExitError telling whether all of those that did were recycled
+func onceOutcome(result pkgoperator.CheckResult) error {
+	if len(result.OverThreshold) == 0 {
+		fmt.Println("✓ No node group reached the threshold")
+		return nil
+	}
+
+	fmt.Printf("⚠️  %d node group(s) reached the threshold, %d recycled\n", len(result.OverThreshold), len(result.Recycled))
+	if len(result.Recycled) >= len(result.OverThreshold) {
+		return &ExitError{Code: OnceExitRecycled}
+	}
+	return &ExitError{Code: OnceExitNotRecycled}
+}
+
+// parseEventType validates an --event-type value and returns the event type to filter on,
+// or "" for all
+func parseEventType(value string) (string, error) {
+	switch value {
+	case corev1.EventTypeWarning, corev1.EventTypeNormal:
+		return value, nil
+	case "all":
+		return "", nil
+	default:
+		return "", fmt.Errorf("invalid --event-type %q (supported: %s, %s, all)", value, corev1.EventTypeWarning, corev1.EventTypeNormal)
+	}
+}
+
+// crdOperatorOptions holds the manager and leader election settings for CRD mode
+type crdOperatorOptions struct {
+	Region                  string
+	RecordEvents            bool
+	ListAttempts            int
+	IgnoreExistingEvents    bool
+	EventType               string
+	WatchResources          []string
+	MaxRecyclesPerCycle     int
+	Detail                  bool
+	MatchField              k8s.EventMatchField
+	DryRun                  bool
+	LeaderElectionNamespace string
+	LeaseDuration           time.Duration
+	RenewDeadline           time.Duration
+	RetryPeriod             time.Duration
+	SyncPeriod              time.Duration
+	HealthProbeAddr         string
+}
+
+// validate checks that the leader election timings are consistent
+func (o crdOperatorOptions) validate() error {
+	if o.LeaderElectionNamespace == "" {
+		return fmt.Errorf("--leader-election-namespace must not be empty")
+	}
+	if o.LeaseDuration <= 0 || o.RenewDeadline <= 0 || o.RetryPeriod <= 0 || o.SyncPeriod <= 0 {
+		return fmt.Errorf("--lease-duration, --renew-deadline, --retry-period and --sync-period must be greater than zero")
+	}
+	if o.RenewDeadline >= o.LeaseDuration {
+		return fmt.Errorf("--renew-deadline (%s) must be less than --lease-duration (%s)", o.RenewDeadline, o.LeaseDuration)
+	}
+	if o.RetryPeriod >= o.RenewDeadline {
+		return fmt.Errorf("--retry-period (%s) must be less than --renew-deadline (%s)", o.RetryPeriod, o.RenewDeadline)
+	}
+	return nil
+}
+
 // runCRDOperator runs the operator in CRD mode using controller-runtime with informers
-func runCRDOperator(region string, verbose bool) error {
+func runCRDOperator(crdOpts crdOperatorOptions, verbose bool) error {
+	if err := crdOpts.validate(); err != nil {
+		return err
+	}
+
 	// Setup logging
 	opts := zap.Options{
 		Development: verbose,
@@ -166,7 +503,13 @@ func runCRDOperator(region string, verbose bool) error {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(kawsv1alpha1.AddToScheme(scheme))
 
-	setupLog.Info("Starting manager with leader election")
+	setupLog.Info("Starting manager with leader election",
+		"namespace", crdOpts.LeaderElectionNamespace,
+		"leaseDuration", crdOpts.LeaseDuration,
+		"renewDeadline", crdOpts.RenewDeadline,
+		"retryPeriod", crdOpts.RetryPeriod,
+		"syncPeriod", crdOpts.SyncPeriod,
+		"healthProbeAddr", crdOpts.HealthProbeAddr)
 
 	// Create manager with informer cache and leader election
 	// The cache provides thread-safe, efficient access to Kubernetes resources
@@ -175,26 +518,50 @@ func runCRDOperator(region string, verbose bool) error {
 		Scheme: scheme,
 		Cache: cache.Options{
 			// Sync period for the informer cache (how often to re-list)
-			SyncPeriod: ptr(10 * time.Minute),
+			SyncPeriod: ptr(crdOpts.SyncPeriod),
 		},
 		// Leader election configuration
 		LeaderElection:          true,
 		LeaderElectionID:        "kaws-operator-lock",
-		LeaderElectionNamespace: "kube-system", // Use kube-system for cluster-scoped operators
-		// Recommended lease durations for production
-		LeaseDuration: ptr(15 * time.Second),
-		RenewDeadline: ptr(10 * time.Second),
-		RetryPeriod:   ptr(2 * time.Second),
+		LeaderElectionNamespace: crdOpts.LeaderElectionNamespace, // Defaults to kube-system for cluster-scoped operators
+		// Defaults are the recommended lease durations for production
+		LeaseDuration: ptr(crdOpts.LeaseDuration),
+		RenewDeadline: ptr(crdOpts.RenewDeadline),
+		RetryPeriod:   ptr(crdOpts.RetryPeriod),
+		// Serves /healthz and /readyz on every replica, leader or not, so followers pass
+		// their readiness gate while they wait for the lease
+		HealthProbeBindAddress: crdOpts.HealthProbeAddr,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to start manager: %w", err)
 	}
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return fmt.Errorf("unable to set up health check: %w", err)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		return fmt.Errorf("unable to set up ready check: %w", err)
+	}
 
 	// Setup the EventRecycler controller with informers
-	if err = (&controllers.EventRecyclerReconciler{
-		Client: mgr.GetClient(), // This client uses the cached informers
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	reconciler := &controllers.EventRecyclerReconciler{
+		Client:               mgr.GetClient(), // This client uses the cached informers
+		Scheme:               mgr.GetScheme(),
+		Region:               crdOpts.Region,
+		ListAttempts:         crdOpts.ListAttempts,
+		IgnoreExistingEvents: crdOpts.IgnoreExistingEvents,
+		EventType:            crdOpts.EventType,
+		WatchResources:       crdOpts.WatchResources,
+		MaxRecyclesPerCycle:  crdOpts.MaxRecyclesPerCycle,
+		Detail:               crdOpts.Detail,
+		MatchField:           crdOpts.MatchField,
+		DryRun:               crdOpts.DryRun,
+	}
+	if crdOpts.RecordEvents {
+		// Events show up in `kubectl describe eventrecycler`
+		reconciler.Recorder = mgr.GetEventRecorderFor("kaws-operator")
+		setupLog.Info("Recording Kubernetes Events for recycle actions")
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller: %w", err)
 	}
 
@@ -212,6 +579,14 @@ func runCRDOperator(region string, verbose bool) error {
 	return nil
 }
 
+// newNodeGroupRecycler returns the recycle action of the standalone operator, which recycles
+// the Auto Scaling Groups of the node group like 'kaws aws ngs recycle' would
+func newNodeGroupRecycler(asgClient *autoscaling.Client, ec2Client *ec2.Client) func(ctx context.Context, ng k8s.NodeGroup) error {
+	return func(ctx context.Context, ng k8s.NodeGroup) error {
+		return recycle.RecycleEventNodeGroup(ctx, asgClient, ec2Client, ng.Name, recycle.DefaultPollInterval, recycle.DefaultTimeout)
+	}
+}
+
 // ptr returns a pointer to the value
 func ptr[T any](v T) *T {
 	return &v
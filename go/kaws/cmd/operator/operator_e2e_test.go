@@ -263,6 +263,22 @@ spec:
         - operator
         - --use-crd
         - --verbose
+        ports:
+        - name: health
+          containerPort: 8081
+          protocol: TCP
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: health
+          initialDelaySeconds: 15
+          periodSeconds: 20
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: health
+          initialDelaySeconds: 5
+          periodSeconds: 10
         env:
         - name: AWS_REGION
           value: "us-east-1"
@@ -286,7 +302,7 @@ spec:
 		t.Fatalf("Failed to apply deployment: %v\n%s", err, output)
 	}
 
-	// Wait for operator pods to be running (no readiness probe defined)
+	// Wait for operator pods to be running and pass their /readyz probe
 	t.Log("Waiting for operator pods to be running...")
 	err := wait.PollImmediate(2*time.Second, 120*time.Second, func() (bool, error) {
 		pods, err := e.Clientset.CoreV1().Pods(e2eNamespace).List(ctx, metav1.ListOptions{
@@ -425,7 +425,7 @@ func testDetectErrorEvents(t *testing.T, env *TestEnvironment) {
 	}
 
 	// Run the check
-	err := pkgoperator.CheckAndRecycle(ctx, env.K8sClient, env.EC2Client, env.ASGClient, opConfig, true)
+	_, err := pkgoperator.CheckAndRecycle(ctx, env.K8sClient, env.EC2Client, env.ASGClient, opConfig, true)
 	if err != nil {
 		t.Errorf("CheckAndRecycle failed: %v", err)
 	}
@@ -517,7 +517,7 @@ func testNodeGroupRecycling(t *testing.T, env *TestEnvironment) {
 	}
 
 	// Run the check - should detect node group needing recycling
-	err := pkgoperator.CheckAndRecycle(ctx, env.K8sClient, env.EC2Client, env.ASGClient, opConfig, true)
+	_, err := pkgoperator.CheckAndRecycle(ctx, env.K8sClient, env.EC2Client, env.ASGClient, opConfig, true)
 	if err != nil {
 		t.Errorf("CheckAndRecycle failed: %v", err)
 	}
@@ -0,0 +1,260 @@
+package operator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pischarti/nix/pkg/k8s"
+	pkgoperator "github.com/pischarti/nix/pkg/operator"
+	"github.com/spf13/viper"
+)
+
+func TestCRDOperatorOptionsValidate(t *testing.T) {
+	defaults := crdOperatorOptions{
+		LeaderElectionNamespace: "kube-system",
+		LeaseDuration:           15 * time.Second,
+		RenewDeadline:           10 * time.Second,
+		RetryPeriod:             2 * time.Second,
+		SyncPeriod:              10 * time.Minute,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(o *crdOperatorOptions)
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			modify: func(o *crdOperatorOptions) {},
+		},
+		{
+			name:   "restricted namespace",
+			modify: func(o *crdOperatorOptions) { o.LeaderElectionNamespace = "kaws-system" },
+		},
+		{
+			name:    "empty namespace",
+			modify:  func(o *crdOperatorOptions) { o.LeaderElectionNamespace = "" },
+			wantErr: true,
+		},
+		{
+			name:    "renew deadline not less than lease duration",
+			modify:  func(o *crdOperatorOptions) { o.RenewDeadline = 15 * time.Second },
+			wantErr: true,
+		},
+		{
+			name:    "retry period not less than renew deadline",
+			modify:  func(o *crdOperatorOptions) { o.RetryPeriod = 10 * time.Second },
+			wantErr: true,
+		},
+		{
+			name:    "zero sync period",
+			modify:  func(o *crdOperatorOptions) { o.SyncPeriod = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaults
+			tt.modify(&opts)
+
+			err := opts.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseEventType(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "Warning", want: "Warning"},
+		{value: "Normal", want: "Normal"},
+		{value: "all", want: ""},
+		{value: "warning", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseEventType(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseEventType(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseEventType(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBindOperatorConfig(t *testing.T) {
+	const configFile = `
+operator:
+  watch_interval: 30s
+  search_terms:
+    - "failed to get sandbox image"
+    - "ImagePullBackOff"
+  threshold: 3
+  dry_run: true
+`
+
+	cmd := NewOperatorCmd()
+	if err := cmd.Flags().Parse([]string{"--threshold", "7"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(configFile)); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if err := bindOperatorConfig(v, cmd); err != nil {
+		t.Fatalf("bindOperatorConfig() error = %v", err)
+	}
+
+	// File values apply where no flag was given
+	if got := v.GetDuration("operator.watch_interval"); got != 30*time.Second {
+		t.Errorf("watch_interval = %s, want 30s", got)
+	}
+	wantTerms := []string{"failed to get sandbox image", "ImagePullBackOff"}
+	if got := v.GetStringSlice("operator.search_terms"); !reflect.DeepEqual(got, wantTerms) {
+		t.Errorf("search_terms = %v, want %v", got, wantTerms)
+	}
+	if !v.GetBool("operator.dry_run") {
+		t.Error("dry_run = false, want true from the config file")
+	}
+
+	// Flags given on the command line override the file
+	if got := v.GetInt("operator.threshold"); got != 7 {
+		t.Errorf("threshold = %d, want 7 from --threshold", got)
+	}
+
+	// Keys missing from the file fall back to the flag defaults
+	if got := v.GetString("operator.event_type"); got != "Warning" {
+		t.Errorf("event_type = %q, want the --event-type default", got)
+	}
+	if got := v.GetInt("operator.list_attempts"); got != 3 {
+		t.Errorf("list_attempts = %d, want the --list-attempts default", got)
+	}
+	if got := v.GetDuration("operator.recycle_cooldown"); got != 30*time.Minute {
+		t.Errorf("recycle_cooldown = %s, want the --recycle-cooldown default", got)
+	}
+
+	// Without a config file every setting comes from the flags
+	empty := viper.New()
+	if err := bindOperatorConfig(empty, NewOperatorCmd()); err != nil {
+		t.Fatalf("bindOperatorConfig() error = %v", err)
+	}
+	if got := empty.GetStringSlice("operator.search_terms"); !reflect.DeepEqual(got, []string{"failed to get sandbox image"}) {
+		t.Errorf("search_terms without a config file = %v, want the --search default", got)
+	}
+}
+
+func TestReloadOperatorConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "kaws.yaml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	writeConfig("operator:\n  watch_interval: 60s\n  threshold: 5\n")
+
+	// --threshold on the command line wins over every reload of the file
+	cmd := NewOperatorCmd()
+	if err := cmd.Flags().Parse([]string{"--threshold", "7"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if err := bindOperatorConfig(v, cmd); err != nil {
+		t.Fatalf("bindOperatorConfig() error = %v", err)
+	}
+
+	opConfig := &pkgoperator.OperatorConfig{
+		WatchInterval:    time.Minute,
+		SearchTerms:      []string{"failed to get sandbox image"},
+		RecycleThreshold: 7,
+		QueryTimeout:     30 * time.Second,
+		ListAttempts:     3,
+	}
+
+	writeConfig("operator:\n  watch_interval: 30s\n  search_terms:\n    - ImagePullBackOff\n  threshold: 2\n")
+	if err := reloadOperatorConfig(v, opConfig); err != nil {
+		t.Fatalf("reloadOperatorConfig() error = %v", err)
+	}
+	if opConfig.WatchInterval != 30*time.Second {
+		t.Errorf("WatchInterval = %s, want 30s from the edited file", opConfig.WatchInterval)
+	}
+	if want := []string{"ImagePullBackOff"}; !reflect.DeepEqual(opConfig.SearchTerms, want) {
+		t.Errorf("SearchTerms = %v, want %v", opConfig.SearchTerms, want)
+	}
+	if opConfig.RecycleThreshold != 7 {
+		t.Errorf("RecycleThreshold = %d, want 7 from --threshold", opConfig.RecycleThreshold)
+	}
+
+	// An invalid file is rejected and the running configuration kept
+	writeConfig("operator:\n  watch_interval: 0s\n")
+	if err := reloadOperatorConfig(v, opConfig); err == nil {
+		t.Error("reloadOperatorConfig() error = nil, want an error for a zero watch interval")
+	}
+	if opConfig.WatchInterval != 30*time.Second {
+		t.Errorf("WatchInterval = %s after a rejected reload, want 30s", opConfig.WatchInterval)
+	}
+}
+
+func TestOnceOutcome(t *testing.T) {
+	ngA := k8s.NodeGroup{Kind: k8s.NodeGroupKindASG, Name: "ng-a"}
+	ngB := k8s.NodeGroup{Kind: k8s.NodeGroupKindASG, Name: "ng-b"}
+
+	tests := []struct {
+		name     string
+		result   pkgoperator.CheckResult
+		wantCode int
+	}{
+		{name: "nothing over threshold", result: pkgoperator.CheckResult{}, wantCode: 0},
+		{
+			name:     "all recycled",
+			result:   pkgoperator.CheckResult{OverThreshold: []k8s.NodeGroup{ngA}, Recycled: []k8s.NodeGroup{ngA}},
+			wantCode: OnceExitRecycled,
+		},
+		{
+			name:     "some not recycled",
+			result:   pkgoperator.CheckResult{OverThreshold: []k8s.NodeGroup{ngA, ngB}, Recycled: []k8s.NodeGroup{ngA}},
+			wantCode: OnceExitNotRecycled,
+		},
+		{
+			name:     "dry run",
+			result:   pkgoperator.CheckResult{OverThreshold: []k8s.NodeGroup{ngA}},
+			wantCode: OnceExitNotRecycled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := onceOutcome(tt.result)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("onceOutcome() = %v, want nil", err)
+				}
+				return
+			}
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("onceOutcome() = %v, want an ExitError", err)
+			}
+			if exitErr.ExitCode() != tt.wantCode {
+				t.Errorf("onceOutcome() exit code = %d, want %d", exitErr.ExitCode(), tt.wantCode)
+			}
+		})
+	}
+}
@@ -3,40 +3,145 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	kawsv1alpha1 "github.com/pischarti/nix/go/kaws/api/v1alpha1"
+	"github.com/pischarti/nix/go/kaws/cmd/aws/ngs/recycle"
 	"github.com/pischarti/nix/pkg/k8s"
 )
 
+// Event reasons recorded on the EventRecycler object
+const (
+	// ReasonNodeGroupRecycled is recorded when a node group crosses the threshold and is recycled
+	ReasonNodeGroupRecycled = "NodeGroupRecycled"
+	// ReasonRecycleSkipped is recorded when a node group crosses the threshold but is not recycled
+	ReasonRecycleSkipped = "RecycleSkipped"
+)
+
+// Condition reasons set on the EventRecycler status
+const (
+	// ReasonCheckSucceeded is set on Ready and Degraded when an event check completes
+	ReasonCheckSucceeded = "CheckSucceeded"
+	// ReasonCheckFailed is set on Ready and Degraded when an event check fails
+	ReasonCheckFailed = "CheckFailed"
+	// ReasonRecycleFailed is set on Degraded when a node group of the last check failed to recycle
+	ReasonRecycleFailed = "RecycleFailed"
+	// ReasonRecycleUnavailable is set on Degraded when node groups of the last check could not
+	// be recycled because the operator has no AWS clients
+	ReasonRecycleUnavailable = "RecycleUnavailable"
+	// ReasonRecycleInProgress is set on Recycling while a node group is being recycled
+	ReasonRecycleInProgress = "RecycleInProgress"
+	// ReasonIdle is set on Recycling when no node group is being recycled
+	ReasonIdle = "Idle"
+)
+
+// Requeue cadence for EventRecyclers
+const (
+	// DefaultWatchInterval is used when an EventRecycler leaves spec.watchInterval unset
+	DefaultWatchInterval = 60 * time.Second
+	// MinWatchInterval is the shortest requeue interval; shorter spec values are raised to it
+	// so a typo like "1ms" cannot turn the reconciler into a tight loop against the API server
+	MinWatchInterval = 10 * time.Second
+)
+
+func init() {
+	// Serve the unmapped event counter on the manager's metrics endpoint
+	metrics.Registry.MustRegister(k8s.UnmappedEventsTotal)
+}
+
 // EventRecyclerReconciler reconciles an EventRecycler object
 type EventRecyclerReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	// Recorder emits Kubernetes Events for recycle actions; nil disables recording
+	Recorder record.EventRecorder
+
+	// ListAttempts bounds retries of the event list on transient API server errors;
+	// zero selects k8s.DefaultListAttempts
+	ListAttempts int
+
+	// IgnoreExistingEvents marks the events that already exist when this replica first
+	// reconciles an EventRecycler as processed instead of counting them. Reconciles only run
+	// on the leader, so after a failover the new leader skips the events seen before it took over.
+	IgnoreExistingEvents bool
+
+	// EventType restricts recycle decisions to events of this type, such as Warning;
+	// empty matches every type
+	EventType string
+
+	// WatchResources lists the involved object kinds whose events are mapped to node groups;
+	// empty selects k8s.DefaultWatchResources
+	WatchResources []string
+
+	// DryRun forces dry-run for every EventRecycler, whatever its spec.dryRun says
+	DryRun bool
+
+	// MaxRecyclesPerCycle caps the node groups recycled per EventRecycler in one reconcile;
+	// zero means no limit. Node groups over the cap are deferred to later reconciles.
+	MaxRecyclesPerCycle int
+
+	// Detail logs the top namespaces and reasons of the matching events of each check
+	Detail bool
+	// MatchField selects the event fields the search terms are matched against; empty
+	// matches the message
+	MatchField k8s.EventMatchField
+
+	// Region is the AWS region of the operator's clients, set with --region; empty uses the
+	// region of the default AWS config
+	Region string
+
 	// AWS clients
 	EC2Client *ec2.Client
 	ASGClient *autoscaling.Client
 
+	// awsConfig is the configuration the AWS clients were built from
+	awsConfig aws.Config
+
+	// regionClients caches the clients for the spec.awsRegion values that differ from the
+	// operator's region
+	regionClients map[string]awsClients
+
 	// Thread-safe tracking of processed events (uses metav1.Time for K8s compatibility)
 	processedEvents map[string]metav1.Time
+
+	// seededRecyclers records the EventRecyclers whose existing events have been marked
+	// processed when IgnoreExistingEvents is set
+	seededRecyclers map[types.NamespacedName]bool
+
+	// deferredRecycles carries, per EventRecycler, the event counts of node groups deferred
+	// by MaxRecyclesPerCycle into the next reconcile
+	deferredRecycles map[types.NamespacedName]k8s.NodeGroupEventCounts
+
+	// recycle performs the recycle of a node group; nil selects recycleNodeGroup. Tests
+	// replace it to observe which node groups would be mutated.
+	recycle func(ctx context.Context, ng k8s.NodeGroup) error
 }
 
 // +kubebuilder:rbac:groups=kaws.pischarti.dev,resources=eventrecyclers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kaws.pischarti.dev,resources=eventrecyclers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kaws.pischarti.dev,resources=eventrecyclers/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *EventRecyclerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -51,15 +156,15 @@ func (r *EventRecyclerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	log.Info("Reconciling EventRecycler", "name", eventRecycler.Name)
 
-	// Get watch interval from spec
-	watchInterval := 60 * time.Second
-	if eventRecycler.Spec.WatchInterval.Duration > 0 {
-		watchInterval = eventRecycler.Spec.WatchInterval.Duration
-	}
+	// Re-check on the configured cadence, not only when the resource changes
+	watchInterval := requeueInterval(eventRecycler.Spec)
 
 	// Process events and check for issues
 	if err := r.checkAndRecycle(ctx, &eventRecycler); err != nil {
 		log.Error(err, "failed to check and recycle")
+		setCondition(&eventRecycler, kawsv1alpha1.ConditionReady, metav1.ConditionFalse, ReasonCheckFailed, err.Error())
+		setCondition(&eventRecycler, kawsv1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonCheckFailed, err.Error())
+		r.updateStatus(ctx, &eventRecycler)
 		return ctrl.Result{RequeueAfter: watchInterval}, err
 	}
 
@@ -67,18 +172,40 @@ func (r *EventRecyclerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{RequeueAfter: watchInterval}, nil
 }
 
+// requeueInterval returns how long to wait before reconciling the EventRecycler again: its
+// spec.watchInterval, DefaultWatchInterval when unset, and never less than MinWatchInterval
+func requeueInterval(spec kawsv1alpha1.EventRecyclerSpec) time.Duration {
+	interval := spec.WatchInterval.Duration
+	if interval <= 0 {
+		return DefaultWatchInterval
+	}
+	if interval < MinWatchInterval {
+		return MinWatchInterval
+	}
+	return interval
+}
+
 // SetupWithManager sets up the controller with the Manager and configures informers
 func (r *EventRecyclerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Initialize AWS clients
 	ctx := context.Background()
-	cfg, err := config.LoadDefaultConfig(ctx)
+	cfg, err := config.LoadDefaultConfig(ctx, func(opts *config.LoadOptions) error {
+		if r.Region != "" {
+			opts.Region = r.Region
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
+	r.awsConfig = cfg
 	r.EC2Client = ec2.NewFromConfig(cfg)
 	r.ASGClient = autoscaling.NewFromConfig(cfg)
+	r.regionClients = make(map[string]awsClients)
 	r.processedEvents = make(map[string]metav1.Time)
+	r.seededRecyclers = make(map[types.NamespacedName]bool)
+	r.deferredRecycles = make(map[types.NamespacedName]k8s.NodeGroupEventCounts)
 
 	// The manager's cache automatically sets up informers for all watched types
 	// This provides thread-safe, cached access to events and avoids race conditions
@@ -95,33 +222,226 @@ func (r *EventRecyclerReconciler) checkAndRecycle(ctx context.Context, recycler
 
 	// Use pkg/k8s CheckAndRecycleWithStatus for the core logic
 	config := k8s.RecyclerConfig{
-		SearchTerms: recycler.Spec.SearchTerms,
-		Threshold:   recycler.Spec.Threshold,
-		DryRun:      recycler.Spec.DryRun,
+		SearchTerms:    recycler.Spec.SearchTerms,
+		Threshold:      recycler.Spec.Threshold,
+		DryRun:         r.isDryRun(recycler),
+		ListAttempts:   r.ListAttempts,
+		EventType:      r.EventType,
+		WatchResources: r.WatchResources,
+		Detail:         r.Detail,
+		MatchField:     r.MatchField,
+	}
+
+	// On the first reconcile after start or failover, treat the events that already exist as handled
+	key := types.NamespacedName{Namespace: recycler.Namespace, Name: recycler.Name}
+	if r.IgnoreExistingEvents && !r.seededRecyclers[key] {
+		marked, err := k8s.MarkExistingEventsProcessed(ctx, r.Client, config, r.processedEvents)
+		if err != nil {
+			return fmt.Errorf("failed to mark existing events as processed: %w", err)
+		}
+		r.seededRecyclers[key] = true
+		log.Info("Marked existing events as processed", "count", marked)
 	}
 
-	nodeGroupCounts, status, err := k8s.CheckAndRecycleWithStatus(ctx, r.Client, r.EC2Client, config, r.processedEvents)
+	clients := r.clientsForRegion(recycler.Spec.AWSRegion)
+	nodeGroupCounts, status, err := k8s.CheckAndRecycleWithStatus(ctx, r.Client, clients.ec2, config, r.processedEvents)
 	if err != nil {
 		return fmt.Errorf("failed to check and recycle: %w", err)
 	}
 
+	return r.applyRecycleDecisions(ctx, recycler, nodeGroupCounts, status)
+}
+
+// isDryRun reports whether recycles for this EventRecycler are only reported, either because
+// the operator runs with --dry-run or because the resource sets spec.dryRun
+func (r *EventRecyclerReconciler) isDryRun(recycler *kawsv1alpha1.EventRecycler) bool {
+	return r.DryRun || recycler.Spec.DryRun
+}
+
+// applyRecycleDecisions records the check results in the EventRecycler status and recycles the
+// node groups at or over the threshold, at most MaxRecyclesPerCycle of them. In dry-run the
+// status, including WouldRecycle, is still updated, but the recycle path is never called.
+// The Recycling condition is True while each recycle runs, and a failed recycle sets Degraded,
+// as does a node group that cannot be recycled for lack of AWS clients.
+func (r *EventRecyclerReconciler) applyRecycleDecisions(ctx context.Context, recycler *kawsv1alpha1.EventRecycler, nodeGroupCounts k8s.NodeGroupEventCounts, status k8s.RecyclerStatus) error {
+	log := log.FromContext(ctx)
+	dryRun := r.isDryRun(recycler)
+
+	// Pick the node groups over the threshold, deferring any beyond the per-cycle cap
+	if r.deferredRecycles == nil {
+		r.deferredRecycles = make(map[types.NamespacedName]k8s.NodeGroupEventCounts)
+	}
+	key := types.NamespacedName{Namespace: recycler.Namespace, Name: recycler.Name}
+	deferred := r.deferredRecycles[key]
+	if deferred == nil {
+		deferred = make(k8s.NodeGroupEventCounts)
+		r.deferredRecycles[key] = deferred
+	}
+	plan := k8s.PlanRecycles(nodeGroupCounts, deferred, recycler.Spec.Threshold, r.MaxRecyclesPerCycle)
+
 	// Update status
 	recycler.Status.EventCounts = status.EventCounts
 	recycler.Status.LastCheckTime = status.LastCheckTime
+	recycler.Status.WouldRecycle = nil
+	if dryRun {
+		recycler.Status.WouldRecycle = nodeGroupNames(plan.Recycle)
+	}
+	setCondition(recycler, kawsv1alpha1.ConditionReady, metav1.ConditionTrue, ReasonCheckSucceeded, "Last event check succeeded")
+	setCondition(recycler, kawsv1alpha1.ConditionDegraded, metav1.ConditionFalse, ReasonCheckSucceeded, "Last event check succeeded")
+	setCondition(recycler, kawsv1alpha1.ConditionRecycling, metav1.ConditionFalse, ReasonIdle, "No node group is being recycled")
+	r.updateStatus(ctx, recycler)
 
-	if err := r.Status().Update(ctx, recycler); err != nil {
-		log.Error(err, "failed to update EventRecycler status")
+	recycleFn := r.recycle
+	if recycleFn == nil {
+		recycleFn = func(ctx context.Context, ng k8s.NodeGroup) error {
+			return r.recycleNodeGroup(ctx, recycler.Spec, ng)
+		}
+	}
+
+	for _, ng := range plan.Deferred {
+		log.Info("Deferred recycle of node group; per-cycle limit reached", "nodeGroup", ng.String(), "count", nodeGroupCounts[ng], "limit", r.MaxRecyclesPerCycle)
 	}
 
-	// Check if any node groups exceed threshold and need actual recycling
-	for ng, count := range nodeGroupCounts {
-		if count >= recycler.Spec.Threshold && !recycler.Spec.DryRun {
-			log.Info("Triggering recycle for node group", "nodeGroup", ng)
-			// TODO: Implement actual recycling logic using ASGClient
-			// For now, just log
-			log.Info("⚠️  Automated recycling not yet fully implemented", "nodeGroup", ng)
+	// Recycle the node groups picked for this cycle
+	canRecycle := r.recycle != nil || (r.ASGClient != nil && r.EC2Client != nil)
+	var attempted bool
+	var failures, unavailable []string
+	for _, ng := range plan.Recycle {
+		count := nodeGroupCounts[ng]
+		if dryRun {
+			r.recordEvent(recycler, corev1.EventTypeNormal, ReasonRecycleSkipped,
+				"Dry run: would recycle node group %s after %d matching event(s) (threshold %d)", ng, count, recycler.Spec.Threshold)
+			continue
+		}
+
+		// Karpenter nodes are recycled by deleting the nodes, not by scaling an ASG
+		if ng.Kind == k8s.NodeGroupKindKarpenter {
+			log.Info("Node group is managed by Karpenter; delete its nodes to recycle", "nodePool", ng.Name, "count", count)
+			r.recordEvent(recycler, corev1.EventTypeWarning, ReasonRecycleSkipped,
+				"Karpenter node pool %s has %d matching event(s) (threshold %d); recycle by deleting its nodes", ng.Name, count, recycler.Spec.Threshold)
+			continue
 		}
+
+		// Recycling is only set when a recycle actually runs
+		if !canRecycle {
+			log.Info("Cannot recycle node group; AWS clients are not configured", "nodeGroup", ng.Name, "count", count)
+			r.recordEvent(recycler, corev1.EventTypeWarning, ReasonRecycleSkipped,
+				"Node group %s has %d matching event(s) (threshold %d) but cannot be recycled: AWS clients are not configured", ng, count, recycler.Spec.Threshold)
+			unavailable = append(unavailable, ng.String())
+			continue
+		}
+
+		attempted = true
+		setCondition(recycler, kawsv1alpha1.ConditionRecycling, metav1.ConditionTrue, ReasonRecycleInProgress, fmt.Sprintf("Recycling node group %s", ng))
+		r.updateStatus(ctx, recycler)
+		if err := recycleFn(ctx, ng); err != nil {
+			log.Error(err, "failed to recycle node group", "nodeGroup", ng.Name)
+			failures = append(failures, fmt.Sprintf("%s: %v", ng, err))
+			continue
+		}
+		r.recordEvent(recycler, corev1.EventTypeNormal, ReasonNodeGroupRecycled,
+			"Recycled node group %s after %d matching event(s) (threshold %d)", ng, count, recycler.Spec.Threshold)
+	}
+
+	if attempted {
+		setCondition(recycler, kawsv1alpha1.ConditionRecycling, metav1.ConditionFalse, ReasonIdle, "No node group is being recycled")
+		if len(failures) > 0 {
+			setCondition(recycler, kawsv1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonRecycleFailed,
+				"Failed to recycle "+strings.Join(failures, "; "))
+		}
+		r.updateStatus(ctx, recycler)
+	}
+	if len(unavailable) > 0 {
+		setCondition(recycler, kawsv1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonRecycleUnavailable,
+			"AWS clients are not configured; not recycled: "+strings.Join(unavailable, ", "))
+		r.updateStatus(ctx, recycler)
 	}
 
 	return nil
 }
+
+// updateStatus writes the status of the EventRecycler, logging a failure instead of returning
+// it so the check results still drive the recycles
+func (r *EventRecyclerReconciler) updateStatus(ctx context.Context, recycler *kawsv1alpha1.EventRecycler) {
+	if err := r.Status().Update(ctx, recycler); err != nil {
+		log.FromContext(ctx).Error(err, "failed to update EventRecycler status")
+	}
+}
+
+// setCondition sets a condition on the EventRecycler status for its current generation
+func setCondition(recycler *kawsv1alpha1.EventRecycler, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&recycler.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: recycler.Generation,
+	})
+}
+
+// recycleNodeGroup recycles the Auto Scaling Groups of the node group like 'kaws aws ngs
+// recycle' would, polling and timing out as the EventRecycler's spec sets. It returns once the
+// new instances are starting, so the reconcile runs for as long as the recycle does.
+func (r *EventRecyclerReconciler) recycleNodeGroup(ctx context.Context, spec kawsv1alpha1.EventRecyclerSpec, ng k8s.NodeGroup) error {
+	clients := r.clientsForRegion(spec.AWSRegion)
+	if clients.asg == nil || clients.ec2 == nil {
+		return fmt.Errorf("AWS clients are not configured")
+	}
+
+	pollInterval := spec.PollInterval.Duration
+	if pollInterval <= 0 {
+		pollInterval = recycle.DefaultPollInterval
+	}
+	timeout := spec.RecycleTimeout.Duration
+	if timeout <= 0 {
+		timeout = recycle.DefaultTimeout
+	}
+
+	log.FromContext(ctx).Info("Recycling node group", "nodeGroup", ng.Name, "pollInterval", pollInterval, "timeout", timeout)
+	return recycle.RecycleEventNodeGroup(ctx, clients.asg, clients.ec2, ng.Name, pollInterval, timeout)
+}
+
+// awsClients are the AWS clients of one region
+type awsClients struct {
+	ec2 *ec2.Client
+	asg *autoscaling.Client
+}
+
+// clientsForRegion returns the AWS clients for an EventRecycler's spec.awsRegion: the
+// operator's own clients when it is unset or names their region, otherwise clients built from
+// the same configuration for that region
+func (r *EventRecyclerReconciler) clientsForRegion(region string) awsClients {
+	if region == "" || region == r.awsConfig.Region || r.EC2Client == nil || r.ASGClient == nil {
+		return awsClients{ec2: r.EC2Client, asg: r.ASGClient}
+	}
+
+	if clients, ok := r.regionClients[region]; ok {
+		return clients
+	}
+	if r.regionClients == nil {
+		r.regionClients = make(map[string]awsClients)
+	}
+	cfg := r.awsConfig.Copy()
+	cfg.Region = region
+	clients := awsClients{ec2: ec2.NewFromConfig(cfg), asg: autoscaling.NewFromConfig(cfg)}
+	r.regionClients[region] = clients
+	return clients
+}
+
+// nodeGroupNames returns the sorted display names of the node groups
+func nodeGroupNames(nodeGroups []k8s.NodeGroup) []string {
+	var names []string
+	for _, ng := range nodeGroups {
+		names = append(names, ng.String())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recordEvent emits a Kubernetes Event on the EventRecycler when a recorder is configured
+func (r *EventRecyclerReconciler) recordEvent(recycler *kawsv1alpha1.EventRecycler, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(recycler, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,409 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kawsv1alpha1 "github.com/pischarti/nix/go/kaws/api/v1alpha1"
+	"github.com/pischarti/nix/pkg/k8s"
+)
+
+func TestApplyRecycleDecisions(t *testing.T) {
+	counts := k8s.NodeGroupEventCounts{
+		{Name: "ng-busy", Kind: k8s.NodeGroupKindASG}:      6,
+		{Name: "ng-quiet", Kind: k8s.NodeGroupKindASG}:     2,
+		{Name: "pool-a", Kind: k8s.NodeGroupKindKarpenter}: 7,
+		{Name: "ng-also-busy", Kind: k8s.NodeGroupKindASG}: 5,
+	}
+
+	tests := []struct {
+		name             string
+		specDryRun       bool
+		operatorDryRun   bool
+		maxRecycles      int
+		wantRecycled     []string
+		wantWouldRecycle []string
+	}{
+		{
+			name:             "spec dry run",
+			specDryRun:       true,
+			wantWouldRecycle: []string{"karpenter/pool-a", "ng-also-busy", "ng-busy"},
+		},
+		{
+			name:             "operator dry run",
+			operatorDryRun:   true,
+			wantWouldRecycle: []string{"karpenter/pool-a", "ng-also-busy", "ng-busy"},
+		},
+		{
+			name:         "live",
+			wantRecycled: []string{"ng-also-busy", "ng-busy"},
+		},
+		{
+			name:             "dry run with recycle limit",
+			specDryRun:       true,
+			maxRecycles:      2,
+			wantWouldRecycle: []string{"karpenter/pool-a", "ng-busy"},
+		},
+		{
+			name:         "live with recycle limit",
+			maxRecycles:  1,
+			wantRecycled: []string{},
+		},
+		{
+			name:         "live with recycle limit over Karpenter",
+			maxRecycles:  2,
+			wantRecycled: []string{"ng-busy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() error = %v", err)
+			}
+
+			recycler := &kawsv1alpha1.EventRecycler{
+				ObjectMeta: metav1.ObjectMeta{Name: "recycler"},
+				Spec: kawsv1alpha1.EventRecyclerSpec{
+					SearchTerms: []string{"failed to get sandbox image"},
+					Threshold:   5,
+					DryRun:      tt.specDryRun,
+				},
+			}
+			kubeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(recycler).
+				WithStatusSubresource(recycler).
+				Build()
+
+			recycled := map[string]bool{}
+			r := &EventRecyclerReconciler{
+				Client:              kubeClient,
+				Scheme:              scheme,
+				DryRun:              tt.operatorDryRun,
+				MaxRecyclesPerCycle: tt.maxRecycles,
+				recycle: func(ctx context.Context, ng k8s.NodeGroup) error {
+					recycled[ng.String()] = true
+					return nil
+				},
+			}
+
+			checkTime := metav1.Now()
+			status := k8s.RecyclerStatus{EventCounts: counts.ByName(), LastCheckTime: checkTime}
+			if err := r.applyRecycleDecisions(context.Background(), recycler, copyCounts(counts), status); err != nil {
+				t.Fatalf("applyRecycleDecisions() error = %v", err)
+			}
+
+			wantRecycled := map[string]bool{}
+			for _, name := range tt.wantRecycled {
+				wantRecycled[name] = true
+			}
+			if !reflect.DeepEqual(recycled, wantRecycled) {
+				t.Errorf("recycled node groups = %v, want %v", recycled, wantRecycled)
+			}
+
+			var got kawsv1alpha1.EventRecycler
+			if err := kubeClient.Get(context.Background(), types.NamespacedName{Name: "recycler"}, &got); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Status.EventCounts, counts.ByName()) {
+				t.Errorf("Status.EventCounts = %v, want %v", got.Status.EventCounts, counts.ByName())
+			}
+			if got.Status.LastCheckTime.IsZero() {
+				t.Error("Status.LastCheckTime was not set")
+			}
+			if !reflect.DeepEqual(got.Status.WouldRecycle, tt.wantWouldRecycle) {
+				t.Errorf("Status.WouldRecycle = %v, want %v", got.Status.WouldRecycle, tt.wantWouldRecycle)
+			}
+		})
+	}
+}
+
+func TestApplyRecycleDecisionsDefersOverLimit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	recycler := &kawsv1alpha1.EventRecycler{
+		ObjectMeta: metav1.ObjectMeta{Name: "recycler"},
+		Spec:       kawsv1alpha1.EventRecyclerSpec{SearchTerms: []string{"failed to get sandbox image"}, Threshold: 5},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(recycler).WithStatusSubresource(recycler).Build()
+
+	var recycled []string
+	r := &EventRecyclerReconciler{
+		Client:              kubeClient,
+		Scheme:              scheme,
+		MaxRecyclesPerCycle: 1,
+		recycle: func(ctx context.Context, ng k8s.NodeGroup) error {
+			recycled = append(recycled, ng.String())
+			return nil
+		},
+	}
+
+	// Two node groups cross the threshold together; the second waits for the next cycle
+	first := k8s.NodeGroupEventCounts{{Name: "ng-a", Kind: k8s.NodeGroupKindASG}: 7, {Name: "ng-b", Kind: k8s.NodeGroupKindASG}: 5}
+	if err := r.applyRecycleDecisions(context.Background(), recycler, first, k8s.RecyclerStatus{EventCounts: first.ByName()}); err != nil {
+		t.Fatalf("applyRecycleDecisions() error = %v", err)
+	}
+	// The next cycle sees no new events, since the earlier ones are marked processed
+	if err := r.applyRecycleDecisions(context.Background(), recycler, k8s.NodeGroupEventCounts{}, k8s.RecyclerStatus{}); err != nil {
+		t.Fatalf("applyRecycleDecisions() error = %v", err)
+	}
+
+	if want := []string{"ng-a", "ng-b"}; !reflect.DeepEqual(recycled, want) {
+		t.Errorf("recycled node groups = %v, want %v", recycled, want)
+	}
+}
+
+func TestApplyRecycleDecisionsConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	recycler := &kawsv1alpha1.EventRecycler{
+		ObjectMeta: metav1.ObjectMeta{Name: "recycler"},
+		Spec:       kawsv1alpha1.EventRecyclerSpec{SearchTerms: []string{"failed to get sandbox image"}, Threshold: 5},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(recycler).WithStatusSubresource(recycler).Build()
+
+	getConditions := func() []metav1.Condition {
+		var got kawsv1alpha1.EventRecycler
+		if err := kubeClient.Get(context.Background(), types.NamespacedName{Name: "recycler"}, &got); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return got.Status.Conditions
+	}
+
+	var recyclingDuringRecycle bool
+	r := &EventRecyclerReconciler{
+		Client: kubeClient,
+		Scheme: scheme,
+		recycle: func(ctx context.Context, ng k8s.NodeGroup) error {
+			recyclingDuringRecycle = meta.IsStatusConditionTrue(getConditions(), kawsv1alpha1.ConditionRecycling)
+			return errors.New("ASG not found")
+		},
+	}
+
+	counts := k8s.NodeGroupEventCounts{{Name: "ng-busy", Kind: k8s.NodeGroupKindASG}: 6}
+	if err := r.applyRecycleDecisions(context.Background(), recycler, counts, k8s.RecyclerStatus{EventCounts: counts.ByName()}); err != nil {
+		t.Fatalf("applyRecycleDecisions() error = %v", err)
+	}
+
+	if !recyclingDuringRecycle {
+		t.Error("Recycling condition was not True while the node group was recycled")
+	}
+	conditions := getConditions()
+	if !meta.IsStatusConditionTrue(conditions, kawsv1alpha1.ConditionReady) {
+		t.Errorf("Ready condition = %v, want True after a successful check", meta.FindStatusCondition(conditions, kawsv1alpha1.ConditionReady))
+	}
+	if !meta.IsStatusConditionFalse(conditions, kawsv1alpha1.ConditionRecycling) {
+		t.Errorf("Recycling condition = %v, want False once the recycle returned", meta.FindStatusCondition(conditions, kawsv1alpha1.ConditionRecycling))
+	}
+	degraded := meta.FindStatusCondition(conditions, kawsv1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRecycleFailed {
+		t.Errorf("Degraded condition = %v, want True with reason %s", degraded, ReasonRecycleFailed)
+	}
+
+	// A later check without failures clears Degraded
+	if err := r.applyRecycleDecisions(context.Background(), recycler, k8s.NodeGroupEventCounts{}, k8s.RecyclerStatus{}); err != nil {
+		t.Fatalf("applyRecycleDecisions() error = %v", err)
+	}
+	if !meta.IsStatusConditionFalse(getConditions(), kawsv1alpha1.ConditionDegraded) {
+		t.Errorf("Degraded condition = %v, want False after a clean check", meta.FindStatusCondition(getConditions(), kawsv1alpha1.ConditionDegraded))
+	}
+}
+
+func TestApplyRecycleDecisionsWithoutAWSClients(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	recycler := &kawsv1alpha1.EventRecycler{
+		ObjectMeta: metav1.ObjectMeta{Name: "recycler"},
+		Spec:       kawsv1alpha1.EventRecyclerSpec{SearchTerms: []string{"failed to get sandbox image"}, Threshold: 5},
+	}
+	var recyclingSet bool
+	kubeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(recycler).
+		WithStatusSubresource(recycler).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if meta.IsStatusConditionTrue(obj.(*kawsv1alpha1.EventRecycler).Status.Conditions, kawsv1alpha1.ConditionRecycling) {
+					recyclingSet = true
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	// No recycle hook and no AWS clients: nothing can be recycled
+	r := &EventRecyclerReconciler{Client: kubeClient, Scheme: scheme}
+	counts := k8s.NodeGroupEventCounts{{Name: "ng-busy", Kind: k8s.NodeGroupKindASG}: 6}
+	if err := r.applyRecycleDecisions(context.Background(), recycler, counts, k8s.RecyclerStatus{EventCounts: counts.ByName()}); err != nil {
+		t.Fatalf("applyRecycleDecisions() error = %v", err)
+	}
+
+	if recyclingSet {
+		t.Error("Recycling condition was set True although no recycle could run")
+	}
+	var got kawsv1alpha1.EventRecycler
+	if err := kubeClient.Get(context.Background(), types.NamespacedName{Name: "recycler"}, &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	degraded := meta.FindStatusCondition(got.Status.Conditions, kawsv1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRecycleUnavailable {
+		t.Errorf("Degraded condition = %v, want True with reason %s", degraded, ReasonRecycleUnavailable)
+	}
+}
+
+func TestReconcileSetsConditionsOnCheckFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("clientgoscheme.AddToScheme() error = %v", err)
+	}
+	if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	recycler := &kawsv1alpha1.EventRecycler{
+		ObjectMeta: metav1.ObjectMeta{Name: "recycler", Namespace: "default"},
+		Spec:       kawsv1alpha1.EventRecyclerSpec{SearchTerms: []string{"failed to get sandbox image"}, Threshold: 5},
+	}
+	kubeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(recycler).
+		WithStatusSubresource(recycler).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("connection refused")
+			},
+		}).
+		Build()
+
+	r := &EventRecyclerReconciler{Client: kubeClient, Scheme: scheme}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "recycler"},
+	}); err == nil {
+		t.Fatal("Reconcile() error = nil, want the event list failure")
+	}
+
+	var got kawsv1alpha1.EventRecycler
+	if err := kubeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "recycler"}, &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	ready := meta.FindStatusCondition(got.Status.Conditions, kawsv1alpha1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != ReasonCheckFailed {
+		t.Errorf("Ready condition = %v, want False with reason %s", ready, ReasonCheckFailed)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, kawsv1alpha1.ConditionDegraded) {
+		t.Errorf("Degraded condition = %v, want True", meta.FindStatusCondition(got.Status.Conditions, kawsv1alpha1.ConditionDegraded))
+	}
+}
+
+// copyCounts returns a copy of counts, since applyRecycleDecisions adds deferred counts into it
+func TestReconcileRequeuesOnWatchInterval(t *testing.T) {
+	tests := []struct {
+		name          string
+		watchInterval time.Duration
+		want          time.Duration
+	}{
+		{name: "spec interval", watchInterval: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "unset uses default", watchInterval: 0, want: DefaultWatchInterval},
+		{name: "too short is clamped", watchInterval: time.Millisecond, want: MinWatchInterval},
+		{name: "minimum is kept", watchInterval: MinWatchInterval, want: MinWatchInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatalf("clientgoscheme.AddToScheme() error = %v", err)
+			}
+			if err := kawsv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() error = %v", err)
+			}
+
+			recycler := &kawsv1alpha1.EventRecycler{
+				ObjectMeta: metav1.ObjectMeta{Name: "recycler", Namespace: "default"},
+				Spec: kawsv1alpha1.EventRecyclerSpec{
+					WatchInterval: metav1.Duration{Duration: tt.watchInterval},
+					SearchTerms:   []string{"failed to get sandbox image"},
+					Threshold:     5,
+				},
+			}
+			kubeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(recycler).
+				WithStatusSubresource(recycler).
+				Build()
+
+			r := &EventRecyclerReconciler{Client: kubeClient, Scheme: scheme}
+			result, err := r.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "recycler"},
+			})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if result.RequeueAfter != tt.want {
+				t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, tt.want)
+			}
+		})
+	}
+}
+
+func copyCounts(counts k8s.NodeGroupEventCounts) k8s.NodeGroupEventCounts {
+	c := make(k8s.NodeGroupEventCounts, len(counts))
+	for ng, count := range counts {
+		c[ng] = count
+	}
+	return c
+}
+
+func TestClientsForRegion(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	r := &EventRecyclerReconciler{
+		awsConfig: cfg,
+		EC2Client: ec2.NewFromConfig(cfg),
+		ASGClient: autoscaling.NewFromConfig(cfg),
+	}
+
+	// Unset or the operator's own region use the operator's clients
+	for _, region := range []string{"", "us-east-1"} {
+		if clients := r.clientsForRegion(region); clients.ec2 != r.EC2Client || clients.asg != r.ASGClient {
+			t.Errorf("clientsForRegion(%q) did not return the operator's clients", region)
+		}
+	}
+
+	clients := r.clientsForRegion("us-west-2")
+	if got := clients.ec2.Options().Region; got != "us-west-2" {
+		t.Errorf("EC2 client region = %q, want us-west-2", got)
+	}
+	if got := clients.asg.Options().Region; got != "us-west-2" {
+		t.Errorf("Auto Scaling client region = %q, want us-west-2", got)
+	}
+	if again := r.clientsForRegion("us-west-2"); again != clients {
+		t.Error("clientsForRegion() built new clients for a region it already had")
+	}
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/pischarti/nix/go/kaws/cmd/aws"
+	"github.com/pischarti/nix/go/kaws/cmd/doctor"
 	"github.com/pischarti/nix/go/kaws/cmd/kube"
 	"github.com/pischarti/nix/go/kaws/cmd/operator"
 	"github.com/pischarti/nix/pkg/config"
@@ -62,10 +64,19 @@ func init() {
 	rootCmd.AddCommand(kube.NewKubeCmd())
 	rootCmd.AddCommand(aws.NewAWSCmd())
 	rootCmd.AddCommand(operator.NewOperatorCmd())
+	rootCmd.AddCommand(doctor.NewDoctorCmd())
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		// Commands such as operator --once report their outcome through the exit status
+		var exitErr *operator.ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Message != "" {
+				fmt.Fprintln(os.Stderr, exitErr.Message)
+			}
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
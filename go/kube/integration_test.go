@@ -58,7 +58,7 @@ func TestPrintImagesTable_Integration(t *testing.T) {
 	}
 
 	// Call the function
-	print.PrintImagesTable(images, "", true, "simple", "image")
+	print.PrintImagesTable(images, "", true, "simple", "image", true, false)
 
 	// Close the write end and restore stdout
 	w.Close()
@@ -96,7 +96,7 @@ func TestPrintImagesTableWithNamespaces_Integration(t *testing.T) {
 	}
 
 	// Call the function
-	print.PrintImagesTableWithNamespaces(imageNamespaceMap, "simple", "namespace")
+	print.PrintImagesTableWithNamespaces(imageNamespaceMap, "simple", "namespace", true, false)
 
 	// Close the write end and restore stdout
 	w.Close()
@@ -11,12 +11,12 @@ func main() {
 
 	app.SubCommand("images", container.ImagesHandler,
 		gofr.AddDescription("List container images running in the cluster"),
-		gofr.AddHelp("Usage: kube images [--namespace NAMESPACE | --all-namespaces] [--by-pod] [--table] [--style STYLE] [--sort SORT]"),
+		gofr.AddHelp("Usage: kube images [--namespace NAMESPACE | --all-namespaces] [--by-pod] [--by-registry] [--by-namespace] [--digest] [--output wide] [--table] [--style STYLE] [--sort SORT] [--timeout DURATION] [--watch [--refresh DURATION]]"),
 	)
 
 	app.SubCommand("services", container.ServicesHandler,
 		gofr.AddDescription("List Kubernetes services with annotations matching specified criteria"),
-		gofr.AddHelp("Usage: kube services [--namespace NAMESPACE | --all-namespaces] [--table] [--style STYLE] [--sort SORT] [--annotation-value VALUE]"),
+		gofr.AddHelp("Usage: kube services [--namespace NAMESPACE | --all-namespaces] [--table] [--style STYLE] [--sort SORT] [--sort-annotations ORDER] [--annotation-value VALUE] [--orphaned] [--timeout DURATION] [--watch [--refresh DURATION]]"),
 	)
 
 	app.Run()
@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	printpkg "github.com/pischarti/nix/pkg/print"
+	"github.com/pischarti/nix/pkg/vpc"
+	"gofr.dev/pkg/gofr"
+)
+
+// ListALBs handles the alb command for listing AWS Application Load Balancers
+func ListALBs(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws alb --vpc VPC_ID [--zone AZ]... [--state STATE] [--sort SORT_BY] [--with-details | --by-zone] [--no-tags] [--color WHEN] [--no-headers] [--output FORMAT]")
+			fmt.Println("Options:")
+			fmt.Println("  --vpc VPC_ID    VPC ID to list ALBs for (required)")
+			fmt.Println("  --zone AZ       Filter by availability zone; repeat to include several zones (optional)")
+			fmt.Println("  --state STATE   Filter by state: active, provisioning, active_impaired, failed (optional)")
+			fmt.Println("  --sort SORT_BY  Sort by: name (default), state, type, scheme, created")
+			fmt.Println("  --with-details  Add CrossZone and AccessLogs columns from the ALB attributes")
+			fmt.Println("  --no-tags       Skip fetching tags for a faster listing; Name shows the load balancer name and Tags is empty")
+			fmt.Println("  --by-zone       List the ALBs covering each zone instead of the ALB table")
+			fmt.Println("  --color WHEN    Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --no-headers    Print only the data rows, space-separated, for piping into awk or cut")
+			fmt.Println("  --output FORMAT Output format: table (default), yaml, json")
+			fmt.Println("  --verbose, -v   Log the AWS region, each API call with its timing, and a call count to stderr")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := vpc.ParseALBArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.VPCID == "" {
+		return nil, fmt.Errorf("vpc parameter is required")
+	}
+
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listLoadBalancers(opts, elbv2types.LoadBalancerTypeEnumApplication, useColor)
+}
+
+// ALBRouter routes alb sub-commands
+func ALBRouter(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for sub-commands first
+	if len(args) >= 2 && args[1] == "list" {
+		// Remove the "list" argument and pass the rest to ListALBs
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		return ListALBs(ctx)
+	}
+
+	// Check for help flag for main alb command
+	if len(args) == 2 && (args[1] == "-h" || args[1] == "--help") {
+		fmt.Println("Usage: aws alb [COMMAND]")
+		fmt.Println("Commands:")
+		fmt.Println("  list               List all Application Load Balancers in a VPC (default)")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  aws alb --vpc vpc-12345678")
+		fmt.Println("  aws alb list --vpc vpc-12345678")
+		fmt.Println("  aws alb list --vpc vpc-12345678 --zone us-east-1a")
+		fmt.Println("  aws alb list --vpc vpc-12345678 --with-details")
+		fmt.Println()
+		fmt.Println("Run 'aws alb list --help' for the list options.")
+		return nil, nil
+	}
+
+	// Default to list command
+	return ListALBs(ctx)
+}
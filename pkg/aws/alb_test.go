@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pischarti/nix/pkg/vpc"
+)
+
+func TestFilterLoadBalancersByType(t *testing.T) {
+	active := &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive}
+	lbs := []elbv2types.LoadBalancer{
+		{LoadBalancerName: aws.String("web-nlb"), Type: elbv2types.LoadBalancerTypeEnumNetwork, VpcId: aws.String("vpc-1"), State: active},
+		{LoadBalancerName: aws.String("web-alb"), Type: elbv2types.LoadBalancerTypeEnumApplication, VpcId: aws.String("vpc-1"), State: active},
+		{LoadBalancerName: aws.String("other-alb"), Type: elbv2types.LoadBalancerTypeEnumApplication, VpcId: aws.String("vpc-2"), State: active},
+	}
+	opts := &vpc.NLBOptions{VPCID: "vpc-1"}
+
+	tests := []struct {
+		lbType elbv2types.LoadBalancerTypeEnum
+		want   string
+	}{
+		{lbType: elbv2types.LoadBalancerTypeEnumNetwork, want: "web-nlb"},
+		{lbType: elbv2types.LoadBalancerTypeEnumApplication, want: "web-alb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lbType), func(t *testing.T) {
+			filtered := filterLoadBalancers(lbs, tt.lbType, opts)
+			if len(filtered) != 1 || aws.ToString(filtered[0].LoadBalancerName) != tt.want {
+				t.Fatalf("filterLoadBalancers(%s) = %v, want only %s", tt.lbType, filtered, tt.want)
+			}
+
+			infos := convertELBv2ToNLBInfoWithoutTags(filtered)
+			if infos[0].Type != string(tt.lbType) {
+				t.Errorf("Type = %q, want %q", infos[0].Type, tt.lbType)
+			}
+		})
+	}
+}
+
+func TestParseALBArgs(t *testing.T) {
+	opts, err := vpc.ParseALBArgs([]string{"alb", "--vpc", "vpc-1", "--zone", "us-east-1a", "--sort", "created"})
+	if err != nil {
+		t.Fatalf("ParseALBArgs() error = %v", err)
+	}
+	if opts.VPCID != "vpc-1" || len(opts.Zones) != 1 || opts.SortBy != "created" {
+		t.Errorf("ParseALBArgs() = %+v", opts)
+	}
+
+	if _, err := vpc.ParseALBArgs([]string{"alb", "--vpc", "vpc-1", "--sort", "cidr"}); err == nil {
+		t.Error("ParseALBArgs() with an invalid sort succeeded, want an error")
+	}
+}
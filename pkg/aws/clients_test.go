@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// fakeEC2 serves canned subnet resources; filters are ignored
+type fakeEC2 struct {
+	subnets   []ec2types.Subnet
+	instances []ec2types.Instance
+	enis      []ec2types.NetworkInterface
+	endpoints []ec2types.VpcEndpoint
+	// deletedENIs records DeleteNetworkInterface calls
+	deletedENIs []string
+}
+
+func (f *fakeEC2) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{Subnets: f.subnets}, nil
+}
+
+func (f *fakeEC2) DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: f.instances}}}, nil
+}
+
+func (f *fakeEC2) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: f.enis}, nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpoints(ctx context.Context, params *ec2.DescribeVpcEndpointsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: f.endpoints}, nil
+}
+
+func (f *fakeEC2) DeleteNetworkInterface(ctx context.Context, params *ec2.DeleteNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	f.deletedENIs = append(f.deletedENIs, aws.ToString(params.NetworkInterfaceId))
+	return &ec2.DeleteNetworkInterfaceOutput{}, nil
+}
+
+// fakeELBv2 serves canned load balancers and their tags by ARN
+type fakeELBv2 struct {
+	loadBalancers []elbv2types.LoadBalancer
+	tags          map[string][]elbv2types.Tag
+	tagCalls      int
+	// tagBatches records the ARNs of each DescribeTags call
+	tagBatches [][]string
+	// tagsErr fails every DescribeTags call when set
+	tagsErr error
+	// targetHealth holds the registered targets of each target group ARN
+	targetHealth map[string][]elbv2types.TargetHealthDescription
+}
+
+func (f *fakeELBv2) DescribeLoadBalancers(ctx context.Context, params *elasticloadbalancingv2.DescribeLoadBalancersInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
+	if len(params.LoadBalancerArns) == 0 {
+		return &elasticloadbalancingv2.DescribeLoadBalancersOutput{LoadBalancers: f.loadBalancers}, nil
+	}
+	var matched []elbv2types.LoadBalancer
+	for _, lb := range f.loadBalancers {
+		if slices.Contains(params.LoadBalancerArns, aws.ToString(lb.LoadBalancerArn)) {
+			matched = append(matched, lb)
+		}
+	}
+	return &elasticloadbalancingv2.DescribeLoadBalancersOutput{LoadBalancers: matched}, nil
+}
+
+func (f *fakeELBv2) DescribeTags(ctx context.Context, params *elasticloadbalancingv2.DescribeTagsInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeTagsOutput, error) {
+	f.tagCalls++
+	f.tagBatches = append(f.tagBatches, params.ResourceArns)
+	if f.tagsErr != nil {
+		return nil, f.tagsErr
+	}
+	var descriptions []elbv2types.TagDescription
+	for _, arn := range params.ResourceArns {
+		descriptions = append(descriptions, elbv2types.TagDescription{ResourceArn: aws.String(arn), Tags: f.tags[arn]})
+	}
+	return &elasticloadbalancingv2.DescribeTagsOutput{TagDescriptions: descriptions}, nil
+}
+
+func (f *fakeELBv2) DescribeLoadBalancerAttributes(ctx context.Context, params *elasticloadbalancingv2.DescribeLoadBalancerAttributesInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeLoadBalancerAttributesOutput, error) {
+	return &elasticloadbalancingv2.DescribeLoadBalancerAttributesOutput{}, nil
+}
+
+func (f *fakeELBv2) DescribeTargetHealth(ctx context.Context, params *elasticloadbalancingv2.DescribeTargetHealthInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeTargetHealthOutput, error) {
+	return &elasticloadbalancingv2.DescribeTargetHealthOutput{TargetHealthDescriptions: f.targetHealth[aws.ToString(params.TargetGroupArn)]}, nil
+}
+
+func (f *fakeELBv2) SetSubnets(ctx context.Context, params *elasticloadbalancingv2.SetSubnetsInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.SetSubnetsOutput, error) {
+	return &elasticloadbalancingv2.SetSubnetsOutput{}, nil
+}
+
+// fakeECR serves canned repositories and images by repository name. Images asked for by a
+// tag or digest that no image carries are left out of the result. A positive pageSize splits
+// repository and image listings into pages of that size.
+type fakeECR struct {
+	repositories []ecrtypes.Repository
+	images       map[string][]ecrtypes.ImageDetail
+	pageSize     int
+}
+
+func (f *fakeECR) DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error) {
+	start, end, next := f.page(len(f.repositories), params.NextToken)
+	return &ecr.DescribeRepositoriesOutput{Repositories: f.repositories[start:end], NextToken: next}, nil
+}
+
+func (f *fakeECR) DescribeImages(ctx context.Context, params *ecr.DescribeImagesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImagesOutput, error) {
+	images := f.images[aws.ToString(params.RepositoryName)]
+	if len(params.ImageIds) == 0 {
+		start, end, next := f.page(len(images), params.NextToken)
+		return &ecr.DescribeImagesOutput{ImageDetails: images[start:end], NextToken: next}, nil
+	}
+
+	var matched []ecrtypes.ImageDetail
+	for _, image := range images {
+		for _, id := range params.ImageIds {
+			if hasTag(image.ImageTags, aws.ToString(id.ImageTag)) || (id.ImageDigest != nil && aws.ToString(id.ImageDigest) == aws.ToString(image.ImageDigest)) {
+				matched = append(matched, image)
+				break
+			}
+		}
+	}
+	return &ecr.DescribeImagesOutput{ImageDetails: matched}, nil
+}
+
+// fakeImageDeleter records BatchDeleteImage calls and fails the digests in failures
+type fakeImageDeleter struct {
+	calls    [][]ecrtypes.ImageIdentifier
+	failures map[string]ecrtypes.ImageFailureCode
+}
+
+func (f *fakeImageDeleter) BatchDeleteImage(ctx context.Context, params *ecr.BatchDeleteImageInput, optFns ...func(*ecr.Options)) (*ecr.BatchDeleteImageOutput, error) {
+	f.calls = append(f.calls, params.ImageIds)
+
+	output := &ecr.BatchDeleteImageOutput{}
+	for _, id := range params.ImageIds {
+		if code, ok := f.failures[aws.ToString(id.ImageDigest)]; ok {
+			output.Failures = append(output.Failures, ecrtypes.ImageFailure{
+				ImageId:       &id,
+				FailureCode:   code,
+				FailureReason: aws.String("canned failure"),
+			})
+			continue
+		}
+		output.ImageIds = append(output.ImageIds, id)
+	}
+	return output, nil
+}
+
+// page returns the bounds of the page of total items starting at token, which holds the
+// index of the first item, and the token of the next page
+func (f *fakeECR) page(total int, token *string) (start, end int, next *string) {
+	if token != nil {
+		start, _ = strconv.Atoi(*token)
+	}
+	end = total
+	if f.pageSize > 0 && start+f.pageSize < total {
+		end = start + f.pageSize
+		next = aws.String(strconv.Itoa(end))
+	}
+	return start, end, next
+}
+
+// fakeScanFindings returns a canned DescribeImageScanFindings result or error
+type fakeScanFindings struct {
+	output *ecr.DescribeImageScanFindingsOutput
+	err    error
+}
+
+func (f *fakeScanFindings) DescribeImageScanFindings(ctx context.Context, params *ecr.DescribeImageScanFindingsInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImageScanFindingsOutput, error) {
+	return f.output, f.err
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile-time checks that the SDK clients and fakes satisfy the interfaces
+var (
+	_ subnetDescriber       = (*ec2.Client)(nil)
+	_ subnetDescriber       = (*fakeEC2)(nil)
+	_ routeTableDescriber   = (*ec2.Client)(nil)
+	_ routeTableDescriber   = (*fakeRouteTables)(nil)
+	_ loadBalancerClient    = (*elasticloadbalancingv2.Client)(nil)
+	_ loadBalancerClient    = (*fakeELBv2)(nil)
+	_ targetHealthDescriber = (*elasticloadbalancingv2.Client)(nil)
+	_ targetHealthDescriber = (*fakeELBv2)(nil)
+	_ imageDescriber        = (*ecr.Client)(nil)
+	_ imageDescriber        = (*fakeECR)(nil)
+	_ imageDeleter          = (*ecr.Client)(nil)
+	_ imageDeleter          = (*fakeImageDeleter)(nil)
+
+	_ networkInterfaceDeleter = (*ec2.Client)(nil)
+	_ networkInterfaceDeleter = (*fakeEC2)(nil)
+	_ scanFindingsDescriber   = (*ecr.Client)(nil)
+	_ scanFindingsDescriber   = (*fakeScanFindings)(nil)
+)
@@ -2,29 +2,47 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pischarti/nix/pkg/cli"
+	printpkg "github.com/pischarti/nix/pkg/print"
 	"gofr.dev/pkg/gofr"
 	"gopkg.in/yaml.v3"
 )
 
-// ECRImageInfo represents ECR image information
+// ECRImageInfo represents ECR image information. The JSON keys match the YAML ones, which
+// are the lowercased field names.
 type ECRImageInfo struct {
-	RepositoryName string
-	ImageTag       string
-	ImageDigest    string
-	PushedAt       time.Time
-	ImageSize      int64
-	ImageManifest  string
+	RepositoryName string    `json:"repositoryname"`
+	ImageTag       string    `json:"imagetag"`
+	ImageDigest    string    `json:"imagedigest"`
+	PushedAt       time.Time `json:"pushedat"`
+	ImageSize      int64     `json:"imagesize"`
+	ImageManifest  string    `json:"imagemanifest"`
+}
+
+// MarshalJSON encodes the image with PushedAt in RFC 3339 format at second precision
+func (i ECRImageInfo) MarshalJSON() ([]byte, error) {
+	type plain ECRImageInfo
+	return json.Marshal(struct {
+		plain
+		PushedAt string `json:"pushedat"`
+	}{
+		plain:    plain(i),
+		PushedAt: i.PushedAt.Format(time.RFC3339),
+	})
 }
 
 // ListECRImages handles the ecr command for listing AWS ECR images
@@ -34,14 +52,19 @@ func ListECRImages(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws ecr [--repository REPO_NAME] [--tag TAG] [--sort SORT_BY] [--all] [--older-than REFERENCE_TAG] [--output FORMAT]")
+			fmt.Println("Usage: aws ecr [--repository REPO_NAME]... [--tag TAG] [--sort SORT_BY] [--all [--repository-prefix PREFIX | --repository-regex PATTERN]] [--older-than REFERENCE_TAG] [--output FORMAT] [--color WHEN] [--no-headers]")
 			fmt.Println("Options:")
-			fmt.Println("  --repository REPO_NAME  ECR repository name (optional, use --all for all repos)")
+			fmt.Println("  --repository REPO_NAME  ECR repository name; repeat to list several repositories (optional, use --all for all repos)")
 			fmt.Println("  --tag TAG               Filter by image tag (optional)")
-			fmt.Println("  --sort SORT_BY          Sort by: pushed (default), tag, size")
+			fmt.Println("  --sort SORT_BY          Sort by: pushed (default, newest first), age (oldest first), tag, size")
 			fmt.Println("  --all                   List images from all repositories")
+			fmt.Println("  --repository-prefix PREFIX  With --all, only include repositories whose name starts with PREFIX")
+			fmt.Println("  --repository-regex PATTERN  With --all, only include repositories whose name matches PATTERN")
 			fmt.Println("  --older-than REFERENCE_TAG  Show only images older than the reference tag")
-			fmt.Println("  --output FORMAT         Output format: table (default), yaml")
+			fmt.Println("  --output FORMAT         Output format: table (default), yaml, json")
+			fmt.Println("  --color WHEN            Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --no-headers            Print only the table rows, space-separated, for piping into awk or cut")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
 			return nil, nil
 		}
 	}
@@ -52,15 +75,21 @@ func ListECRImages(ctx *gofr.Context) (any, error) {
 		return nil, err
 	}
 
-	if opts.RepositoryName == "" && !opts.AllRepos {
+	if len(opts.Repositories) == 0 && !opts.AllRepos {
 		return nil, fmt.Errorf("repository parameter is required (use --repository REPO_NAME or --all for all repositories)")
 	}
 
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create ECR client
 	ecrClient := ecr.NewFromConfig(cfg)
@@ -69,13 +98,22 @@ func ListECRImages(ctx *gofr.Context) (any, error) {
 
 	if opts.AllRepos {
 		// List all repositories first
-		reposResult, err := ecrClient.DescribeRepositories(context.TODO(), &ecr.DescribeRepositoriesInput{})
+		repositories, err := describeAllRepositories(ecrClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to describe repositories: %w", err)
 		}
 
-		// Get images from all repositories
-		for _, repo := range reposResult.Repositories {
+		// Narrow down to matching repositories before fetching any image data
+		if opts.RepositoryPrefix != "" {
+			repositories = filterRepositoriesByPrefix(repositories, opts.RepositoryPrefix)
+		}
+		if opts.RepositoryPattern != nil {
+			repositories = filterRepositoriesByPattern(repositories, opts.RepositoryPattern)
+		}
+
+		// Get images from all repositories, tallying empty and failed ones for the summary
+		summary := ecrScanSummary{Scanned: len(repositories)}
+		for _, repo := range repositories {
 			input := &ecr.DescribeImagesInput{
 				RepositoryName: repo.RepositoryName,
 			}
@@ -88,44 +126,42 @@ func ListECRImages(ctx *gofr.Context) (any, error) {
 				}
 			}
 
-			result, err := ecrClient.DescribeImages(context.TODO(), input)
+			imageDetails, err := describeAllImages(ecrClient, input)
+			var notFound *types.ImageNotFoundException
+			if errors.As(err, &notFound) {
+				// The repository has no image with the requested tag
+				summary.Empty++
+				continue
+			}
 			if err != nil {
 				// Log error but continue with other repositories
-				fmt.Printf("Warning: failed to describe images in repository %s: %v\n", aws.ToString(repo.RepositoryName), err)
+				fmt.Fprintf(os.Stderr, "Warning: failed to describe images in repository %s: %v\n", aws.ToString(repo.RepositoryName), err)
+				summary.Errored = append(summary.Errored, aws.ToString(repo.RepositoryName))
+				continue
+			}
+			if len(imageDetails) == 0 {
+				summary.Empty++
 				continue
 			}
 
 			// Convert to ECRImageInfo structs and add to the list
-			repoImages := convertECRImagesToImageInfo(result.ImageDetails)
+			repoImages := convertECRImagesToImageInfo(imageDetails)
 			images = append(images, repoImages...)
 		}
-	} else {
-		// Single repository
-		input := &ecr.DescribeImagesInput{
-			RepositoryName: aws.String(opts.RepositoryName),
-		}
 
-		if opts.Tag != "" {
-			input.ImageIds = []types.ImageIdentifier{
-				{
-					ImageTag: aws.String(opts.Tag),
-				},
-			}
-		}
-
-		result, err := ecrClient.DescribeImages(context.TODO(), input)
+		// Report on stderr so table, YAML and JSON output stay clean for piping
+		defer summary.print(os.Stderr)
+	} else {
+		images, err = listRepositoryImages(ecrClient, opts.Repositories, opts.Tag)
 		if err != nil {
-			return nil, fmt.Errorf("failed to describe images: %w", err)
+			return nil, err
 		}
-
-		// Convert to ECRImageInfo structs
-		images = convertECRImagesToImageInfo(result.ImageDetails)
 	}
 
 	// Filter images older than reference tag if specified
 	var referenceDate *time.Time
 	if opts.OlderThan != "" {
-		images, referenceDate, err = filterImagesOlderThan(ecrClient, images, opts.OlderThan, opts.RepositoryName, opts.AllRepos)
+		images, referenceDate, err = filterImagesOlderThan(ecrClient, images, opts.OlderThan, opts.Repositories, opts.AllRepos)
 		if err != nil {
 			return nil, err
 		}
@@ -138,65 +174,196 @@ func ListECRImages(ctx *gofr.Context) (any, error) {
 	switch opts.OutputFormat {
 	case "yaml":
 		printECRImagesYAML(images, opts, referenceDate)
+	case "json":
+		if err := printECRImagesJSON(os.Stdout, images, opts, referenceDate); err != nil {
+			return nil, err
+		}
 	default:
-		printECRImagesTable(images)
+		printECRImagesTable(images, opts.AllRepos || len(opts.Repositories) > 1, useColor, opts.NoHeaders)
 	}
 
 	return nil, nil
 }
 
+// listRepositoryImages returns the images of the named repositories, optionally only those
+// with the given tag. With several repositories, one without the tag is skipped rather than
+// failing the whole listing.
+func listRepositoryImages(ecrClient imageDescriber, repositories []string, tag string) ([]ECRImageInfo, error) {
+	var images []ECRImageInfo
+	for _, repository := range repositories {
+		input := &ecr.DescribeImagesInput{
+			RepositoryName: aws.String(repository),
+		}
+
+		if tag != "" {
+			input.ImageIds = []types.ImageIdentifier{
+				{
+					ImageTag: aws.String(tag),
+				},
+			}
+		}
+
+		imageDetails, err := describeAllImages(ecrClient, input)
+		var notFound *types.ImageNotFoundException
+		if len(repositories) > 1 && errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			if len(repositories) > 1 {
+				return nil, fmt.Errorf("failed to describe images in repository %s: %w", repository, err)
+			}
+			return nil, fmt.Errorf("failed to describe images: %w", err)
+		}
+
+		// Convert to ECRImageInfo structs
+		images = append(images, convertECRImagesToImageInfo(imageDetails)...)
+	}
+	return images, nil
+}
+
+// describeAllImages reads every page of DescribeImages for the input, so repositories with
+// more images than fit in one page are listed in full
+func describeAllImages(ecrClient imageDescriber, input *ecr.DescribeImagesInput) ([]types.ImageDetail, error) {
+	var imageDetails []types.ImageDetail
+	paginator := ecr.NewDescribeImagesPaginator(ecrClient, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		imageDetails = append(imageDetails, page.ImageDetails...)
+	}
+	return imageDetails, nil
+}
+
+// describeAllRepositories reads every page of DescribeRepositories
+func describeAllRepositories(ecrClient imageDescriber) ([]types.Repository, error) {
+	var repositories []types.Repository
+	paginator := ecr.NewDescribeRepositoriesPaginator(ecrClient, &ecr.DescribeRepositoriesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, page.Repositories...)
+	}
+	return repositories, nil
+}
+
+// ecrScanSummary tallies the repositories visited by --all
+type ecrScanSummary struct {
+	Scanned int
+	// Empty counts repositories with no images, or none matching --tag
+	Empty int
+	// Errored names the repositories whose images could not be described
+	Errored []string
+}
+
+// String returns a one-line summary such as "scanned 120 repositories, 4 errored, 15 empty"
+func (s ecrScanSummary) String() string {
+	return fmt.Sprintf("scanned %d repositories, %d errored, %d empty", s.Scanned, len(s.Errored), s.Empty)
+}
+
+// print writes the summary and, when any repository failed, their names, so failures are
+// not lost among the per-repository warnings
+func (s ecrScanSummary) print(w io.Writer) {
+	fmt.Fprintf(w, "\nECR --all: %s\n", s)
+	if len(s.Errored) > 0 {
+		fmt.Fprintf(w, "Repositories that could not be read: %s\n", strings.Join(s.Errored, ", "))
+	}
+}
+
 // ECRArgs represents parsed ECR command arguments
 type ECRArgs struct {
-	RepositoryName string
-	Tag            string
-	SortBy         string
-	AllRepos       bool
-	OlderThan      string
-	OutputFormat   string
+	// Repositories lists the repositories given with --repository, in order
+	Repositories     []string
+	Tag              string
+	SortBy           string
+	AllRepos         bool
+	RepositoryPrefix string
+	RepositoryRegex  string
+	OlderThan        string
+	OutputFormat     string
+	Color            string
+	NoHeaders        bool
+	Verbose          bool
+	// RepositoryPattern is RepositoryRegex compiled, or nil when it is not set
+	RepositoryPattern *regexp.Regexp
 }
 
 // parseECRArgs parses command line arguments for ECR commands
 func parseECRArgs(args []string) (*ECRArgs, error) {
-	opts := &ECRArgs{
-		SortBy:       "pushed", // default sort by push date (newest first)
-		OutputFormat: "table",  // default output format
+	opts := &ECRArgs{}
+
+	fs := cli.NewFlagSet("ecr")
+	fs.StringArrayVar(&opts.Repositories, "repository", nil, "ECR repository name (can specify multiple)")
+	fs.StringVar(&opts.Tag, "tag", "", "filter by image tag")
+	fs.StringVar(&opts.SortBy, "sort", "pushed", "sort by: pushed, age, tag, size") // newest first by default
+	fs.BoolVar(&opts.AllRepos, "all", false, "list images from all repositories")
+	fs.StringVar(&opts.RepositoryPrefix, "repository-prefix", "", "with --all, only include repositories with this prefix")
+	fs.StringVar(&opts.RepositoryRegex, "repository-regex", "", "with --all, only include repositories whose name matches this pattern")
+	fs.StringVar(&opts.OlderThan, "older-than", "", "show only images older than the reference tag")
+	fs.StringVar(&opts.OutputFormat, "output", "table", "output format: table, yaml, json")
+	fs.StringVar(&opts.Color, "color", printpkg.ColorAuto, "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
-	for i, arg := range args {
-		switch arg {
-		case "--repository":
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--repository requires a value")
-			}
-			opts.RepositoryName = args[i+1]
-		case "--tag":
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--tag requires a value")
-			}
-			opts.Tag = args[i+1]
-		case "--sort":
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--sort requires a value")
-			}
-			opts.SortBy = args[i+1]
-		case "--all":
-			opts.AllRepos = true
-		case "--older-than":
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--older-than requires a value")
-			}
-			opts.OlderThan = args[i+1]
-		case "--output":
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--output requires a value")
-			}
-			opts.OutputFormat = args[i+1]
+	if len(opts.Repositories) > 0 && opts.AllRepos {
+		return nil, fmt.Errorf("--repository cannot be combined with --all")
+	}
+	if opts.RepositoryPrefix != "" && !opts.AllRepos {
+		return nil, fmt.Errorf("--repository-prefix requires --all")
+	}
+	if opts.RepositoryRegex != "" {
+		if !opts.AllRepos {
+			return nil, fmt.Errorf("--repository-regex requires --all")
+		}
+		if opts.RepositoryPrefix != "" || len(opts.Repositories) > 0 {
+			return nil, fmt.Errorf("--repository-regex cannot be combined with --repository-prefix or --repository")
 		}
+		pattern, err := regexp.Compile(opts.RepositoryRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --repository-regex: %w", err)
+		}
+		opts.RepositoryPattern = pattern
+	}
+	switch opts.OutputFormat {
+	case "table", "yaml", "json":
+	default:
+		return nil, fmt.Errorf("invalid output format '%s'. Valid options: table, yaml, json", opts.OutputFormat)
+	}
+	if opts.NoHeaders && opts.OutputFormat != "table" {
+		return nil, fmt.Errorf("--no-headers only applies to table output")
 	}
 
 	return opts, nil
 }
 
+// filterRepositoriesByPrefix returns the repositories whose name starts with prefix
+func filterRepositoriesByPrefix(repos []types.Repository, prefix string) []types.Repository {
+	var filtered []types.Repository
+	for _, repo := range repos {
+		if strings.HasPrefix(aws.ToString(repo.RepositoryName), prefix) {
+			filtered = append(filtered, repo)
+		}
+	}
+	return filtered
+}
+
+// filterRepositoriesByPattern returns the repositories whose name matches pattern
+func filterRepositoriesByPattern(repos []types.Repository, pattern *regexp.Regexp) []types.Repository {
+	var filtered []types.Repository
+	for _, repo := range repos {
+		if pattern.MatchString(aws.ToString(repo.RepositoryName)) {
+			filtered = append(filtered, repo)
+		}
+	}
+	return filtered
+}
+
 // convertECRImagesToImageInfo converts ECR image details to ECRImageInfo structs
 func convertECRImagesToImageInfo(imageDetails []types.ImageDetail) []ECRImageInfo {
 	var images []ECRImageInfo
@@ -242,6 +409,11 @@ func sortECRImages(images []ECRImageInfo, sortBy string) {
 		sort.Slice(images, func(i, j int) bool {
 			return images[i].ImageSize > images[j].ImageSize
 		})
+	case "age":
+		// Oldest first, the reverse of "pushed"
+		sort.Slice(images, func(i, j int) bool {
+			return images[i].PushedAt.Before(images[j].PushedAt)
+		})
 	case "tag":
 		fallthrough
 	default:
@@ -251,24 +423,73 @@ func sortECRImages(images []ECRImageInfo, sortBy string) {
 	}
 }
 
-// printECRImagesTable prints ECR images in a formatted table
-func printECRImagesTable(images []ECRImageInfo) {
+// selectUntaggedImagesToPrune returns the untagged images to delete: in each repository, all
+// but the keepLast most recently pushed, newest first
+func selectUntaggedImagesToPrune(images []ECRImageInfo, keepLast int) []ECRImageInfo {
+	var repositories []string
+	untagged := make(map[string][]ECRImageInfo)
+	for _, image := range images {
+		if image.ImageTag != "<untagged>" {
+			continue
+		}
+		if _, ok := untagged[image.RepositoryName]; !ok {
+			repositories = append(repositories, image.RepositoryName)
+		}
+		untagged[image.RepositoryName] = append(untagged[image.RepositoryName], image)
+	}
+
+	var prune []ECRImageInfo
+	for _, repository := range repositories {
+		repoImages := untagged[repository]
+		sortECRImages(repoImages, "pushed")
+		if len(repoImages) > keepLast {
+			prune = append(prune, repoImages[keepLast:]...)
+		}
+	}
+	return prune
+}
+
+// printECRImagesTable prints ECR images in a formatted table with a size subtotal footer per
+// repository, plus a grand total when allRepos is set. With noHeaders it prints only the data
+// rows, without the header, footer, borders or the empty-repository message.
+func printECRImagesTable(images []ECRImageInfo, allRepos, color, noHeaders bool) {
 	if len(images) == 0 {
-		fmt.Println("No images found in the repository.")
+		if !noHeaders {
+			fmt.Println("No images found in the repository.")
+		}
 		return
 	}
 
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleColoredBright)
-	t.AppendHeader(table.Row{"Repository", "Tag", "Digest", "Pushed At", "Size", "Manifest"})
+	if noHeaders {
+		printpkg.SetNoHeadersStyle(t)
+	} else {
+		printpkg.SetColoredStyle(t, color)
+		t.AppendHeader(table.Row{"Repository", "Tag", "Digest", "Pushed At", "Age", "Size", "Manifest"})
 
-	// Set column widths to keep tag column narrow
-	t.SetColumnConfigs([]table.ColumnConfig{
-		{Name: "Tag", WidthMax: 20}, // Limit tag column to 20 characters
-	})
+		// Set column widths to keep tag column narrow
+		t.SetColumnConfigs([]table.ColumnConfig{
+			{Name: "Tag", WidthMax: 20}, // Limit tag column to 20 characters
+		})
+	}
 
+	now := time.Now()
 	for _, image := range images {
+		if noHeaders {
+			// Keep every field free of spaces and untruncated so rows split cleanly in scripts
+			t.AppendRow(table.Row{
+				image.RepositoryName,
+				image.ImageTag,
+				image.ImageDigest,
+				image.PushedAt.Format(time.RFC3339),
+				formatAge(image.PushedAt, now),
+				image.ImageSize,
+				image.ImageManifest,
+			})
+			continue
+		}
+
 		// Format size in human-readable format
 		sizeStr := formatBytes(image.ImageSize)
 
@@ -286,52 +507,134 @@ func printECRImagesTable(images []ECRImageInfo) {
 			image.ImageTag,
 			digest,
 			pushedStr,
+			formatAge(image.PushedAt, now),
 			sizeStr,
 			image.ImageManifest,
 		})
 	}
 
+	if !noHeaders {
+		for _, row := range ecrSizeFooterRows(images, allRepos) {
+			t.AppendFooter(row)
+		}
+	}
+
 	t.Render()
 }
 
-// printECRImagesYAML prints ECR images in YAML format
-func printECRImagesYAML(images []ECRImageInfo, opts *ECRArgs, referenceDate *time.Time) {
-	// Convert to YAML-friendly structure
-	yamlData := struct {
-		Input struct {
-			RepositoryName string     `yaml:"repository,omitempty"`
-			Tag            string     `yaml:"tag,omitempty"`
-			SortBy         string     `yaml:"sort_by,omitempty"`
-			AllRepos       bool       `yaml:"all_repositories,omitempty"`
-			OlderThan      string     `yaml:"older_than,omitempty"`
-			OutputFormat   string     `yaml:"output_format,omitempty"`
-			ReferenceDate  *time.Time `yaml:"reference_date,omitempty"`
-		} `yaml:"input"`
-		Images []ECRImageInfo `yaml:"images"`
-		Count  int            `yaml:"count"`
-	}{
-		Input: struct {
-			RepositoryName string     `yaml:"repository,omitempty"`
-			Tag            string     `yaml:"tag,omitempty"`
-			SortBy         string     `yaml:"sort_by,omitempty"`
-			AllRepos       bool       `yaml:"all_repositories,omitempty"`
-			OlderThan      string     `yaml:"older_than,omitempty"`
-			OutputFormat   string     `yaml:"output_format,omitempty"`
-			ReferenceDate  *time.Time `yaml:"reference_date,omitempty"`
-		}{
-			RepositoryName: opts.RepositoryName,
-			Tag:            opts.Tag,
-			SortBy:         opts.SortBy,
-			AllRepos:       opts.AllRepos,
-			OlderThan:      opts.OlderThan,
-			OutputFormat:   opts.OutputFormat,
-			ReferenceDate:  referenceDate,
+// ecrRepositorySize is the total size of the distinct images in a repository
+type ecrRepositorySize struct {
+	RepositoryName string
+	ImageCount     int
+	TotalSize      int64
+}
+
+// ecrRepositorySizes sums image sizes per repository, sorted by repository name. Images are
+// listed once per tag, so each digest is counted only once per repository.
+func ecrRepositorySizes(images []ECRImageInfo) []ecrRepositorySize {
+	seen := make(map[string]map[string]struct{})
+	totals := make(map[string]*ecrRepositorySize)
+
+	for _, image := range images {
+		if seen[image.RepositoryName] == nil {
+			seen[image.RepositoryName] = map[string]struct{}{}
+			totals[image.RepositoryName] = &ecrRepositorySize{RepositoryName: image.RepositoryName}
+		}
+		if _, ok := seen[image.RepositoryName][image.ImageDigest]; ok {
+			continue
+		}
+		seen[image.RepositoryName][image.ImageDigest] = struct{}{}
+		totals[image.RepositoryName].ImageCount++
+		totals[image.RepositoryName].TotalSize += image.ImageSize
+	}
+
+	sizes := make([]ecrRepositorySize, 0, len(totals))
+	for _, total := range totals {
+		sizes = append(sizes, *total)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i].RepositoryName < sizes[j].RepositoryName
+	})
+	return sizes
+}
+
+// ecrSizeFooterRows builds one subtotal footer row per repository, followed by a grand total
+// row when grandTotal is set, laid out to line up with the ECR images table columns
+func ecrSizeFooterRows(images []ECRImageInfo, grandTotal bool) []table.Row {
+	sizes := ecrRepositorySizes(images)
+
+	rows := make([]table.Row, 0, len(sizes)+1)
+	var total int64
+	var count int
+	for _, size := range sizes {
+		rows = append(rows, table.Row{size.RepositoryName, "Total", fmt.Sprintf("%d image(s)", size.ImageCount), "", "", formatBytes(size.TotalSize), ""})
+		total += size.TotalSize
+		count += size.ImageCount
+	}
+	if grandTotal {
+		rows = append(rows, table.Row{"All repositories", "Total", fmt.Sprintf("%d image(s)", count), "", "", formatBytes(total), ""})
+	}
+	return rows
+}
+
+// ecrImagesReport is the document printed by --output yaml and --output json: the input
+// options, the images and their count
+type ecrImagesReport struct {
+	Input  ecrImagesReportInput `yaml:"input" json:"input"`
+	Images []ECRImageInfo       `yaml:"images" json:"images"`
+	Count  int                  `yaml:"count" json:"count"`
+}
+
+// ecrImagesReportInput echoes the options of an ECR listing
+type ecrImagesReportInput struct {
+	RepositoryName   string     `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Repositories     []string   `yaml:"repositories,omitempty" json:"repositories,omitempty"`
+	Tag              string     `yaml:"tag,omitempty" json:"tag,omitempty"`
+	SortBy           string     `yaml:"sort_by,omitempty" json:"sort_by,omitempty"`
+	AllRepos         bool       `yaml:"all_repositories,omitempty" json:"all_repositories,omitempty"`
+	RepositoryPrefix string     `yaml:"repository_prefix,omitempty" json:"repository_prefix,omitempty"`
+	RepositoryRegex  string     `yaml:"repository_regex,omitempty" json:"repository_regex,omitempty"`
+	OlderThan        string     `yaml:"older_than,omitempty" json:"older_than,omitempty"`
+	OutputFormat     string     `yaml:"output_format,omitempty" json:"output_format,omitempty"`
+	ReferenceDate    *time.Time `yaml:"reference_date,omitempty" json:"reference_date,omitempty"`
+}
+
+// newECRImagesReport builds the report of an ECR listing
+func newECRImagesReport(images []ECRImageInfo, opts *ECRArgs, referenceDate *time.Time) ecrImagesReport {
+	// A single repository keeps the original "repository" key; several use "repositories"
+	var repositoryName string
+	var repositories []string
+	if len(opts.Repositories) == 1 {
+		repositoryName = opts.Repositories[0]
+	} else {
+		repositories = opts.Repositories
+	}
+
+	if images == nil {
+		images = []ECRImageInfo{}
+	}
+
+	return ecrImagesReport{
+		Input: ecrImagesReportInput{
+			RepositoryName:   repositoryName,
+			Repositories:     repositories,
+			Tag:              opts.Tag,
+			SortBy:           opts.SortBy,
+			AllRepos:         opts.AllRepos,
+			RepositoryPrefix: opts.RepositoryPrefix,
+			RepositoryRegex:  opts.RepositoryRegex,
+			OlderThan:        opts.OlderThan,
+			OutputFormat:     opts.OutputFormat,
+			ReferenceDate:    referenceDate,
 		},
 		Images: images,
 		Count:  len(images),
 	}
+}
 
-	yamlBytes, err := yaml.Marshal(yamlData)
+// printECRImagesYAML prints ECR images in YAML format
+func printECRImagesYAML(images []ECRImageInfo, opts *ECRArgs, referenceDate *time.Time) {
+	yamlBytes, err := yaml.Marshal(newECRImagesReport(images, opts, referenceDate))
 	if err != nil {
 		fmt.Printf("Error marshaling to YAML: %v\n", err)
 		return
@@ -340,6 +643,68 @@ func printECRImagesYAML(images []ECRImageInfo, opts *ECRArgs, referenceDate *tim
 	fmt.Print(string(yamlBytes))
 }
 
+// printECRImagesJSON writes ECR images as indented JSON with the same structure as the YAML
+// output
+func printECRImagesJSON(w io.Writer, images []ECRImageInfo, opts *ECRArgs, referenceDate *time.Time) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(newECRImagesReport(images, opts, referenceDate)); err != nil {
+		return fmt.Errorf("failed to encode images as JSON: %w", err)
+	}
+	return nil
+}
+
+// ECRScanInfo summarizes the vulnerability scan of an image: its status and the number of
+// findings of each severity
+type ECRScanInfo struct {
+	RepositoryName    string     `yaml:"repositoryname" json:"repositoryname"`
+	ImageTag          string     `yaml:"imagetag" json:"imagetag"`
+	ImageDigest       string     `yaml:"imagedigest" json:"imagedigest"`
+	Status            string     `yaml:"status" json:"status"`
+	StatusDescription string     `yaml:"statusdescription,omitempty" json:"statusdescription,omitempty"`
+	CompletedAt       *time.Time `yaml:"completedat,omitempty" json:"completedat,omitempty"`
+	Critical          int32      `yaml:"critical" json:"critical"`
+	High              int32      `yaml:"high" json:"high"`
+	Medium            int32      `yaml:"medium" json:"medium"`
+	Low               int32      `yaml:"low" json:"low"`
+	Informational     int32      `yaml:"informational" json:"informational"`
+	Undefined         int32      `yaml:"undefined" json:"undefined"`
+}
+
+// Total returns the number of findings of all severities
+func (s ECRScanInfo) Total() int32 {
+	return s.Critical + s.High + s.Medium + s.Low + s.Informational + s.Undefined
+}
+
+// printECRScanTable prints the scan status of an image followed by a table of its findings
+// by severity, most severe first
+func printECRScanTable(w io.Writer, scan ECRScanInfo, color bool) {
+	fmt.Fprintf(w, "Image %s:%s (%s)\n", scan.RepositoryName, scan.ImageTag, scan.ImageDigest)
+	status := scan.Status
+	if scan.CompletedAt != nil {
+		status += fmt.Sprintf(", completed %s", scan.CompletedAt.Format("2006-01-02 15:04:05"))
+	}
+	if scan.StatusDescription != "" {
+		status += ": " + scan.StatusDescription
+	}
+	fmt.Fprintf(w, "Scan status: %s\n\n", status)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	printpkg.SetColoredStyle(t, color)
+	t.AppendHeader(table.Row{"Severity", "Findings"})
+	t.AppendRows([]table.Row{
+		{string(types.FindingSeverityCritical), scan.Critical},
+		{string(types.FindingSeverityHigh), scan.High},
+		{string(types.FindingSeverityMedium), scan.Medium},
+		{string(types.FindingSeverityLow), scan.Low},
+		{string(types.FindingSeverityInformational), scan.Informational},
+		{string(types.FindingSeverityUndefined), scan.Undefined},
+	})
+	t.AppendFooter(table.Row{"Total", scan.Total()})
+	t.Render()
+}
+
 // formatBytes formats bytes into human-readable format
 func formatBytes(bytes int64) string {
 	const unit = 1024
@@ -354,8 +719,28 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// formatAge returns the time elapsed since t in the compact form kubectl uses for ages,
+// such as 45s, 12m, 3h or 42d
+func formatAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", max(int(age.Seconds()), 0))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
 // filterImagesOlderThan filters images to show only those older than the reference tag
-func filterImagesOlderThan(ecrClient *ecr.Client, images []ECRImageInfo, referenceTag string, repositoryName string, allRepos bool) ([]ECRImageInfo, *time.Time, error) {
+func filterImagesOlderThan(ecrClient imageDescriber, images []ECRImageInfo, referenceTag string, repositories []string, allRepos bool) ([]ECRImageInfo, *time.Time, error) {
 	var referenceTime *time.Time
 	var err error
 
@@ -363,8 +748,8 @@ func filterImagesOlderThan(ecrClient *ecr.Client, images []ECRImageInfo, referen
 		// For all repositories, we need to find the reference tag across all repos
 		referenceTime, err = findReferenceTagInAllRepos(ecrClient, referenceTag)
 	} else {
-		// For single repository, find the reference tag in that specific repo
-		referenceTime, err = findReferenceTagInRepo(ecrClient, referenceTag, repositoryName)
+		// Otherwise, find the reference tag in the listed repositories
+		referenceTime, err = findReferenceTagInRepos(ecrClient, referenceTag, repositories)
 	}
 
 	if err != nil {
@@ -387,8 +772,28 @@ func filterImagesOlderThan(ecrClient *ecr.Client, images []ECRImageInfo, referen
 	return filteredImages, referenceTime, nil
 }
 
+// findReferenceTagInRepos finds the reference tag in the first of the repositories that has it.
+// With several repositories, one without the tag is skipped rather than failing the search.
+func findReferenceTagInRepos(ecrClient imageDescriber, referenceTag string, repositories []string) (*time.Time, error) {
+	for _, repository := range repositories {
+		pushTime, err := findReferenceTagInRepo(ecrClient, referenceTag, repository)
+		var notFound *types.ImageNotFoundException
+		if len(repositories) > 1 && errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pushTime != nil {
+			return pushTime, nil
+		}
+	}
+
+	return nil, nil // Tag not found in any repository
+}
+
 // findReferenceTagInRepo finds the reference tag in a specific repository
-func findReferenceTagInRepo(ecrClient *ecr.Client, referenceTag string, repositoryName string) (*time.Time, error) {
+func findReferenceTagInRepo(ecrClient imageDescriber, referenceTag string, repositoryName string) (*time.Time, error) {
 	input := &ecr.DescribeImagesInput{
 		RepositoryName: aws.String(repositoryName),
 		ImageIds: []types.ImageIdentifier{
@@ -398,30 +803,30 @@ func findReferenceTagInRepo(ecrClient *ecr.Client, referenceTag string, reposito
 		},
 	}
 
-	result, err := ecrClient.DescribeImages(context.TODO(), input)
+	imageDetails, err := describeAllImages(ecrClient, input)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(result.ImageDetails) == 0 {
+	if len(imageDetails) == 0 {
 		return nil, nil // Tag not found
 	}
 
 	// Return the push time of the reference tag
-	pushTime := aws.ToTime(result.ImageDetails[0].ImagePushedAt)
+	pushTime := aws.ToTime(imageDetails[0].ImagePushedAt)
 	return &pushTime, nil
 }
 
 // findReferenceTagInAllRepos finds the reference tag across all repositories
-func findReferenceTagInAllRepos(ecrClient *ecr.Client, referenceTag string) (*time.Time, error) {
+func findReferenceTagInAllRepos(ecrClient imageDescriber, referenceTag string) (*time.Time, error) {
 	// List all repositories
-	reposResult, err := ecrClient.DescribeRepositories(context.TODO(), &ecr.DescribeRepositoriesInput{})
+	repositories, err := describeAllRepositories(ecrClient)
 	if err != nil {
 		return nil, err
 	}
 
 	// Search for the reference tag in each repository
-	for _, repo := range reposResult.Repositories {
+	for _, repo := range repositories {
 		input := &ecr.DescribeImagesInput{
 			RepositoryName: repo.RepositoryName,
 			ImageIds: []types.ImageIdentifier{
@@ -431,15 +836,15 @@ func findReferenceTagInAllRepos(ecrClient *ecr.Client, referenceTag string) (*ti
 			},
 		}
 
-		result, err := ecrClient.DescribeImages(context.TODO(), input)
+		imageDetails, err := describeAllImages(ecrClient, input)
 		if err != nil {
 			// Continue searching in other repositories
 			continue
 		}
 
-		if len(result.ImageDetails) > 0 {
+		if len(imageDetails) > 0 {
 			// Found the reference tag, return its push time
-			pushTime := aws.ToTime(result.ImageDetails[0].ImagePushedAt)
+			pushTime := aws.ToTime(imageDetails[0].ImagePushedAt)
 			return &pushTime, nil
 		}
 	}
@@ -459,6 +864,20 @@ func ECRRouter(ctx *gofr.Context) (any, error) {
 			switch subcommand {
 			case "list":
 				return ListECRImages(ctx)
+			case "repos":
+				return ListECRRepositories(ctx)
+			case "untag":
+				return UntagECRImage(ctx)
+			case "delete":
+				return DeleteECRImages(ctx)
+			case "prune":
+				return PruneECRImages(ctx)
+			case "scan":
+				return ScanECRImage(ctx)
+			case "compare":
+				return CompareECRImages(ctx)
+			case "get-login":
+				return GetECRLogin(ctx)
 			default:
 				return nil, fmt.Errorf("unknown ECR subcommand: %s. Use 'aws ecr --help' for usage information", subcommand)
 			}
@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// CompareECRImages handles the ecr compare command, which checks whether two tags, possibly
+// in different repositories, point at the same image digest
+func CompareECRImages(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr compare --repository REPO_NAME --tag-a TAG --tag-b TAG [--repo-b REPO_NAME]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository REPO_NAME  ECR repository of the first tag (required)")
+			fmt.Println("  --tag-a TAG             First tag to compare (required)")
+			fmt.Println("  --tag-b TAG             Second tag to compare (required)")
+			fmt.Println("  --repo-b REPO_NAME      ECR repository of the second tag (default: --repository)")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command reports whether both tags point at the same image digest, with the push")
+			fmt.Println("time and size of each. It fails when the digests differ, so it can gate a promotion.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRCompareArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	imageA, err := resolveECRTag(ecrClient, opts.RepositoryName, opts.TagA)
+	if err != nil {
+		return nil, err
+	}
+	imageB, err := resolveECRTag(ecrClient, opts.RepositoryB, opts.TagB)
+	if err != nil {
+		return nil, err
+	}
+
+	printECRComparison(os.Stdout, imageA, imageB, time.Now())
+
+	if imageA.ImageDigest != imageB.ImageDigest {
+		return nil, fmt.Errorf("%s:%s and %s:%s point at different images", opts.RepositoryName, opts.TagA, opts.RepositoryB, opts.TagB)
+	}
+	return nil, nil
+}
+
+// resolveECRTag looks up the image a tag points to
+func resolveECRTag(ecrClient imageDescriber, repositoryName, tag string) (ECRImageInfo, error) {
+	image, err := describeECRImage(ecrClient, repositoryName, types.ImageIdentifier{ImageTag: aws.String(tag)})
+	if err != nil {
+		return ECRImageInfo{}, err
+	}
+	if image == nil {
+		return ECRImageInfo{}, fmt.Errorf("tag %s not found in repository %s", tag, repositoryName)
+	}
+
+	return ECRImageInfo{
+		RepositoryName: repositoryName,
+		ImageTag:       tag,
+		ImageDigest:    aws.ToString(image.ImageDigest),
+		PushedAt:       aws.ToTime(image.ImagePushedAt),
+		ImageSize:      aws.ToInt64(image.ImageSizeInBytes),
+		ImageManifest:  aws.ToString(image.ImageManifestMediaType),
+	}, nil
+}
+
+// printECRComparison prints both images and whether they share a digest
+func printECRComparison(w io.Writer, a, b ECRImageInfo, now time.Time) {
+	for _, image := range []ECRImageInfo{a, b} {
+		fmt.Fprintf(w, "%s:%s\n", image.RepositoryName, image.ImageTag)
+		fmt.Fprintf(w, "   Digest: %s\n", image.ImageDigest)
+		fmt.Fprintf(w, "   Pushed: %s (%s ago)\n", image.PushedAt.Format("2006-01-02 15:04:05"), formatAge(image.PushedAt, now))
+		fmt.Fprintf(w, "   Size:   %s\n", formatBytes(image.ImageSize))
+	}
+	fmt.Fprintln(w)
+
+	if a.ImageDigest == b.ImageDigest {
+		fmt.Fprintf(w, "✅ Same image: both tags point at %s\n", a.ImageDigest)
+		return
+	}
+	fmt.Fprintln(w, "❌ Different images: the tags point at different digests")
+}
+
+// ECRCompareArgs represents parsed ecr compare command arguments
+type ECRCompareArgs struct {
+	RepositoryName string
+	// RepositoryB is the repository of TagB; it defaults to RepositoryName
+	RepositoryB string
+	TagA        string
+	TagB        string
+	Verbose     bool
+}
+
+// parseECRCompareArgs parses command line arguments for the ecr compare command
+func parseECRCompareArgs(args []string) (*ECRCompareArgs, error) {
+	opts := &ECRCompareArgs{}
+
+	fs := cli.NewFlagSet("ecr compare")
+	fs.StringVar(&opts.RepositoryName, "repository", "", "ECR repository of the first tag")
+	fs.StringVar(&opts.RepositoryB, "repo-b", "", "ECR repository of the second tag")
+	fs.StringVar(&opts.TagA, "tag-a", "", "first tag to compare")
+	fs.StringVar(&opts.TagB, "tag-b", "", "second tag to compare")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RepositoryName == "" {
+		return nil, fmt.Errorf("repository parameter is required")
+	}
+	if opts.TagA == "" || opts.TagB == "" {
+		return nil, fmt.Errorf("tag-a and tag-b parameters are required")
+	}
+	if opts.RepositoryB == "" {
+		opts.RepositoryB = opts.RepositoryName
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestParseECRCompareArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRCompareArgs
+		expectError bool
+	}{
+		{
+			name:     "same repository",
+			args:     []string{"ecr", "compare", "--repository", "my-repo", "--tag-a", "staging", "--tag-b", "prod"},
+			expected: &ECRCompareArgs{RepositoryName: "my-repo", RepositoryB: "my-repo", TagA: "staging", TagB: "prod"},
+		},
+		{
+			name:     "cross repository",
+			args:     []string{"ecr", "compare", "--repository", "staging/app", "--tag-a", "v1", "--repo-b", "prod/app", "--tag-b", "v1", "-v"},
+			expected: &ECRCompareArgs{RepositoryName: "staging/app", RepositoryB: "prod/app", TagA: "v1", TagB: "v1", Verbose: true},
+		},
+		{
+			name:        "missing repository",
+			args:        []string{"ecr", "compare", "--tag-a", "staging", "--tag-b", "prod"},
+			expectError: true,
+		},
+		{
+			name:        "missing second tag",
+			args:        []string{"ecr", "compare", "--repository", "my-repo", "--tag-a", "staging"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRCompareArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRCompareArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveECRTag(t *testing.T) {
+	pushed := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeECR{images: map[string][]ecrtypes.ImageDetail{
+		"my-repo": {{
+			ImageDigest:      aws.String("sha256:aaa"),
+			ImageTags:        []string{"staging", "prod"},
+			ImagePushedAt:    aws.Time(pushed),
+			ImageSizeInBytes: aws.Int64(2048),
+		}},
+	}}
+
+	image, err := resolveECRTag(client, "my-repo", "prod")
+	if err != nil {
+		t.Fatalf("resolveECRTag() error = %v", err)
+	}
+	expected := ECRImageInfo{RepositoryName: "my-repo", ImageTag: "prod", ImageDigest: "sha256:aaa", PushedAt: pushed, ImageSize: 2048}
+	if image != expected {
+		t.Errorf("resolveECRTag() = %+v, want %+v", image, expected)
+	}
+
+	if _, err := resolveECRTag(client, "my-repo", "missing"); err == nil || !strings.Contains(err.Error(), "tag missing not found") {
+		t.Errorf("resolveECRTag() of a missing tag error = %v, want not found", err)
+	}
+}
+
+func TestPrintECRComparison(t *testing.T) {
+	now := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+	a := ECRImageInfo{RepositoryName: "my-repo", ImageTag: "staging", ImageDigest: "sha256:aaa", PushedAt: now.Add(-48 * time.Hour), ImageSize: 2048}
+	b := a
+	b.ImageTag = "prod"
+
+	var out bytes.Buffer
+	printECRComparison(&out, a, b, now)
+	for _, want := range []string{"my-repo:staging\n", "my-repo:prod\n", "(2d ago)", "✅ Same image: both tags point at sha256:aaa"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	b.ImageDigest = "sha256:bbb"
+	out.Reset()
+	printECRComparison(&out, a, b, now)
+	if !strings.Contains(out.String(), "❌ Different images") {
+		t.Errorf("output = %s, want the digests reported as different", out.String())
+	}
+}
@@ -0,0 +1,235 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// ecrBatchDeleteLimit is the most image IDs BatchDeleteImage accepts in one call
+const ecrBatchDeleteLimit = 100
+
+// DeleteECRImages handles the ecr delete command, which deletes images identified by tag or
+// digest. A tag is resolved to its image first, so the whole image is deleted along with
+// every tag it carries.
+func DeleteECRImages(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr delete --repository REPO_NAME [--tag TAG]... [--digest DIGEST]... [--force]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository REPO_NAME  ECR repository name (required)")
+			fmt.Println("  --tag TAG               Delete the image this tag points to (repeatable)")
+			fmt.Println("  --digest DIGEST         Delete the image with this digest, e.g. sha256:... (repeatable)")
+			fmt.Println("  --force                 Skip the confirmation prompt")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("At least one --tag or --digest is required. The whole image is deleted, including")
+			fmt.Println("any other tags it carries; use 'aws ecr untag' to remove a single tag instead.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRDeleteArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	// Resolve every tag and digest before deleting anything
+	images, err := resolveECRDeleteTargets(ecrClient, opts.RepositoryName, opts.Tags, opts.Digests)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Found %d image(s) to delete from %s:\n", len(images), opts.RepositoryName)
+	for _, image := range images {
+		fmt.Printf("  - %s (%s)\n", aws.ToString(image.ImageDigest), formatECRImageTags(image.ImageTags))
+	}
+
+	// Confirm unless --force is used
+	if !opts.Force {
+		fmt.Printf("\nAre you sure you want to delete these %d image(s)? (yes/no): ", len(images))
+		var response string
+		fmt.Scanln(&response)
+		if response != "yes" {
+			fmt.Println("Deletion cancelled.")
+			return nil, nil
+		}
+	}
+
+	digests := make([]string, 0, len(images))
+	for _, image := range images {
+		digests = append(digests, aws.ToString(image.ImageDigest))
+	}
+
+	deleted, failures, err := batchDeleteECRImages(ecrClient, opts.RepositoryName, digests)
+	printECRDeleteResult(os.Stdout, deleted, failures)
+	if err != nil {
+		return nil, err
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("%d of %d image(s) could not be deleted", len(failures), len(digests))
+	}
+
+	return nil, nil
+}
+
+// resolveECRDeleteTargets looks up the images the tags and digests point to, each image
+// once. A tag or digest that matches no image is an error, so nothing is deleted on a typo.
+func resolveECRDeleteTargets(ecrClient imageDescriber, repositoryName string, tags, digests []string) ([]types.ImageDetail, error) {
+	var ids []types.ImageIdentifier
+	for _, tag := range tags {
+		ids = append(ids, types.ImageIdentifier{ImageTag: aws.String(tag)})
+	}
+	for _, digest := range digests {
+		ids = append(ids, types.ImageIdentifier{ImageDigest: aws.String(digest)})
+	}
+
+	seen := make(map[string]bool)
+	var images []types.ImageDetail
+	for _, id := range ids {
+		image, err := describeECRImage(ecrClient, repositoryName, id)
+		if err != nil {
+			return nil, err
+		}
+		if image == nil {
+			if id.ImageTag != nil {
+				return nil, fmt.Errorf("tag %s not found in repository %s", aws.ToString(id.ImageTag), repositoryName)
+			}
+			return nil, fmt.Errorf("digest %s not found in repository %s", aws.ToString(id.ImageDigest), repositoryName)
+		}
+
+		digest := aws.ToString(image.ImageDigest)
+		if seen[digest] {
+			continue
+		}
+		seen[digest] = true
+		images = append(images, *image)
+	}
+
+	return images, nil
+}
+
+// batchDeleteECRImages deletes the images by digest, in batches of ecrBatchDeleteLimit. It
+// returns the image IDs ECR reports as deleted (one per removed tag reference) and the
+// per-image failures; the error is only set when a call itself fails.
+func batchDeleteECRImages(ecrClient imageDeleter, repositoryName string, digests []string) ([]types.ImageIdentifier, []types.ImageFailure, error) {
+	var deleted []types.ImageIdentifier
+	var failures []types.ImageFailure
+
+	for start := 0; start < len(digests); start += ecrBatchDeleteLimit {
+		end := min(start+ecrBatchDeleteLimit, len(digests))
+
+		ids := make([]types.ImageIdentifier, 0, end-start)
+		for _, digest := range digests[start:end] {
+			ids = append(ids, types.ImageIdentifier{ImageDigest: aws.String(digest)})
+		}
+
+		result, err := ecrClient.BatchDeleteImage(context.TODO(), &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repositoryName),
+			ImageIds:       ids,
+		})
+		if err != nil {
+			return deleted, failures, fmt.Errorf("failed to delete images from repository %s: %w", repositoryName, err)
+		}
+		deleted = append(deleted, result.ImageIds...)
+		failures = append(failures, result.Failures...)
+	}
+
+	return deleted, failures, nil
+}
+
+// printECRDeleteResult prints each deleted digest with the tags removed along with it,
+// followed by each failure and its reason
+func printECRDeleteResult(w io.Writer, deleted []types.ImageIdentifier, failures []types.ImageFailure) {
+	var digests []string
+	tagsByDigest := make(map[string][]string)
+	for _, id := range deleted {
+		digest := aws.ToString(id.ImageDigest)
+		if _, ok := tagsByDigest[digest]; !ok {
+			digests = append(digests, digest)
+			tagsByDigest[digest] = nil
+		}
+		if tag := aws.ToString(id.ImageTag); tag != "" {
+			tagsByDigest[digest] = append(tagsByDigest[digest], tag)
+		}
+	}
+
+	for _, digest := range digests {
+		fmt.Fprintf(w, "✓ Deleted %s (%s)\n", digest, formatECRImageTags(tagsByDigest[digest]))
+	}
+	for _, failure := range failures {
+		id := aws.ToString(failure.ImageId.ImageDigest)
+		if id == "" {
+			id = aws.ToString(failure.ImageId.ImageTag)
+		}
+		fmt.Fprintf(w, "✗ Failed to delete %s: %s: %s\n", id, failure.FailureCode, aws.ToString(failure.FailureReason))
+	}
+}
+
+// formatECRImageTags joins an image's tags for display, or "<untagged>" when there are none
+func formatECRImageTags(tags []string) string {
+	if len(tags) == 0 {
+		return "<untagged>"
+	}
+	return strings.Join(tags, ", ")
+}
+
+// ECRDeleteArgs represents parsed ecr delete command arguments
+type ECRDeleteArgs struct {
+	RepositoryName string
+	Tags           []string
+	Digests        []string
+	Force          bool
+	Verbose        bool
+}
+
+// parseECRDeleteArgs parses command line arguments for the ecr delete command
+func parseECRDeleteArgs(args []string) (*ECRDeleteArgs, error) {
+	opts := &ECRDeleteArgs{}
+
+	fs := cli.NewFlagSet("ecr delete")
+	fs.StringVar(&opts.RepositoryName, "repository", "", "ECR repository name")
+	fs.StringArrayVar(&opts.Tags, "tag", nil, "tag of an image to delete (repeatable)")
+	fs.StringArrayVar(&opts.Digests, "digest", nil, "digest of an image to delete (repeatable)")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RepositoryName == "" {
+		return nil, fmt.Errorf("repository parameter is required")
+	}
+	if len(opts.Tags) == 0 && len(opts.Digests) == 0 {
+		return nil, fmt.Errorf("at least one --tag or --digest is required")
+	}
+	for _, digest := range opts.Digests {
+		if !strings.HasPrefix(digest, "sha256:") {
+			return nil, fmt.Errorf("invalid digest '%s': expected sha256:...", digest)
+		}
+	}
+
+	return opts, nil
+}
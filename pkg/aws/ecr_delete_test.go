@@ -0,0 +1,177 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestParseECRDeleteArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRDeleteArgs
+		expectError bool
+	}{
+		{
+			name:     "single tag",
+			args:     []string{"ecr", "delete", "--repository", "my-repo", "--tag", "v1.0"},
+			expected: &ECRDeleteArgs{RepositoryName: "my-repo", Tags: []string{"v1.0"}},
+		},
+		{
+			name: "repeated tags and digests",
+			args: []string{"ecr", "delete", "--repository=my-repo", "--tag", "v1.0", "--tag", "v1.1", "--digest", "sha256:abc", "--force", "-v"},
+			expected: &ECRDeleteArgs{
+				RepositoryName: "my-repo",
+				Tags:           []string{"v1.0", "v1.1"},
+				Digests:        []string{"sha256:abc"},
+				Force:          true,
+				Verbose:        true,
+			},
+		},
+		{
+			name:        "missing repository",
+			args:        []string{"ecr", "delete", "--tag", "v1.0"},
+			expectError: true,
+		},
+		{
+			name:        "no tag or digest",
+			args:        []string{"ecr", "delete", "--repository", "my-repo"},
+			expectError: true,
+		},
+		{
+			name:        "digest without algorithm",
+			args:        []string{"ecr", "delete", "--repository", "my-repo", "--digest", "abc"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRDeleteArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRDeleteArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveECRDeleteTargets(t *testing.T) {
+	client := &fakeECR{images: map[string][]ecrtypes.ImageDetail{
+		"my-repo": {
+			{ImageDigest: aws.String("sha256:aaa"), ImageTags: []string{"v1.0", "latest"}},
+			{ImageDigest: aws.String("sha256:bbb")},
+		},
+	}}
+
+	t.Run("tag and digest of the same image are deleted once", func(t *testing.T) {
+		images, err := resolveECRDeleteTargets(client, "my-repo", []string{"v1.0", "latest"}, []string{"sha256:aaa", "sha256:bbb"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var digests []string
+		for _, image := range images {
+			digests = append(digests, aws.ToString(image.ImageDigest))
+		}
+		if want := []string{"sha256:aaa", "sha256:bbb"}; !reflect.DeepEqual(digests, want) {
+			t.Errorf("resolved digests = %v, want %v", digests, want)
+		}
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		_, err := resolveECRDeleteTargets(client, "my-repo", []string{"missing"}, nil)
+		if err == nil || !strings.Contains(err.Error(), "tag missing not found") {
+			t.Errorf("Expected tag not found error, got %v", err)
+		}
+	})
+
+	t.Run("unknown digest", func(t *testing.T) {
+		_, err := resolveECRDeleteTargets(client, "my-repo", nil, []string{"sha256:ccc"})
+		if err == nil || !strings.Contains(err.Error(), "digest sha256:ccc not found") {
+			t.Errorf("Expected digest not found error, got %v", err)
+		}
+	})
+}
+
+func TestBatchDeleteECRImages(t *testing.T) {
+	t.Run("partial failure", func(t *testing.T) {
+		client := &fakeImageDeleter{failures: map[string]ecrtypes.ImageFailureCode{
+			"sha256:bbb": ecrtypes.ImageFailureCodeImageReferencedByManifestList,
+		}}
+
+		deleted, failures, err := batchDeleteECRImages(client, "my-repo", []string{"sha256:aaa", "sha256:bbb"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(deleted) != 1 || aws.ToString(deleted[0].ImageDigest) != "sha256:aaa" {
+			t.Errorf("deleted = %+v, want sha256:aaa only", deleted)
+		}
+		if len(failures) != 1 || aws.ToString(failures[0].ImageId.ImageDigest) != "sha256:bbb" {
+			t.Errorf("failures = %+v, want sha256:bbb only", failures)
+		}
+	})
+
+	t.Run("batches of the API limit", func(t *testing.T) {
+		client := &fakeImageDeleter{}
+		digests := make([]string, ecrBatchDeleteLimit+1)
+		for i := range digests {
+			digests[i] = fmt.Sprintf("sha256:%03d", i)
+		}
+
+		deleted, _, err := batchDeleteECRImages(client, "my-repo", digests)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(client.calls) != 2 || len(client.calls[0]) != ecrBatchDeleteLimit || len(client.calls[1]) != 1 {
+			t.Errorf("Expected calls of %d and 1 IDs, got %d calls", ecrBatchDeleteLimit, len(client.calls))
+		}
+		if len(deleted) != len(digests) {
+			t.Errorf("Expected %d deleted, got %d", len(digests), len(deleted))
+		}
+	})
+}
+
+func TestPrintECRDeleteResult(t *testing.T) {
+	deleted := []ecrtypes.ImageIdentifier{
+		{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("v1.0")},
+		{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("latest")},
+		{ImageDigest: aws.String("sha256:bbb")},
+	}
+	failures := []ecrtypes.ImageFailure{
+		{
+			ImageId:       &ecrtypes.ImageIdentifier{ImageDigest: aws.String("sha256:ccc")},
+			FailureCode:   ecrtypes.ImageFailureCodeImageNotFound,
+			FailureReason: aws.String("Requested image not found"),
+		},
+	}
+
+	var buf bytes.Buffer
+	printECRDeleteResult(&buf, deleted, failures)
+	output := buf.String()
+
+	for _, want := range []string{
+		"✓ Deleted sha256:aaa (v1.0, latest)",
+		"✓ Deleted sha256:bbb (<untagged>)",
+		"✗ Failed to delete sha256:ccc: ImageNotFound: Requested image not found",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// registryIDPattern matches an AWS account ID, which is also the ECR registry ID
+var registryIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+// GetECRLogin handles the ecr get-login command, which prints the registry password for
+// docker login, or a complete docker login command with --print-command
+func GetECRLogin(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr get-login [--registry-id ACCOUNT_ID] [--print-command]")
+			fmt.Println("Options:")
+			fmt.Println("  --registry-id ACCOUNT_ID  Registry to log in to, for cross-account registries (default: your account)")
+			fmt.Println("  --print-command           Print a ready-to-run docker login command instead of the password")
+			fmt.Println("  --verbose, -v             Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("By default only the password is printed, for piping into docker login:")
+			fmt.Println("  aws ecr get-login | docker login --username AWS --password-stdin 123456789012.dkr.ecr.us-east-1.amazonaws.com")
+			fmt.Println("The token is valid for 12 hours.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRLoginArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	input := &ecr.GetAuthorizationTokenInput{}
+	if opts.RegistryID != "" {
+		input.RegistryIds = []string{opts.RegistryID}
+	}
+	result, err := ecrClient.GetAuthorizationToken(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ECR authorization token: %w", err)
+	}
+	if len(result.AuthorizationData) == 0 {
+		return nil, fmt.Errorf("ECR returned no authorization data")
+	}
+
+	login, err := decodeECRAuthorization(result.AuthorizationData[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.PrintCommand {
+		fmt.Println(login.dockerLoginCommand())
+	} else {
+		fmt.Println(login.Password)
+	}
+
+	return nil, nil
+}
+
+// ecrLogin holds the decoded docker credentials for one registry
+type ecrLogin struct {
+	Username string
+	Password string
+	// Registry is the registry host, without the https:// scheme of the proxy endpoint
+	Registry string
+}
+
+// decodeECRAuthorization decodes the base64 "user:password" token of an ECR authorization
+func decodeECRAuthorization(data types.AuthorizationData) (*ecrLogin, error) {
+	decoded, err := base64.StdEncoding.DecodeString(aws.ToString(data.AuthorizationToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ECR authorization token: %w", err)
+	}
+
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found || username == "" || password == "" {
+		return nil, fmt.Errorf("ECR authorization token is not in user:password form")
+	}
+
+	return &ecrLogin{
+		Username: username,
+		Password: password,
+		Registry: strings.TrimPrefix(aws.ToString(data.ProxyEndpoint), "https://"),
+	}, nil
+}
+
+// dockerLoginCommand returns a docker login command for the registry, feeding the password
+// on stdin so it does not show up in the process list
+func (l *ecrLogin) dockerLoginCommand() string {
+	return fmt.Sprintf("echo '%s' | docker login --username %s --password-stdin %s", l.Password, l.Username, l.Registry)
+}
+
+// ECRLoginArgs represents parsed ecr get-login command arguments
+type ECRLoginArgs struct {
+	RegistryID   string
+	PrintCommand bool
+	Verbose      bool
+}
+
+// parseECRLoginArgs parses command line arguments for the ecr get-login command
+func parseECRLoginArgs(args []string) (*ECRLoginArgs, error) {
+	opts := &ECRLoginArgs{}
+
+	fs := cli.NewFlagSet("ecr get-login")
+	fs.StringVar(&opts.RegistryID, "registry-id", "", "registry (account) ID to log in to")
+	fs.BoolVar(&opts.PrintCommand, "print-command", false, "print a docker login command instead of the password")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RegistryID != "" && !registryIDPattern.MatchString(opts.RegistryID) {
+		return nil, fmt.Errorf("invalid registry ID '%s': expected a 12-digit AWS account ID", opts.RegistryID)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestParseECRLoginArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRLoginArgs
+		expectError bool
+	}{
+		{
+			name:     "defaults",
+			args:     []string{"ecr", "get-login"},
+			expected: &ECRLoginArgs{},
+		},
+		{
+			name:     "registry id and print command",
+			args:     []string{"ecr", "get-login", "--registry-id", "123456789012", "--print-command", "-v"},
+			expected: &ECRLoginArgs{RegistryID: "123456789012", PrintCommand: true, Verbose: true},
+		},
+		{
+			name:        "registry id too short",
+			args:        []string{"ecr", "get-login", "--registry-id=12345"},
+			expectError: true,
+		},
+		{
+			name:        "registry id not numeric",
+			args:        []string{"ecr", "get-login", "--registry-id=12345678901a"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRLoginArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRLoginArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeECRAuthorization(t *testing.T) {
+	encode := func(s string) *string {
+		return aws.String(base64.StdEncoding.EncodeToString([]byte(s)))
+	}
+	endpoint := aws.String("https://123456789012.dkr.ecr.us-east-1.amazonaws.com")
+
+	tests := []struct {
+		name        string
+		data        types.AuthorizationData
+		expected    *ecrLogin
+		expectError bool
+	}{
+		{
+			name:     "valid token",
+			data:     types.AuthorizationData{AuthorizationToken: encode("AWS:secret:with:colons"), ProxyEndpoint: endpoint},
+			expected: &ecrLogin{Username: "AWS", Password: "secret:with:colons", Registry: "123456789012.dkr.ecr.us-east-1.amazonaws.com"},
+		},
+		{
+			name:        "not base64",
+			data:        types.AuthorizationData{AuthorizationToken: aws.String("not base64!"), ProxyEndpoint: endpoint},
+			expectError: true,
+		},
+		{
+			name:        "no password",
+			data:        types.AuthorizationData{AuthorizationToken: encode("AWS"), ProxyEndpoint: endpoint},
+			expectError: true,
+		},
+		{
+			name:        "missing token",
+			data:        types.AuthorizationData{ProxyEndpoint: endpoint},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login, err := decodeECRAuthorization(tt.data)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(login, tt.expected) {
+				t.Errorf("decodeECRAuthorization() = %+v, want %+v", login, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDockerLoginCommand(t *testing.T) {
+	login := &ecrLogin{Username: "AWS", Password: "secret", Registry: "123456789012.dkr.ecr.us-east-1.amazonaws.com"}
+
+	want := "echo 'secret' | docker login --username AWS --password-stdin 123456789012.dkr.ecr.us-east-1.amazonaws.com"
+	if got := login.dockerLoginCommand(); got != want {
+		t.Errorf("dockerLoginCommand() = %q, want %q", got, want)
+	}
+}
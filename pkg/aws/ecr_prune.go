@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// PruneECRImages handles the ecr prune command, which deletes the untagged images of a
+// repository, or of every repository, except the most recently pushed ones
+func PruneECRImages(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr prune (--repository REPO_NAME | --all) [--keep-last N] [--dry-run] [--force]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository REPO_NAME  ECR repository to prune")
+			fmt.Println("  --all                   Prune every repository")
+			fmt.Println("  --keep-last N           Keep the N most recently pushed untagged images of each repository (default 0)")
+			fmt.Println("  --dry-run               Print the images that would be deleted without deleting them")
+			fmt.Println("  --force                 Skip the confirmation prompt")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("Untagged images are usually left behind when a tag is pushed again. Tagged images")
+			fmt.Println("are never deleted by this command.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRPruneArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	repositories := []string{opts.RepositoryName}
+	if opts.AllRepos {
+		repos, err := describeAllRepositories(ecrClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe repositories: %w", err)
+		}
+		repositories = repositories[:0]
+		for _, repo := range repos {
+			repositories = append(repositories, aws.ToString(repo.RepositoryName))
+		}
+	}
+
+	candidates, err := listUntaggedImagesToPrune(ecrClient, repositories, opts.KeepLast)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		fmt.Printf("No untagged images to prune (keeping the newest %d per repository)\n", opts.KeepLast)
+		return nil, nil
+	}
+
+	fmt.Printf("Found %d untagged image(s) to prune (keeping the newest %d per repository):\n\n", len(candidates), opts.KeepLast)
+	printECRImagesTable(candidates, opts.AllRepos, false, false)
+
+	if opts.DryRun {
+		fmt.Println("\nDry run: no images were deleted.")
+		return nil, nil
+	}
+
+	// Confirm unless --force is used
+	if !opts.Force {
+		fmt.Printf("\nAre you sure you want to delete these %d image(s)? (yes/no): ", len(candidates))
+		var response string
+		fmt.Scanln(&response)
+		if response != "yes" {
+			fmt.Println("Prune cancelled.")
+			return nil, nil
+		}
+	}
+
+	// Delete repository by repository, as BatchDeleteImage works on one repository
+	var pruned []string
+	digestsByRepository := make(map[string][]string)
+	for _, image := range candidates {
+		if _, ok := digestsByRepository[image.RepositoryName]; !ok {
+			pruned = append(pruned, image.RepositoryName)
+		}
+		digestsByRepository[image.RepositoryName] = append(digestsByRepository[image.RepositoryName], image.ImageDigest)
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, repository := range pruned {
+		deleted, failures, err := batchDeleteECRImages(ecrClient, repository, digestsByRepository[repository])
+		printECRDeleteResult(os.Stdout, deleted, failures)
+		if err != nil {
+			return nil, err
+		}
+		failed += len(failures)
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("%d of %d image(s) could not be deleted", failed, len(candidates))
+	}
+
+	return nil, nil
+}
+
+// listUntaggedImagesToPrune lists the untagged images of each repository and selects those
+// to delete with selectUntaggedImagesToPrune
+func listUntaggedImagesToPrune(ecrClient imageDescriber, repositories []string, keepLast int) ([]ECRImageInfo, error) {
+	var untagged []ECRImageInfo
+	for _, repository := range repositories {
+		imageDetails, err := describeAllImages(ecrClient, &ecr.DescribeImagesInput{
+			RepositoryName: aws.String(repository),
+			Filter:         &types.DescribeImagesFilter{TagStatus: types.TagStatusUntagged},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe images in repository %s: %w", repository, err)
+		}
+		for _, image := range convertECRImagesToImageInfo(imageDetails) {
+			// Convert leaves RepositoryName empty when the response omits it
+			image.RepositoryName = repository
+			untagged = append(untagged, image)
+		}
+	}
+	return selectUntaggedImagesToPrune(untagged, keepLast), nil
+}
+
+// ECRPruneArgs represents parsed ecr prune command arguments
+type ECRPruneArgs struct {
+	RepositoryName string
+	AllRepos       bool
+	KeepLast       int
+	DryRun         bool
+	Force          bool
+	Verbose        bool
+}
+
+// parseECRPruneArgs parses command line arguments for the ecr prune command
+func parseECRPruneArgs(args []string) (*ECRPruneArgs, error) {
+	opts := &ECRPruneArgs{}
+
+	fs := cli.NewFlagSet("ecr prune")
+	fs.StringVar(&opts.RepositoryName, "repository", "", "ECR repository name")
+	fs.BoolVar(&opts.AllRepos, "all", false, "prune every repository")
+	fs.IntVar(&opts.KeepLast, "keep-last", 0, "number of newest untagged images to keep per repository")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "print what would be deleted without deleting")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RepositoryName == "" && !opts.AllRepos {
+		return nil, fmt.Errorf("repository parameter is required (use --repository REPO_NAME or --all for all repositories)")
+	}
+	if opts.RepositoryName != "" && opts.AllRepos {
+		return nil, fmt.Errorf("--repository cannot be combined with --all")
+	}
+	if opts.KeepLast < 0 {
+		return nil, fmt.Errorf("--keep-last must not be negative")
+	}
+
+	return opts, nil
+}
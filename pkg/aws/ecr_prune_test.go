@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestParseECRPruneArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRPruneArgs
+		expectError bool
+	}{
+		{
+			name:     "repository",
+			args:     []string{"ecr", "prune", "--repository", "my-repo"},
+			expected: &ECRPruneArgs{RepositoryName: "my-repo"},
+		},
+		{
+			name:     "all repositories with keep last and dry run",
+			args:     []string{"ecr", "prune", "--all", "--keep-last", "5", "--dry-run", "-v"},
+			expected: &ECRPruneArgs{AllRepos: true, KeepLast: 5, DryRun: true, Verbose: true},
+		},
+		{
+			name:        "missing repository",
+			args:        []string{"ecr", "prune", "--keep-last", "5"},
+			expectError: true,
+		},
+		{
+			name:        "repository with all",
+			args:        []string{"ecr", "prune", "--repository", "my-repo", "--all"},
+			expectError: true,
+		},
+		{
+			name:        "negative keep last",
+			args:        []string{"ecr", "prune", "--repository", "my-repo", "--keep-last", "-1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRPruneArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRPruneArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestListUntaggedImagesToPrune(t *testing.T) {
+	pushed := func(d int) *time.Time { t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC); return &t }
+	client := &fakeECR{
+		pageSize: 1,
+		images: map[string][]ecrtypes.ImageDetail{
+			"api": {
+				{ImageDigest: aws.String("sha256:a1"), ImagePushedAt: pushed(1)},
+				{ImageDigest: aws.String("sha256:a3"), ImagePushedAt: pushed(3)},
+				{ImageDigest: aws.String("sha256:a2"), ImagePushedAt: pushed(2)},
+			},
+			"web": {
+				{ImageDigest: aws.String("sha256:w1"), ImagePushedAt: pushed(1)},
+			},
+		},
+	}
+
+	candidates, err := listUntaggedImagesToPrune(client, []string{"api", "web"}, 1)
+	if err != nil {
+		t.Fatalf("listUntaggedImagesToPrune() error = %v", err)
+	}
+
+	var got []string
+	for _, image := range candidates {
+		got = append(got, image.RepositoryName+"@"+image.ImageDigest)
+	}
+	if want := []string{"api@sha256:a2", "api@sha256:a1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("listUntaggedImagesToPrune() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pischarti/nix/pkg/cli"
+	printpkg "github.com/pischarti/nix/pkg/print"
+	"gofr.dev/pkg/gofr"
+)
+
+// ECRRepositoryInfo summarizes an ECR repository and how many tags and untagged images it holds
+type ECRRepositoryInfo struct {
+	RepositoryName string
+	RepositoryURI  string
+	TaggedCount    int
+	UntaggedCount  int
+	CreatedAt      time.Time
+}
+
+// ListECRRepositories handles the ecr repos command for an inventory of repositories and
+// their tag counts, without fetching image details
+func ListECRRepositories(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr repos [--repository-prefix PREFIX] [--color WHEN] [--no-headers]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository-prefix PREFIX  Only include repositories whose name starts with PREFIX")
+			fmt.Println("  --color WHEN            Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --no-headers            Print only the table rows, space-separated, for piping into awk or cut")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command lists every repository with its URI, creation date and the number of tags")
+			fmt.Println("and untagged images it holds. It only lists image IDs, so it is much faster than")
+			fmt.Println("'aws ecr --all' when you just need an overview.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRReposArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	var repositories []types.Repository
+	reposPaginator := ecr.NewDescribeRepositoriesPaginator(ecrClient, &ecr.DescribeRepositoriesInput{})
+	for reposPaginator.HasMorePages() {
+		page, err := reposPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe repositories: %w", err)
+		}
+		repositories = append(repositories, page.Repositories...)
+	}
+
+	if opts.RepositoryPrefix != "" {
+		repositories = filterRepositoriesByPrefix(repositories, opts.RepositoryPrefix)
+	}
+
+	repos := make([]ECRRepositoryInfo, 0, len(repositories))
+	for _, repo := range repositories {
+		info := ECRRepositoryInfo{
+			RepositoryName: aws.ToString(repo.RepositoryName),
+			RepositoryURI:  aws.ToString(repo.RepositoryUri),
+			CreatedAt:      aws.ToTime(repo.CreatedAt),
+		}
+
+		imagesPaginator := ecr.NewListImagesPaginator(ecrClient, &ecr.ListImagesInput{
+			RepositoryName: repo.RepositoryName,
+		})
+		for imagesPaginator.HasMorePages() {
+			page, err := imagesPaginator.NextPage(context.TODO())
+			if err != nil {
+				// Log error but continue with other repositories
+				fmt.Printf("Warning: failed to list images in repository %s: %v\n", info.RepositoryName, err)
+				break
+			}
+			tagged, untagged := countImageTags(page.ImageIds)
+			info.TaggedCount += tagged
+			info.UntaggedCount += untagged
+		}
+
+		repos = append(repos, info)
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].RepositoryName < repos[j].RepositoryName
+	})
+
+	printECRRepositoriesTable(repos, useColor, opts.NoHeaders)
+
+	return nil, nil
+}
+
+// ECRReposArgs represents parsed ecr repos command arguments
+type ECRReposArgs struct {
+	RepositoryPrefix string
+	Color            string
+	NoHeaders        bool
+	Verbose          bool
+}
+
+// parseECRReposArgs parses command line arguments for the ecr repos command
+func parseECRReposArgs(args []string) (*ECRReposArgs, error) {
+	opts := &ECRReposArgs{}
+
+	fs := cli.NewFlagSet("repos")
+	fs.StringVar(&opts.RepositoryPrefix, "repository-prefix", "", "only include repositories with this prefix")
+	fs.StringVar(&opts.Color, "color", printpkg.ColorAuto, "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// countImageTags counts the tags and untagged images in a ListImages result. ListImages
+// returns one identifier per tag, so an image with several tags counts once per tag.
+func countImageTags(imageIDs []types.ImageIdentifier) (tagged, untagged int) {
+	for _, id := range imageIDs {
+		if aws.ToString(id.ImageTag) != "" {
+			tagged++
+		} else {
+			untagged++
+		}
+	}
+	return tagged, untagged
+}
+
+// printECRRepositoriesTable prints ECR repositories and their tag counts in a formatted table
+func printECRRepositoriesTable(repos []ECRRepositoryInfo, color, noHeaders bool) {
+	if len(repos) == 0 {
+		if !noHeaders {
+			fmt.Println("No repositories found.")
+		}
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if noHeaders {
+		printpkg.SetNoHeadersStyle(t)
+	} else {
+		printpkg.SetColoredStyle(t, color)
+		t.AppendHeader(table.Row{"Repository", "URI", "Tagged", "Untagged", "Created"})
+	}
+
+	for _, repo := range repos {
+		created := repo.CreatedAt.Format("2006-01-02 15:04:05")
+		if noHeaders {
+			created = repo.CreatedAt.Format(time.RFC3339)
+		}
+		t.AppendRow(table.Row{
+			repo.RepositoryName,
+			repo.RepositoryURI,
+			repo.TaggedCount,
+			repo.UntaggedCount,
+			created,
+		})
+	}
+
+	t.Render()
+}
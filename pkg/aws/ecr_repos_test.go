@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestCountImageTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		imageIDs     []types.ImageIdentifier
+		wantTagged   int
+		wantUntagged int
+	}{
+		{
+			name: "empty repository",
+		},
+		{
+			name: "image with several tags counts once per tag",
+			imageIDs: []types.ImageIdentifier{
+				{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("latest")},
+				{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("v1.2.0")},
+			},
+			wantTagged: 2,
+		},
+		{
+			name: "tagged and untagged",
+			imageIDs: []types.ImageIdentifier{
+				{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("latest")},
+				{ImageDigest: aws.String("sha256:bbb")},
+				{ImageDigest: aws.String("sha256:ccc"), ImageTag: aws.String("")},
+			},
+			wantTagged:   1,
+			wantUntagged: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagged, untagged := countImageTags(tt.imageIDs)
+			if tagged != tt.wantTagged || untagged != tt.wantUntagged {
+				t.Errorf("countImageTags() = (%d, %d), want (%d, %d)", tagged, untagged, tt.wantTagged, tt.wantUntagged)
+			}
+		})
+	}
+}
+
+func TestParseECRReposArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRReposArgs
+		expectError bool
+	}{
+		{
+			name:     "defaults",
+			args:     []string{"ecr", "repos"},
+			expected: &ECRReposArgs{Color: "auto"},
+		},
+		{
+			name:     "prefix and no headers",
+			args:     []string{"ecr", "repos", "--repository-prefix=team-a/", "--color=never", "--no-headers", "-v"},
+			expected: &ECRReposArgs{RepositoryPrefix: "team-a/", Color: "never", NoHeaders: true, Verbose: true},
+		},
+		{
+			name:        "image listing flag",
+			args:        []string{"ecr", "repos", "--tag", "latest"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRReposArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRReposArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	printpkg "github.com/pischarti/nix/pkg/print"
+	"gofr.dev/pkg/gofr"
+	"gopkg.in/yaml.v3"
+)
+
+// ScanECRImage handles the ecr scan command, which summarizes the vulnerability scan
+// findings of an image by severity
+func ScanECRImage(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr scan --repository REPO_NAME --tag TAG [--output FORMAT] [--color WHEN]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository REPO_NAME  ECR repository name (required)")
+			fmt.Println("  --tag TAG               Tag of the image to report on (required)")
+			fmt.Println("  --output FORMAT         Output format: table (default), yaml, json")
+			fmt.Println("  --color WHEN            Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command reads the results of the last scan of the image; it does not start one.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRScanArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	scan, err := describeECRScan(ecrClient, opts.RepositoryName, opts.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Print output in requested format
+	switch opts.OutputFormat {
+	case "yaml":
+		yamlBytes, err := yaml.Marshal(scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode scan as YAML: %w", err)
+		}
+		fmt.Print(string(yamlBytes))
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(scan); err != nil {
+			return nil, fmt.Errorf("failed to encode scan as JSON: %w", err)
+		}
+	default:
+		printECRScanTable(os.Stdout, scan, useColor)
+	}
+
+	return nil, nil
+}
+
+// describeECRScan reads the scan status and severity counts of the image tagged tag. A
+// repository without scan results gets an error that says so instead of the bare
+// ScanNotFoundException.
+func describeECRScan(ecrClient scanFindingsDescriber, repositoryName, tag string) (ECRScanInfo, error) {
+	// The severity counts are on every page, so one page is enough
+	result, err := ecrClient.DescribeImageScanFindings(context.TODO(), &ecr.DescribeImageScanFindingsInput{
+		RepositoryName: aws.String(repositoryName),
+		ImageId:        &types.ImageIdentifier{ImageTag: aws.String(tag)},
+		MaxResults:     aws.Int32(1),
+	})
+	var scanNotFound *types.ScanNotFoundException
+	if errors.As(err, &scanNotFound) {
+		return ECRScanInfo{}, fmt.Errorf("no scan results for %s:%s: image scanning is not enabled on repository %s, or this image has not been scanned yet (enable scan on push, or start a scan with 'aws ecr start-image-scan' from the AWS CLI)", repositoryName, tag, repositoryName)
+	}
+	var imageNotFound *types.ImageNotFoundException
+	if errors.As(err, &imageNotFound) {
+		return ECRScanInfo{}, fmt.Errorf("tag %s not found in repository %s", tag, repositoryName)
+	}
+	if err != nil {
+		return ECRScanInfo{}, fmt.Errorf("failed to describe scan findings for %s:%s: %w", repositoryName, tag, err)
+	}
+
+	scan := ECRScanInfo{RepositoryName: repositoryName, ImageTag: tag}
+	if result.ImageId != nil {
+		scan.ImageDigest = aws.ToString(result.ImageId.ImageDigest)
+	}
+	if result.ImageScanStatus != nil {
+		scan.Status = string(result.ImageScanStatus.Status)
+		scan.StatusDescription = aws.ToString(result.ImageScanStatus.Description)
+	}
+	if findings := result.ImageScanFindings; findings != nil {
+		scan.CompletedAt = findings.ImageScanCompletedAt
+		counts := findings.FindingSeverityCounts
+		scan.Critical = counts[string(types.FindingSeverityCritical)]
+		scan.High = counts[string(types.FindingSeverityHigh)]
+		scan.Medium = counts[string(types.FindingSeverityMedium)]
+		scan.Low = counts[string(types.FindingSeverityLow)]
+		scan.Informational = counts[string(types.FindingSeverityInformational)]
+		scan.Undefined = counts[string(types.FindingSeverityUndefined)]
+	}
+
+	return scan, nil
+}
+
+// ECRScanArgs represents parsed ecr scan command arguments
+type ECRScanArgs struct {
+	RepositoryName string
+	Tag            string
+	OutputFormat   string
+	Color          string
+	Verbose        bool
+}
+
+// parseECRScanArgs parses command line arguments for the ecr scan command
+func parseECRScanArgs(args []string) (*ECRScanArgs, error) {
+	opts := &ECRScanArgs{}
+
+	fs := cli.NewFlagSet("ecr scan")
+	fs.StringVar(&opts.RepositoryName, "repository", "", "ECR repository name")
+	fs.StringVar(&opts.Tag, "tag", "", "tag of the image to report on")
+	fs.StringVar(&opts.OutputFormat, "output", "table", "output format: table, yaml, json")
+	fs.StringVar(&opts.Color, "color", printpkg.ColorAuto, "color table output: auto, always, never")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RepositoryName == "" {
+		return nil, fmt.Errorf("repository parameter is required")
+	}
+	if opts.Tag == "" {
+		return nil, fmt.Errorf("tag parameter is required")
+	}
+	switch opts.OutputFormat {
+	case "table", "yaml", "json":
+	default:
+		return nil, fmt.Errorf("invalid output format '%s'. Valid options: table, yaml, json", opts.OutputFormat)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+func TestParseECRScanArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRScanArgs
+		expectError bool
+	}{
+		{
+			name:     "repository and tag",
+			args:     []string{"ecr", "scan", "--repository", "my-repo", "--tag", "v1.0"},
+			expected: &ECRScanArgs{RepositoryName: "my-repo", Tag: "v1.0", OutputFormat: "table", Color: "auto"},
+		},
+		{
+			name:     "json output",
+			args:     []string{"ecr", "scan", "--repository", "my-repo", "--tag", "v1.0", "--output", "json"},
+			expected: &ECRScanArgs{RepositoryName: "my-repo", Tag: "v1.0", OutputFormat: "json", Color: "auto"},
+		},
+		{
+			name:        "missing tag",
+			args:        []string{"ecr", "scan", "--repository", "my-repo"},
+			expectError: true,
+		},
+		{
+			name:        "invalid output format",
+			args:        []string{"ecr", "scan", "--repository", "my-repo", "--tag", "v1.0", "--output", "xml"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRScanArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRScanArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDescribeECRScan(t *testing.T) {
+	completed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("severity counts", func(t *testing.T) {
+		client := &fakeScanFindings{output: &ecr.DescribeImageScanFindingsOutput{
+			ImageId:         &ecrtypes.ImageIdentifier{ImageDigest: aws.String("sha256:aaa"), ImageTag: aws.String("v1.0")},
+			ImageScanStatus: &ecrtypes.ImageScanStatus{Status: ecrtypes.ScanStatusComplete},
+			ImageScanFindings: &ecrtypes.ImageScanFindings{
+				ImageScanCompletedAt:  &completed,
+				FindingSeverityCounts: map[string]int32{"CRITICAL": 1, "HIGH": 2, "LOW": 4},
+			},
+		}}
+
+		scan, err := describeECRScan(client, "my-repo", "v1.0")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := ECRScanInfo{
+			RepositoryName: "my-repo",
+			ImageTag:       "v1.0",
+			ImageDigest:    "sha256:aaa",
+			Status:         "COMPLETE",
+			CompletedAt:    &completed,
+			Critical:       1,
+			High:           2,
+			Low:            4,
+		}
+		if !reflect.DeepEqual(scan, expected) {
+			t.Errorf("describeECRScan() = %+v, want %+v", scan, expected)
+		}
+		if scan.Total() != 7 {
+			t.Errorf("Total() = %d, want 7", scan.Total())
+		}
+	})
+
+	t.Run("scanning not enabled", func(t *testing.T) {
+		client := &fakeScanFindings{err: &ecrtypes.ScanNotFoundException{Message: aws.String("scan not found")}}
+
+		_, err := describeECRScan(client, "my-repo", "v1.0")
+		if err == nil || !strings.Contains(err.Error(), "image scanning is not enabled on repository my-repo") {
+			t.Errorf("Expected scanning not enabled error, got %v", err)
+		}
+	})
+}
+
+func TestPrintECRScanTable(t *testing.T) {
+	scan := ECRScanInfo{
+		RepositoryName: "my-repo",
+		ImageTag:       "v1.0",
+		ImageDigest:    "sha256:aaa",
+		Status:         "COMPLETE",
+		Critical:       1,
+		Medium:         3,
+	}
+
+	var buf bytes.Buffer
+	printECRScanTable(&buf, scan, false)
+	output := buf.String()
+
+	for _, want := range []string{"my-repo:v1.0 (sha256:aaa)", "Scan status: COMPLETE", "CRITICAL", "UNDEFINED", "TOTAL"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
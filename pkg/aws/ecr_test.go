@@ -0,0 +1,587 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestParseECRArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRArgs
+		expectError bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"ecr", "--repository", "my-repo"},
+			expected: &ECRArgs{
+				Repositories: []string{"my-repo"},
+				SortBy:       "pushed",
+				OutputFormat: "table",
+				Color:        "auto",
+			},
+		},
+		{
+			name: "repeated repository",
+			args: []string{"ecr", "--repository", "api", "--repository", "web"},
+			expected: &ECRArgs{
+				Repositories: []string{"api", "web"},
+				SortBy:       "pushed",
+				OutputFormat: "table",
+				Color:        "auto",
+			},
+		},
+		{
+			name: "verbose",
+			args: []string{"ecr", "--repository", "my-repo", "--verbose"},
+			expected: &ECRArgs{
+				Repositories: []string{"my-repo"},
+				SortBy:       "pushed",
+				OutputFormat: "table",
+				Color:        "auto",
+				Verbose:      true,
+			},
+		},
+		{
+			name: "all with repository prefix",
+			args: []string{"ecr", "--all", "--repository-prefix", "team-a/"},
+			expected: &ECRArgs{
+				AllRepos:         true,
+				RepositoryPrefix: "team-a/",
+				SortBy:           "pushed",
+				OutputFormat:     "table",
+				Color:            "auto",
+			},
+		},
+		{
+			name: "repository prefix before all",
+			args: []string{"ecr", "--repository-prefix", "team-b/", "--all", "--sort", "size"},
+			expected: &ECRArgs{
+				AllRepos:         true,
+				RepositoryPrefix: "team-b/",
+				SortBy:           "size",
+				OutputFormat:     "table",
+				Color:            "auto",
+			},
+		},
+		{
+			name: "equals values",
+			args: []string{"ecr", "list", "--repository=my-repo", "--output=yaml"},
+			expected: &ECRArgs{
+				Repositories: []string{"my-repo"},
+				SortBy:       "pushed",
+				OutputFormat: "yaml",
+				Color:        "auto",
+			},
+		},
+		{
+			name: "no headers",
+			args: []string{"ecr", "--repository", "my-repo", "--no-headers"},
+			expected: &ECRArgs{
+				Repositories: []string{"my-repo"},
+				SortBy:       "pushed",
+				OutputFormat: "table",
+				Color:        "auto",
+				NoHeaders:    true,
+			},
+		},
+		{
+			name: "json output",
+			args: []string{"ecr", "--repository", "my-repo", "--output", "json"},
+			expected: &ECRArgs{
+				Repositories: []string{"my-repo"},
+				SortBy:       "pushed",
+				OutputFormat: "json",
+				Color:        "auto",
+			},
+		},
+		{
+			name:        "invalid output format",
+			args:        []string{"ecr", "--repository", "my-repo", "--output", "jsn"},
+			expectError: true,
+		},
+		{
+			name:        "no headers with yaml output",
+			args:        []string{"ecr", "--repository", "my-repo", "--no-headers", "--output", "yaml"},
+			expectError: true,
+		},
+		{
+			name:        "unknown flag",
+			args:        []string{"ecr", "--repo", "my-repo"},
+			expectError: true,
+		},
+		{
+			name:        "repository with all",
+			args:        []string{"ecr", "--all", "--repository", "api", "--repository", "web"},
+			expectError: true,
+		},
+		{
+			name:        "repository prefix without all",
+			args:        []string{"ecr", "--repository", "my-repo", "--repository-prefix", "team-a/"},
+			expectError: true,
+		},
+		{
+			name:        "repository prefix missing value",
+			args:        []string{"ecr", "--all", "--repository-prefix"},
+			expectError: true,
+		},
+		{
+			name:        "repository regex without all",
+			args:        []string{"ecr", "--repository-regex", "^team-a/"},
+			expectError: true,
+		},
+		{
+			name:        "repository regex with prefix",
+			args:        []string{"ecr", "--all", "--repository-regex", "^team-a/", "--repository-prefix", "team-a/"},
+			expectError: true,
+		},
+		{
+			name:        "repository regex with repository",
+			args:        []string{"ecr", "--all", "--repository-regex", "^team-a/", "--repository", "my-repo"},
+			expectError: true,
+		},
+		{
+			name:        "invalid repository regex",
+			args:        []string{"ecr", "--all", "--repository-regex", "team-(a"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterRepositoriesByPrefix(t *testing.T) {
+	repos := []types.Repository{
+		{RepositoryName: aws.String("team-a/api")},
+		{RepositoryName: aws.String("team-a/worker")},
+		{RepositoryName: aws.String("team-b/api")},
+		{RepositoryName: aws.String("shared")},
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{
+			name:     "matching prefix",
+			prefix:   "team-a/",
+			expected: []string{"team-a/api", "team-a/worker"},
+		},
+		{
+			name:     "broader prefix",
+			prefix:   "team-",
+			expected: []string{"team-a/api", "team-a/worker", "team-b/api"},
+		},
+		{
+			name:     "no matches",
+			prefix:   "team-c/",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, repo := range filterRepositoriesByPrefix(repos, tt.prefix) {
+				got = append(got, aws.ToString(repo.RepositoryName))
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("filterRepositoriesByPrefix(%q) = %v, want %v", tt.prefix, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		pushedAt time.Time
+		expected string
+	}{
+		{name: "unknown", pushedAt: time.Time{}, expected: "-"},
+		{name: "seconds", pushedAt: now.Add(-45 * time.Second), expected: "45s"},
+		{name: "future clock skew", pushedAt: now.Add(5 * time.Second), expected: "0s"},
+		{name: "minutes", pushedAt: now.Add(-12*time.Minute - 30*time.Second), expected: "12m"},
+		{name: "hours", pushedAt: now.Add(-3*time.Hour - 59*time.Minute), expected: "3h"},
+		{name: "days", pushedAt: now.Add(-42*24*time.Hour - 5*time.Hour), expected: "42d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAge(tt.pushedAt, now); got != tt.expected {
+				t.Errorf("formatAge() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortECRImagesByAge(t *testing.T) {
+	now := time.Now()
+	images := []ECRImageInfo{
+		{ImageTag: "new", PushedAt: now},
+		{ImageTag: "oldest", PushedAt: now.Add(-72 * time.Hour)},
+		{ImageTag: "old", PushedAt: now.Add(-24 * time.Hour)},
+	}
+
+	sortECRImages(images, "age")
+
+	var got []string
+	for _, image := range images {
+		got = append(got, image.ImageTag)
+	}
+	if want := []string{"oldest", "old", "new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortECRImages(age) order = %v, want %v", got, want)
+	}
+}
+
+func TestSelectUntaggedImagesToPrune(t *testing.T) {
+	now := time.Now()
+	images := []ECRImageInfo{
+		{RepositoryName: "api", ImageTag: "<untagged>", ImageDigest: "sha256:old", PushedAt: now.Add(-72 * time.Hour)},
+		{RepositoryName: "api", ImageTag: "v1", ImageDigest: "sha256:tagged", PushedAt: now.Add(-96 * time.Hour)},
+		{RepositoryName: "api", ImageTag: "<untagged>", ImageDigest: "sha256:new", PushedAt: now},
+		{RepositoryName: "api", ImageTag: "<untagged>", ImageDigest: "sha256:mid", PushedAt: now.Add(-24 * time.Hour)},
+		{RepositoryName: "web", ImageTag: "<untagged>", ImageDigest: "sha256:web", PushedAt: now.Add(-96 * time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		keepLast int
+		want     []string
+	}{
+		{name: "keep none", keepLast: 0, want: []string{"sha256:new", "sha256:mid", "sha256:old", "sha256:web"}},
+		{name: "keep newest per repository", keepLast: 1, want: []string{"sha256:mid", "sha256:old"}},
+		{name: "keep more than there are", keepLast: 5, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, image := range selectUntaggedImagesToPrune(images, tt.keepLast) {
+				got = append(got, image.ImageDigest)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectUntaggedImagesToPrune(%d) = %v, want %v", tt.keepLast, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcrSizeFooterRows(t *testing.T) {
+	images := []ECRImageInfo{
+		{RepositoryName: "api", ImageTag: "v1", ImageDigest: "sha256:aaa", ImageSize: 1024},
+		{RepositoryName: "api", ImageTag: "latest", ImageDigest: "sha256:aaa", ImageSize: 1024}, // same image, second tag
+		{RepositoryName: "api", ImageTag: "v0", ImageDigest: "sha256:bbb", ImageSize: 2048},
+		{RepositoryName: "web", ImageTag: "v1", ImageDigest: "sha256:aaa", ImageSize: 512}, // same digest, other repository
+	}
+
+	tests := []struct {
+		name       string
+		grandTotal bool
+		want       []table.Row
+	}{
+		{
+			name: "subtotals only",
+			want: []table.Row{
+				{"api", "Total", "2 image(s)", "", "", "3.0 KB", ""},
+				{"web", "Total", "1 image(s)", "", "", "512 B", ""},
+			},
+		},
+		{
+			name:       "with grand total",
+			grandTotal: true,
+			want: []table.Row{
+				{"api", "Total", "2 image(s)", "", "", "3.0 KB", ""},
+				{"web", "Total", "1 image(s)", "", "", "512 B", ""},
+				{"All repositories", "Total", "3 image(s)", "", "", "3.5 KB", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ecrSizeFooterRows(images, tt.grandTotal); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ecrSizeFooterRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcrScanSummaryPrint(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary ecrScanSummary
+		want    string
+	}{
+		{
+			name:    "no failures",
+			summary: ecrScanSummary{Scanned: 120, Empty: 15},
+			want:    "\nECR --all: scanned 120 repositories, 0 errored, 15 empty\n",
+		},
+		{
+			name:    "failed repositories are listed",
+			summary: ecrScanSummary{Scanned: 120, Empty: 15, Errored: []string{"team-a/api", "team-a/web"}},
+			want: "\nECR --all: scanned 120 repositories, 2 errored, 15 empty\n" +
+				"Repositories that could not be read: team-a/api, team-a/web\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.summary.print(&out)
+			if out.String() != tt.want {
+				t.Errorf("print() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseECRArgsRepositoryRegex(t *testing.T) {
+	opts, err := parseECRArgs([]string{"ecr", "--all", "--repository-regex", "^team-(a|b)/service-.*$"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.RepositoryRegex != "^team-(a|b)/service-.*$" {
+		t.Errorf("RepositoryRegex = %q, want %q", opts.RepositoryRegex, "^team-(a|b)/service-.*$")
+	}
+	if opts.RepositoryPattern == nil || opts.RepositoryPattern.String() != opts.RepositoryRegex {
+		t.Errorf("RepositoryPattern = %v, want compiled %q", opts.RepositoryPattern, opts.RepositoryRegex)
+	}
+}
+
+func TestFilterRepositoriesByPattern(t *testing.T) {
+	repos := []types.Repository{
+		{RepositoryName: aws.String("team-a/service-api")},
+		{RepositoryName: aws.String("team-b/service-web")},
+		{RepositoryName: aws.String("team-c/service-api")},
+		{RepositoryName: aws.String("team-a/tools")},
+	}
+
+	filtered := filterRepositoriesByPattern(repos, regexp.MustCompile(`^team-(a|b)/service-.*$`))
+
+	var names []string
+	for _, repo := range filtered {
+		names = append(names, aws.ToString(repo.RepositoryName))
+	}
+	expected := []string{"team-a/service-api", "team-b/service-web"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("filterRepositoriesByPattern() = %v, want %v", names, expected)
+	}
+}
+
+func TestFilterImagesOlderThan(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	pushed := func(d int) *time.Time { t := day(d); return &t }
+
+	client := &fakeECR{
+		repositories: []types.Repository{{RepositoryName: aws.String("api")}, {RepositoryName: aws.String("web")}},
+		images: map[string][]types.ImageDetail{
+			"api": {{ImageTags: []string{"v1.0"}, ImagePushedAt: pushed(10)}},
+			"web": {{ImageTags: []string{"v2.0"}, ImagePushedAt: pushed(20)}},
+		},
+	}
+	images := []ECRImageInfo{
+		{RepositoryName: "api", ImageTag: "v0.9", PushedAt: day(5)},
+		{RepositoryName: "api", ImageTag: "v1.0", PushedAt: day(10)},
+		{RepositoryName: "web", ImageTag: "v1.5", PushedAt: day(15)},
+	}
+
+	tests := []struct {
+		name         string
+		referenceTag string
+		repositories []string
+		allRepos     bool
+		wantTags     []string
+		wantRefDay   int
+	}{
+		{name: "single repository", referenceTag: "v1.0", repositories: []string{"api"}, wantTags: []string{"v0.9"}, wantRefDay: 10},
+		{name: "reference found in a later listed repository", referenceTag: "v2.0", repositories: []string{"api", "web"}, wantTags: []string{"v0.9", "v1.0", "v1.5"}, wantRefDay: 20},
+		{name: "reference found in another repository", referenceTag: "v2.0", allRepos: true, wantTags: []string{"v0.9", "v1.0", "v1.5"}, wantRefDay: 20},
+		{name: "reference tag not found", referenceTag: "v9", repositories: []string{"api"}, wantTags: []string{"v0.9", "v1.0", "v1.5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, referenceDate, err := filterImagesOlderThan(client, images, tt.referenceTag, tt.repositories, tt.allRepos)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var tags []string
+			for _, image := range filtered {
+				tags = append(tags, image.ImageTag)
+			}
+			if !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("filterImagesOlderThan() tags = %v, want %v", tags, tt.wantTags)
+			}
+
+			if tt.wantRefDay == 0 {
+				if referenceDate != nil {
+					t.Errorf("reference date = %v, want nil", referenceDate)
+				}
+			} else if referenceDate == nil || !referenceDate.Equal(day(tt.wantRefDay)) {
+				t.Errorf("reference date = %v, want %v", referenceDate, day(tt.wantRefDay))
+			}
+		})
+	}
+}
+
+func TestListRepositoryImages(t *testing.T) {
+	client := &fakeECR{
+		images: map[string][]types.ImageDetail{
+			"api":    {{RepositoryName: aws.String("api"), ImageTags: []string{"v1", "latest"}}},
+			"web":    {{RepositoryName: aws.String("web"), ImageTags: []string{"v2"}}},
+			"worker": {{RepositoryName: aws.String("worker"), ImageTags: []string{"latest"}}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		repositories []string
+		tag          string
+		want         []string
+	}{
+		{name: "single repository", repositories: []string{"api"}, want: []string{"api:v1", "api:latest"}},
+		{name: "several repositories in order", repositories: []string{"web", "api"}, want: []string{"web:v2", "api:v1", "api:latest"}},
+		{name: "tag across repositories", repositories: []string{"api", "web", "worker"}, tag: "latest", want: []string{"api:latest", "worker:latest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images, err := listRepositoryImages(client, tt.repositories, tt.tag)
+			if err != nil {
+				t.Fatalf("listRepositoryImages() error = %v", err)
+			}
+
+			var got []string
+			for _, image := range images {
+				if tt.tag != "" && image.ImageTag != tt.tag {
+					continue
+				}
+				got = append(got, image.RepositoryName+":"+image.ImageTag)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listRepositoryImages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeAllImagesPaginated(t *testing.T) {
+	day := func(d int) *time.Time { t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC); return &t }
+	client := &fakeECR{
+		pageSize: 2,
+		repositories: []types.Repository{
+			{RepositoryName: aws.String("empty")},
+			{RepositoryName: aws.String("other")},
+			{RepositoryName: aws.String("api")},
+		},
+		images: map[string][]types.ImageDetail{
+			"api": {
+				{RepositoryName: aws.String("api"), ImageTags: []string{"v1"}, ImagePushedAt: day(1)},
+				{RepositoryName: aws.String("api"), ImageTags: []string{"v2"}, ImagePushedAt: day(2)},
+				{RepositoryName: aws.String("api"), ImageTags: []string{"v3"}, ImagePushedAt: day(3)},
+			},
+		},
+	}
+
+	images, err := listRepositoryImages(client, []string{"api"}, "")
+	if err != nil {
+		t.Fatalf("listRepositoryImages() error = %v", err)
+	}
+	var tags []string
+	for _, image := range images {
+		tags = append(tags, image.ImageTag)
+	}
+	if want := []string{"v1", "v2", "v3"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("listRepositoryImages() tags = %v, want %v", tags, want)
+	}
+
+	// The repository holding the reference tag is only on the second page of repositories
+	referenceDate, err := findReferenceTagInAllRepos(client, "v3")
+	if err != nil {
+		t.Fatalf("findReferenceTagInAllRepos() error = %v", err)
+	}
+	if referenceDate == nil || !referenceDate.Equal(*day(3)) {
+		t.Errorf("findReferenceTagInAllRepos() = %v, want %v", referenceDate, *day(3))
+	}
+}
+
+func TestPrintECRImagesJSON(t *testing.T) {
+	pushedAt := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	images := []ECRImageInfo{
+		{RepositoryName: "my-repo", ImageTag: "v1.2.0", ImageDigest: "sha256:aaa", PushedAt: pushedAt, ImageSize: 1024},
+		{RepositoryName: "my-repo", ImageTag: "<untagged>", ImageDigest: "sha256:bbb", PushedAt: pushedAt.Add(500 * time.Millisecond)},
+	}
+	opts := &ECRArgs{Repositories: []string{"my-repo"}, SortBy: "pushed", OutputFormat: "json"}
+
+	var out bytes.Buffer
+	if err := printECRImagesJSON(&out, images, opts, nil); err != nil {
+		t.Fatalf("printECRImagesJSON() error = %v", err)
+	}
+
+	var report struct {
+		Input struct {
+			Repository   string `json:"repository"`
+			OutputFormat string `json:"output_format"`
+		} `json:"input"`
+		Images []map[string]any `json:"images"`
+		Count  int              `json:"count"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if report.Input.Repository != "my-repo" || report.Input.OutputFormat != "json" {
+		t.Errorf("input = %+v, want repository my-repo and output_format json", report.Input)
+	}
+	if report.Count != 2 || len(report.Images) != 2 {
+		t.Fatalf("count = %d with %d images, want 2", report.Count, len(report.Images))
+	}
+	if got := report.Images[1]["imagetag"]; got != "<untagged>" {
+		t.Errorf("untagged imagetag = %v, want <untagged>", got)
+	}
+	if got := report.Images[1]["pushedat"]; got != "2024-03-01T12:30:00Z" {
+		t.Errorf("pushedat = %v, want RFC 3339 2024-03-01T12:30:00Z", got)
+	}
+
+	// No images still produces an empty array rather than null
+	out.Reset()
+	if err := printECRImagesJSON(&out, nil, opts, nil); err != nil {
+		t.Fatalf("printECRImagesJSON() error = %v", err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte(`"images": []`)) {
+		t.Errorf("empty listing = %s, want an empty images array", out.String())
+	}
+}
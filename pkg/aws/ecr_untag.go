@@ -0,0 +1,180 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// UntagECRImage handles the ecr untag command, which removes one tag from an image while
+// leaving the image and its other tags in place
+func UntagECRImage(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws ecr untag --repository REPO_NAME --tag TAG [--force] [--allow-delete]")
+			fmt.Println("Options:")
+			fmt.Println("  --repository REPO_NAME  ECR repository name (required)")
+			fmt.Println("  --tag TAG               Tag to remove (required)")
+			fmt.Println("  --force                 Skip the confirmation prompt")
+			fmt.Println("  --allow-delete          Allow removing the image's last tag, which makes ECR delete the image")
+			fmt.Println("  --verbose, -v           Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command removes a single tag reference. The image keeps its digest and any other")
+			fmt.Println("tags. ECR deletes an image when its last tag is removed, so that is refused unless")
+			fmt.Println("--allow-delete is given.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseECRUntagArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ECR client
+	ecrClient := ecr.NewFromConfig(cfg)
+
+	// Look up the image the tag points to, with all of its tags
+	image, err := describeECRImage(ecrClient, opts.RepositoryName, types.ImageIdentifier{ImageTag: aws.String(opts.Tag)})
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, fmt.Errorf("tag %s not found in repository %s", opts.Tag, opts.RepositoryName)
+	}
+
+	digest := aws.ToString(image.ImageDigest)
+	remaining := remainingTags(image.ImageTags, opts.Tag)
+
+	fmt.Printf("Tag %s in %s points to image %s\n", opts.Tag, opts.RepositoryName, digest)
+	if len(remaining) > 0 {
+		fmt.Printf("The image keeps its other tags: %s\n", strings.Join(remaining, ", "))
+	} else {
+		if !opts.AllowDelete {
+			return nil, fmt.Errorf("%s is the only tag of image %s, and ECR deletes an image when its last tag is removed; use --allow-delete to remove it anyway", opts.Tag, digest)
+		}
+		fmt.Printf("⚠️  %s is the image's only tag, so ECR will delete the image\n", opts.Tag)
+	}
+
+	// Confirm unless --force is used
+	if !opts.Force {
+		fmt.Printf("\nAre you sure you want to remove tag %s from %s? (yes/no): ", opts.Tag, opts.RepositoryName)
+		var response string
+		fmt.Scanln(&response)
+		if response != "yes" {
+			fmt.Println("Untag cancelled.")
+			return nil, nil
+		}
+	}
+
+	// Identifying the image by tag alone removes only that tag reference
+	result, err := ecrClient.BatchDeleteImage(context.TODO(), &ecr.BatchDeleteImageInput{
+		RepositoryName: aws.String(opts.RepositoryName),
+		ImageIds:       []types.ImageIdentifier{{ImageTag: aws.String(opts.Tag)}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove tag %s: %w", opts.Tag, err)
+	}
+	if len(result.Failures) > 0 {
+		failure := result.Failures[0]
+		return nil, fmt.Errorf("failed to remove tag %s: %s: %s", opts.Tag, failure.FailureCode, aws.ToString(failure.FailureReason))
+	}
+
+	// Report what is left of the image
+	after, err := describeECRImage(ecrClient, opts.RepositoryName, types.ImageIdentifier{ImageDigest: aws.String(digest)})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case after == nil:
+		fmt.Printf("✓ Removed tag %s; image %s was deleted\n", opts.Tag, digest)
+	case len(after.ImageTags) == 0:
+		fmt.Printf("✓ Removed tag %s; image %s is now untagged\n", opts.Tag, digest)
+	default:
+		fmt.Printf("✓ Removed tag %s; image %s is still tagged %s\n", opts.Tag, digest, strings.Join(after.ImageTags, ", "))
+	}
+
+	return nil, nil
+}
+
+// describeECRImage returns the image matching id, or nil if the repository has none
+func describeECRImage(ecrClient imageDescriber, repositoryName string, id types.ImageIdentifier) (*types.ImageDetail, error) {
+	result, err := ecrClient.DescribeImages(context.TODO(), &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repositoryName),
+		ImageIds:       []types.ImageIdentifier{id},
+	})
+	var notFound *types.ImageNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe image in repository %s: %w", repositoryName, err)
+	}
+	if len(result.ImageDetails) == 0 {
+		return nil, nil
+	}
+	return &result.ImageDetails[0], nil
+}
+
+// remainingTags returns the tags left after removing tag
+func remainingTags(tags []string, tag string) []string {
+	var remaining []string
+	for _, t := range tags {
+		if t != tag {
+			remaining = append(remaining, t)
+		}
+	}
+	return remaining
+}
+
+// ECRUntagArgs represents parsed ecr untag command arguments
+type ECRUntagArgs struct {
+	RepositoryName string
+	Tag            string
+	Force          bool
+	AllowDelete    bool
+	Verbose        bool
+}
+
+// parseECRUntagArgs parses command line arguments for the ecr untag command
+func parseECRUntagArgs(args []string) (*ECRUntagArgs, error) {
+	opts := &ECRUntagArgs{}
+
+	fs := cli.NewFlagSet("ecr untag")
+	fs.StringVar(&opts.RepositoryName, "repository", "", "ECR repository name")
+	fs.StringVar(&opts.Tag, "tag", "", "tag to remove")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVar(&opts.AllowDelete, "allow-delete", false, "allow removing the last tag, which deletes the image")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.RepositoryName == "" {
+		return nil, fmt.Errorf("repository parameter is required")
+	}
+	if opts.Tag == "" {
+		return nil, fmt.Errorf("tag parameter is required")
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseECRUntagArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    *ECRUntagArgs
+		expectError bool
+	}{
+		{
+			name:     "repository and tag",
+			args:     []string{"ecr", "untag", "--repository", "my-repo", "--tag", "v1.0"},
+			expected: &ECRUntagArgs{RepositoryName: "my-repo", Tag: "v1.0"},
+		},
+		{
+			name:     "force and allow delete",
+			args:     []string{"ecr", "untag", "--repository=my-repo", "--tag=v1.0", "--force", "--allow-delete", "-v"},
+			expected: &ECRUntagArgs{RepositoryName: "my-repo", Tag: "v1.0", Force: true, AllowDelete: true, Verbose: true},
+		},
+		{
+			name:        "missing repository",
+			args:        []string{"ecr", "untag", "--tag", "v1.0"},
+			expectError: true,
+		},
+		{
+			name:        "missing tag",
+			args:        []string{"ecr", "untag", "--repository", "my-repo"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseECRUntagArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(opts, tt.expected) {
+				t.Errorf("parseECRUntagArgs() = %+v, want %+v", opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemainingTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		tag      string
+		expected []string
+	}{
+		{
+			name:     "other tags remain",
+			tags:     []string{"latest", "v1.0", "v1"},
+			tag:      "v1.0",
+			expected: []string{"latest", "v1"},
+		},
+		{
+			name:     "only tag",
+			tags:     []string{"v1.0"},
+			tag:      "v1.0",
+			expected: nil,
+		},
+		{
+			name:     "tag not present",
+			tags:     []string{"latest"},
+			tag:      "v1.0",
+			expected: []string{"latest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingTags(tt.tags, tt.tag); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("remainingTags() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
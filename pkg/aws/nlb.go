@@ -2,20 +2,29 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pischarti/nix/pkg/cli"
+	kubeconfig "github.com/pischarti/nix/pkg/config"
+	"github.com/pischarti/nix/pkg/k8s"
 	printpkg "github.com/pischarti/nix/pkg/print"
 	"github.com/pischarti/nix/pkg/vpc"
 	"gofr.dev/pkg/gofr"
+	"k8s.io/client-go/kubernetes"
 )
 
 // ListNLBs handles the nlb command for listing AWS Network Load Balancers
@@ -25,11 +34,19 @@ func ListNLBs(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws nlb --vpc VPC_ID [--zone AZ] [--sort SORT_BY]")
+			fmt.Println("Usage: aws nlb --vpc VPC_ID [--zone AZ]... [--state STATE] [--sort SORT_BY] [--with-details | --by-zone] [--no-tags] [--color WHEN] [--no-headers] [--output FORMAT]")
 			fmt.Println("Options:")
 			fmt.Println("  --vpc VPC_ID    VPC ID to list NLBs for (required)")
-			fmt.Println("  --zone AZ       Filter by availability zone (optional)")
+			fmt.Println("  --zone AZ       Filter by availability zone; repeat to include several zones (optional)")
+			fmt.Println("  --state STATE   Filter by state: active, provisioning, active_impaired, failed (optional)")
 			fmt.Println("  --sort SORT_BY  Sort by: name (default), state, type, scheme, created")
+			fmt.Println("  --with-details  Add CrossZone and AccessLogs columns from the NLB attributes")
+			fmt.Println("  --no-tags       Skip fetching tags for a faster listing; Name shows the load balancer name and Tags is empty")
+			fmt.Println("  --by-zone       List the NLBs covering each zone instead of the NLB table")
+			fmt.Println("  --color WHEN    Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --no-headers    Print only the data rows, space-separated, for piping into awk or cut")
+			fmt.Println("  --output FORMAT Output format: table (default), yaml, json")
+			fmt.Println("  --verbose, -v   Log the AWS region, each API call with its timing, and a call count to stderr")
 			return nil, nil
 		}
 	}
@@ -44,11 +61,23 @@ func ListNLBs(ctx *gofr.Context) (any, error) {
 		return nil, fmt.Errorf("vpc parameter is required")
 	}
 
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listLoadBalancers(opts, elbv2types.LoadBalancerTypeEnumNetwork, useColor)
+}
+
+// listLoadBalancers prints the load balancers of type lbType in opts.VPCID, for the nlb and
+// alb listings
+func listLoadBalancers(opts *vpc.NLBOptions, lbType elbv2types.LoadBalancerTypeEnum, useColor bool) error {
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create ELBv2 client
 	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
@@ -58,14 +87,64 @@ func ListNLBs(ctx *gofr.Context) (any, error) {
 
 	result, err := elbv2Client.DescribeLoadBalancers(context.TODO(), input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe load balancers: %w", err)
+		return fmt.Errorf("failed to describe load balancers: %w", err)
 	}
 
-	// Filter NLBs by VPC and optionally by zone
-	var nlbs []elbv2types.LoadBalancer
-	for _, lb := range result.LoadBalancers {
-		// Only include Network Load Balancers
-		if lb.Type != elbv2types.LoadBalancerTypeEnumNetwork {
+	lbs := filterLoadBalancers(result.LoadBalancers, lbType, opts)
+
+	// Convert to NLBInfo structs, fetching tags unless --no-tags is set
+	var nlbInfos []vpc.NLBInfo
+	if opts.NoTags {
+		nlbInfos = convertELBv2ToNLBInfoWithoutTags(lbs)
+	} else {
+		arns := make([]string, 0, len(lbs))
+		for _, lb := range lbs {
+			arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+		}
+		tagsByARN, err := describeLoadBalancerTags(elbv2Client, newLoadBalancerTagCache(), arns)
+		if err != nil {
+			return fmt.Errorf("%w (use --no-tags to list load balancers without them)", err)
+		}
+		nlbInfos = convertELBv2ToNLBInfo(tagsByARN, lbs)
+	}
+
+	// Sort load balancers
+	vpc.SortNLBs(nlbInfos, opts.SortBy)
+
+	if opts.WithDetails {
+		addNLBAttributes(elbv2Client, nlbInfos)
+	}
+
+	switch opts.OutputFormat {
+	case "yaml":
+		return printpkg.PrintNLBYAML(os.Stdout, nlbInfos)
+	case "json":
+		return printpkg.PrintNLBJSON(os.Stdout, nlbInfos)
+	}
+
+	// Print table output
+	if opts.ByZone {
+		printpkg.PrintNLBZoneTable(vpc.GroupNLBsByZone(nlbInfos, opts.Zones), useColor, opts.NoHeaders)
+	} else if opts.WithDetails {
+		printpkg.PrintNLBTableWithDetails(nlbInfos, useColor, opts.NoHeaders)
+	} else {
+		printpkg.PrintNLBTable(nlbInfos, useColor, opts.NoHeaders)
+	}
+
+	if opts.NoTags && !opts.NoHeaders && len(nlbInfos) > 0 {
+		fmt.Println("Tags were skipped (--no-tags): Name shows the load balancer name and the Tags column is empty")
+	}
+
+	return nil
+}
+
+// filterLoadBalancers returns the load balancers of type lbType in opts.VPCID, keeping only
+// those in one of opts.Zones and in opts.State when they are set
+func filterLoadBalancers(lbs []elbv2types.LoadBalancer, lbType elbv2types.LoadBalancerTypeEnum, opts *vpc.NLBOptions) []elbv2types.LoadBalancer {
+	var filtered []elbv2types.LoadBalancer
+	for _, lb := range lbs {
+		// Only include load balancers of the listed type
+		if lb.Type != lbType {
 			continue
 		}
 
@@ -74,11 +153,11 @@ func ListNLBs(ctx *gofr.Context) (any, error) {
 			continue
 		}
 
-		// Filter by zone if specified
-		if opts.Zone != "" {
+		// Filter by zones if specified
+		if len(opts.Zones) > 0 {
 			hasZone := false
 			for _, az := range lb.AvailabilityZones {
-				if aws.ToString(az.ZoneName) == opts.Zone {
+				if slices.Contains(opts.Zones, aws.ToString(az.ZoneName)) {
 					hasZone = true
 					break
 				}
@@ -88,121 +167,283 @@ func ListNLBs(ctx *gofr.Context) (any, error) {
 			}
 		}
 
-		nlbs = append(nlbs, lb)
+		// Filter by state if specified
+		if opts.State != "" && !loadBalancerInState(lb, opts.State) {
+			continue
+		}
+
+		filtered = append(filtered, lb)
 	}
+	return filtered
+}
 
-	// Convert to NLBInfo structs
-	nlbInfos := convertELBv2ToNLBInfo(nlbs)
+// loadBalancerInState reports whether a load balancer's state code is state
+func loadBalancerInState(lb elbv2types.LoadBalancer, state string) bool {
+	return lb.State != nil && string(lb.State.Code) == state
+}
 
-	// Sort NLBs
-	vpc.SortNLBs(nlbInfos, opts.SortBy)
+// maxConcurrentAttributeLookups bounds the parallel DescribeLoadBalancerAttributes calls
+// made by --with-details, which has no multi-ARN form
+const maxConcurrentAttributeLookups = 5
+
+// addNLBAttributes fills in the cross-zone and access log fields of each NLB. Lookups
+// run concurrently in small batches; an NLB whose attributes can't be read shows "-".
+func addNLBAttributes(client loadBalancerClient, nlbInfos []vpc.NLBInfo) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAttributeLookups)
+
+	for i := range nlbInfos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(info *vpc.NLBInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := client.DescribeLoadBalancerAttributes(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+				LoadBalancerArn: aws.String(info.LoadBalancerArn),
+			})
+			if err != nil {
+				applyNLBAttributes(info, nil)
+				return
+			}
+			applyNLBAttributes(info, loadBalancerAttributeMap(result.Attributes))
+		}(&nlbInfos[i])
+	}
 
-	// Print table output
-	printpkg.PrintNLBTable(nlbInfos)
+	wg.Wait()
+}
 
-	return nil, nil
+// applyNLBAttributes sets the detail fields of info from a load balancer attribute map
+func applyNLBAttributes(info *vpc.NLBInfo, attrs map[string]string) {
+	info.CrossZone = enabledOrDefault(attrs, "load_balancing.cross_zone.enabled")
+	info.AccessLogs = enabledOrDefault(attrs, "access_logs.s3.enabled")
+	info.AccessLogsBucket = ""
+	if attrs["access_logs.s3.enabled"] == "true" {
+		info.AccessLogsBucket = attributeOrDefault(attrs, "access_logs.s3.bucket")
+	}
+}
+
+// enabledOrDefault renders a boolean attribute as "enabled"/"disabled", or "-" if not set
+func enabledOrDefault(attrs map[string]string, key string) string {
+	switch attrs[key] {
+	case "true":
+		return "enabled"
+	case "false":
+		return "disabled"
+	default:
+		return "-"
+	}
 }
 
-// convertELBv2ToNLBInfo converts AWS ELBv2 load balancer types to NLBInfo structs
-func convertELBv2ToNLBInfo(lbs []elbv2types.LoadBalancer) []vpc.NLBInfo {
+// loadBalancerAttributeMap indexes load balancer attributes by key
+func loadBalancerAttributeMap(attributes []elbv2types.LoadBalancerAttribute) map[string]string {
+	attrs := make(map[string]string, len(attributes))
+	for _, attr := range attributes {
+		attrs[aws.ToString(attr.Key)] = aws.ToString(attr.Value)
+	}
+	return attrs
+}
+
+// convertELBv2ToNLBInfo converts AWS ELBv2 load balancer types to NLBInfo structs, taking
+// each load balancer's tags from tagsByARN
+func convertELBv2ToNLBInfo(tagsByARN map[string][]elbv2types.Tag, lbs []elbv2types.LoadBalancer) []vpc.NLBInfo {
 	var nlbInfos []vpc.NLBInfo
 
 	for _, lb := range lbs {
-		// Extract name from tags
-		name := ""
-		var relevantTags []string
-
-		// Get tags for this load balancer
-		// Note: In a real implementation, you might want to batch tag requests
-		// for better performance when dealing with many load balancers
-		tags := getLoadBalancerTags(lb.LoadBalancerArn)
-
-		for _, tag := range tags {
-			key := aws.ToString(tag.Key)
-			value := aws.ToString(tag.Value)
-
-			switch key {
-			case "Name":
-				name = value
-			default:
-				// Include relevant tags
-				if strings.HasPrefix(key, "kubernetes.io/") ||
-					strings.HasPrefix(key, "aws:") ||
-					key == "Environment" ||
-					key == "Project" ||
-					key == "Service" {
-					relevantTags = append(relevantTags, key)
-				}
+		tags := tagsByARN[aws.ToString(lb.LoadBalancerArn)]
+		nlbInfos = append(nlbInfos, nlbInfoFromLoadBalancer(lb, tags))
+	}
+
+	return nlbInfos
+}
+
+// convertELBv2ToNLBInfoWithoutTags converts load balancers without calling DescribeTags, for
+// --no-tags. The Name column shows the load balancer's own name and Tags is left empty.
+func convertELBv2ToNLBInfoWithoutTags(lbs []elbv2types.LoadBalancer) []vpc.NLBInfo {
+	var nlbInfos []vpc.NLBInfo
+
+	for _, lb := range lbs {
+		nlbInfo := nlbInfoFromLoadBalancer(lb, nil)
+		nlbInfo.Name = aws.ToString(lb.LoadBalancerName)
+		nlbInfos = append(nlbInfos, nlbInfo)
+	}
+
+	return nlbInfos
+}
+
+// nlbInfoFromLoadBalancer builds the NLBInfo for one load balancer, taking the name from its
+// Name tag and listing its relevant tag keys
+func nlbInfoFromLoadBalancer(lb elbv2types.LoadBalancer, tags []elbv2types.Tag) vpc.NLBInfo {
+	// Extract name from tags
+	name := ""
+	var relevantTags []string
+
+	for _, tag := range tags {
+		key := aws.ToString(tag.Key)
+		value := aws.ToString(tag.Value)
+
+		switch key {
+		case "Name":
+			name = value
+		default:
+			// Include relevant tags
+			if strings.HasPrefix(key, "kubernetes.io/") ||
+				strings.HasPrefix(key, "aws:") ||
+				key == "Environment" ||
+				key == "Project" ||
+				key == "Service" {
+				relevantTags = append(relevantTags, key)
 			}
 		}
+	}
 
-		// Format availability zones
-		var azs []string
-		var subnets []string
-		for _, az := range lb.AvailabilityZones {
-			azs = append(azs, aws.ToString(az.ZoneName))
-			subnets = append(subnets, aws.ToString(az.SubnetId))
-		}
+	// Format availability zones
+	var azs []string
+	var subnets []string
+	for _, az := range lb.AvailabilityZones {
+		azs = append(azs, aws.ToString(az.ZoneName))
+		subnets = append(subnets, aws.ToString(az.SubnetId))
+	}
 
-		// Format tags with each tag on a separate line
-		tagsStr := strings.Join(relevantTags, "\n")
+	// Format tags with each tag on a separate line
+	tagsStr := strings.Join(relevantTags, "\n")
 
-		// Format created time
-		createdTime := ""
-		if lb.CreatedTime != nil {
-			createdTime = lb.CreatedTime.Format(time.RFC3339)
-		}
+	// Format created time
+	createdTime := ""
+	if lb.CreatedTime != nil {
+		createdTime = lb.CreatedTime.Format(time.RFC3339)
+	}
 
-		nlbInfo := vpc.NLBInfo{
-			LoadBalancerArn:   aws.ToString(lb.LoadBalancerArn),
-			Name:              name,
-			DNSName:           aws.ToString(lb.DNSName),
-			State:             string(lb.State.Code),
-			Type:              string(lb.Type),
-			Scheme:            string(lb.Scheme),
-			VPCID:             aws.ToString(lb.VpcId),
-			AvailabilityZones: strings.Join(azs, ", "),
-			Subnets:           strings.Join(subnets, ", "),
-			CreatedTime:       createdTime,
-			Tags:              tagsStr,
-		}
-		nlbInfos = append(nlbInfos, nlbInfo)
+	return vpc.NLBInfo{
+		LoadBalancerArn:   aws.ToString(lb.LoadBalancerArn),
+		Name:              name,
+		DNSName:           aws.ToString(lb.DNSName),
+		State:             string(lb.State.Code),
+		Type:              string(lb.Type),
+		Scheme:            string(lb.Scheme),
+		VPCID:             aws.ToString(lb.VpcId),
+		AvailabilityZones: strings.Join(azs, ", "),
+		Subnets:           strings.Join(subnets, ", "),
+		CreatedTime:       createdTime,
+		Tags:              tagsStr,
 	}
+}
 
-	return nlbInfos
+// loadBalancerTagCache holds the tags fetched for each load balancer ARN, so a command that
+// looks up an NLB's name or tags several times calls DescribeTags once per ARN. Each command
+// invocation creates its own with newLoadBalancerTagCache; a nil cache caches nothing. It is
+// safe for concurrent use.
+type loadBalancerTagCache struct {
+	mu   sync.Mutex
+	tags map[string][]elbv2types.Tag
 }
 
-// getLoadBalancerTags retrieves tags for a load balancer
-// This is a simplified implementation - in production you might want to batch these requests
-func getLoadBalancerTags(arn *string) []elbv2types.Tag {
-	if arn == nil {
-		return []elbv2types.Tag{}
+// newLoadBalancerTagCache returns an empty tag cache
+func newLoadBalancerTagCache() *loadBalancerTagCache {
+	return &loadBalancerTagCache{tags: make(map[string][]elbv2types.Tag)}
+}
+
+// lookup returns the cached tags for arn
+func (c *loadBalancerTagCache) lookup(arn string) ([]elbv2types.Tag, bool) {
+	if c == nil {
+		return nil, false
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tags, ok := c.tags[arn]
+	return tags, ok
+}
 
-	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+// put caches the tags fetched for arn
+func (c *loadBalancerTagCache) put(arn string, tags []elbv2types.Tag) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags[arn] = tags
+}
+
+// get returns the cached tags for arn, calling fetch on a miss. Failed fetches are not cached,
+// so a later lookup retries. The lock is not held during fetch, so lookups of other ARNs are
+// not serialized.
+func (c *loadBalancerTagCache) get(arn string, fetch func() ([]elbv2types.Tag, error)) ([]elbv2types.Tag, error) {
+	if tags, ok := c.lookup(arn); ok {
+		return tags, nil
+	}
+	tags, err := fetch()
 	if err != nil {
-		return []elbv2types.Tag{}
+		return nil, err
 	}
+	c.put(arn, tags)
+	return tags, nil
+}
 
-	// Create ELBv2 client
-	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+// elbv2DescribeTagsLimit is the most resource ARNs DescribeTags accepts per request
+const elbv2DescribeTagsLimit = 20
+
+// describeLoadBalancerTags returns the tags of each load balancer ARN. ARNs not yet in cache
+// are fetched with one DescribeTags call per 20 ARNs and then cached.
+func describeLoadBalancerTags(client loadBalancerClient, cache *loadBalancerTagCache, arns []string) (map[string][]elbv2types.Tag, error) {
+	tagsByARN := make(map[string][]elbv2types.Tag, len(arns))
 
-	input := &elasticloadbalancingv2.DescribeTagsInput{
-		ResourceArns: []string{aws.ToString(arn)},
+	var missing []string
+	for _, arn := range arns {
+		if _, seen := tagsByARN[arn]; seen {
+			continue
+		}
+		if tags, ok := cache.lookup(arn); ok {
+			tagsByARN[arn] = tags
+			continue
+		}
+		tagsByARN[arn] = []elbv2types.Tag{}
+		missing = append(missing, arn)
 	}
 
-	result, err := elbv2Client.DescribeTags(context.TODO(), input)
-	if err != nil {
-		// Return empty tags on error to avoid breaking the listing
+	for start := 0; start < len(missing); start += elbv2DescribeTagsLimit {
+		batch := missing[start:min(start+elbv2DescribeTagsLimit, len(missing))]
+		result, err := client.DescribeTags(context.TODO(), &elasticloadbalancingv2.DescribeTagsInput{
+			ResourceArns: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancer tags: %w", err)
+		}
+		for _, description := range result.TagDescriptions {
+			tagsByARN[aws.ToString(description.ResourceArn)] = description.Tags
+		}
+		for _, arn := range batch {
+			cache.put(arn, tagsByARN[arn])
+		}
+	}
+
+	return tagsByARN, nil
+}
+
+// getLoadBalancerTags retrieves tags for a load balancer, fetching them at most once per ARN
+// per cache
+func getLoadBalancerTags(client loadBalancerClient, cache *loadBalancerTagCache, arn *string) []elbv2types.Tag {
+	if arn == nil {
 		return []elbv2types.Tag{}
 	}
 
-	if len(result.TagDescriptions) > 0 {
-		return result.TagDescriptions[0].Tags
+	tags, err := cache.get(aws.ToString(arn), func() ([]elbv2types.Tag, error) {
+		result, err := client.DescribeTags(context.TODO(), &elasticloadbalancingv2.DescribeTagsInput{
+			ResourceArns: []string{aws.ToString(arn)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(result.TagDescriptions) > 0 {
+			return result.TagDescriptions[0].Tags, nil
+		}
+		return []elbv2types.Tag{}, nil
+	})
+	if err != nil {
+		// Return empty tags on error to avoid breaking the listing
+		return []elbv2types.Tag{}
 	}
 
-	return []elbv2types.Tag{}
+	return tags
 }
 
 // RemoveSubnetFromNLB handles the remove-subnet command for removing a subnet from an NLB
@@ -212,15 +453,19 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws nlb remove-subnet --vpc VPC_ID --zone AZ [--nlb-name NLB_NAME] [--force]")
+			fmt.Println("Usage: aws nlb remove-subnet --vpc VPC_ID --zone AZ [--nlb-name NLB_NAME] [--force] [--dry-run] [--timeout DURATION]")
 			fmt.Println("Options:")
 			fmt.Println("  --vpc VPC_ID       VPC ID containing the NLB (required)")
 			fmt.Println("  --zone AZ          Availability zone of the subnet to remove (required)")
 			fmt.Println("  --nlb-name NAME    Specific NLB name to target (optional, removes from all NLBs if not specified)")
 			fmt.Println("  --force           Skip confirmation prompt")
+			fmt.Println("  --dry-run          Print each NLB's subnets before and after, and the SetSubnets request, without sending it")
+			fmt.Println("  --timeout DURATION Deadline for each SetSubnets call (default 2m)")
+			fmt.Println("  --verbose, -v      Log the AWS region, each API call with its timing, and a call count to stderr")
 			fmt.Println()
 			fmt.Println("This command removes a subnet from Network Load Balancers in the specified VPC and zone.")
 			fmt.Println("If no NLB name is specified, it will remove the subnet from all NLBs in the VPC that have subnets in the specified zone.")
+			fmt.Println("Press Ctrl+C to cancel the in-flight call and stop; the NLBs already modified are listed.")
 			return nil, nil
 		}
 	}
@@ -239,16 +484,18 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 	}
 
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create ELBv2 client
 	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+	tagCache := newLoadBalancerTagCache()
 
 	// Find NLBs in the VPC
-	nlbs, err := findNLBsInVPC(elbv2Client, opts.VPCID, opts.NLBName)
+	nlbs, err := findNLBsInVPC(elbv2Client, tagCache, opts.VPCID, opts.NLBName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find NLBs: %w", err)
 	}
@@ -279,7 +526,7 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 	// Show what will be modified
 	fmt.Printf("Found %d NLB(s) in VPC %s with subnets in zone %s:\n", len(targetNLBs), opts.VPCID, opts.Zone)
 	for _, nlb := range targetNLBs {
-		nlbName := getNLBName(nlb)
+		nlbName := getNLBName(elbv2Client, tagCache, nlb)
 		fmt.Printf("  - %s (%s)\n", nlbName, aws.ToString(nlb.LoadBalancerArn))
 	}
 
@@ -287,8 +534,8 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 	fmt.Printf("\n⚠️  Note: If NLBs are associated with Kubernetes services or ECS services, subnet removal may fail.\n")
 	fmt.Printf("   Use 'kubectl get services -o wide' to check for Kubernetes service associations.\n")
 
-	// Confirm removal unless --force is used
-	if !opts.Force {
+	// Confirm unless --force is used; a dry run changes nothing, so it never prompts
+	if !opts.Force && !opts.DryRun {
 		fmt.Printf("\nAre you sure you want to remove subnets in zone %s from these NLBs? (yes/no): ", opts.Zone)
 		var response string
 		fmt.Scanln(&response)
@@ -298,10 +545,18 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 		}
 	}
 
+	// Cancel in-flight mutations on SIGINT; installed after the prompt so Ctrl+C there still exits
+	mutateCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt)
+	defer stop()
+
 	// Remove subnets from each NLB
 	successCount := 0
-	for _, nlb := range targetNLBs {
-		nlbName := getNLBName(nlb)
+	var modified []string
+	for i, nlb := range targetNLBs {
+		if mutateCtx.Err() != nil {
+			return nil, interruptedMutationError(modified, nlbNames(targetNLBs[i:]))
+		}
+		nlbName := getNLBName(elbv2Client, tagCache, nlb)
 
 		// Get current subnets
 		currentSubnets := make([]string, 0, len(nlb.AvailabilityZones))
@@ -347,13 +602,20 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 			continue
 		}
 
-		// Update the NLB
-		input := &elasticloadbalancingv2.SetSubnetsInput{
-			LoadBalancerArn: nlb.LoadBalancerArn,
-			Subnets:         newSubnets,
+		if opts.DryRun {
+			if err := printSetSubnetsDryRun(os.Stdout, nlbName, nlb.LoadBalancerArn, currentSubnets, newSubnets); err != nil {
+				return nil, err
+			}
+			successCount++
+			continue
 		}
 
-		_, err = elbv2Client.SetSubnets(context.TODO(), input)
+		// Update the NLB
+		err = setNLBSubnets(mutateCtx, elbv2Client, nlb.LoadBalancerArn, newSubnets, opts.Timeout)
+		if mutateCtx.Err() != nil {
+			// The call may or may not have been applied, so list this NLB with the unattempted ones
+			return nil, interruptedMutationError(modified, nlbNames(targetNLBs[i:]))
+		}
 		if err != nil {
 			// Provide specific guidance for common AWS errors
 			if strings.Contains(err.Error(), "ResourceInUse") && strings.Contains(err.Error(), "Subnets cannot be removed") {
@@ -376,40 +638,99 @@ func RemoveSubnetFromNLB(ctx *gofr.Context) (any, error) {
 
 		fmt.Printf("Successfully removed subnets from NLB %s\n", nlbName)
 		successCount++
+		modified = append(modified, aws.ToString(nlb.LoadBalancerName))
 	}
 
+	if opts.DryRun {
+		fmt.Printf("\nDry run completed. Would update %d out of %d NLB(s); no changes were made.\n", successCount, len(targetNLBs))
+		return nil, nil
+	}
 	fmt.Printf("\nOperation completed. Successfully updated %d out of %d NLB(s).\n", successCount, len(targetNLBs))
 	return nil, nil
 }
 
+// DefaultSubnetMutationTimeout is the default deadline for each SetSubnets call made by
+// add-subnet and remove-subnet
+const DefaultSubnetMutationTimeout = 2 * time.Minute
+
+// setNLBSubnets replaces the subnets of an NLB, giving up once timeout elapses or ctx is cancelled
+func setNLBSubnets(ctx context.Context, client loadBalancerClient, arn *string, subnets []string, timeout time.Duration) error {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := client.SetSubnets(callCtx, setSubnetsInput(arn, subnets))
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("SetSubnets timed out after %s: %w", timeout, err)
+	}
+	return err
+}
+
+// setSubnetsInput builds the SetSubnets request that replaces the subnets of an NLB
+func setSubnetsInput(arn *string, subnets []string) *elasticloadbalancingv2.SetSubnetsInput {
+	return &elasticloadbalancingv2.SetSubnetsInput{
+		LoadBalancerArn: arn,
+		Subnets:         subnets,
+	}
+}
+
+// printSetSubnetsDryRun prints an NLB's subnets before and after a change and the
+// SetSubnets request that would make it, for --dry-run
+func printSetSubnetsDryRun(w io.Writer, nlbName string, arn *string, before, after []string) error {
+	input, err := json.MarshalIndent(setSubnetsInput(arn, after), "   ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode SetSubnets request: %w", err)
+	}
+	fmt.Fprintf(w, "🔎 Dry run: NLB %s (%s)\n", nlbName, aws.ToString(arn))
+	fmt.Fprintf(w, "   Before: %s\n", strings.Join(before, ", "))
+	fmt.Fprintf(w, "   After:  %s\n", strings.Join(after, ", "))
+	fmt.Fprintf(w, "   SetSubnetsInput:\n   %s\n", input)
+	return nil
+}
+
+// nlbNames returns the load balancer names of the given NLBs without further API calls,
+// so it is safe to use after an interrupt
+func nlbNames(nlbs []elbv2types.LoadBalancer) []string {
+	names := make([]string, 0, len(nlbs))
+	for _, nlb := range nlbs {
+		names = append(names, aws.ToString(nlb.LoadBalancerName))
+	}
+	return names
+}
+
+// interruptedMutationError prints which NLBs were modified before an interrupt and which were
+// left unchanged or in an unknown state, and returns the error that ends the command
+func interruptedMutationError(modified, remaining []string) error {
+	fmt.Printf("\n⚠️  Interrupted. Modified %d NLB(s) before stopping:\n", len(modified))
+	for _, name := range modified {
+		fmt.Printf("  - %s\n", name)
+	}
+	if len(remaining) > 0 {
+		fmt.Printf("Not modified (the first may have an in-flight change; verify with 'aws nlb describe'):\n")
+		for _, name := range remaining {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+	return fmt.Errorf("interrupted after modifying %d NLB(s)", len(modified))
+}
+
 // parseRemoveSubnetArgs parses command line arguments for the remove-subnet command
 func parseRemoveSubnetArgs(args []string) (*RemoveSubnetOptions, error) {
 	opts := &RemoveSubnetOptions{}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "nlb", "remove-subnet":
-			// Skip command names
-			continue
-		case "--vpc":
-			if i+1 < len(args) {
-				i++
-				opts.VPCID = args[i]
-			}
-		case "--zone":
-			if i+1 < len(args) {
-				i++
-				opts.Zone = args[i]
-			}
-		case "--nlb-name":
-			if i+1 < len(args) {
-				i++
-				opts.NLBName = args[i]
-			}
-		case "--force":
-			opts.Force = true
-		}
+	fs := cli.NewFlagSet("remove-subnet")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID containing the NLBs")
+	fs.StringVar(&opts.Zone, "zone", "", "availability zone of the subnet to remove")
+	fs.StringVar(&opts.NLBName, "nlb-name", "", "specific NLB name")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "print the SetSubnets requests without sending them")
+	fs.DurationVar(&opts.Timeout, "timeout", DefaultSubnetMutationTimeout, "deadline for each SetSubnets call")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.Timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be greater than zero")
 	}
 
 	return opts, nil
@@ -421,10 +742,14 @@ type RemoveSubnetOptions struct {
 	Zone    string
 	NLBName string
 	Force   bool
+	// DryRun prints the SetSubnets request for each NLB instead of sending it
+	DryRun  bool
+	Timeout time.Duration
+	Verbose bool
 }
 
 // findNLBsInVPC finds NLBs in a VPC, optionally filtered by name
-func findNLBsInVPC(client *elasticloadbalancingv2.Client, vpcID, nlbName string) ([]elbv2types.LoadBalancer, error) {
+func findNLBsInVPC(client loadBalancerClient, cache *loadBalancerTagCache, vpcID, nlbName string) ([]elbv2types.LoadBalancer, error) {
 	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{}
 
 	result, err := client.DescribeLoadBalancers(context.TODO(), input)
@@ -432,7 +757,8 @@ func findNLBsInVPC(client *elasticloadbalancingv2.Client, vpcID, nlbName string)
 		return nil, err
 	}
 
-	var nlbs []elbv2types.LoadBalancer
+	var candidates []elbv2types.LoadBalancer
+	var arns []string
 	for _, lb := range result.LoadBalancers {
 		// Only include Network Load Balancers
 		if lb.Type != elbv2types.LoadBalancerTypeEnumNetwork {
@@ -444,25 +770,38 @@ func findNLBsInVPC(client *elasticloadbalancingv2.Client, vpcID, nlbName string)
 			continue
 		}
 
-		// Filter by name if specified
-		if nlbName != "" {
-			actualName := getNLBName(lb)
-			if actualName != nlbName {
-				continue
-			}
-		}
+		candidates = append(candidates, lb)
+		arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+	}
+
+	if nlbName == "" {
+		return candidates, nil
+	}
+
+	// Fetch the tags of every candidate in batches, rather than one lookup per load balancer
+	tagsByARN, err := describeLoadBalancerTags(client, cache, arns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up NLB names: %w", err)
+	}
 
-		nlbs = append(nlbs, lb)
+	// Filter by name
+	var nlbs []elbv2types.LoadBalancer
+	for _, lb := range candidates {
+		if nlbNameFromTags(lb, tagsByARN[aws.ToString(lb.LoadBalancerArn)]) == nlbName {
+			nlbs = append(nlbs, lb)
+		}
 	}
 
 	return nlbs, nil
 }
 
 // getNLBName gets the name of an NLB from its tags
-func getNLBName(lb elbv2types.LoadBalancer) string {
-	// Get tags for this load balancer
-	tags := getLoadBalancerTags(lb.LoadBalancerArn)
+func getNLBName(client loadBalancerClient, cache *loadBalancerTagCache, lb elbv2types.LoadBalancer) string {
+	return nlbNameFromTags(lb, getLoadBalancerTags(client, cache, lb.LoadBalancerArn))
+}
 
+// nlbNameFromTags returns the Name tag of an NLB, falling back to its ARN when it has none
+func nlbNameFromTags(lb elbv2types.LoadBalancer, tags []elbv2types.Tag) string {
 	for _, tag := range tags {
 		if aws.ToString(tag.Key) == "Name" {
 			return aws.ToString(tag.Value)
@@ -480,12 +819,18 @@ func CheckNLBAssociations(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws nlb check-associations --vpc VPC_ID [--nlb-name NLB_NAME]")
+			fmt.Println("Usage: aws nlb check-associations --vpc VPC_ID [--nlb-name NLB_NAME] [--zone AZ] [--color WHEN]")
 			fmt.Println("Options:")
 			fmt.Println("  --vpc VPC_ID       VPC ID containing the NLB (required)")
 			fmt.Println("  --nlb-name NAME    Specific NLB name to check (optional, checks all NLBs if not specified)")
+			fmt.Println("  --zone AZ          Zone you plan to remove; targets still healthy in it are flagged (optional)")
+			fmt.Println("  --color WHEN       Flag in-service targets in red: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --verbose, -v      Log the AWS region, each API call with its timing, and a call count to stderr")
 			fmt.Println()
 			fmt.Println("This command checks for service associations that might prevent subnet removal from NLBs.")
+			fmt.Println("For NLBs tagged with kubernetes.io/service-name, it looks up the Kubernetes service and")
+			fmt.Println("its EndpointSlices to report whether the service still has ready endpoints.")
+			fmt.Println("It also counts the healthy and draining targets of each NLB's target groups per zone.")
 			fmt.Println("It provides guidance on how to resolve common association issues.")
 			return nil, nil
 		}
@@ -501,17 +846,24 @@ func CheckNLBAssociations(ctx *gofr.Context) (any, error) {
 		return nil, fmt.Errorf("vpc parameter is required")
 	}
 
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create ELBv2 client
 	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+	tagCache := newLoadBalancerTagCache()
 
 	// Find NLBs in the VPC
-	nlbs, err := findNLBsInVPC(elbv2Client, opts.VPCID, opts.NLBName)
+	nlbs, err := findNLBsInVPC(elbv2Client, tagCache, opts.VPCID, opts.NLBName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find NLBs: %w", err)
 	}
@@ -522,8 +874,13 @@ func CheckNLBAssociations(ctx *gofr.Context) (any, error) {
 
 	fmt.Printf("Checking associations for %d NLB(s) in VPC %s:\n\n", len(nlbs), opts.VPCID)
 
+	// Kubernetes client is created lazily, only when an NLB is tagged with a service name
+	var clientset kubernetes.Interface
+	var clientsetErr error
+	clientsetLoaded := false
+
 	for _, nlb := range nlbs {
-		nlbName := getNLBName(nlb)
+		nlbName := getNLBName(elbv2Client, tagCache, nlb)
 		fmt.Printf("🔍 NLB: %s\n", nlbName)
 		fmt.Printf("   ARN: %s\n", aws.ToString(nlb.LoadBalancerArn))
 		fmt.Printf("   State: %s\n", string(nlb.State.Code))
@@ -551,12 +908,34 @@ func CheckNLBAssociations(ctx *gofr.Context) (any, error) {
 		if err == nil && len(targetGroupsResult.TargetGroups) > 0 {
 			fmt.Printf("   ⚠️  Has %d target group(s) - may be in use by services\n", len(targetGroupsResult.TargetGroups))
 			hasAssociations = true
+
+			targetGroupArns := make([]string, 0, len(targetGroupsResult.TargetGroups))
+			for _, tg := range targetGroupsResult.TargetGroups {
+				targetGroupArns = append(targetGroupArns, aws.ToString(tg.TargetGroupArn))
+			}
+			zones, err := describeTargetHealthByZone(elbv2Client, targetGroupArns)
+			if err != nil {
+				fmt.Printf("   ⚠️  %v\n", err)
+			} else {
+				printTargetHealthByZone(os.Stdout, zones, opts.Zone, useColor)
+			}
 		}
 
 		if !hasAssociations {
 			fmt.Printf("   ✅ No obvious service associations detected\n")
 		}
 
+		// Check the backing Kubernetes service's endpoints if the NLB is tagged with one
+		if serviceTag := findTagValue(getLoadBalancerTags(elbv2Client, tagCache, nlb.LoadBalancerArn), k8s.ServiceNameTag); serviceTag != "" {
+			if !clientsetLoaded {
+				clientset, clientsetErr = newKubernetesClientset()
+				clientsetLoaded = true
+			}
+			printServiceEndpointHealth(clientset, clientsetErr, serviceTag)
+			fmt.Println()
+			continue
+		}
+
 		fmt.Printf("   💡 To check for Kubernetes services: kubectl get services -o wide | grep %s\n", aws.ToString(nlb.LoadBalancerArn))
 		fmt.Printf("   💡 To check for ECS services: aws ecs describe-services --cluster CLUSTER_NAME\n")
 		fmt.Println()
@@ -572,27 +951,76 @@ func CheckNLBAssociations(ctx *gofr.Context) (any, error) {
 	return nil, nil
 }
 
+// printServiceEndpointHealth reports whether the Kubernetes service behind an NLB still has ready endpoints
+func printServiceEndpointHealth(clientset kubernetes.Interface, clientsetErr error, serviceTag string) {
+	namespace, name, ok := k8s.ParseServiceNameTag(serviceTag)
+	if !ok {
+		fmt.Printf("   ⚠️  Unrecognized %s tag value %q\n", k8s.ServiceNameTag, serviceTag)
+		return
+	}
+
+	fmt.Printf("   ☸️  Kubernetes service: %s/%s\n", namespace, name)
+
+	if clientsetErr != nil {
+		fmt.Printf("   ⚠️  Could not connect to Kubernetes: %v\n", clientsetErr)
+		fmt.Printf("   💡 To check manually: kubectl get endpointslices -n %s -l kubernetes.io/service-name=%s\n", namespace, name)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), k8s.DefaultTimeout)
+	defer cancel()
+
+	status, err := k8s.GetServiceEndpointStatus(ctx, clientset, namespace, name)
+	if err != nil {
+		fmt.Printf("   ⚠️  Could not check service endpoints: %v\n", k8s.WrapTimeoutError(ctx, err, k8s.DefaultTimeout))
+		return
+	}
+
+	switch {
+	case !status.Found:
+		fmt.Printf("   ✅ Service no longer exists - safe subnet-removal candidate\n")
+	case status.ReadyEndpoints == 0:
+		fmt.Printf("   ✅ Service has no ready endpoints (%d total) - safe subnet-removal candidate\n", status.TotalEndpoints)
+	default:
+		fmt.Printf("   ❌ Service has %d ready endpoint(s) - still serving traffic, not safe for subnet removal\n", status.ReadyEndpoints)
+	}
+}
+
+// newKubernetesClientset creates a Kubernetes clientset from the in-cluster config or kubeconfig
+func newKubernetesClientset() (kubernetes.Interface, error) {
+	cfg, err := kubeconfig.GetKubeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load kubeconfig: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	return clientset, nil
+}
+
+// findTagValue returns the value of the tag with the given key, or an empty string
+func findTagValue(tags []elbv2types.Tag, key string) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
 // parseCheckAssociationsArgs parses command line arguments for the check-associations command
 func parseCheckAssociationsArgs(args []string) (*CheckAssociationsOptions, error) {
 	opts := &CheckAssociationsOptions{}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "nlb", "check-associations":
-			// Skip command names
-			continue
-		case "--vpc":
-			if i+1 < len(args) {
-				i++
-				opts.VPCID = args[i]
-			}
-		case "--nlb-name":
-			if i+1 < len(args) {
-				i++
-				opts.NLBName = args[i]
-			}
-		}
+	fs := cli.NewFlagSet("check-associations")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID containing the NLBs")
+	fs.StringVar(&opts.NLBName, "nlb-name", "", "specific NLB name")
+	fs.StringVar(&opts.Zone, "zone", "", "zone planned for removal")
+	fs.StringVar(&opts.Color, "color", printpkg.ColorAuto, "color output: auto, always, never")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
 	return opts, nil
@@ -602,6 +1030,10 @@ func parseCheckAssociationsArgs(args []string) (*CheckAssociationsOptions, error
 type CheckAssociationsOptions struct {
 	VPCID   string
 	NLBName string
+	// Zone is the availability zone planned for removal, whose in-service targets are flagged
+	Zone    string
+	Color   string
+	Verbose bool
 }
 
 // AddSubnetToNLB handles the add-subnet command for adding subnets to an NLB
@@ -611,15 +1043,19 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws nlb add-subnet --vpc VPC_ID --zone AZ [--nlb-name NLB_NAME] [--force]")
+			fmt.Println("Usage: aws nlb add-subnet --vpc VPC_ID --zone AZ [--nlb-name NLB_NAME] [--force] [--dry-run] [--timeout DURATION]")
 			fmt.Println("Options:")
 			fmt.Println("  --vpc VPC_ID       VPC ID containing the NLB (required)")
 			fmt.Println("  --zone AZ          Availability zone to add subnets from (required)")
 			fmt.Println("  --nlb-name NAME    Specific NLB name to target (optional, adds to all NLBs if not specified)")
 			fmt.Println("  --force           Skip confirmation prompt")
+			fmt.Println("  --dry-run          Print each NLB's subnets before and after, and the SetSubnets request, without sending it")
+			fmt.Println("  --timeout DURATION Deadline for each SetSubnets call (default 2m)")
+			fmt.Println("  --verbose, -v      Log the AWS region, each API call with its timing, and a call count to stderr")
 			fmt.Println()
 			fmt.Println("This command adds subnets from the specified zone to NLBs in the VPC.")
 			fmt.Println("This is useful when you need to add subnets before removing others.")
+			fmt.Println("Press Ctrl+C to cancel the in-flight call and stop; the NLBs already modified are listed.")
 			return nil, nil
 		}
 	}
@@ -638,16 +1074,18 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 	}
 
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create ELBv2 client
 	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+	tagCache := newLoadBalancerTagCache()
 
 	// Find NLBs in the VPC
-	nlbs, err := findNLBsInVPC(elbv2Client, opts.VPCID, opts.NLBName)
+	nlbs, err := findNLBsInVPC(elbv2Client, tagCache, opts.VPCID, opts.NLBName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find NLBs: %w", err)
 	}
@@ -657,7 +1095,7 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 	}
 
 	// Find subnets in the specified zone
-	subnets, err := findSubnetsInZone(elbv2Client, opts.VPCID, opts.Zone)
+	subnets, err := findSubnetsInZone(ec2.NewFromConfig(cfg), opts.VPCID, opts.Zone)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find subnets in zone %s: %w", opts.Zone, err)
 	}
@@ -669,7 +1107,7 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 	// Show what will be modified
 	fmt.Printf("Found %d NLB(s) in VPC %s:\n", len(nlbs), opts.VPCID)
 	for _, nlb := range nlbs {
-		nlbName := getNLBName(nlb)
+		nlbName := getNLBName(elbv2Client, tagCache, nlb)
 		fmt.Printf("  - %s (%s)\n", nlbName, aws.ToString(nlb.LoadBalancerArn))
 	}
 
@@ -678,8 +1116,8 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 		fmt.Printf("  - %s (%s)\n", aws.ToString(subnet.SubnetId), aws.ToString(subnet.CidrBlock))
 	}
 
-	// Confirm addition unless --force is used
-	if !opts.Force {
+	// Confirm unless --force is used; a dry run changes nothing, so it never prompts
+	if !opts.Force && !opts.DryRun {
 		fmt.Printf("\nAre you sure you want to add subnets from zone %s to these NLBs? (yes/no): ", opts.Zone)
 		var response string
 		fmt.Scanln(&response)
@@ -689,10 +1127,18 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 		}
 	}
 
+	// Cancel in-flight mutations on SIGINT; installed after the prompt so Ctrl+C there still exits
+	mutateCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt)
+	defer stop()
+
 	// Add subnets to each NLB
 	successCount := 0
-	for _, nlb := range nlbs {
-		nlbName := getNLBName(nlb)
+	var modified []string
+	for i, nlb := range nlbs {
+		if mutateCtx.Err() != nil {
+			return nil, interruptedMutationError(modified, nlbNames(nlbs[i:]))
+		}
+		nlbName := getNLBName(elbv2Client, tagCache, nlb)
 
 		// Get current subnets
 		currentSubnets := make([]string, 0, len(nlb.AvailabilityZones))
@@ -724,13 +1170,20 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 			continue
 		}
 
-		// Update the NLB
-		input := &elasticloadbalancingv2.SetSubnetsInput{
-			LoadBalancerArn: nlb.LoadBalancerArn,
-			Subnets:         newSubnets,
+		if opts.DryRun {
+			if err := printSetSubnetsDryRun(os.Stdout, nlbName, nlb.LoadBalancerArn, currentSubnets, newSubnets); err != nil {
+				return nil, err
+			}
+			successCount++
+			continue
 		}
 
-		_, err = elbv2Client.SetSubnets(context.TODO(), input)
+		// Update the NLB
+		err = setNLBSubnets(mutateCtx, elbv2Client, nlb.LoadBalancerArn, newSubnets, opts.Timeout)
+		if mutateCtx.Err() != nil {
+			// The call may or may not have been applied, so list this NLB with the unattempted ones
+			return nil, interruptedMutationError(modified, nlbNames(nlbs[i:]))
+		}
 		if err != nil {
 			fmt.Printf("❌ Failed to add subnets to NLB %s: %v\n", nlbName, err)
 			continue
@@ -738,8 +1191,13 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 
 		fmt.Printf("✅ Successfully added %d subnet(s) to NLB %s\n", addedCount, nlbName)
 		successCount++
+		modified = append(modified, aws.ToString(nlb.LoadBalancerName))
 	}
 
+	if opts.DryRun {
+		fmt.Printf("\nDry run completed. Would update %d out of %d NLB(s); no changes were made.\n", successCount, len(nlbs))
+		return nil, nil
+	}
 	fmt.Printf("\nOperation completed. Successfully updated %d out of %d NLB(s).\n", successCount, len(nlbs))
 	return nil, nil
 }
@@ -748,30 +1206,20 @@ func AddSubnetToNLB(ctx *gofr.Context) (any, error) {
 func parseAddSubnetArgs(args []string) (*AddSubnetOptions, error) {
 	opts := &AddSubnetOptions{}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "nlb", "add-subnet":
-			// Skip command names
-			continue
-		case "--vpc":
-			if i+1 < len(args) {
-				i++
-				opts.VPCID = args[i]
-			}
-		case "--zone":
-			if i+1 < len(args) {
-				i++
-				opts.Zone = args[i]
-			}
-		case "--nlb-name":
-			if i+1 < len(args) {
-				i++
-				opts.NLBName = args[i]
-			}
-		case "--force":
-			opts.Force = true
-		}
+	fs := cli.NewFlagSet("add-subnet")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID containing the NLBs")
+	fs.StringVar(&opts.Zone, "zone", "", "availability zone of the subnets to add")
+	fs.StringVar(&opts.NLBName, "nlb-name", "", "specific NLB name")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "print the SetSubnets requests without sending them")
+	fs.DurationVar(&opts.Timeout, "timeout", DefaultSubnetMutationTimeout, "deadline for each SetSubnets call")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.Timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be greater than zero")
 	}
 
 	return opts, nil
@@ -783,18 +1231,14 @@ type AddSubnetOptions struct {
 	Zone    string
 	NLBName string
 	Force   bool
+	// DryRun prints the SetSubnets request for each NLB instead of sending it
+	DryRun  bool
+	Timeout time.Duration
+	Verbose bool
 }
 
 // findSubnetsInZone finds subnets in a specific VPC and zone
-func findSubnetsInZone(client *elasticloadbalancingv2.Client, vpcID, zone string) ([]types.Subnet, error) {
-	// We need to use EC2 client for subnet operations
-	cfg, err := config.LoadDefaultConfig(context.TODO())
-	if err != nil {
-		return nil, err
-	}
-
-	ec2Client := ec2.NewFromConfig(cfg)
-
+func findSubnetsInZone(ec2Client subnetDescriber, vpcID, zone string) ([]types.Subnet, error) {
 	input := &ec2.DescribeSubnetsInput{
 		Filters: []types.Filter{
 			{
@@ -816,6 +1260,242 @@ func findSubnetsInZone(client *elasticloadbalancingv2.Client, vpcID, zone string
 	return result.Subnets, nil
 }
 
+// DescribeNLB handles the describe command for showing the full details of a single NLB
+func DescribeNLB(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws nlb describe (--vpc VPC_ID --nlb-name NLB_NAME | --arn NLB_ARN)")
+			fmt.Println("Options:")
+			fmt.Println("  --vpc VPC_ID       VPC ID containing the NLB (required with --nlb-name)")
+			fmt.Println("  --nlb-name NAME    Name of the NLB to describe")
+			fmt.Println("  --arn NLB_ARN      ARN of the NLB to describe (alternative to --vpc/--nlb-name)")
+			fmt.Println("  --verbose, -v      Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command prints a detailed view of a single Network Load Balancer, including")
+			fmt.Println("availability zones, tags, listeners, target groups with target health, and attributes.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseDescribeNLBArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ARN == "" {
+		if opts.NLBName == "" {
+			return nil, fmt.Errorf("nlb-name or arn parameter is required")
+		}
+		if opts.VPCID == "" {
+			return nil, fmt.Errorf("vpc parameter is required when using --nlb-name")
+		}
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ELBv2 client
+	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+	tagCache := newLoadBalancerTagCache()
+
+	// Resolve the load balancer
+	nlb, err := resolveDescribeNLB(elbv2Client, tagCache, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	nlbArn := aws.ToString(nlb.LoadBalancerArn)
+
+	// General information
+	fmt.Printf("Name:          %s\n", getNLBName(elbv2Client, tagCache, nlb))
+	fmt.Printf("ARN:           %s\n", nlbArn)
+	fmt.Printf("DNS Name:      %s\n", aws.ToString(nlb.DNSName))
+	fmt.Printf("Type:          %s\n", string(nlb.Type))
+	fmt.Printf("Scheme:        %s\n", string(nlb.Scheme))
+	if nlb.State != nil {
+		fmt.Printf("State:         %s\n", string(nlb.State.Code))
+	}
+	fmt.Printf("VPC:           %s\n", aws.ToString(nlb.VpcId))
+	if nlb.CreatedTime != nil {
+		fmt.Printf("Created Time:  %s\n", nlb.CreatedTime.Format(time.RFC3339))
+	}
+
+	// Availability zones and subnets
+	fmt.Printf("\nAvailability Zones (%d):\n", len(nlb.AvailabilityZones))
+	for _, az := range nlb.AvailabilityZones {
+		fmt.Printf("  - %s / %s\n", aws.ToString(az.ZoneName), aws.ToString(az.SubnetId))
+	}
+
+	// Tags
+	tags := getLoadBalancerTags(elbv2Client, tagCache, nlb.LoadBalancerArn)
+	fmt.Printf("\nTags (%d):\n", len(tags))
+	for _, tag := range tags {
+		fmt.Printf("  %s=%s\n", aws.ToString(tag.Key), aws.ToString(tag.Value))
+	}
+
+	// Attributes
+	fmt.Printf("\nAttributes:\n")
+	attrsResult, err := elbv2Client.DescribeLoadBalancerAttributes(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: nlb.LoadBalancerArn,
+	})
+	if err != nil {
+		fmt.Printf("  ⚠️  Failed to describe attributes: %v\n", err)
+	} else {
+		attrs := loadBalancerAttributeMap(attrsResult.Attributes)
+		fmt.Printf("  Cross-zone load balancing: %s\n", attributeOrDefault(attrs, "load_balancing.cross_zone.enabled"))
+		fmt.Printf("  Deletion protection:       %s\n", attributeOrDefault(attrs, "deletion_protection.enabled"))
+		fmt.Printf("  Access logs:               %s\n", attributeOrDefault(attrs, "access_logs.s3.enabled"))
+		if attrs["access_logs.s3.enabled"] == "true" {
+			fmt.Printf("  Access logs bucket:        %s\n", attributeOrDefault(attrs, "access_logs.s3.bucket"))
+			if prefix := attrs["access_logs.s3.prefix"]; prefix != "" {
+				fmt.Printf("  Access logs prefix:        %s\n", prefix)
+			}
+		}
+	}
+
+	// Listeners
+	listenersResult, err := elbv2Client.DescribeListeners(context.TODO(), &elasticloadbalancingv2.DescribeListenersInput{
+		LoadBalancerArn: nlb.LoadBalancerArn,
+	})
+	if err != nil {
+		fmt.Printf("\nListeners:\n  ⚠️  Failed to describe listeners: %v\n", err)
+	} else {
+		fmt.Printf("\nListeners (%d):\n", len(listenersResult.Listeners))
+		for _, listener := range listenersResult.Listeners {
+			fmt.Printf("  - %s:%d\n", string(listener.Protocol), aws.ToInt32(listener.Port))
+		}
+	}
+
+	// Target groups and target health
+	targetGroupsResult, err := elbv2Client.DescribeTargetGroups(context.TODO(), &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: nlb.LoadBalancerArn,
+	})
+	if err != nil {
+		fmt.Printf("\nTarget Groups:\n  ⚠️  Failed to describe target groups: %v\n", err)
+		return nil, nil
+	}
+
+	fmt.Printf("\nTarget Groups (%d):\n", len(targetGroupsResult.TargetGroups))
+	for _, tg := range targetGroupsResult.TargetGroups {
+		fmt.Printf("  - %s (%s:%d, target type: %s)\n",
+			aws.ToString(tg.TargetGroupName), string(tg.Protocol), aws.ToInt32(tg.Port), string(tg.TargetType))
+
+		healthResult, err := elbv2Client.DescribeTargetHealth(context.TODO(), &elasticloadbalancingv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err != nil {
+			fmt.Printf("      ⚠️  Failed to describe target health: %v\n", err)
+			continue
+		}
+
+		if len(healthResult.TargetHealthDescriptions) == 0 {
+			fmt.Printf("      No registered targets\n")
+			continue
+		}
+
+		for _, desc := range healthResult.TargetHealthDescriptions {
+			targetID, targetAZ := "", ""
+			var targetPort int32
+			if desc.Target != nil {
+				targetID = aws.ToString(desc.Target.Id)
+				targetAZ = aws.ToString(desc.Target.AvailabilityZone)
+				targetPort = aws.ToInt32(desc.Target.Port)
+			}
+			state := "unknown"
+			if desc.TargetHealth != nil {
+				state = string(desc.TargetHealth.State)
+			}
+			fmt.Printf("      %s:%d", targetID, targetPort)
+			if targetAZ != "" {
+				fmt.Printf(" [%s]", targetAZ)
+			}
+			fmt.Printf(" %s\n", state)
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveDescribeNLB returns the load balancer the describe command reports on, looked up by
+// ARN or by name within the VPC. Load balancers of another type and names shared by several
+// NLBs are rejected.
+func resolveDescribeNLB(client loadBalancerClient, cache *loadBalancerTagCache, opts *DescribeNLBOptions) (elbv2types.LoadBalancer, error) {
+	if opts.ARN != "" {
+		result, err := client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancersInput{
+			LoadBalancerArns: []string{opts.ARN},
+		})
+		if err != nil {
+			return elbv2types.LoadBalancer{}, fmt.Errorf("failed to describe load balancer %s: %w", opts.ARN, err)
+		}
+		if len(result.LoadBalancers) == 0 {
+			return elbv2types.LoadBalancer{}, fmt.Errorf("load balancer %s not found", opts.ARN)
+		}
+		lb := result.LoadBalancers[0]
+		if lb.Type != elbv2types.LoadBalancerTypeEnumNetwork {
+			return elbv2types.LoadBalancer{}, fmt.Errorf("load balancer %s is of type %s, not a network load balancer", opts.ARN, lb.Type)
+		}
+		return lb, nil
+	}
+
+	nlbs, err := findNLBsInVPC(client, cache, opts.VPCID, opts.NLBName)
+	if err != nil {
+		return elbv2types.LoadBalancer{}, fmt.Errorf("failed to find NLBs: %w", err)
+	}
+	switch len(nlbs) {
+	case 0:
+		return elbv2types.LoadBalancer{}, fmt.Errorf("no NLB named %s found in VPC %s", opts.NLBName, opts.VPCID)
+	case 1:
+		return nlbs[0], nil
+	default:
+		arns := make([]string, len(nlbs))
+		for i, nlb := range nlbs {
+			arns[i] = aws.ToString(nlb.LoadBalancerArn)
+		}
+		return elbv2types.LoadBalancer{}, fmt.Errorf("%d NLBs named %s found in VPC %s, use --arn to pick one: %s",
+			len(nlbs), opts.NLBName, opts.VPCID, strings.Join(arns, ", "))
+	}
+}
+
+// attributeOrDefault returns the attribute value for the key, or "-" if not set
+func attributeOrDefault(attrs map[string]string, key string) string {
+	if value, ok := attrs[key]; ok && value != "" {
+		return value
+	}
+	return "-"
+}
+
+// parseDescribeNLBArgs parses command line arguments for the describe command
+func parseDescribeNLBArgs(args []string) (*DescribeNLBOptions, error) {
+	opts := &DescribeNLBOptions{}
+
+	fs := cli.NewFlagSet("describe")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID containing the NLB")
+	fs.StringVar(&opts.NLBName, "nlb-name", "", "NLB name")
+	fs.StringVar(&opts.ARN, "arn", "", "NLB ARN")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// DescribeNLBOptions represents the parsed command line options for the describe command
+type DescribeNLBOptions struct {
+	VPCID   string
+	NLBName string
+	ARN     string
+	Verbose bool
+}
+
 // NLBRouter routes nlb sub-commands
 func NLBRouter(ctx *gofr.Context) (any, error) {
 	args := os.Args[1:] // Get command line args for parsing flags
@@ -831,6 +1511,10 @@ func NLBRouter(ctx *gofr.Context) (any, error) {
 				return RemoveSubnetFromNLB(ctx)
 			case "check-associations":
 				return CheckNLBAssociations(ctx)
+			case "check-zones":
+				return CheckNLBZones(ctx)
+			case "describe":
+				return DescribeNLB(ctx)
 			}
 		}
 
@@ -841,6 +1525,10 @@ func NLBRouter(ctx *gofr.Context) (any, error) {
 			return RemoveSubnetFromNLB(ctx)
 		case "check-associations":
 			return CheckNLBAssociations(ctx)
+		case "check-zones":
+			return CheckNLBZones(ctx)
+		case "describe":
+			return DescribeNLB(ctx)
 		case "list":
 			// Remove the "list" argument and pass the rest to ListNLBs
 			os.Args = append(os.Args[:1], os.Args[2:]...)
@@ -854,9 +1542,11 @@ func NLBRouter(ctx *gofr.Context) (any, error) {
 			fmt.Println("Usage: aws nlb [COMMAND]")
 			fmt.Println("Commands:")
 			fmt.Println("  list               List all Network Load Balancers in a VPC (default)")
+			fmt.Println("  describe           Show detailed information for a single NLB")
 			fmt.Println("  add-subnet         Add subnets from a zone to NLBs in a VPC")
 			fmt.Println("  remove-subnet      Remove a subnet from NLBs in a VPC and zone")
 			fmt.Println("  check-associations Check for service associations that might prevent subnet removal")
+			fmt.Println("  check-zones        Flag NLBs that are missing availability zones other NLBs in the VPC cover")
 			fmt.Println()
 			fmt.Println("Examples:")
 			fmt.Println("  aws nlb --vpc vpc-12345678")
@@ -864,7 +1554,9 @@ func NLBRouter(ctx *gofr.Context) (any, error) {
 			fmt.Println("  aws nlb list --vpc vpc-12345678 --zone us-east-1a")
 			fmt.Println("  aws nlb list --vpc vpc-12345678 --sort state")
 			fmt.Println("  aws nlb add-subnet --vpc vpc-12345678 --zone us-east-1b")
+			fmt.Println("  aws nlb describe --vpc vpc-12345678 --nlb-name my-nlb")
 			fmt.Println("  aws nlb check-associations --vpc vpc-12345678")
+			fmt.Println("  aws nlb check-zones --vpc vpc-12345678")
 			fmt.Println("  aws nlb remove-subnet --vpc vpc-12345678 --zone us-east-1a")
 			fmt.Println("  aws nlb remove-subnet --vpc vpc-12345678 --zone us-east-1a --nlb-name my-nlb")
 			return nil, nil
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// zoneTargetHealth counts the targets of an NLB's target groups in one availability zone by
+// health state. Targets whose zone is not reported are counted under an empty Zone.
+type zoneTargetHealth struct {
+	Zone     string
+	Healthy  int
+	Draining int
+	// Other counts initial, unhealthy, unused and unavailable targets
+	Other int
+}
+
+// describeTargetHealthByZone calls DescribeTargetHealth for each target group and returns
+// the target counts per zone, sorted by zone
+func describeTargetHealthByZone(client targetHealthDescriber, targetGroupArns []string) ([]zoneTargetHealth, error) {
+	byZone := make(map[string]*zoneTargetHealth)
+	for _, arn := range targetGroupArns {
+		result, err := client.DescribeTargetHealth(context.TODO(), &elasticloadbalancingv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(arn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe target health of %s: %w", arn, err)
+		}
+
+		for _, desc := range result.TargetHealthDescriptions {
+			zone := ""
+			if desc.Target != nil {
+				zone = aws.ToString(desc.Target.AvailabilityZone)
+			}
+			counts, ok := byZone[zone]
+			if !ok {
+				counts = &zoneTargetHealth{Zone: zone}
+				byZone[zone] = counts
+			}
+
+			var state elbv2types.TargetHealthStateEnum
+			if desc.TargetHealth != nil {
+				state = desc.TargetHealth.State
+			}
+			switch state {
+			case elbv2types.TargetHealthStateEnumHealthy:
+				counts.Healthy++
+			case elbv2types.TargetHealthStateEnumDraining:
+				counts.Draining++
+			default:
+				counts.Other++
+			}
+		}
+	}
+
+	zones := make([]zoneTargetHealth, 0, len(byZone))
+	for _, counts := range byZone {
+		zones = append(zones, *counts)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Zone < zones[j].Zone })
+	return zones, nil
+}
+
+// printTargetHealthByZone writes the per-zone target counts to w. Healthy targets in
+// removeZone are flagged, in red when color is set, since removing a zone whose targets are
+// still in service is likely to fail with ResourceInUse.
+func printTargetHealthByZone(w io.Writer, zones []zoneTargetHealth, removeZone string, color bool) {
+	if len(zones) == 0 {
+		fmt.Fprintf(w, "   🎯 No registered targets\n")
+		return
+	}
+
+	fmt.Fprintf(w, "   🎯 Targets by zone:\n")
+	for _, counts := range zones {
+		zone := counts.Zone
+		if zone == "" {
+			zone = "(zone not reported)"
+		}
+		summary := []string{
+			fmt.Sprintf("%d healthy", counts.Healthy),
+			fmt.Sprintf("%d draining", counts.Draining),
+		}
+		if counts.Other > 0 {
+			summary = append(summary, fmt.Sprintf("%d other", counts.Other))
+		}
+		line := fmt.Sprintf("%s: %s", zone, strings.Join(summary, ", "))
+
+		if removeZone != "" && counts.Zone == removeZone && counts.Healthy > 0 {
+			line = fmt.Sprintf("❌ %s - still in service in the zone being removed; SetSubnets is likely to fail with ResourceInUse", line)
+			if color {
+				line = text.FgRed.Sprint(line)
+			}
+		}
+		fmt.Fprintf(w, "      %s\n", line)
+	}
+}
@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// targetIn returns a target health description for a target in zone with state
+func targetIn(id, zone string, state elbv2types.TargetHealthStateEnum) elbv2types.TargetHealthDescription {
+	return elbv2types.TargetHealthDescription{
+		Target:       &elbv2types.TargetDescription{Id: aws.String(id), AvailabilityZone: aws.String(zone)},
+		TargetHealth: &elbv2types.TargetHealth{State: state},
+	}
+}
+
+func TestDescribeTargetHealthByZone(t *testing.T) {
+	client := &fakeELBv2{targetHealth: map[string][]elbv2types.TargetHealthDescription{
+		"arn:tg/http": {
+			targetIn("10.0.1.10", "us-east-1b", elbv2types.TargetHealthStateEnumHealthy),
+			targetIn("10.0.0.10", "us-east-1a", elbv2types.TargetHealthStateEnumHealthy),
+			targetIn("10.0.0.11", "us-east-1a", elbv2types.TargetHealthStateEnumDraining),
+		},
+		"arn:tg/https": {
+			targetIn("10.0.0.10", "us-east-1a", elbv2types.TargetHealthStateEnumUnhealthy),
+			{Target: &elbv2types.TargetDescription{Id: aws.String("i-123")}, TargetHealth: &elbv2types.TargetHealth{State: elbv2types.TargetHealthStateEnumHealthy}},
+		},
+	}}
+
+	zones, err := describeTargetHealthByZone(client, []string{"arn:tg/http", "arn:tg/https"})
+	if err != nil {
+		t.Fatalf("describeTargetHealthByZone() error = %v", err)
+	}
+
+	want := []zoneTargetHealth{
+		{Zone: "", Healthy: 1},
+		{Zone: "us-east-1a", Healthy: 1, Draining: 1, Other: 1},
+		{Zone: "us-east-1b", Healthy: 1},
+	}
+	if len(zones) != len(want) {
+		t.Fatalf("describeTargetHealthByZone() = %+v, want %+v", zones, want)
+	}
+	for i := range want {
+		if zones[i] != want[i] {
+			t.Errorf("zone %d = %+v, want %+v", i, zones[i], want[i])
+		}
+	}
+}
+
+func TestPrintTargetHealthByZone(t *testing.T) {
+	zones := []zoneTargetHealth{
+		{Zone: "us-east-1a", Healthy: 2, Draining: 1},
+		{Zone: "us-east-1b", Draining: 3, Other: 1},
+	}
+
+	tests := []struct {
+		name       string
+		removeZone string
+		color      bool
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:    "no zone being removed",
+			want:    []string{"us-east-1a: 2 healthy, 1 draining\n", "us-east-1b: 0 healthy, 3 draining, 1 other"},
+			notWant: []string{"❌"},
+		},
+		{
+			name:       "healthy targets in the zone being removed",
+			removeZone: "us-east-1a",
+			want:       []string{"❌ us-east-1a: 2 healthy, 1 draining - still in service", "ResourceInUse"},
+			notWant:    []string{"\x1b["},
+		},
+		{
+			name:       "flag is red with color",
+			removeZone: "us-east-1a",
+			color:      true,
+			want:       []string{"\x1b[31m❌ us-east-1a"},
+		},
+		{
+			name:       "only draining targets in the zone being removed",
+			removeZone: "us-east-1b",
+			notWant:    []string{"❌"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			printTargetHealthByZone(&out, zones, tt.removeZone, tt.color)
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
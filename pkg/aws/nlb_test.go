@@ -1,8 +1,16 @@
 package aws
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/pischarti/nix/pkg/vpc"
 )
 
@@ -18,17 +26,26 @@ func TestParseNLBArgs(t *testing.T) {
 			args: []string{"nlb", "--vpc", "vpc-12345678"},
 			expected: &vpc.NLBOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "",
 				SortBy: "name",
 			},
 			wantErr: false,
 		},
+		{
+			name: "no headers",
+			args: []string{"nlb", "--vpc", "vpc-12345678", "--no-headers"},
+			expected: &vpc.NLBOptions{
+				VPCID:     "vpc-12345678",
+				SortBy:    "name",
+				NoHeaders: true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "vpc with zone",
 			args: []string{"nlb", "--vpc", "vpc-12345678", "--zone", "us-east-1a"},
 			expected: &vpc.NLBOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "us-east-1a",
+				Zones:  []string{"us-east-1a"},
 				SortBy: "name",
 			},
 			wantErr: false,
@@ -38,23 +55,91 @@ func TestParseNLBArgs(t *testing.T) {
 			args: []string{"nlb", "--vpc", "vpc-12345678", "--zone", "us-east-1a", "--sort", "state"},
 			expected: &vpc.NLBOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "us-east-1a",
+				Zones:  []string{"us-east-1a"},
 				SortBy: "state",
 			},
 			wantErr: false,
 		},
+		{
+			name: "vpc with details",
+			args: []string{"nlb", "--vpc", "vpc-12345678", "--with-details"},
+			expected: &vpc.NLBOptions{
+				VPCID:       "vpc-12345678",
+				SortBy:      "name",
+				WithDetails: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "no tags",
+			args: []string{"nlb", "--vpc", "vpc-12345678", "--no-tags"},
+			expected: &vpc.NLBOptions{
+				VPCID:  "vpc-12345678",
+				SortBy: "name",
+				NoTags: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "equals values",
+			args: []string{"nlb", "--vpc=vpc-12345678", "--sort=created"},
+			expected: &vpc.NLBOptions{
+				VPCID:  "vpc-12345678",
+				SortBy: "created",
+			},
+			wantErr: false,
+		},
+		{
+			name: "repeated zones by zone",
+			args: []string{"nlb", "--vpc", "vpc-12345678", "--zone", "us-east-1a", "--zone", "us-east-1b", "--by-zone"},
+			expected: &vpc.NLBOptions{
+				VPCID:  "vpc-12345678",
+				Zones:  []string{"us-east-1a", "us-east-1b"},
+				SortBy: "name",
+				ByZone: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "by zone with details",
+			args:     []string{"nlb", "--vpc", "vpc-12345678", "--by-zone", "--with-details"},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"nlb", "--vpc", "vpc-12345678", "--details"},
+			expected: nil,
+			wantErr:  true,
+		},
 		{
 			name:     "invalid sort option",
 			args:     []string{"nlb", "--vpc", "vpc-12345678", "--sort", "invalid"},
 			expected: nil,
 			wantErr:  true,
 		},
+		{
+			name: "state filter",
+			args: []string{"nlb", "--vpc", "vpc-12345678", "--state", "active_impaired"},
+			expected: &vpc.NLBOptions{
+				VPCID:  "vpc-12345678",
+				State:  "active_impaired",
+				SortBy: "name",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid state",
+			args:     []string{"nlb", "--vpc", "vpc-12345678", "--state", "available"},
+			expected: nil,
+			wantErr:  true,
+		},
 		{
 			name: "no vpc provided",
 			args: []string{"nlb", "--zone", "us-east-1a"},
 			expected: &vpc.NLBOptions{
 				VPCID:  "",
-				Zone:   "us-east-1a",
+				Zones:  []string{"us-east-1a"},
 				SortBy: "name",
 			},
 			wantErr: false,
@@ -72,12 +157,63 @@ func TestParseNLBArgs(t *testing.T) {
 				if result.VPCID != tt.expected.VPCID {
 					t.Errorf("ParseNLBArgs() VPCID = %v, want %v", result.VPCID, tt.expected.VPCID)
 				}
-				if result.Zone != tt.expected.Zone {
-					t.Errorf("ParseNLBArgs() Zone = %v, want %v", result.Zone, tt.expected.Zone)
+				if !slices.Equal(result.Zones, tt.expected.Zones) {
+					t.Errorf("ParseNLBArgs() Zones = %v, want %v", result.Zones, tt.expected.Zones)
+				}
+				if result.ByZone != tt.expected.ByZone {
+					t.Errorf("ParseNLBArgs() ByZone = %v, want %v", result.ByZone, tt.expected.ByZone)
+				}
+				if result.State != tt.expected.State {
+					t.Errorf("ParseNLBArgs() State = %v, want %v", result.State, tt.expected.State)
 				}
 				if result.SortBy != tt.expected.SortBy {
 					t.Errorf("ParseNLBArgs() SortBy = %v, want %v", result.SortBy, tt.expected.SortBy)
 				}
+				if result.NoTags != tt.expected.NoTags {
+					t.Errorf("ParseNLBArgs() NoTags = %v, want %v", result.NoTags, tt.expected.NoTags)
+				}
+				if result.WithDetails != tt.expected.WithDetails {
+					t.Errorf("ParseNLBArgs() WithDetails = %v, want %v", result.WithDetails, tt.expected.WithDetails)
+				}
+				if result.NoHeaders != tt.expected.NoHeaders {
+					t.Errorf("ParseNLBArgs() NoHeaders = %v, want %v", result.NoHeaders, tt.expected.NoHeaders)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadBalancerInState(t *testing.T) {
+	tests := []struct {
+		name     string
+		lb       elbv2types.LoadBalancer
+		state    string
+		expected bool
+	}{
+		{
+			name:     "matching state",
+			lb:       elbv2types.LoadBalancer{State: &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive}},
+			state:    "active",
+			expected: true,
+		},
+		{
+			name:     "different state",
+			lb:       elbv2types.LoadBalancer{State: &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumProvisioning}},
+			state:    "active",
+			expected: false,
+		},
+		{
+			name:     "no state reported",
+			lb:       elbv2types.LoadBalancer{},
+			state:    "active",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loadBalancerInState(tt.lb, tt.state); got != tt.expected {
+				t.Errorf("loadBalancerInState() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
@@ -129,3 +265,482 @@ func TestSortNLBs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDescribeNLBArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected DescribeNLBOptions
+		wantErr  bool
+	}{
+		{
+			name:     "vpc and name",
+			args:     []string{"nlb", "describe", "--vpc", "vpc-12345678", "--nlb-name", "my-nlb"},
+			expected: DescribeNLBOptions{VPCID: "vpc-12345678", NLBName: "my-nlb"},
+		},
+		{
+			name:     "arn only",
+			args:     []string{"nlb", "describe", "--arn", "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/net/my-nlb/abc"},
+			expected: DescribeNLBOptions{ARN: "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/net/my-nlb/abc"},
+		},
+		{
+			name:     "arn with verbose",
+			args:     []string{"nlb", "describe", "--verbose", "--arn", "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/net/my-nlb/abc"},
+			expected: DescribeNLBOptions{ARN: "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/net/my-nlb/abc", Verbose: true},
+		},
+		{
+			name:     "equals value",
+			args:     []string{"nlb", "describe", "--nlb-name=my-nlb"},
+			expected: DescribeNLBOptions{NLBName: "my-nlb"},
+		},
+		{
+			name:    "missing value",
+			args:    []string{"nlb", "describe", "--nlb-name"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDescribeNLBArgs(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("parseDescribeNLBArgs() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDescribeNLBArgs() unexpected error: %v", err)
+			}
+			if *result != tt.expected {
+				t.Errorf("parseDescribeNLBArgs() = %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindTagValue(t *testing.T) {
+	tags := []elbv2types.Tag{
+		{Key: aws.String("Name"), Value: aws.String("my-nlb")},
+		{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("default/web")},
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{name: "service name tag", key: "kubernetes.io/service-name", expected: "default/web"},
+		{name: "name tag", key: "Name", expected: "my-nlb"},
+		{name: "missing tag", key: "kubernetes.io/cluster/prod", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findTagValue(tags, tt.key); got != tt.expected {
+				t.Errorf("findTagValue(%q) = %q, want %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyNLBAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]string
+		crossZone  string
+		accessLogs string
+		bucket     string
+	}{
+		{
+			name: "cross-zone and access logs enabled",
+			attrs: map[string]string{
+				"load_balancing.cross_zone.enabled": "true",
+				"access_logs.s3.enabled":            "true",
+				"access_logs.s3.bucket":             "nlb-logs",
+			},
+			crossZone:  "enabled",
+			accessLogs: "enabled",
+			bucket:     "nlb-logs",
+		},
+		{
+			name: "both disabled",
+			attrs: map[string]string{
+				"load_balancing.cross_zone.enabled": "false",
+				"access_logs.s3.enabled":            "false",
+				"access_logs.s3.bucket":             "",
+			},
+			crossZone:  "disabled",
+			accessLogs: "disabled",
+		},
+		{
+			name:       "attributes unavailable",
+			attrs:      nil,
+			crossZone:  "-",
+			accessLogs: "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := vpc.NLBInfo{Name: "test-nlb"}
+			applyNLBAttributes(&info, tt.attrs)
+
+			if info.CrossZone != tt.crossZone {
+				t.Errorf("CrossZone = %q, want %q", info.CrossZone, tt.crossZone)
+			}
+			if info.AccessLogs != tt.accessLogs {
+				t.Errorf("AccessLogs = %q, want %q", info.AccessLogs, tt.accessLogs)
+			}
+			if info.AccessLogsBucket != tt.bucket {
+				t.Errorf("AccessLogsBucket = %q, want %q", info.AccessLogsBucket, tt.bucket)
+			}
+		})
+	}
+}
+
+func TestParseSubnetMutationArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantTimeout time.Duration
+		wantDryRun  bool
+		wantErr     bool
+	}{
+		{
+			name:        "default timeout",
+			args:        []string{"nlb", "add-subnet", "--vpc", "vpc-12345678", "--zone", "us-east-1a"},
+			wantTimeout: DefaultSubnetMutationTimeout,
+		},
+		{
+			name:        "custom timeout",
+			args:        []string{"nlb", "add-subnet", "--vpc", "vpc-12345678", "--zone", "us-east-1a", "--timeout", "45s"},
+			wantTimeout: 45 * time.Second,
+		},
+		{
+			name:        "dry run with force",
+			args:        []string{"nlb", "add-subnet", "--vpc", "vpc-12345678", "--zone", "us-east-1a", "--dry-run", "--force"},
+			wantTimeout: DefaultSubnetMutationTimeout,
+			wantDryRun:  true,
+		},
+		{
+			name:    "zero timeout",
+			args:    []string{"nlb", "add-subnet", "--vpc", "vpc-12345678", "--zone", "us-east-1a", "--timeout", "0s"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addOpts, err := parseAddSubnetArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAddSubnetArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			removeOpts, err := parseRemoveSubnetArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRemoveSubnetArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if addOpts.Timeout != tt.wantTimeout {
+				t.Errorf("parseAddSubnetArgs() Timeout = %v, want %v", addOpts.Timeout, tt.wantTimeout)
+			}
+			if removeOpts.Timeout != tt.wantTimeout {
+				t.Errorf("parseRemoveSubnetArgs() Timeout = %v, want %v", removeOpts.Timeout, tt.wantTimeout)
+			}
+			if addOpts.DryRun != tt.wantDryRun || removeOpts.DryRun != tt.wantDryRun {
+				t.Errorf("DryRun = %v (add), %v (remove), want %v", addOpts.DryRun, removeOpts.DryRun, tt.wantDryRun)
+			}
+		})
+	}
+}
+
+func TestPrintSetSubnetsDryRun(t *testing.T) {
+	var out strings.Builder
+	err := printSetSubnetsDryRun(&out, "web-nlb", aws.String("arn:nlb/1"),
+		[]string{"subnet-a", "subnet-b"}, []string{"subnet-a", "subnet-b", "subnet-c"})
+	if err != nil {
+		t.Fatalf("printSetSubnetsDryRun() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Dry run: NLB web-nlb (arn:nlb/1)",
+		"Before: subnet-a, subnet-b\n",
+		"After:  subnet-a, subnet-b, subnet-c\n",
+		`"LoadBalancerArn": "arn:nlb/1"`,
+		`"subnet-c"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestLoadBalancerTagCache(t *testing.T) {
+	cache := newLoadBalancerTagCache()
+	fetches := 0
+	fetch := func() ([]elbv2types.Tag, error) {
+		fetches++
+		return []elbv2types.Tag{{Key: aws.String("Name"), Value: aws.String("my-nlb")}}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		tags, err := cache.get("arn:nlb/1", fetch)
+		if err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		if findTagValue(tags, "Name") != "my-nlb" {
+			t.Errorf("get() tags = %v, want Name=my-nlb", tags)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetches for one ARN = %d, want 1", fetches)
+	}
+
+	cache.get("arn:nlb/2", fetch)
+	if fetches != 2 {
+		t.Errorf("fetches after a second ARN = %d, want 2", fetches)
+	}
+
+	// A failed fetch is not cached
+	failing := func() ([]elbv2types.Tag, error) {
+		fetches++
+		return nil, errors.New("throttled")
+	}
+	if _, err := cache.get("arn:nlb/3", failing); err == nil {
+		t.Error("get() with a failing fetch returned no error")
+	}
+	cache.get("arn:nlb/3", fetch)
+	if fetches != 4 {
+		t.Errorf("fetches after a failed lookup = %d, want 4", fetches)
+	}
+
+	// A nil cache fetches every time
+	var uncached *loadBalancerTagCache
+	uncached.get("arn:nlb/1", fetch)
+	uncached.get("arn:nlb/1", fetch)
+	if fetches != 6 {
+		t.Errorf("fetches without a cache = %d, want 6", fetches)
+	}
+}
+
+func TestConvertELBv2ToNLBInfo(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeELBv2{tags: map[string][]elbv2types.Tag{
+		"arn:nlb/1": {
+			{Key: aws.String("Name"), Value: aws.String("web-nlb")},
+			{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("default/web")},
+			{Key: aws.String("Owner"), Value: aws.String("team-a")},
+		},
+	}}
+	lbs := []elbv2types.LoadBalancer{
+		{
+			LoadBalancerArn: aws.String("arn:nlb/1"),
+			DNSName:         aws.String("web-nlb.elb.amazonaws.com"),
+			State:           &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive},
+			Type:            elbv2types.LoadBalancerTypeEnumNetwork,
+			Scheme:          elbv2types.LoadBalancerSchemeEnumInternetFacing,
+			VpcId:           aws.String("vpc-1"),
+			CreatedTime:     &created,
+			AvailabilityZones: []elbv2types.AvailabilityZone{
+				{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+				{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+			},
+		},
+	}
+
+	cache := newLoadBalancerTagCache()
+	tagsByARN, err := describeLoadBalancerTags(client, cache, []string{"arn:nlb/1"})
+	if err != nil {
+		t.Fatalf("describeLoadBalancerTags() error = %v", err)
+	}
+	infos := convertELBv2ToNLBInfo(tagsByARN, lbs)
+	if len(infos) != 1 {
+		t.Fatalf("convertELBv2ToNLBInfo() returned %d NLBs, want 1", len(infos))
+	}
+
+	want := vpc.NLBInfo{
+		LoadBalancerArn:   "arn:nlb/1",
+		Name:              "web-nlb",
+		DNSName:           "web-nlb.elb.amazonaws.com",
+		State:             "active",
+		Type:              "network",
+		Scheme:            "internet-facing",
+		VPCID:             "vpc-1",
+		AvailabilityZones: "us-east-1a, us-east-1b",
+		Subnets:           "subnet-a, subnet-b",
+		CreatedTime:       "2024-03-01T12:00:00Z",
+		Tags:              "kubernetes.io/service-name",
+	}
+	if infos[0] != want {
+		t.Errorf("convertELBv2ToNLBInfo() = %+v, want %+v", infos[0], want)
+	}
+
+	// Tags are cached per invocation, so looking them up again does not call DescribeTags
+	describeLoadBalancerTags(client, cache, []string{"arn:nlb/1"})
+	getLoadBalancerTags(client, cache, aws.String("arn:nlb/1"))
+	if client.tagCalls != 1 {
+		t.Errorf("DescribeTags calls = %d, want 1", client.tagCalls)
+	}
+}
+
+func TestDescribeLoadBalancerTagsBatches(t *testing.T) {
+	client := &fakeELBv2{tags: map[string][]elbv2types.Tag{}}
+	var arns []string
+	for i := range 45 {
+		arn := fmt.Sprintf("arn:nlb/%d", i)
+		arns = append(arns, arn)
+		client.tags[arn] = []elbv2types.Tag{{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("nlb-%d", i))}}
+	}
+	// Duplicates are fetched once
+	arns = append(arns, "arn:nlb/0")
+
+	tagsByARN, err := describeLoadBalancerTags(client, newLoadBalancerTagCache(), arns)
+	if err != nil {
+		t.Fatalf("describeLoadBalancerTags() error = %v", err)
+	}
+
+	if len(client.tagBatches) != 3 {
+		t.Fatalf("DescribeTags calls = %d, want 3", len(client.tagBatches))
+	}
+	var fetched []string
+	for i, batch := range client.tagBatches {
+		if want := []int{20, 20, 5}[i]; len(batch) != want {
+			t.Errorf("batch %d has %d ARNs, want %d", i, len(batch), want)
+		}
+		fetched = append(fetched, batch...)
+	}
+	if !slices.Equal(fetched, arns[:45]) {
+		t.Errorf("fetched ARNs = %v, want %v", fetched, arns[:45])
+	}
+
+	if len(tagsByARN) != 45 {
+		t.Errorf("describeLoadBalancerTags() returned %d ARNs, want 45", len(tagsByARN))
+	}
+	if name := findTagValue(tagsByARN["arn:nlb/44"], "Name"); name != "nlb-44" {
+		t.Errorf("Name tag of arn:nlb/44 = %q, want nlb-44", name)
+	}
+}
+
+func TestFindNLBsInVPCByName(t *testing.T) {
+	network := func(arn, vpcID string) elbv2types.LoadBalancer {
+		return elbv2types.LoadBalancer{LoadBalancerArn: aws.String(arn), VpcId: aws.String(vpcID), Type: elbv2types.LoadBalancerTypeEnumNetwork}
+	}
+	client := &fakeELBv2{
+		loadBalancers: []elbv2types.LoadBalancer{
+			network("arn:nlb/a", "vpc-1"),
+			network("arn:nlb/b", "vpc-1"),
+			network("arn:nlb/other-vpc", "vpc-2"),
+			{LoadBalancerArn: aws.String("arn:alb/a"), VpcId: aws.String("vpc-1"), Type: elbv2types.LoadBalancerTypeEnumApplication},
+		},
+		tags: map[string][]elbv2types.Tag{
+			"arn:nlb/a": {{Key: aws.String("Name"), Value: aws.String("ingress")}},
+			"arn:nlb/b": {{Key: aws.String("Name"), Value: aws.String("internal")}},
+		},
+	}
+
+	nlbs, err := findNLBsInVPC(client, newLoadBalancerTagCache(), "vpc-1", "ingress")
+	if err != nil {
+		t.Fatalf("findNLBsInVPC() error = %v", err)
+	}
+	if len(nlbs) != 1 || aws.ToString(nlbs[0].LoadBalancerArn) != "arn:nlb/a" {
+		t.Errorf("findNLBsInVPC() = %v, want arn:nlb/a", nlbs)
+	}
+	// The names of both candidates come from one batched call
+	if want := [][]string{{"arn:nlb/a", "arn:nlb/b"}}; !reflect.DeepEqual(client.tagBatches, want) {
+		t.Errorf("DescribeTags batches = %v, want %v", client.tagBatches, want)
+	}
+
+	// A failed batch is reported instead of falling back to one call per load balancer
+	failing := &fakeELBv2{loadBalancers: client.loadBalancers, tagsErr: errors.New("throttled")}
+	if _, err := findNLBsInVPC(failing, newLoadBalancerTagCache(), "vpc-1", "ingress"); err == nil {
+		t.Error("findNLBsInVPC() error = nil, want the DescribeTags failure")
+	}
+	if failing.tagCalls != 1 {
+		t.Errorf("DescribeTags calls after a failure = %d, want 1", failing.tagCalls)
+	}
+}
+
+func TestResolveDescribeNLB(t *testing.T) {
+	network := func(arn string) elbv2types.LoadBalancer {
+		return elbv2types.LoadBalancer{LoadBalancerArn: aws.String(arn), VpcId: aws.String("vpc-1"), Type: elbv2types.LoadBalancerTypeEnumNetwork}
+	}
+	nameTag := func(name string) []elbv2types.Tag {
+		return []elbv2types.Tag{{Key: aws.String("Name"), Value: aws.String(name)}}
+	}
+
+	tests := []struct {
+		name    string
+		opts    DescribeNLBOptions
+		wantARN string
+		wantErr string
+	}{
+		{name: "by ARN", opts: DescribeNLBOptions{ARN: "arn:nlb/ingress"}, wantARN: "arn:nlb/ingress"},
+		{name: "ARN of an ALB", opts: DescribeNLBOptions{ARN: "arn:alb/web"}, wantErr: "not a network load balancer"},
+		{name: "unknown ARN", opts: DescribeNLBOptions{ARN: "arn:nlb/missing"}, wantErr: "not found"},
+		{name: "by name", opts: DescribeNLBOptions{VPCID: "vpc-1", NLBName: "ingress"}, wantARN: "arn:nlb/ingress"},
+		{name: "ambiguous name", opts: DescribeNLBOptions{VPCID: "vpc-1", NLBName: "shared"}, wantErr: "2 NLBs named shared"},
+		{name: "unknown name", opts: DescribeNLBOptions{VPCID: "vpc-1", NLBName: "missing"}, wantErr: "no NLB named missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeELBv2{
+				loadBalancers: []elbv2types.LoadBalancer{
+					network("arn:nlb/ingress"),
+					network("arn:nlb/shared-1"),
+					network("arn:nlb/shared-2"),
+					{LoadBalancerArn: aws.String("arn:alb/web"), VpcId: aws.String("vpc-1"), Type: elbv2types.LoadBalancerTypeEnumApplication},
+				},
+				tags: map[string][]elbv2types.Tag{
+					"arn:nlb/ingress":  nameTag("ingress"),
+					"arn:nlb/shared-1": nameTag("shared"),
+					"arn:nlb/shared-2": nameTag("shared"),
+				},
+			}
+
+			nlb, err := resolveDescribeNLB(client, newLoadBalancerTagCache(), &tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveDescribeNLB() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveDescribeNLB() error = %v", err)
+			}
+			if got := aws.ToString(nlb.LoadBalancerArn); got != tt.wantARN {
+				t.Errorf("resolveDescribeNLB() = %s, want %s", got, tt.wantARN)
+			}
+		})
+	}
+}
+
+func TestConvertELBv2ToNLBInfoWithoutTags(t *testing.T) {
+	lbs := []elbv2types.LoadBalancer{
+		{
+			LoadBalancerArn:  aws.String("arn:nlb/1"),
+			LoadBalancerName: aws.String("k8s-web-nlb"),
+			State:            &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive},
+			Type:             elbv2types.LoadBalancerTypeEnumNetwork,
+			AvailabilityZones: []elbv2types.AvailabilityZone{
+				{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+			},
+		},
+	}
+
+	infos := convertELBv2ToNLBInfoWithoutTags(lbs)
+	if len(infos) != 1 {
+		t.Fatalf("convertELBv2ToNLBInfoWithoutTags() returned %d NLBs, want 1", len(infos))
+	}
+	if infos[0].Name != "k8s-web-nlb" {
+		t.Errorf("Name = %q, want %q", infos[0].Name, "k8s-web-nlb")
+	}
+	if infos[0].Tags != "" {
+		t.Errorf("Tags = %q, want empty", infos[0].Tags)
+	}
+	if infos[0].State != "active" || infos[0].Subnets != "subnet-a" {
+		t.Errorf("convertELBv2ToNLBInfoWithoutTags() = %+v, want state and subnets copied", infos[0])
+	}
+}
@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// nlbZoneCoverage records the availability zones an NLB spans and the zones other NLBs
+// in the same VPC cover that it does not
+type nlbZoneCoverage struct {
+	Name    string
+	Zones   []string
+	Missing []string
+}
+
+// CheckNLBZones handles the check-zones command for verifying that every NLB in a VPC spans
+// the same set of availability zones
+func CheckNLBZones(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws nlb check-zones --vpc VPC_ID")
+			fmt.Println("Options:")
+			fmt.Println("  --vpc VPC_ID       VPC ID containing the NLBs (required)")
+			fmt.Println("  --verbose, -v      Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command reports the availability zones each NLB in the VPC covers and flags NLBs")
+			fmt.Println("missing a zone that other NLBs have, which leads to uneven traffic. Run it after")
+			fmt.Println("add-subnet or remove-subnet to confirm the NLBs are consistent.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parseCheckZonesArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.VPCID == "" {
+		return nil, fmt.Errorf("vpc parameter is required")
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create ELBv2 client
+	elbv2Client := elasticloadbalancingv2.NewFromConfig(cfg)
+	tagCache := newLoadBalancerTagCache()
+
+	// Find NLBs in the VPC
+	nlbs, err := findNLBsInVPC(elbv2Client, tagCache, opts.VPCID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find NLBs: %w", err)
+	}
+
+	if len(nlbs) == 0 {
+		return nil, fmt.Errorf("no NLBs found in VPC %s", opts.VPCID)
+	}
+
+	coverage := make([]nlbZoneCoverage, 0, len(nlbs))
+	for _, nlb := range nlbs {
+		coverage = append(coverage, nlbZoneCoverage{
+			Name:  getNLBName(elbv2Client, tagCache, nlb),
+			Zones: loadBalancerZones(nlb),
+		})
+	}
+	allZones := compareNLBZones(coverage)
+
+	fmt.Printf("Checking availability zones for %d NLB(s) in VPC %s:\n", len(nlbs), opts.VPCID)
+	fmt.Printf("Zones covered by any NLB: %s\n\n", strings.Join(allZones, ", "))
+
+	inconsistent := 0
+	for _, c := range coverage {
+		fmt.Printf("🔍 NLB: %s\n", c.Name)
+		fmt.Printf("   Zones: %s\n", strings.Join(c.Zones, ", "))
+		if len(c.Missing) > 0 {
+			inconsistent++
+			fmt.Printf("   ⚠️  Missing zone(s): %s\n", strings.Join(c.Missing, ", "))
+		} else {
+			fmt.Printf("   ✅ Covers all zones\n")
+		}
+		fmt.Println()
+	}
+
+	if inconsistent == 0 {
+		fmt.Printf("✅ All %d NLB(s) span the same availability zones\n", len(coverage))
+		return nil, nil
+	}
+
+	fmt.Printf("⚠️  %d of %d NLB(s) are missing availability zones other NLBs cover\n", inconsistent, len(coverage))
+	fmt.Printf("💡 To add a zone: aws nlb add-subnet --vpc %s --zone ZONE --nlb-name NLB_NAME\n", opts.VPCID)
+
+	return nil, nil
+}
+
+// loadBalancerZones returns the sorted availability zone names a load balancer is enabled in
+func loadBalancerZones(lb elbv2types.LoadBalancer) []string {
+	zones := make([]string, 0, len(lb.AvailabilityZones))
+	for _, az := range lb.AvailabilityZones {
+		if name := aws.ToString(az.ZoneName); name != "" {
+			zones = append(zones, name)
+		}
+	}
+	slices.Sort(zones)
+	return slices.Compact(zones)
+}
+
+// compareNLBZones fills in the zones each NLB is missing relative to the union of all NLBs'
+// zones, and returns that union sorted
+func compareNLBZones(coverage []nlbZoneCoverage) []string {
+	var allZones []string
+	for _, c := range coverage {
+		allZones = append(allZones, c.Zones...)
+	}
+	slices.Sort(allZones)
+	allZones = slices.Compact(allZones)
+
+	for i := range coverage {
+		coverage[i].Missing = nil
+		for _, zone := range allZones {
+			if !slices.Contains(coverage[i].Zones, zone) {
+				coverage[i].Missing = append(coverage[i].Missing, zone)
+			}
+		}
+	}
+
+	return allZones
+}
+
+// parseCheckZonesArgs parses command line arguments for the check-zones command
+func parseCheckZonesArgs(args []string) (*CheckZonesOptions, error) {
+	opts := &CheckZonesOptions{}
+
+	fs := cli.NewFlagSet("check-zones")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID containing the NLBs")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// CheckZonesOptions represents the parsed command line options for the check-zones command
+type CheckZonesOptions struct {
+	VPCID   string
+	Verbose bool
+}
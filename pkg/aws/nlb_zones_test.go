@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+func TestLoadBalancerZones(t *testing.T) {
+	lb := elbv2types.LoadBalancer{
+		AvailabilityZones: []elbv2types.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1c")},
+			{ZoneName: aws.String("us-east-1a")},
+			{ZoneName: aws.String("us-east-1a")},
+			{},
+		},
+	}
+
+	want := []string{"us-east-1a", "us-east-1c"}
+	if got := loadBalancerZones(lb); !reflect.DeepEqual(got, want) {
+		t.Errorf("loadBalancerZones() = %v, want %v", got, want)
+	}
+}
+
+func TestCompareNLBZones(t *testing.T) {
+	tests := []struct {
+		name        string
+		coverage    []nlbZoneCoverage
+		wantZones   []string
+		wantMissing [][]string
+	}{
+		{
+			name: "consistent",
+			coverage: []nlbZoneCoverage{
+				{Name: "a", Zones: []string{"us-east-1a", "us-east-1b"}},
+				{Name: "b", Zones: []string{"us-east-1a", "us-east-1b"}},
+			},
+			wantZones:   []string{"us-east-1a", "us-east-1b"},
+			wantMissing: [][]string{nil, nil},
+		},
+		{
+			name: "one nlb missing a zone",
+			coverage: []nlbZoneCoverage{
+				{Name: "a", Zones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+				{Name: "b", Zones: []string{"us-east-1a", "us-east-1c"}},
+			},
+			wantZones:   []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			wantMissing: [][]string{nil, {"us-east-1b"}},
+		},
+		{
+			name: "disjoint zones",
+			coverage: []nlbZoneCoverage{
+				{Name: "a", Zones: []string{"us-east-1a"}},
+				{Name: "b", Zones: []string{"us-east-1b"}},
+			},
+			wantZones:   []string{"us-east-1a", "us-east-1b"},
+			wantMissing: [][]string{{"us-east-1b"}, {"us-east-1a"}},
+		},
+		{
+			name: "single nlb",
+			coverage: []nlbZoneCoverage{
+				{Name: "a", Zones: []string{"us-east-1a"}},
+			},
+			wantZones:   []string{"us-east-1a"},
+			wantMissing: [][]string{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zones := compareNLBZones(tt.coverage)
+			if !reflect.DeepEqual(zones, tt.wantZones) {
+				t.Errorf("compareNLBZones() zones = %v, want %v", zones, tt.wantZones)
+			}
+			for i, c := range tt.coverage {
+				if !reflect.DeepEqual(c.Missing, tt.wantMissing[i]) {
+					t.Errorf("NLB %s missing = %v, want %v", c.Name, c.Missing, tt.wantMissing[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCheckZonesArgs(t *testing.T) {
+	opts, err := parseCheckZonesArgs([]string{"nlb", "check-zones", "--vpc=vpc-12345678", "-v"})
+	if err != nil {
+		t.Fatalf("parseCheckZonesArgs() error = %v", err)
+	}
+	if opts.VPCID != "vpc-12345678" || !opts.Verbose {
+		t.Errorf("parseCheckZonesArgs() = %+v", opts)
+	}
+
+	if _, err := parseCheckZonesArgs([]string{"nlb", "check-zones", "--nlb-name", "x"}); err == nil {
+		t.Error("parseCheckZonesArgs() with unknown flag error = nil, want error")
+	}
+}
@@ -3,15 +3,21 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pischarti/nix/pkg/k8s"
 )
 
 // NodeGroupInfo contains information about a node group and its instances
 type NodeGroupInfo struct {
 	InstanceID    string
 	NodeGroupName string
+	NodeGroupType string
 	ClusterName   string
 	Status        string
 	InstanceType  string
@@ -80,11 +86,10 @@ func FindNodeGroups(ctx context.Context, ec2Client *ec2.Client, instanceIDs []st
 			info.ClusterName = strings.TrimPrefix(cluster, "kubernetes.io/cluster/")
 		}
 
-		// Try to get node group name from tags
-		if nodeGroup, ok := details.Tags["eks:nodegroup-name"]; ok {
-			info.NodeGroupName = nodeGroup
-		} else if nodeGroup, ok := details.Tags["alpha.eksctl.io/nodegroup-name"]; ok {
-			info.NodeGroupName = nodeGroup
+		// Try to get node group name from tags (EKS, eksctl or Karpenter)
+		if nodeGroup, ok := k8s.NodeGroupFromTags(details.Tags); ok {
+			info.NodeGroupName = nodeGroup.Name
+			info.NodeGroupType = string(nodeGroup.Kind)
 		}
 
 		// If we still don't have node group info, mark as unknown
@@ -100,3 +105,131 @@ func FindNodeGroups(ctx context.Context, ec2Client *ec2.Client, instanceIDs []st
 
 	return results, nil
 }
+
+// ASGNodeGroup describes an Auto Scaling Group that backs an EKS or eksctl node group
+type ASGNodeGroup struct {
+	// ASGName is the Auto Scaling Group name, which is what the recycle command takes
+	ASGName       string
+	NodeGroupName string
+	ClusterName   string
+	MinSize       int32
+	MaxSize       int32
+	DesiredSize   int32
+	InstanceCount int
+}
+
+// Sort orders for ListASGNodeGroups results
+const (
+	NodeGroupSortName      = "name"
+	NodeGroupSortCluster   = "cluster"
+	NodeGroupSortDesired   = "desired"
+	NodeGroupSortInstances = "instances"
+)
+
+// ListASGNodeGroups returns the Auto Scaling Groups tagged as EKS or eksctl node groups,
+// optionally only those of one cluster
+func ListASGNodeGroups(ctx context.Context, asgClient *autoscaling.Client, clusterName string) ([]ASGNodeGroup, error) {
+	// Only groups carrying a node group tag; values of one filter are ORed
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		Filters: []asgtypes.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{k8s.TagEKSNodeGroup, k8s.TagEksctlNodeGroup},
+			},
+		},
+	}
+
+	var results []ASGNodeGroup
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(asgClient, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe Auto Scaling Groups: %w", err)
+		}
+
+		for _, asg := range page.AutoScalingGroups {
+			nodeGroup, ok := nodeGroupFromASG(asg)
+			if !ok {
+				continue
+			}
+			if clusterName != "" && nodeGroup.ClusterName != clusterName {
+				continue
+			}
+			results = append(results, nodeGroup)
+		}
+	}
+
+	return results, nil
+}
+
+// nodeGroupFromASG builds the node group description of an Auto Scaling Group, returning
+// false when the group has no EKS or eksctl node group tag
+func nodeGroupFromASG(asg asgtypes.AutoScalingGroup) (ASGNodeGroup, bool) {
+	tags := make(map[string]string, len(asg.Tags))
+	for _, tag := range asg.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	nodeGroup, ok := k8s.NodeGroupFromTags(tags)
+	if !ok || nodeGroup.Kind != k8s.NodeGroupKindASG {
+		return ASGNodeGroup{}, false
+	}
+
+	return ASGNodeGroup{
+		ASGName:       aws.ToString(asg.AutoScalingGroupName),
+		NodeGroupName: nodeGroup.Name,
+		ClusterName:   asgClusterName(tags),
+		MinSize:       aws.ToInt32(asg.MinSize),
+		MaxSize:       aws.ToInt32(asg.MaxSize),
+		DesiredSize:   aws.ToInt32(asg.DesiredCapacity),
+		InstanceCount: len(asg.Instances),
+	}, true
+}
+
+// asgClusterName returns the cluster an Auto Scaling Group belongs to from its EKS, eksctl or
+// kubernetes.io/cluster tags, or "Unknown"
+func asgClusterName(tags map[string]string) string {
+	for _, key := range []string{"eks:cluster-name", "alpha.eksctl.io/cluster-name"} {
+		if name := tags[key]; name != "" {
+			return name
+		}
+	}
+	for key := range tags {
+		if name, ok := strings.CutPrefix(key, "kubernetes.io/cluster/"); ok && name != "" {
+			return name
+		}
+	}
+	return "Unknown"
+}
+
+// SortASGNodeGroups sorts node groups in place by name, cluster, desired size or instance
+// count. Ties are broken by ASG name.
+func SortASGNodeGroups(nodeGroups []ASGNodeGroup, sortBy string) error {
+	var less func(a, b ASGNodeGroup) bool
+	switch sortBy {
+	case NodeGroupSortName:
+		less = func(a, b ASGNodeGroup) bool { return false }
+	case NodeGroupSortCluster:
+		less = func(a, b ASGNodeGroup) bool { return a.ClusterName < b.ClusterName }
+	case NodeGroupSortDesired:
+		less = func(a, b ASGNodeGroup) bool { return a.DesiredSize > b.DesiredSize }
+	case NodeGroupSortInstances:
+		less = func(a, b ASGNodeGroup) bool { return a.InstanceCount > b.InstanceCount }
+	default:
+		return fmt.Errorf("unsupported sort option: %s (supported: %s, %s, %s, %s)", sortBy,
+			NodeGroupSortName, NodeGroupSortCluster, NodeGroupSortDesired, NodeGroupSortInstances)
+	}
+
+	sort.SliceStable(nodeGroups, func(i, j int) bool {
+		if less(nodeGroups[i], nodeGroups[j]) {
+			return true
+		}
+		if less(nodeGroups[j], nodeGroups[i]) {
+			return false
+		}
+		return nodeGroups[i].ASGName < nodeGroups[j].ASGName
+	})
+	return nil
+}
@@ -1,7 +1,11 @@
 package aws
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 )
 
 func TestNodeGroupInfo_Struct(t *testing.T) {
@@ -36,3 +40,98 @@ func TestNodeGroupInfo_Struct(t *testing.T) {
 // 2. AWS SDK fake/stub clients
 // 3. Integration tests with real AWS
 // These are typically done in integration tests rather than unit tests
+
+func TestNodeGroupFromASG(t *testing.T) {
+	tag := func(key, value string) asgtypes.TagDescription {
+		return asgtypes.TagDescription{Key: aws.String(key), Value: aws.String(value)}
+	}
+
+	tests := []struct {
+		name     string
+		asg      asgtypes.AutoScalingGroup
+		expected ASGNodeGroup
+		ok       bool
+	}{
+		{
+			name: "EKS managed node group",
+			asg: asgtypes.AutoScalingGroup{
+				AutoScalingGroupName: aws.String("eks-workers-1-abc"),
+				MinSize:              aws.Int32(1),
+				MaxSize:              aws.Int32(5),
+				DesiredCapacity:      aws.Int32(2),
+				Instances:            []asgtypes.Instance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}},
+				Tags:                 []asgtypes.TagDescription{tag("eks:nodegroup-name", "workers-1"), tag("eks:cluster-name", "prod")},
+			},
+			expected: ASGNodeGroup{ASGName: "eks-workers-1-abc", NodeGroupName: "workers-1", ClusterName: "prod", MinSize: 1, MaxSize: 5, DesiredSize: 2, InstanceCount: 2},
+			ok:       true,
+		},
+		{
+			name: "eksctl node group with kubernetes.io cluster tag",
+			asg: asgtypes.AutoScalingGroup{
+				AutoScalingGroupName: aws.String("eksctl-prod-ng-batch"),
+				Tags:                 []asgtypes.TagDescription{tag("alpha.eksctl.io/nodegroup-name", "batch"), tag("kubernetes.io/cluster/prod", "owned")},
+			},
+			expected: ASGNodeGroup{ASGName: "eksctl-prod-ng-batch", NodeGroupName: "batch", ClusterName: "prod"},
+			ok:       true,
+		},
+		{
+			name: "no node group tag",
+			asg: asgtypes.AutoScalingGroup{
+				AutoScalingGroupName: aws.String("bastion"),
+				Tags:                 []asgtypes.TagDescription{tag("eks:cluster-name", "prod")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nodeGroupFromASG(tt.asg)
+			if ok != tt.ok {
+				t.Fatalf("nodeGroupFromASG() ok = %v, want %v", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("nodeGroupFromASG() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortASGNodeGroups(t *testing.T) {
+	nodeGroups := func() []ASGNodeGroup {
+		return []ASGNodeGroup{
+			{ASGName: "c", ClusterName: "prod", DesiredSize: 1, InstanceCount: 4},
+			{ASGName: "a", ClusterName: "staging", DesiredSize: 3, InstanceCount: 1},
+			{ASGName: "b", ClusterName: "prod", DesiredSize: 3, InstanceCount: 2},
+		}
+	}
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: NodeGroupSortName, expected: []string{"a", "b", "c"}},
+		{sortBy: NodeGroupSortCluster, expected: []string{"b", "c", "a"}},
+		{sortBy: NodeGroupSortDesired, expected: []string{"a", "b", "c"}},
+		{sortBy: NodeGroupSortInstances, expected: []string{"c", "b", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			groups := nodeGroups()
+			if err := SortASGNodeGroups(groups, tt.sortBy); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var names []string
+			for _, g := range groups {
+				names = append(names, g.ASGName)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("order = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+
+	if err := SortASGNodeGroups(nodeGroups(), "size"); err == nil {
+		t.Error("Expected error for unsupported sort option")
+	}
+}
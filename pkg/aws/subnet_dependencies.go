@@ -0,0 +1,367 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/pischarti/nix/pkg/k8s"
+)
+
+// SubnetDependencyType identifies the kind of resource blocking subnet deletion
+type SubnetDependencyType string
+
+const (
+	DependencyInstance         SubnetDependencyType = "instance"
+	DependencyNetworkInterface SubnetDependencyType = "network-interface"
+	DependencyVPCEndpoint      SubnetDependencyType = "vpc-endpoint"
+	DependencyLoadBalancer     SubnetDependencyType = "load-balancer"
+)
+
+// SubnetDependency is a single resource that prevents a subnet from being deleted
+type SubnetDependency struct {
+	Type SubnetDependencyType
+	// ID is the instance, network interface, or VPC endpoint ID
+	ID string
+	// AttachmentID is set for attached network interfaces
+	AttachmentID string
+	// LoadBalancerName and LoadBalancerType ("net", "app", or "" for classic) are parsed
+	// from the description of load balancer network interfaces
+	LoadBalancerName string
+	LoadBalancerType string
+	// ServiceInfo is a best-effort Kubernetes service hint taken from the ENI description
+	ServiceInfo string
+	// ServiceNamespace and ServiceName are resolved from the load balancer's
+	// kubernetes.io/service-name tag by resolveLoadBalancerServices
+	ServiceNamespace string
+	ServiceName      string
+}
+
+// IsNetworkLoadBalancer reports whether the dependency is an NLB network interface
+func (d SubnetDependency) IsNetworkLoadBalancer() bool {
+	return d.Type == DependencyLoadBalancer && d.LoadBalancerType == "net"
+}
+
+// findSubnetDependencies collects every resource in the subnet that blocks its deletion
+func findSubnetDependencies(ec2Client subnetDescriber, subnetID string) ([]SubnetDependency, error) {
+	ctx := context.TODO()
+	subnetFilter := []types.Filter{
+		{
+			Name:   aws.String("subnet-id"),
+			Values: []string{subnetID},
+		},
+	}
+
+	var dependencies []SubnetDependency
+
+	// Check for EC2 instances
+	instancesResult, err := ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{Filters: subnetFilter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for EC2 instances: %w", err)
+	}
+	for _, reservation := range instancesResult.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil && instance.State.Name != types.InstanceStateNameTerminated {
+				dependencies = append(dependencies, SubnetDependency{
+					Type: DependencyInstance,
+					ID:   aws.ToString(instance.InstanceId),
+				})
+			}
+		}
+	}
+
+	// Check for Network Interfaces (ENIs); load balancer ENIs are reported as load balancers
+	// since they can only be removed by deleting the load balancer
+	eniResult, err := ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{Filters: subnetFilter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for network interfaces: %w", err)
+	}
+	for _, eni := range eniResult.NetworkInterfaces {
+		if dep, ok := networkInterfaceDependency(eni); ok {
+			dependencies = append(dependencies, dep)
+		}
+	}
+
+	// Check for VPC Endpoints
+	endpointsResult, err := ec2Client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{Filters: subnetFilter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for VPC endpoints: %w", err)
+	}
+	for _, endpoint := range endpointsResult.VpcEndpoints {
+		if endpoint.State != types.StateDeleted {
+			dependencies = append(dependencies, SubnetDependency{
+				Type: DependencyVPCEndpoint,
+				ID:   aws.ToString(endpoint.VpcEndpointId),
+			})
+		}
+	}
+
+	return dependencies, nil
+}
+
+// networkInterfaceDependency classifies an ENI as a blocking dependency, if it is one
+func networkInterfaceDependency(eni types.NetworkInterface) (SubnetDependency, bool) {
+	eniID := aws.ToString(eni.NetworkInterfaceId)
+	desc := aws.ToString(eni.Description)
+
+	if lbType, lbName, ok := parseLoadBalancerENIDescription(desc); ok {
+		return SubnetDependency{
+			Type:             DependencyLoadBalancer,
+			ID:               eniID,
+			LoadBalancerName: lbName,
+			LoadBalancerType: lbType,
+			ServiceInfo:      extractServiceInfoFromDescription(desc),
+		}, true
+	}
+
+	if eni.Attachment != nil && eni.Attachment.Status != types.AttachmentStatusDetached {
+		return SubnetDependency{
+			Type:         DependencyNetworkInterface,
+			ID:           eniID,
+			AttachmentID: aws.ToString(eni.Attachment.AttachmentId),
+		}, true
+	}
+
+	return SubnetDependency{}, false
+}
+
+// parseLoadBalancerENIDescription extracts the load balancer type and name from an ENI
+// description such as "ELB net/my-nlb/50dc6c495c0c9188" or "ELB my-classic-elb"
+func parseLoadBalancerENIDescription(desc string) (lbType, lbName string, ok bool) {
+	rest, found := strings.CutPrefix(desc, "ELB ")
+	if !found || rest == "" {
+		return "", "", false
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) == 3 && (parts[0] == "net" || parts[0] == "app" || parts[0] == "gwy") {
+		return parts[0], parts[1], true
+	}
+
+	// Classic load balancers have a bare name
+	return "", rest, true
+}
+
+// resolveLoadBalancerServices looks up each NLB/ALB dependency's load balancer and records
+// the Kubernetes service that owns it, from the kubernetes.io/service-name tag. Lookup
+// failures leave the dependency unresolved.
+func resolveLoadBalancerServices(elbv2Client loadBalancerClient, dependencies []SubnetDependency) {
+	services := make(map[string]serviceRef)
+
+	for i := range dependencies {
+		dep := &dependencies[i]
+		if dep.Type != DependencyLoadBalancer || dep.LoadBalancerType == "" {
+			continue
+		}
+
+		service, seen := services[dep.LoadBalancerName]
+		if !seen {
+			service = lookupLoadBalancerService(elbv2Client, dep.LoadBalancerName)
+			services[dep.LoadBalancerName] = service
+		}
+		dep.ServiceNamespace, dep.ServiceName = service.namespace, service.name
+	}
+}
+
+// serviceRef identifies a Kubernetes service
+type serviceRef struct {
+	namespace string
+	name      string
+}
+
+// lookupLoadBalancerService returns the Kubernetes service tagged on the named load
+// balancer, or an empty serviceRef if there is none
+func lookupLoadBalancerService(elbv2Client loadBalancerClient, lbName string) serviceRef {
+	lbResult, err := elbv2Client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancersInput{
+		Names: []string{lbName},
+	})
+	if err != nil || len(lbResult.LoadBalancers) == 0 {
+		return serviceRef{}
+	}
+
+	// Each load balancer is looked up once per report, so its tags are not cached
+	tags := getLoadBalancerTags(elbv2Client, nil, lbResult.LoadBalancers[0].LoadBalancerArn)
+	namespace, name, ok := k8s.ParseServiceNameTag(findTagValue(tags, k8s.ServiceNameTag))
+	if !ok {
+		return serviceRef{}
+	}
+	return serviceRef{namespace: namespace, name: name}
+}
+
+// remediationCommand returns the command that removes a blocking dependency
+func remediationCommand(dep SubnetDependency) string {
+	switch dep.Type {
+	case DependencyInstance:
+		return fmt.Sprintf("aws ec2 terminate-instances --instance-ids %s", dep.ID)
+	case DependencyNetworkInterface:
+		if dep.AttachmentID != "" {
+			return fmt.Sprintf("aws ec2 detach-network-interface --attachment-id %s && aws ec2 delete-network-interface --network-interface-id %s", dep.AttachmentID, dep.ID)
+		}
+		return fmt.Sprintf("aws ec2 delete-network-interface --network-interface-id %s", dep.ID)
+	case DependencyVPCEndpoint:
+		return fmt.Sprintf("aws ec2 delete-vpc-endpoints --vpc-endpoint-ids %s", dep.ID)
+	case DependencyLoadBalancer:
+		// Load balancers owned by a Kubernetes service must be removed through the service,
+		// otherwise the controller recreates them
+		if dep.ServiceName != "" {
+			return fmt.Sprintf("kubectl delete service %s -n %s", dep.ServiceName, dep.ServiceNamespace)
+		}
+		if dep.LoadBalancerType == "" {
+			return fmt.Sprintf("aws elb delete-load-balancer --load-balancer-name %s", dep.LoadBalancerName)
+		}
+		return fmt.Sprintf("aws elbv2 delete-load-balancer --load-balancer-arn $(aws elbv2 describe-load-balancers --names %s --query 'LoadBalancers[0].LoadBalancerArn' --output text)", dep.LoadBalancerName)
+	}
+	return ""
+}
+
+// describeDependency returns a one-line description of a blocking dependency
+func describeDependency(dep SubnetDependency) string {
+	switch dep.Type {
+	case DependencyInstance:
+		return fmt.Sprintf("EC2 instance %s", dep.ID)
+	case DependencyNetworkInterface:
+		return fmt.Sprintf("Network interface %s", dep.ID)
+	case DependencyVPCEndpoint:
+		return fmt.Sprintf("VPC endpoint %s", dep.ID)
+	case DependencyLoadBalancer:
+		kind := "Load balancer"
+		switch dep.LoadBalancerType {
+		case "net":
+			kind = "Network Load Balancer"
+		case "app":
+			kind = "Application Load Balancer"
+		case "gwy":
+			kind = "Gateway Load Balancer"
+		}
+		description := fmt.Sprintf("%s %s (network interface %s)", kind, dep.LoadBalancerName, dep.ID)
+		if dep.ServiceName != "" {
+			description += fmt.Sprintf(", owned by Kubernetes service %s/%s", dep.ServiceNamespace, dep.ServiceName)
+		}
+		return description
+	}
+	return dep.ID
+}
+
+// SubnetBlocker is a blocking dependency in the form reported by check-dependencies
+type SubnetBlocker struct {
+	Type        SubnetDependencyType `json:"type"`
+	ID          string               `json:"id"`
+	Detail      string               `json:"detail"`
+	Remediation string               `json:"remediation"`
+
+	dependency SubnetDependency
+}
+
+// SubnetDependencyReport is the structured result of check-dependencies --output json
+type SubnetDependencyReport struct {
+	SubnetID  string          `json:"subnetId"`
+	VPCID     string          `json:"vpcId"`
+	CIDR      string          `json:"cidr"`
+	AZ        string          `json:"az"`
+	State     string          `json:"state"`
+	CanDelete bool            `json:"canDelete"`
+	Blockers  []SubnetBlocker `json:"blockers"`
+}
+
+// blockerCategory describes a group of blockers that are removed the same way
+type blockerCategory struct {
+	heading  string
+	guidance string
+}
+
+// blockerCategories lists the categories in the order their blockers have to be removed
+var blockerCategories = []blockerCategory{
+	{heading: "subnet has running EC2 instances", guidance: "Please terminate these instances first"},
+	{heading: "subnet has attached network interfaces", guidance: "Please detach these interfaces first"},
+	{heading: "subnet has VPC endpoints", guidance: "Please delete these endpoints first"},
+	{
+		heading:  "subnet has Network Load Balancer (NLB) network interfaces",
+		guidance: "These ENIs are managed by Kubernetes services and cannot be manually detached.\nPlease delete the associated NLB services first (e.g., via kubectl delete service <service-name>)",
+	},
+	{heading: "subnet has load balancer network interfaces", guidance: "Please delete the associated load balancers first"},
+}
+
+// blockerCategoryIndex returns the index in blockerCategories of the dependency's category
+func blockerCategoryIndex(dep SubnetDependency) int {
+	switch {
+	case dep.Type == DependencyInstance:
+		return 0
+	case dep.Type == DependencyNetworkInterface:
+		return 1
+	case dep.Type == DependencyVPCEndpoint:
+		return 2
+	case dep.IsNetworkLoadBalancer():
+		return 3
+	}
+	return 4
+}
+
+// subnetBlockers converts dependencies to blockers, ordered by the category that has to be
+// removed first
+func subnetBlockers(dependencies []SubnetDependency) []SubnetBlocker {
+	blockers := make([]SubnetBlocker, 0, len(dependencies))
+	for _, dep := range dependencies {
+		blockers = append(blockers, SubnetBlocker{
+			Type:        dep.Type,
+			ID:          dep.ID,
+			Detail:      describeDependency(dep),
+			Remediation: remediationCommand(dep),
+			dependency:  dep,
+		})
+	}
+
+	sort.SliceStable(blockers, func(i, j int) bool {
+		return blockerCategoryIndex(blockers[i].dependency) < blockerCategoryIndex(blockers[j].dependency)
+	})
+	return blockers
+}
+
+// subnetBlockersError reports the first category of blockers with guidance for removing
+// them, or nil when there are none
+func subnetBlockersError(blockers []SubnetBlocker) error {
+	if len(blockers) == 0 {
+		return nil
+	}
+
+	category := blockerCategoryIndex(blockers[0].dependency)
+	var ids []string
+	for _, blocker := range blockers {
+		if blockerCategoryIndex(blocker.dependency) != category {
+			break
+		}
+		if blocker.dependency.IsNetworkLoadBalancer() && blocker.dependency.ServiceInfo != "" {
+			ids = append(ids, fmt.Sprintf("%s (%s)", blocker.ID, blocker.dependency.ServiceInfo))
+		} else {
+			ids = append(ids, blocker.ID)
+		}
+	}
+
+	return fmt.Errorf("%s:\n   %s\n%s", blockerCategories[category].heading, strings.Join(ids, "\n   "), blockerCategories[category].guidance)
+}
+
+// newSubnetDependencyReport describes the subnet and every resource blocking its deletion.
+// Load balancers are resolved to their Kubernetes services so that the remediation removes
+// the service rather than a load balancer its controller would recreate.
+func newSubnetDependencyReport(ec2Client subnetDescriber, elbv2Client loadBalancerClient, subnet types.Subnet) (*SubnetDependencyReport, error) {
+	dependencies, err := findSubnetDependencies(ec2Client, aws.ToString(subnet.SubnetId))
+	if err != nil {
+		return nil, err
+	}
+	resolveLoadBalancerServices(elbv2Client, dependencies)
+
+	blockers := subnetBlockers(dependencies)
+	return &SubnetDependencyReport{
+		SubnetID:  aws.ToString(subnet.SubnetId),
+		VPCID:     aws.ToString(subnet.VpcId),
+		CIDR:      aws.ToString(subnet.CidrBlock),
+		AZ:        aws.ToString(subnet.AvailabilityZone),
+		State:     string(subnet.State),
+		CanDelete: len(blockers) == 0,
+		Blockers:  blockers,
+	}, nil
+}
@@ -0,0 +1,303 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+func TestParseLoadBalancerENIDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		desc     string
+		wantType string
+		wantName string
+		wantOK   bool
+	}{
+		{
+			name:     "network load balancer",
+			desc:     "ELB net/k8s-default-web-6ed31c790f/e26bb4a60f1986ae",
+			wantType: "net",
+			wantName: "k8s-default-web-6ed31c790f",
+			wantOK:   true,
+		},
+		{
+			name:     "application load balancer",
+			desc:     "ELB app/my-alb/50dc6c495c0c9188",
+			wantType: "app",
+			wantName: "my-alb",
+			wantOK:   true,
+		},
+		{
+			name:     "classic load balancer",
+			desc:     "ELB my-classic-elb",
+			wantType: "",
+			wantName: "my-classic-elb",
+			wantOK:   true,
+		},
+		{
+			name:   "regular interface",
+			desc:   "Primary network interface",
+			wantOK: false,
+		},
+		{
+			name:   "empty description",
+			desc:   "",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lbType, lbName, ok := parseLoadBalancerENIDescription(tt.desc)
+			if ok != tt.wantOK || lbType != tt.wantType || lbName != tt.wantName {
+				t.Errorf("parseLoadBalancerENIDescription(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.desc, lbType, lbName, ok, tt.wantType, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNetworkInterfaceDependency(t *testing.T) {
+	tests := []struct {
+		name     string
+		eni      types.NetworkInterface
+		expected SubnetDependency
+		wantOK   bool
+	}{
+		{
+			name: "attached interface",
+			eni: types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-123"),
+				Description:        aws.String("Primary network interface"),
+				Attachment: &types.NetworkInterfaceAttachment{
+					AttachmentId: aws.String("eni-attach-123"),
+					Status:       types.AttachmentStatusAttached,
+				},
+			},
+			expected: SubnetDependency{Type: DependencyNetworkInterface, ID: "eni-123", AttachmentID: "eni-attach-123"},
+			wantOK:   true,
+		},
+		{
+			name: "detached interface",
+			eni: types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-456"),
+				Attachment:         &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusDetached},
+			},
+			wantOK: false,
+		},
+		{
+			name: "nlb interface is a load balancer dependency",
+			eni: types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-789"),
+				Description:        aws.String("ELB net/my-nlb/50dc6c495c0c9188"),
+				Attachment: &types.NetworkInterfaceAttachment{
+					AttachmentId: aws.String("ela-attach-789"),
+					Status:       types.AttachmentStatusAttached,
+				},
+			},
+			expected: SubnetDependency{Type: DependencyLoadBalancer, ID: "eni-789", LoadBalancerName: "my-nlb", LoadBalancerType: "net"},
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep, ok := networkInterfaceDependency(tt.eni)
+			if ok != tt.wantOK || dep != tt.expected {
+				t.Errorf("networkInterfaceDependency() = (%+v, %v), want (%+v, %v)", dep, ok, tt.expected, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRemediationCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		dep      SubnetDependency
+		expected string
+	}{
+		{
+			name:     "instance",
+			dep:      SubnetDependency{Type: DependencyInstance, ID: "i-0123456789abcdef0"},
+			expected: "aws ec2 terminate-instances --instance-ids i-0123456789abcdef0",
+		},
+		{
+			name:     "attached network interface",
+			dep:      SubnetDependency{Type: DependencyNetworkInterface, ID: "eni-123", AttachmentID: "eni-attach-123"},
+			expected: "aws ec2 detach-network-interface --attachment-id eni-attach-123 && aws ec2 delete-network-interface --network-interface-id eni-123",
+		},
+		{
+			name:     "vpc endpoint",
+			dep:      SubnetDependency{Type: DependencyVPCEndpoint, ID: "vpce-123"},
+			expected: "aws ec2 delete-vpc-endpoints --vpc-endpoint-ids vpce-123",
+		},
+		{
+			name: "kubernetes service load balancer",
+			dep: SubnetDependency{
+				Type:             DependencyLoadBalancer,
+				ID:               "eni-789",
+				LoadBalancerName: "k8s-default-web-6ed31c790f",
+				LoadBalancerType: "net",
+				ServiceNamespace: "default",
+				ServiceName:      "web",
+			},
+			expected: "kubectl delete service web -n default",
+		},
+		{
+			name:     "unowned network load balancer",
+			dep:      SubnetDependency{Type: DependencyLoadBalancer, ID: "eni-789", LoadBalancerName: "my-nlb", LoadBalancerType: "net"},
+			expected: "aws elbv2 delete-load-balancer --load-balancer-arn $(aws elbv2 describe-load-balancers --names my-nlb --query 'LoadBalancers[0].LoadBalancerArn' --output text)",
+		},
+		{
+			name:     "classic load balancer",
+			dep:      SubnetDependency{Type: DependencyLoadBalancer, ID: "eni-999", LoadBalancerName: "my-classic-elb"},
+			expected: "aws elb delete-load-balancer --load-balancer-name my-classic-elb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remediationCommand(tt.dep); got != tt.expected {
+				t.Errorf("remediationCommand() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCheckDependenciesArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected CheckDependenciesOptions
+	}{
+		{
+			name:     "subnet only",
+			args:     []string{"subnets", "check-dependencies", "--subnet-id", "subnet-123"},
+			expected: CheckDependenciesOptions{SubnetID: "subnet-123", OutputFormat: "text"},
+		},
+		{
+			name:     "explain",
+			args:     []string{"subnets", "check-dependencies", "--explain", "--subnet-id", "subnet-123"},
+			expected: CheckDependenciesOptions{SubnetID: "subnet-123", Explain: true, OutputFormat: "text"},
+		},
+		{
+			name:     "explain with verbose",
+			args:     []string{"subnets", "check-dependencies", "--subnet-id", "subnet-123", "--explain", "-v"},
+			expected: CheckDependenciesOptions{SubnetID: "subnet-123", Explain: true, OutputFormat: "text", Verbose: true},
+		},
+		{
+			name:     "json output",
+			args:     []string{"subnets", "check-dependencies", "--subnet-id", "subnet-123", "--output", "json"},
+			expected: CheckDependenciesOptions{SubnetID: "subnet-123", OutputFormat: "json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseCheckDependenciesArgs(tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if *opts != tt.expected {
+				t.Errorf("parseCheckDependenciesArgs() = %+v, want %+v", *opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCheckDependenciesArgsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown output", args: []string{"subnets", "check-dependencies", "--subnet-id", "subnet-123", "--output", "yaml"}},
+		{name: "explain with json", args: []string{"subnets", "check-dependencies", "--subnet-id", "subnet-123", "--explain", "--output", "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCheckDependenciesArgs(tt.args); err == nil {
+				t.Error("parseCheckDependenciesArgs() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestNewSubnetDependencyReport(t *testing.T) {
+	subnet := types.Subnet{
+		SubnetId:         aws.String("subnet-1"),
+		VpcId:            aws.String("vpc-1"),
+		CidrBlock:        aws.String("10.0.1.0/24"),
+		AvailabilityZone: aws.String("us-east-1a"),
+		State:            types.SubnetStateAvailable,
+	}
+	ec2Client := &fakeEC2{
+		enis: []types.NetworkInterface{
+			{NetworkInterfaceId: aws.String("eni-nlb"), Description: aws.String("ELB net/k8s-default-web-abc123/def456")},
+		},
+		endpoints: []types.VpcEndpoint{{VpcEndpointId: aws.String("vpce-1"), State: types.StateAvailable}},
+		instances: []types.Instance{
+			{InstanceId: aws.String("i-123"), State: &types.InstanceState{Name: types.InstanceStateNameRunning}},
+		},
+	}
+	elbv2Client := &fakeELBv2{
+		loadBalancers: []elbv2types.LoadBalancer{{LoadBalancerArn: aws.String("arn:nlb")}},
+		tags: map[string][]elbv2types.Tag{
+			"arn:nlb": {{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("default/web")}},
+		},
+	}
+
+	report, err := newSubnetDependencyReport(ec2Client, elbv2Client, subnet)
+	if err != nil {
+		t.Fatalf("newSubnetDependencyReport() error = %v", err)
+	}
+
+	if report.CanDelete {
+		t.Error("CanDelete = true, want false")
+	}
+	wantIDs := []string{"i-123", "vpce-1", "eni-nlb"}
+	if len(report.Blockers) != len(wantIDs) {
+		t.Fatalf("got %d blockers, want %d: %+v", len(report.Blockers), len(wantIDs), report.Blockers)
+	}
+	for i, id := range wantIDs {
+		if report.Blockers[i].ID != id {
+			t.Errorf("blocker %d = %s, want %s", i, report.Blockers[i].ID, id)
+		}
+	}
+	if got := report.Blockers[2].Remediation; got != "kubectl delete service web -n default" {
+		t.Errorf("NLB remediation = %q, want the kubectl command", got)
+	}
+
+	var buf bytes.Buffer
+	if err := printSubnetDependencyReportJSON(&buf, report); err != nil {
+		t.Fatalf("printSubnetDependencyReportJSON() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	for key, want := range map[string]any{"subnetId": "subnet-1", "vpcId": "vpc-1", "cidr": "10.0.1.0/24", "az": "us-east-1a", "state": "available", "canDelete": false} {
+		if decoded[key] != want {
+			t.Errorf("%s = %v, want %v", key, decoded[key], want)
+		}
+	}
+	blocker := decoded["blockers"].([]any)[0].(map[string]any)
+	if blocker["type"] != "instance" || blocker["detail"] != "EC2 instance i-123" || blocker["remediation"] != "aws ec2 terminate-instances --instance-ids i-123" {
+		t.Errorf("first blocker = %v", blocker)
+	}
+}
+
+func TestPrintSubnetDependencyReportJSONDeletable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printSubnetDependencyReportJSON(&buf, &SubnetDependencyReport{SubnetID: "subnet-1", CanDelete: true}); err != nil {
+		t.Fatalf("printSubnetDependencyReportJSON() error = %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"blockers": []`)) {
+		t.Errorf("output = %s, want an empty blockers array", buf.String())
+	}
+}
@@ -0,0 +1,309 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/pischarti/nix/pkg/cli"
+	"gofr.dev/pkg/gofr"
+)
+
+// ENIOwner identifies the kind of resource that owns a network interface
+type ENIOwner string
+
+const (
+	ENIOwnerInstance     ENIOwner = "instance"
+	ENIOwnerLoadBalancer ENIOwner = "load-balancer"
+	ENIOwnerECS          ENIOwner = "ecs"
+	ENIOwnerLambda       ENIOwner = "lambda"
+	ENIOwnerVPCEndpoint  ENIOwner = "vpc-endpoint"
+	ENIOwnerRDS          ENIOwner = "rds"
+	ENIOwnerOther        ENIOwner = "other"
+)
+
+// eniOwnerOrder is the order categories are reported in
+var eniOwnerOrder = []ENIOwner{
+	ENIOwnerInstance,
+	ENIOwnerLoadBalancer,
+	ENIOwnerECS,
+	ENIOwnerLambda,
+	ENIOwnerVPCEndpoint,
+	ENIOwnerRDS,
+	ENIOwnerOther,
+}
+
+// classifyNetworkInterface returns the kind of resource that owns an ENI, from its
+// interface type, requester, description and attachment
+func classifyNetworkInterface(eni types.NetworkInterface) ENIOwner {
+	desc := aws.ToString(eni.Description)
+	requester := aws.ToString(eni.RequesterId)
+
+	switch {
+	case eni.InterfaceType == types.NetworkInterfaceTypeVpcEndpoint ||
+		eni.InterfaceType == types.NetworkInterfaceTypeGatewayLoadBalancerEndpoint ||
+		strings.HasPrefix(desc, "VPC Endpoint Interface"):
+		return ENIOwnerVPCEndpoint
+	case eni.InterfaceType == types.NetworkInterfaceTypeLambda ||
+		strings.HasPrefix(desc, "AWS Lambda VPC ENI"):
+		return ENIOwnerLambda
+	case eni.InterfaceType == types.NetworkInterfaceTypeNetworkLoadBalancer ||
+		eni.InterfaceType == types.NetworkInterfaceTypeGatewayLoadBalancer ||
+		eni.InterfaceType == types.NetworkInterfaceTypeLoadBalancer:
+		return ENIOwnerLoadBalancer
+	case requester == "amazon-rds" || strings.HasPrefix(desc, "RDSNetworkInterface"):
+		return ENIOwnerRDS
+	case strings.HasPrefix(desc, "arn:aws:ecs:"):
+		// awsvpc-mode ECS tasks describe their ENI with the task attachment ARN
+		return ENIOwnerECS
+	}
+
+	if _, _, ok := parseLoadBalancerENIDescription(desc); ok {
+		return ENIOwnerLoadBalancer
+	}
+	if eni.Attachment != nil && aws.ToString(eni.Attachment.InstanceId) != "" {
+		return ENIOwnerInstance
+	}
+	return ENIOwnerOther
+}
+
+// eniOwnerTitle returns the report heading for an ENI owner category
+func eniOwnerTitle(owner ENIOwner) string {
+	switch owner {
+	case ENIOwnerInstance:
+		return "EC2 instances"
+	case ENIOwnerLoadBalancer:
+		return "Load balancers"
+	case ENIOwnerECS:
+		return "ECS tasks"
+	case ENIOwnerLambda:
+		return "Lambda functions"
+	case ENIOwnerVPCEndpoint:
+		return "VPC endpoints"
+	case ENIOwnerRDS:
+		return "RDS instances"
+	default:
+		return "Other network interfaces"
+	}
+}
+
+// eniOwnerRemediation returns guidance for moving a category of ENIs out of a subnet
+func eniOwnerRemediation(owner ENIOwner) string {
+	switch owner {
+	case ENIOwnerInstance:
+		return "Terminate or replace the instances; for node groups, remove the subnet from the group and recycle its nodes."
+	case ENIOwnerLoadBalancer:
+		return "Remove the subnet from the load balancer (aws nlb remove-subnet) or delete the Kubernetes service that owns it."
+	case ENIOwnerECS:
+		return "Remove the subnet from the ECS service's network configuration and force a new deployment."
+	case ENIOwnerLambda:
+		return "Remove the subnet from the function's VPC configuration. Lambda releases the ENIs after a delay."
+	case ENIOwnerVPCEndpoint:
+		return "Remove the subnet from the endpoint (aws ec2 modify-vpc-endpoint --remove-subnet-ids) or delete the endpoint."
+	case ENIOwnerRDS:
+		return "Remove the subnet from the DB subnet group and fail over or modify the instance so it moves to another subnet."
+	default:
+		return "Detach and delete the interfaces, or identify their owner from the description."
+	}
+}
+
+// classifiedENI is a network interface with the owner it was classified as
+type classifiedENI struct {
+	ID          string
+	Owner       ENIOwner
+	Description string
+	// Detail names the owning resource when it can be determined from the ENI
+	Detail string
+	// Dependency is set for load balancer ENIs so the owning Kubernetes service can be resolved
+	Dependency *SubnetDependency
+}
+
+// classifyNetworkInterfaces classifies each ENI and groups them by owner
+func classifyNetworkInterfaces(enis []types.NetworkInterface) map[ENIOwner][]classifiedENI {
+	groups := make(map[ENIOwner][]classifiedENI)
+	for _, eni := range enis {
+		owner := classifyNetworkInterface(eni)
+		desc := aws.ToString(eni.Description)
+		classified := classifiedENI{
+			ID:          aws.ToString(eni.NetworkInterfaceId),
+			Owner:       owner,
+			Description: desc,
+		}
+
+		switch owner {
+		case ENIOwnerInstance:
+			classified.Detail = aws.ToString(eni.Attachment.InstanceId)
+		case ENIOwnerLoadBalancer:
+			if dep, ok := networkInterfaceDependency(eni); ok && dep.Type == DependencyLoadBalancer {
+				classified.Dependency = &dep
+			}
+			classified.Detail = extractServiceInfoFromDescription(desc)
+		}
+
+		groups[owner] = append(groups[owner], classified)
+	}
+	return groups
+}
+
+// MoveENIsOptions represents the parsed command line options for the move-enis command
+type MoveENIsOptions struct {
+	SubnetID string
+	Verbose  bool
+}
+
+// parseMoveENIsArgs parses command line arguments for the move-enis command
+func parseMoveENIsArgs(args []string) (*MoveENIsOptions, error) {
+	opts := &MoveENIsOptions{}
+
+	fs := cli.NewFlagSet("move-enis")
+	fs.StringVar(&opts.SubnetID, "subnet-id", "", "subnet ID to report network interfaces for")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	if opts.SubnetID == "" {
+		return nil, fmt.Errorf("subnet-id parameter is required")
+	}
+
+	return opts, nil
+}
+
+// MoveSubnetENIs handles the move-enis command, which reports every network interface in a
+// subnet grouped by the resource that owns it, with what to do to move each group elsewhere
+func MoveSubnetENIs(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws subnets move-enis --subnet-id SUBNET_ID")
+			fmt.Println("Options:")
+			fmt.Println("  --subnet-id SUBNET_ID  Subnet ID to report network interfaces for (required)")
+			fmt.Println("  --verbose, -v          Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command lists every network interface in the subnet, grouped by the resource")
+			fmt.Println("that owns it (EC2 instance, load balancer, ECS, Lambda, VPC endpoint, RDS), with")
+			fmt.Println("the steps that move each group out of the subnet. Nothing is changed.")
+			return nil, nil
+		}
+	}
+
+	opts, err := parseMoveENIsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	var enis []types.NetworkInterface
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(ec2Client, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("subnet-id"),
+				Values: []string{opts.SubnetID},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe network interfaces in subnet %s: %w", opts.SubnetID, err)
+		}
+		enis = append(enis, page.NetworkInterfaces...)
+	}
+
+	if len(enis) == 0 {
+		fmt.Printf("✅ No network interfaces found in subnet %s\n", opts.SubnetID)
+		return nil, nil
+	}
+
+	groups := classifyNetworkInterfaces(enis)
+	resolveClassifiedLoadBalancerServices(elasticloadbalancingv2.NewFromConfig(cfg), groups[ENIOwnerLoadBalancer])
+
+	fmt.Printf("Found %d network interface(s) in subnet %s\n", len(enis), opts.SubnetID)
+	printENIReport(groups)
+
+	return nil, nil
+}
+
+// resolveClassifiedLoadBalancerServices records the Kubernetes service that owns each
+// load balancer ENI, when the load balancer is tagged with one
+func resolveClassifiedLoadBalancerServices(elbv2Client loadBalancerClient, enis []classifiedENI) {
+	var dependencies []SubnetDependency
+	for _, eni := range enis {
+		if eni.Dependency != nil {
+			dependencies = append(dependencies, *eni.Dependency)
+		}
+	}
+	if len(dependencies) == 0 {
+		return
+	}
+
+	resolveLoadBalancerServices(elbv2Client, dependencies)
+
+	i := 0
+	for _, eni := range enis {
+		if eni.Dependency != nil {
+			*eni.Dependency = dependencies[i]
+			i++
+		}
+	}
+}
+
+// printENIReport prints the classified network interfaces category by category
+func printENIReport(groups map[ENIOwner][]classifiedENI) {
+	for _, owner := range eniOwnerOrder {
+		enis := groups[owner]
+		if len(enis) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s (%d):\n", eniOwnerTitle(owner), len(enis))
+		for _, eni := range enis {
+			fmt.Printf("   %s\n", describeClassifiedENI(eni))
+		}
+		fmt.Printf("   → %s\n", eniOwnerRemediation(owner))
+
+		// A load balancer has one ENI per zone, so print each service's command once
+		printed := make(map[string]bool)
+		for _, eni := range enis {
+			if eni.Dependency == nil || eni.Dependency.ServiceName == "" {
+				continue
+			}
+			command := remediationCommand(*eni.Dependency)
+			if !printed[command] {
+				printed[command] = true
+				fmt.Printf("     %s\n", command)
+			}
+		}
+	}
+}
+
+// describeClassifiedENI returns a one-line description of a classified network interface
+func describeClassifiedENI(eni classifiedENI) string {
+	if eni.Dependency != nil {
+		return describeDependency(*eni.Dependency)
+	}
+
+	line := eni.ID
+	if eni.Detail != "" {
+		line += fmt.Sprintf(" (%s)", eni.Detail)
+	}
+	if eni.Description != "" {
+		line += fmt.Sprintf(" - %s", eni.Description)
+	}
+	return line
+}
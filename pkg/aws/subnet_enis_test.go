@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestClassifyNetworkInterface(t *testing.T) {
+	tests := []struct {
+		name string
+		eni  types.NetworkInterface
+		want ENIOwner
+	}{
+		{
+			name: "instance primary interface",
+			eni: types.NetworkInterface{
+				Description:   aws.String("Primary network interface"),
+				InterfaceType: types.NetworkInterfaceTypeInterface,
+				Attachment:    &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678")},
+			},
+			want: ENIOwnerInstance,
+		},
+		{
+			name: "network load balancer",
+			eni: types.NetworkInterface{
+				Description:   aws.String("ELB net/k8s-default-web-6ed31c790f/e26bb4a60f1986ae"),
+				InterfaceType: types.NetworkInterfaceTypeNetworkLoadBalancer,
+			},
+			want: ENIOwnerLoadBalancer,
+		},
+		{
+			name: "classic load balancer",
+			eni: types.NetworkInterface{
+				Description:   aws.String("ELB my-classic-elb"),
+				InterfaceType: types.NetworkInterfaceTypeInterface,
+				RequesterId:   aws.String("amazon-elb"),
+			},
+			want: ENIOwnerLoadBalancer,
+		},
+		{
+			name: "ecs task",
+			eni: types.NetworkInterface{
+				Description:   aws.String("arn:aws:ecs:us-east-1:123456789012:attachment/0a1b2c3d-aaaa-bbbb-cccc-0123456789ab"),
+				InterfaceType: types.NetworkInterfaceTypeInterface,
+			},
+			want: ENIOwnerECS,
+		},
+		{
+			name: "lambda function",
+			eni: types.NetworkInterface{
+				Description:   aws.String("AWS Lambda VPC ENI-my-function-0a1b2c3d"),
+				InterfaceType: types.NetworkInterfaceTypeLambda,
+			},
+			want: ENIOwnerLambda,
+		},
+		{
+			name: "vpc endpoint",
+			eni: types.NetworkInterface{
+				Description:   aws.String("VPC Endpoint Interface vpce-0123456789abcdef0"),
+				InterfaceType: types.NetworkInterfaceTypeVpcEndpoint,
+			},
+			want: ENIOwnerVPCEndpoint,
+		},
+		{
+			name: "rds instance",
+			eni: types.NetworkInterface{
+				Description:   aws.String("RDSNetworkInterface"),
+				InterfaceType: types.NetworkInterfaceTypeInterface,
+				RequesterId:   aws.String("amazon-rds"),
+			},
+			want: ENIOwnerRDS,
+		},
+		{
+			name: "unattached interface",
+			eni: types.NetworkInterface{
+				Description:   aws.String("leftover"),
+				InterfaceType: types.NetworkInterfaceTypeInterface,
+			},
+			want: ENIOwnerOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyNetworkInterface(tt.eni); got != tt.want {
+				t.Errorf("classifyNetworkInterface() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyNetworkInterfaces(t *testing.T) {
+	enis := []types.NetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-instance"),
+			Attachment:         &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678")},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-nlb"),
+			Description:        aws.String("ELB net/k8s-default-web-6ed31c790f/e26bb4a60f1986ae"),
+			InterfaceType:      types.NetworkInterfaceTypeNetworkLoadBalancer,
+		},
+	}
+
+	groups := classifyNetworkInterfaces(enis)
+
+	instances := groups[ENIOwnerInstance]
+	if len(instances) != 1 || instances[0].Detail != "i-12345678" {
+		t.Errorf("instance group = %+v, want one ENI owned by i-12345678", instances)
+	}
+
+	lbs := groups[ENIOwnerLoadBalancer]
+	if len(lbs) != 1 || lbs[0].Dependency == nil {
+		t.Fatalf("load balancer group = %+v, want one ENI with a dependency", lbs)
+	}
+	if lbs[0].Dependency.LoadBalancerName != "k8s-default-web-6ed31c790f" {
+		t.Errorf("LoadBalancerName = %q, want %q", lbs[0].Dependency.LoadBalancerName, "k8s-default-web-6ed31c790f")
+	}
+}
@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pischarti/nix/pkg/vpc"
+)
+
+// resolveSubnetRouting sets the RouteTableID and Routing of each subnet. Subnets without an
+// explicit route table association use the main route table of their VPC.
+func resolveSubnetRouting(ctx context.Context, ec2Client routeTableDescriber, subnets []vpc.SubnetInfo) error {
+	if len(subnets) == 0 {
+		return nil
+	}
+
+	subnetIDs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		subnetIDs[i] = subnet.SubnetID
+	}
+
+	associated, err := describeRouteTables(ctx, ec2Client, types.Filter{
+		Name:   aws.String("association.subnet-id"),
+		Values: subnetIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	bySubnet := make(map[string]types.RouteTable)
+	for _, routeTable := range associated {
+		for _, assoc := range routeTable.Associations {
+			if subnetID := aws.ToString(assoc.SubnetId); subnetID != "" {
+				bySubnet[subnetID] = routeTable
+			}
+		}
+	}
+
+	// Only look up main route tables for the VPCs of subnets that rely on them
+	var vpcIDs []string
+	for _, subnet := range subnets {
+		if _, ok := bySubnet[subnet.SubnetID]; !ok && !slices.Contains(vpcIDs, subnet.VPCID) {
+			vpcIDs = append(vpcIDs, subnet.VPCID)
+		}
+	}
+	mainTables := make(map[string]types.RouteTable)
+	if len(vpcIDs) > 0 {
+		routeTables, err := describeRouteTables(ctx, ec2Client,
+			types.Filter{Name: aws.String("vpc-id"), Values: vpcIDs},
+			types.Filter{Name: aws.String("association.main"), Values: []string{"true"}},
+		)
+		if err != nil {
+			return err
+		}
+		for _, routeTable := range routeTables {
+			mainTables[aws.ToString(routeTable.VpcId)] = routeTable
+		}
+	}
+
+	for i := range subnets {
+		routeTable, ok := bySubnet[subnets[i].SubnetID]
+		if !ok {
+			mainTable, ok := mainTables[subnets[i].VPCID]
+			if !ok {
+				subnets[i].RouteTableID = "-"
+				subnets[i].Routing = vpc.RoutingIsolated
+				continue
+			}
+			routeTable = mainTable
+		}
+		subnets[i].RouteTableID = aws.ToString(routeTable.RouteTableId)
+		subnets[i].Routing = vpc.ClassifyRouteTable(routeTable)
+	}
+
+	return nil
+}
+
+// describeRouteTables returns every route table matching the filters, following pagination
+func describeRouteTables(ctx context.Context, ec2Client routeTableDescriber, filters ...types.Filter) ([]types.RouteTable, error) {
+	var routeTables []types.RouteTable
+	paginator := ec2.NewDescribeRouteTablesPaginator(ec2Client, &ec2.DescribeRouteTablesInput{Filters: filters})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe route tables: %w", err)
+		}
+		routeTables = append(routeTables, page.RouteTables...)
+	}
+	return routeTables, nil
+}
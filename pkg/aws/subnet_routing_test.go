@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pischarti/nix/pkg/vpc"
+)
+
+// fakeRouteTables serves canned route tables, honoring the association filters
+type fakeRouteTables struct {
+	routeTables []ec2types.RouteTable
+	calls       int
+}
+
+func (f *fakeRouteTables) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	f.calls++
+	var out []ec2types.RouteTable
+	for _, routeTable := range f.routeTables {
+		if routeTableMatches(routeTable, params.Filters) {
+			out = append(out, routeTable)
+		}
+	}
+	return &ec2.DescribeRouteTablesOutput{RouteTables: out}, nil
+}
+
+// routeTableMatches applies the association.subnet-id and association.main filters
+func routeTableMatches(routeTable ec2types.RouteTable, filters []ec2types.Filter) bool {
+	for _, filter := range filters {
+		matched := false
+		for _, assoc := range routeTable.Associations {
+			switch aws.ToString(filter.Name) {
+			case "association.subnet-id":
+				matched = matched || slices.Contains(filter.Values, aws.ToString(assoc.SubnetId))
+			case "association.main":
+				matched = matched || aws.ToBool(assoc.Main)
+			default:
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResolveSubnetRouting(t *testing.T) {
+	defaultRoute := func(route ec2types.Route) []ec2types.Route {
+		route.DestinationCidrBlock = aws.String("0.0.0.0/0")
+		route.State = ec2types.RouteStateActive
+		return []ec2types.Route{route}
+	}
+	client := &fakeRouteTables{routeTables: []ec2types.RouteTable{
+		{
+			RouteTableId: aws.String("rtb-main"),
+			VpcId:        aws.String("vpc-1"),
+			Associations: []ec2types.RouteTableAssociation{{Main: aws.Bool(true)}},
+		},
+		{
+			RouteTableId: aws.String("rtb-public"),
+			Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+			Routes:       defaultRoute(ec2types.Route{GatewayId: aws.String("igw-1")}),
+		},
+		{
+			RouteTableId: aws.String("rtb-private"),
+			Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+			Routes:       defaultRoute(ec2types.Route{NatGatewayId: aws.String("nat-1")}),
+		},
+	}}
+
+	subnets := []vpc.SubnetInfo{
+		{SubnetID: "subnet-public", VPCID: "vpc-1"},
+		{SubnetID: "subnet-private", VPCID: "vpc-1"},
+		{SubnetID: "subnet-unassociated", VPCID: "vpc-1"},
+		{SubnetID: "subnet-other-vpc", VPCID: "vpc-2"},
+	}
+	if err := resolveSubnetRouting(context.Background(), client, subnets); err != nil {
+		t.Fatalf("resolveSubnetRouting() error = %v", err)
+	}
+
+	want := map[string][2]string{
+		"subnet-public":       {"rtb-public", vpc.RoutingPublic},
+		"subnet-private":      {"rtb-private", vpc.RoutingPrivate},
+		"subnet-unassociated": {"rtb-main", vpc.RoutingIsolated},
+		"subnet-other-vpc":    {"-", vpc.RoutingIsolated}, // its VPC has no main table here
+	}
+	for _, subnet := range subnets {
+		got := [2]string{subnet.RouteTableID, subnet.Routing}
+		if got != want[subnet.SubnetID] {
+			t.Errorf("%s routing = %v, want %v", subnet.SubnetID, got, want[subnet.SubnetID])
+		}
+	}
+
+	// Every subnet has an explicit association, so the main table is not looked up
+	client.calls = 0
+	associated := subnets[:2]
+	if err := resolveSubnetRouting(context.Background(), client, associated); err != nil {
+		t.Fatalf("resolveSubnetRouting() error = %v", err)
+	}
+	if client.calls != 1 {
+		t.Errorf("DescribeRouteTables calls = %d, want 1", client.calls)
+	}
+}
@@ -2,14 +2,18 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/pischarti/nix/pkg/cli"
 	printpkg "github.com/pischarti/nix/pkg/print"
 	"github.com/pischarti/nix/pkg/vpc"
 	"gofr.dev/pkg/gofr"
@@ -22,11 +26,21 @@ func ListSubnets(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws subnets --vpc VPC_ID [--zone AZ] [--sort SORT_BY]")
+			fmt.Println("Usage: aws subnets (--vpc VPC_ID | --all) [--zone AZ]... [--tag KEY=VALUE]... [--state STATE] [--sort SORT_BY] [--color WHEN] [--no-headers] [--check-overlap] [--with-routing | --by-zone] [--output FORMAT]")
 			fmt.Println("Options:")
-			fmt.Println("  --vpc VPC_ID    VPC ID to list subnets for (required)")
-			fmt.Println("  --zone AZ       Filter by availability zone (optional)")
-			fmt.Println("  --sort SORT_BY  Sort by: cidr (default), az, name, type")
+			fmt.Println("  --vpc VPC_ID    VPC ID to list subnets for (required unless --all)")
+			fmt.Println("  --all           List the subnets of every VPC in the region, with a VPC ID column, sorted by VPC then CIDR")
+			fmt.Println("  --zone AZ       Filter by availability zone; repeat to include several zones (optional)")
+			fmt.Println("  --tag KEY=VALUE Filter by tag; repeat to require several tags, or a key to accept several values (optional)")
+			fmt.Println("  --state STATE   Filter by state: pending, available, unavailable, failed, failed-insufficient-capacity (optional)")
+			fmt.Println("  --sort SORT_BY  Sort by: cidr (default), az, name, type, available (most free IPs first), vpc (default with --all)")
+			fmt.Println("  --color WHEN    Color the table: auto (default, only when stdout is a terminal), always, never")
+			fmt.Println("  --no-headers    Print only the data rows, space-separated, for piping into awk or cut")
+			fmt.Println("  --check-overlap Report subnets with overlapping CIDR blocks and exit with an error if any are found")
+			fmt.Println("  --with-routing  Add the route table and a Public/Private/Isolated classification from its default route")
+			fmt.Println("  --by-zone       Print the subnet count and total available IPs of each zone instead of the subnet table")
+			fmt.Println("  --output FORMAT Output format: table (default), yaml, json")
+			fmt.Println("  --verbose, -v   Log the AWS region, each API call with its timing, and a call count to stderr")
 			return nil, nil
 		}
 	}
@@ -37,37 +51,27 @@ func ListSubnets(ctx *gofr.Context) (any, error) {
 		return nil, err
 	}
 
-	if opts.VPCID == "" {
-		return nil, fmt.Errorf("vpc parameter is required")
+	if opts.VPCID == "" && !opts.AllVPCs {
+		return nil, fmt.Errorf("vpc parameter is required (use --vpc VPC_ID or --all for all VPCs)")
+	}
+
+	useColor, err := printpkg.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create EC2 client
 	ec2Client := ec2.NewFromConfig(cfg)
 
 	// Describe subnets
-	input := &ec2.DescribeSubnetsInput{
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("vpc-id"),
-				Values: []string{opts.VPCID},
-			},
-		},
-	}
-
-	if opts.Zone != "" {
-		input.Filters = append(input.Filters, types.Filter{
-			Name:   aws.String("availability-zone"),
-			Values: []string{opts.Zone},
-		})
-	}
-
-	result, err := ec2Client.DescribeSubnets(context.TODO(), input)
+	result, err := ec2Client.DescribeSubnets(context.TODO(), describeSubnetsInput(opts))
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe subnets: %w", err)
 	}
@@ -75,11 +79,51 @@ func ListSubnets(ctx *gofr.Context) (any, error) {
 	// Convert to SubnetInfo structs
 	subnets := vpc.ConvertEC2SubnetsToSubnetInfo(result.Subnets)
 
+	// Resolve route tables when requested
+	if opts.WithRouting {
+		if err := resolveSubnetRouting(context.TODO(), ec2Client, subnets); err != nil {
+			return nil, err
+		}
+	}
+
 	// Sort subnets
 	vpc.SortSubnets(subnets, opts.SortBy)
 
+	// YAML and JSON output stand alone, as --check-overlap is rejected with them
+	switch opts.OutputFormat {
+	case "yaml":
+		return nil, printpkg.PrintSubnetsYAML(os.Stdout, subnets)
+	case "json":
+		return nil, printpkg.PrintSubnetsJSON(os.Stdout, subnets)
+	}
+
 	// Print table output
-	printpkg.PrintSubnetsTable(subnets)
+	if opts.ByZone {
+		printpkg.PrintSubnetZoneSummary(vpc.SummarizeSubnetsByZone(subnets), useColor, opts.NoHeaders)
+	} else if opts.AllVPCs {
+		printpkg.PrintSubnetsTableAllVPCs(subnets, opts.WithRouting, useColor, opts.NoHeaders)
+	} else if opts.WithRouting {
+		printpkg.PrintSubnetsTableWithRouting(subnets, useColor, opts.NoHeaders)
+	} else {
+		printpkg.PrintSubnetsTable(subnets, useColor, opts.NoHeaders)
+	}
+
+	if opts.CheckOverlap {
+		if overlaps := vpc.FindOverlappingSubnets(subnets); len(overlaps) > 0 {
+			fmt.Println()
+			for _, overlap := range overlaps {
+				fmt.Printf("⚠️  Subnet %s (%s) overlaps subnet %s (%s)\n",
+					overlap.First.SubnetID, overlap.First.CIDRBlock, overlap.Second.SubnetID, overlap.Second.CIDRBlock)
+			}
+			if opts.AllVPCs {
+				return nil, fmt.Errorf("found %d overlapping subnet CIDR pair(s)", len(overlaps))
+			}
+			return nil, fmt.Errorf("found %d overlapping subnet CIDR pair(s) in VPC %s", len(overlaps), opts.VPCID)
+		}
+		if !opts.NoHeaders {
+			fmt.Printf("\n✅ No overlapping subnet CIDR blocks found\n")
+		}
+	}
 
 	return nil, nil
 }
@@ -91,29 +135,37 @@ func DeleteSubnet(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws subnets delete --subnet-id SUBNET_ID [--force]")
+			fmt.Println("Usage: aws subnets delete --subnet-id SUBNET_ID [--force [--detach-dependencies]]")
 			fmt.Println("Options:")
 			fmt.Println("  --subnet-id SUBNET_ID  Subnet ID to delete (required)")
 			fmt.Println("  --force               Skip confirmation prompt")
+			fmt.Println("  --detach-dependencies If deletion fails on dependencies, delete the subnet's detached")
+			fmt.Println("                        network interfaces and retry (requires --force)")
+			fmt.Println("  --verbose, -v         Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("--detach-dependencies never deletes load balancer, VPC endpoint or other AWS-managed")
+			fmt.Println("network interfaces, and instances still block deletion.")
 			return nil, nil
 		}
 	}
 
 	// Parse arguments
-	subnetID, force, err := parseDeleteSubnetArgs(args)
+	opts, err := parseDeleteSubnetArgs(args)
 	if err != nil {
 		return nil, err
 	}
 
+	subnetID := opts.SubnetID
 	if subnetID == "" {
 		return nil, fmt.Errorf("subnet-id parameter is required")
 	}
 
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create EC2 client
 	ec2Client := ec2.NewFromConfig(cfg)
@@ -135,12 +187,16 @@ func DeleteSubnet(ctx *gofr.Context) (any, error) {
 	subnet := describeResult.Subnets[0]
 
 	// Check for dependencies that might prevent deletion
-	if err := checkSubnetDependencies(ec2Client, subnet); err != nil {
+	blockers, err := checkSubnetDependencies(ec2Client, subnet)
+	if err != nil {
 		return nil, fmt.Errorf("cannot delete subnet %s: %w", subnetID, err)
 	}
+	if len(blockers) > 0 {
+		return nil, fmt.Errorf("cannot delete subnet %s: %w", subnetID, subnetBlockersError(blockers))
+	}
 
 	// Confirm deletion unless --force is used
-	if !force {
+	if !opts.Force {
 		fmt.Printf("Are you sure you want to delete subnet %s? (yes/no): ", subnetID)
 		var response string
 		fmt.Scanln(&response)
@@ -156,13 +212,23 @@ func DeleteSubnet(ctx *gofr.Context) (any, error) {
 	}
 
 	_, err = ec2Client.DeleteSubnet(context.TODO(), deleteInput)
+	if err != nil && opts.DetachDependencies && isSubnetDependencyViolation(err) {
+		// Leftover detached interfaces block deletion without showing up as blockers
+		removed, teardownErr := deleteDetachedNetworkInterfaces(ec2Client, subnetID, os.Stdout)
+		if teardownErr != nil {
+			return nil, fmt.Errorf("cannot delete subnet %s: %w", subnetID, teardownErr)
+		}
+		if removed > 0 {
+			_, err = ec2Client.DeleteSubnet(context.TODO(), deleteInput)
+		}
+	}
 	if err != nil {
 		// Provide more helpful error messages for common dependency issues
 		if strings.Contains(err.Error(), "has dependencies") {
-			return nil, fmt.Errorf("subnet %s has dependencies and cannot be deleted. Use 'aws subnets check-dependencies --subnet-id %s' to see what resources are preventing deletion", subnetID, subnetID)
+			return nil, fmt.Errorf("subnet %s has dependencies and cannot be deleted. Use 'aws subnets check-dependencies --subnet-id %s --explain' to see what resources are preventing deletion and how to remove them", subnetID, subnetID)
 		}
 		if strings.Contains(err.Error(), "network_load_balancer") {
-			return nil, fmt.Errorf("subnet %s has Network Load Balancer dependencies that cannot be manually detached. Use 'aws subnets check-dependencies --subnet-id %s' for details, then delete the NLB services via kubectl", subnetID, subnetID)
+			return nil, fmt.Errorf("subnet %s has Network Load Balancer dependencies that cannot be manually detached. Use 'aws subnets check-dependencies --subnet-id %s --explain' for the services to delete via kubectl", subnetID, subnetID)
 		}
 		if strings.Contains(err.Error(), "InvalidSubnetID.NotFound") {
 			return nil, fmt.Errorf("subnet %s not found or may have already been deleted", subnetID)
@@ -178,150 +244,135 @@ func DeleteSubnet(ctx *gofr.Context) (any, error) {
 }
 
 // parseDeleteSubnetArgs parses command line arguments for the delete subnet command
-func parseDeleteSubnetArgs(args []string) (subnetID string, force bool, err error) {
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "subnets", "delete":
-			// Skip command names
-			continue
-		case "--subnet-id":
-			if i+1 < len(args) {
-				i++
-				subnetID = args[i]
-			}
-		case "--force":
-			force = true
-		}
+func parseDeleteSubnetArgs(args []string) (*DeleteSubnetOptions, error) {
+	opts := &DeleteSubnetOptions{}
+
+	fs := cli.NewFlagSet("delete")
+	fs.StringVar(&opts.SubnetID, "subnet-id", "", "subnet ID to delete")
+	fs.BoolVar(&opts.Force, "force", false, "skip confirmation prompt")
+	fs.BoolVar(&opts.DetachDependencies, "detach-dependencies", false, "delete detached network interfaces blocking deletion")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
-	return subnetID, force, nil
+
+	if opts.DetachDependencies && !opts.Force {
+		return nil, fmt.Errorf("--detach-dependencies requires --force")
+	}
+
+	return opts, nil
 }
 
-// checkSubnetDependencies checks for resources that might prevent subnet deletion
-func checkSubnetDependencies(ec2Client *ec2.Client, subnet types.Subnet) error {
-	ctx := context.TODO()
-	subnetID := aws.ToString(subnet.SubnetId)
+// DeleteSubnetOptions represents the parsed command line options for the delete subnet command
+type DeleteSubnetOptions struct {
+	SubnetID           string
+	Force              bool
+	DetachDependencies bool
+	Verbose            bool
+}
 
-	// Check for EC2 instances
-	instancesInput := &ec2.DescribeInstancesInput{
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("subnet-id"),
-				Values: []string{subnetID},
-			},
-		},
-	}
+// isSubnetDependencyViolation reports whether a DeleteSubnet error is caused by resources
+// still in the subnet
+func isSubnetDependencyViolation(err error) bool {
+	return strings.Contains(err.Error(), "DependencyViolation") || strings.Contains(err.Error(), "has dependencies")
+}
 
-	instancesResult, err := ec2Client.DescribeInstances(ctx, instancesInput)
-	if err != nil {
-		return fmt.Errorf("failed to check for EC2 instances: %w", err)
+// isRemovableNetworkInterface reports whether --detach-dependencies may delete an ENI: it
+// must be detached and neither requester-managed nor owned by a load balancer or VPC endpoint
+func isRemovableNetworkInterface(eni types.NetworkInterface) bool {
+	if eni.Attachment != nil && eni.Attachment.Status != types.AttachmentStatusDetached {
+		return false
 	}
-
-	var runningInstances []string
-	for _, reservation := range instancesResult.Reservations {
-		for _, instance := range reservation.Instances {
-			if instance.State != nil && instance.State.Name != types.InstanceStateNameTerminated {
-				runningInstances = append(runningInstances, aws.ToString(instance.InstanceId))
-			}
-		}
+	if aws.ToBool(eni.RequesterManaged) {
+		return false
 	}
-
-	if len(runningInstances) > 0 {
-		instanceList := strings.Join(runningInstances, "\n   ")
-		return fmt.Errorf("subnet has running EC2 instances:\n   %s\nPlease terminate these instances first", instanceList)
+	switch classifyNetworkInterface(eni) {
+	case ENIOwnerLoadBalancer, ENIOwnerVPCEndpoint:
+		return false
 	}
+	return true
+}
 
-	// Check for Network Interfaces (ENIs)
-	eniInput := &ec2.DescribeNetworkInterfacesInput{
+// deleteDetachedNetworkInterfaces deletes the removable network interfaces in the subnet,
+// printing each one to w, and returns how many were deleted
+func deleteDetachedNetworkInterfaces(ec2Client networkInterfaceDeleter, subnetID string, w io.Writer) (int, error) {
+	result, err := ec2Client.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
 		Filters: []types.Filter{
 			{
 				Name:   aws.String("subnet-id"),
 				Values: []string{subnetID},
 			},
 		},
-	}
-
-	eniResult, err := ec2Client.DescribeNetworkInterfaces(ctx, eniInput)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to check for network interfaces: %w", err)
+		return 0, fmt.Errorf("failed to describe network interfaces: %w", err)
 	}
 
-	var attachedENIs []string
-	for _, eni := range eniResult.NetworkInterfaces {
-		if eni.Attachment != nil && eni.Attachment.Status != types.AttachmentStatusDetached {
-			attachedENIs = append(attachedENIs, aws.ToString(eni.NetworkInterfaceId))
+	removed := 0
+	for _, eni := range result.NetworkInterfaces {
+		if !isRemovableNetworkInterface(eni) {
+			continue
 		}
+		eniID := aws.ToString(eni.NetworkInterfaceId)
+		if _, err := ec2Client.DeleteNetworkInterface(context.TODO(), &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(eniID),
+		}); err != nil {
+			return removed, fmt.Errorf("failed to delete network interface %s: %w", eniID, err)
+		}
+		if desc := aws.ToString(eni.Description); desc != "" {
+			fmt.Fprintf(w, "✓ Deleted detached network interface %s (%s)\n", eniID, desc)
+		} else {
+			fmt.Fprintf(w, "✓ Deleted detached network interface %s\n", eniID)
+		}
+		removed++
 	}
+	return removed, nil
+}
 
-	if len(attachedENIs) > 0 {
-		eniList := strings.Join(attachedENIs, "\n   ")
-		return fmt.Errorf("subnet has attached network interfaces:\n   %s\nPlease detach these interfaces first", eniList)
-	}
-
-	// Check for VPC Endpoints
-	endpointsInput := &ec2.DescribeVpcEndpointsInput{
-		Filters: []types.Filter{
-			{
-				Name:   aws.String("subnet-id"),
-				Values: []string{subnetID},
-			},
-		},
-	}
+// describeSubnetsInput builds the DescribeSubnets request for the subnets command, filtering
+// by VPC unless all VPCs are listed and, when set, by zones, state and tags
+func describeSubnetsInput(opts *vpc.SubnetsOptions) *ec2.DescribeSubnetsInput {
+	input := &ec2.DescribeSubnetsInput{}
 
-	endpointsResult, err := ec2Client.DescribeVpcEndpoints(ctx, endpointsInput)
-	if err != nil {
-		return fmt.Errorf("failed to check for VPC endpoints: %w", err)
+	if !opts.AllVPCs {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{opts.VPCID},
+		})
 	}
 
-	var vpcEndpoints []string
-	for _, endpoint := range endpointsResult.VpcEndpoints {
-		if endpoint.State != types.StateDeleted {
-			vpcEndpoints = append(vpcEndpoints, aws.ToString(endpoint.VpcEndpointId))
-		}
+	if len(opts.Zones) > 0 {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("availability-zone"),
+			Values: opts.Zones,
+		})
 	}
 
-	if len(vpcEndpoints) > 0 {
-		endpointList := strings.Join(vpcEndpoints, "\n   ")
-		return fmt.Errorf("subnet has VPC endpoints:\n   %s\nPlease delete these endpoints first", endpointList)
+	if opts.State != "" {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("state"),
+			Values: []string{opts.State},
+		})
 	}
 
-	// Check for Load Balancers (via Network Interfaces)
-	// This is a simplified check - in practice, you might want to use ELBv2 client for more detailed checks
-	var loadBalancerENIs []string
-	var nlbENIs []string
-
-	for _, eni := range eniResult.NetworkInterfaces {
-		if eni.Description != nil {
-			desc := aws.ToString(eni.Description)
-			eniID := aws.ToString(eni.NetworkInterfaceId)
-
-			if strings.Contains(desc, "ELB") || strings.Contains(desc, "load balancer") {
-				loadBalancerENIs = append(loadBalancerENIs, eniID)
-			}
-
-			if strings.Contains(desc, "network_load_balancer") || strings.Contains(desc, "net/") {
-				// Extract service name from description if possible
-				serviceInfo := extractServiceInfoFromDescription(desc)
-				if serviceInfo != "" {
-					nlbENIs = append(nlbENIs, fmt.Sprintf("%s (%s)", eniID, serviceInfo))
-				} else {
-					nlbENIs = append(nlbENIs, eniID)
-				}
-			}
-		}
+	for _, tag := range opts.Tags {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("tag:" + tag.Key),
+			Values: tag.Values,
+		})
 	}
 
-	if len(nlbENIs) > 0 {
-		nlbList := strings.Join(nlbENIs, "\n   ")
-		return fmt.Errorf("subnet has Network Load Balancer (NLB) network interfaces:\n   %s\nThese ENIs are managed by Kubernetes services and cannot be manually detached.\nPlease delete the associated NLB services first (e.g., via kubectl delete service <service-name>)", nlbList)
-	}
+	return input
+}
 
-	if len(loadBalancerENIs) > 0 {
-		lbList := strings.Join(loadBalancerENIs, "\n   ")
-		return fmt.Errorf("subnet has load balancer network interfaces:\n   %s\nPlease delete the associated load balancers first", lbList)
+// checkSubnetDependencies returns the resources that prevent subnet deletion, ordered by the
+// category that has to be removed first. Use subnetBlockersError to report them.
+func checkSubnetDependencies(ec2Client subnetDescriber, subnet types.Subnet) ([]SubnetBlocker, error) {
+	dependencies, err := findSubnetDependencies(ec2Client, aws.ToString(subnet.SubnetId))
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	return subnetBlockers(dependencies), nil
 }
 
 // extractServiceInfoFromDescription extracts service information from ENI description
@@ -370,9 +421,12 @@ func CheckSubnetDependencies(ctx *gofr.Context) (any, error) {
 	// Check for help flag first
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
-			fmt.Println("Usage: aws subnets check-dependencies --subnet-id SUBNET_ID")
+			fmt.Println("Usage: aws subnets check-dependencies --subnet-id SUBNET_ID [--explain] [--output FORMAT]")
 			fmt.Println("Options:")
 			fmt.Println("  --subnet-id SUBNET_ID  Subnet ID to check dependencies for (required)")
+			fmt.Println("  --explain             List every blocking resource with the command that removes it")
+			fmt.Println("  --output FORMAT       Output format: text (default), json")
+			fmt.Println("  --verbose, -v         Log the AWS region, each API call with its timing, and a call count to stderr")
 			fmt.Println()
 			fmt.Println("This command checks what AWS resources are preventing a subnet from being deleted.")
 			return nil, nil
@@ -380,20 +434,22 @@ func CheckSubnetDependencies(ctx *gofr.Context) (any, error) {
 	}
 
 	// Parse arguments
-	subnetID, _, err := parseDeleteSubnetArgs(args)
+	opts, err := parseCheckDependenciesArgs(args)
 	if err != nil {
 		return nil, err
 	}
+	subnetID := opts.SubnetID
 
 	if subnetID == "" {
 		return nil, fmt.Errorf("subnet-id parameter is required")
 	}
 
 	// Initialize AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	defer logAPISummary()
 
 	// Create EC2 client
 	ec2Client := ec2.NewFromConfig(cfg)
@@ -414,6 +470,14 @@ func CheckSubnetDependencies(ctx *gofr.Context) (any, error) {
 
 	subnet := describeResult.Subnets[0]
 
+	if opts.OutputFormat == "json" {
+		report, err := newSubnetDependencyReport(ec2Client, elasticloadbalancingv2.NewFromConfig(cfg), subnet)
+		if err != nil {
+			return nil, err
+		}
+		return nil, printSubnetDependencyReportJSON(os.Stdout, report)
+	}
+
 	// Display subnet information
 	fmt.Printf("Checking dependencies for subnet: %s\n", subnetID)
 	fmt.Printf("VPC: %s\n", aws.ToString(subnet.VpcId))
@@ -421,10 +485,18 @@ func CheckSubnetDependencies(ctx *gofr.Context) (any, error) {
 	fmt.Printf("AZ: %s\n", aws.ToString(subnet.AvailabilityZone))
 	fmt.Printf("State: %s\n\n", string(subnet.State))
 
+	if opts.Explain {
+		return nil, explainSubnetDependencies(ec2Client, elasticloadbalancingv2.NewFromConfig(cfg), subnetID)
+	}
+
 	// Check for dependencies
-	if err := checkSubnetDependencies(ec2Client, subnet); err != nil {
+	blockers, err := checkSubnetDependencies(ec2Client, subnet)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockers) > 0 {
 		fmt.Printf("❌ Dependencies found that prevent deletion:\n")
-		fmt.Printf("   %s\n", err.Error())
+		fmt.Printf("   %s\n", subnetBlockersError(blockers).Error())
 		return nil, nil
 	}
 
@@ -432,6 +504,257 @@ func CheckSubnetDependencies(ctx *gofr.Context) (any, error) {
 	return nil, nil
 }
 
+// explainSubnetDependencies prints every blocking dependency with its remediation command
+func explainSubnetDependencies(ec2Client subnetDescriber, elbv2Client loadBalancerClient, subnetID string) error {
+	dependencies, err := findSubnetDependencies(ec2Client, subnetID)
+	if err != nil {
+		return err
+	}
+
+	if len(dependencies) == 0 {
+		fmt.Printf("✅ No dependencies found. Subnet can be deleted.\n")
+		return nil
+	}
+
+	resolveLoadBalancerServices(elbv2Client, dependencies)
+
+	fmt.Printf("❌ %d dependency(ies) prevent deletion. Run these commands to remove them:\n\n", len(dependencies))
+	for i, blocker := range subnetBlockers(dependencies) {
+		fmt.Printf("%d. %s\n", i+1, blocker.Detail)
+		fmt.Printf("   %s\n\n", blocker.Remediation)
+	}
+	fmt.Printf("Then delete the subnet: aws subnets delete --subnet-id %s\n", subnetID)
+
+	return nil
+}
+
+// printSubnetDependencyReportJSON writes the report as indented JSON. Blockers is always an
+// array so that consumers need not special-case a deletable subnet.
+func printSubnetDependencyReportJSON(w io.Writer, report *SubnetDependencyReport) error {
+	if report.Blockers == nil {
+		report.Blockers = []SubnetBlocker{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// CheckDependenciesOptions represents the parsed command line options for the check-dependencies command
+type CheckDependenciesOptions struct {
+	SubnetID     string
+	Explain      bool
+	OutputFormat string
+	Verbose      bool
+}
+
+// parseCheckDependenciesArgs parses command line arguments for the check-dependencies command
+func parseCheckDependenciesArgs(args []string) (*CheckDependenciesOptions, error) {
+	opts := &CheckDependenciesOptions{}
+
+	fs := cli.NewFlagSet("check-dependencies")
+	fs.StringVar(&opts.SubnetID, "subnet-id", "", "subnet ID to check dependencies for")
+	fs.BoolVar(&opts.Explain, "explain", false, "list every blocking resource with the command that removes it")
+	fs.StringVar(&opts.OutputFormat, "output", "text", "output format: text, json")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	switch opts.OutputFormat {
+	case "text", "json":
+	default:
+		return nil, fmt.Errorf("invalid --output %q: must be text or json", opts.OutputFormat)
+	}
+	if opts.Explain && opts.OutputFormat != "text" {
+		return nil, fmt.Errorf("--explain only applies to text output; json output always includes remediations")
+	}
+
+	return opts, nil
+}
+
+// PruneSubnets handles the prune command, which finds and optionally deletes empty subnets in a VPC
+func PruneSubnets(ctx *gofr.Context) (any, error) {
+	args := os.Args[1:] // Get command line args for parsing flags
+
+	// Check for help flag first
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Println("Usage: aws subnets prune --vpc VPC_ID [--require-free-ips] [--force]")
+			fmt.Println("Options:")
+			fmt.Println("  --vpc VPC_ID          VPC ID to prune empty subnets from (required)")
+			fmt.Println("  --require-free-ips    Only consider subnets with no allocated IP addresses")
+			fmt.Println("  --force               Delete the candidate subnets (default: preview only)")
+			fmt.Println("  --verbose, -v         Log the AWS region, each API call with its timing, and a call count to stderr")
+			fmt.Println()
+			fmt.Println("This command lists subnets with no dependencies as deletion candidates.")
+			fmt.Println("Nothing is deleted unless --force is given.")
+			return nil, nil
+		}
+	}
+
+	// Parse arguments
+	opts, err := parsePruneSubnetsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.VPCID == "" {
+		return nil, fmt.Errorf("vpc parameter is required")
+	}
+
+	// Initialize AWS config
+	cfg, logAPISummary, err := loadAWSConfig(context.TODO(), opts.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	defer logAPISummary()
+
+	// Create EC2 client
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	// Describe subnets in the VPC
+	result, err := ec2Client.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{opts.VPCID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	if len(result.Subnets) == 0 {
+		fmt.Printf("No subnets found in VPC %s\n", opts.VPCID)
+		return nil, nil
+	}
+
+	fmt.Printf("Checking %d subnet(s) in VPC %s for dependencies...\n\n", len(result.Subnets), opts.VPCID)
+
+	// Collect subnets that can be deleted
+	var candidates []types.Subnet
+	for _, subnet := range result.Subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+
+		if opts.RequireFreeIPs && !subnetHasNoIPConsumers(subnet) {
+			fmt.Printf("⏭️  %s: skipped, has allocated IP addresses\n", subnetID)
+			continue
+		}
+
+		blockers, err := checkSubnetDependencies(ec2Client, subnet)
+		if err == nil && len(blockers) > 0 {
+			err = subnetBlockersError(blockers)
+		}
+		if err != nil {
+			fmt.Printf("⏭️  %s: skipped, %s\n", subnetID, strings.SplitN(err.Error(), "\n", 2)[0])
+			continue
+		}
+
+		candidates = append(candidates, subnet)
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("\n✅ No empty subnets found. Nothing to prune.")
+		return nil, nil
+	}
+
+	// List deletion candidates
+	fmt.Printf("\nFound %d subnet(s) with no dependencies:\n", len(candidates))
+	for _, subnet := range candidates {
+		fmt.Printf("   %s  %s  %s  %s\n",
+			aws.ToString(subnet.SubnetId),
+			aws.ToString(subnet.CidrBlock),
+			aws.ToString(subnet.AvailabilityZone),
+			subnetNameTag(subnet))
+	}
+
+	if !opts.Force {
+		fmt.Println("\nPreview only. Re-run with --force to delete these subnets.")
+		return nil, nil
+	}
+
+	// Delete candidates, continuing past individual failures
+	fmt.Println()
+	var failed []string
+	for _, subnet := range candidates {
+		subnetID := aws.ToString(subnet.SubnetId)
+
+		_, err := ec2Client.DeleteSubnet(context.TODO(), &ec2.DeleteSubnetInput{
+			SubnetId: subnet.SubnetId,
+		})
+		if err != nil {
+			fmt.Printf("❌ Failed to delete subnet %s: %v\n", subnetID, err)
+			failed = append(failed, subnetID)
+			continue
+		}
+
+		fmt.Printf("✅ Successfully deleted subnet %s\n", subnetID)
+	}
+
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to delete %d of %d subnet(s): %s", len(failed), len(candidates), strings.Join(failed, ", "))
+	}
+
+	fmt.Printf("\nPruned %d subnet(s) from VPC %s\n", len(candidates), opts.VPCID)
+	return nil, nil
+}
+
+// PruneSubnetsOptions represents the parsed command line options for the prune command
+type PruneSubnetsOptions struct {
+	VPCID          string
+	Force          bool
+	RequireFreeIPs bool
+	Verbose        bool
+}
+
+// parsePruneSubnetsArgs parses command line arguments for the prune command
+func parsePruneSubnetsArgs(args []string) (*PruneSubnetsOptions, error) {
+	opts := &PruneSubnetsOptions{}
+
+	fs := cli.NewFlagSet("prune")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID to prune empty subnets from")
+	fs.BoolVar(&opts.Force, "force", false, "delete the candidate subnets")
+	fs.BoolVar(&opts.RequireFreeIPs, "require-free-ips", false, "only consider subnets with no allocated IP addresses")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// subnetHasNoIPConsumers reports whether every usable address in the subnet is still available.
+// AWS reserves the first four and the last address of each subnet, so those are not counted.
+func subnetHasNoIPConsumers(subnet types.Subnet) bool {
+	_, ipNet, err := net.ParseCIDR(aws.ToString(subnet.CidrBlock))
+	if err != nil {
+		return false
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	usable := (int64(1) << (bits - ones)) - 5
+	if usable < 0 {
+		usable = 0
+	}
+
+	return int64(aws.ToInt32(subnet.AvailableIpAddressCount)) >= usable
+}
+
+// subnetNameTag returns the value of the subnet's Name tag, or an empty string
+func subnetNameTag(subnet types.Subnet) string {
+	for _, tag := range subnet.Tags {
+		if aws.ToString(tag.Key) == "Name" {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
 // SubnetsRouter routes subnets sub-commands
 func SubnetsRouter(ctx *gofr.Context) (any, error) {
 	args := os.Args[1:] // Get command line args for parsing flags
@@ -447,6 +770,16 @@ func SubnetsRouter(ctx *gofr.Context) (any, error) {
 		return CheckSubnetDependencies(ctx)
 	}
 
+	if len(args) >= 2 && args[1] == "move-enis" {
+		// Route to move-enis command
+		return MoveSubnetENIs(ctx)
+	}
+
+	if len(args) >= 2 && args[1] == "prune" {
+		// Route to prune command
+		return PruneSubnets(ctx)
+	}
+
 	// Check for help flag for main subnets command
 	for _, arg := range args {
 		if arg == "-h" || arg == "--help" {
@@ -455,12 +788,16 @@ func SubnetsRouter(ctx *gofr.Context) (any, error) {
 			fmt.Println("  list               List all subnets in a VPC (default)")
 			fmt.Println("  delete             Delete a subnet by ID")
 			fmt.Println("  check-dependencies Check what resources are preventing subnet deletion")
+			fmt.Println("  move-enis          Report the network interfaces in a subnet by owner, with how to move them")
+			fmt.Println("  prune              Find and delete subnets with no dependencies")
 			fmt.Println()
 			fmt.Println("Examples:")
 			fmt.Println("  aws subnets --vpc vpc-12345678")
 			fmt.Println("  aws subnets list --vpc vpc-12345678")
 			fmt.Println("  aws subnets delete --subnet-id subnet-12345678")
 			fmt.Println("  aws subnets check-dependencies --subnet-id subnet-12345678")
+			fmt.Println("  aws subnets move-enis --subnet-id subnet-12345678")
+			fmt.Println("  aws subnets prune --vpc vpc-12345678")
 			return nil, nil
 		}
 	}
@@ -4,6 +4,10 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pischarti/nix/pkg/vpc"
 )
 
 // For testing, we'll create a simple mock that satisfies the gofr.Context interface
@@ -252,12 +256,76 @@ func TestListSubnetsOutputFormat(t *testing.T) {
 	}
 }
 
+func TestDescribeSubnetsInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *vpc.SubnetsOptions
+		expected map[string]string
+	}{
+		{
+			name:     "vpc only",
+			opts:     &vpc.SubnetsOptions{VPCID: "vpc-12345678"},
+			expected: map[string]string{"vpc-id": "vpc-12345678"},
+		},
+		{
+			name:     "zone and state",
+			opts:     &vpc.SubnetsOptions{VPCID: "vpc-12345678", Zones: []string{"us-east-1a"}, State: "pending"},
+			expected: map[string]string{"vpc-id": "vpc-12345678", "availability-zone": "us-east-1a", "state": "pending"},
+		},
+		{
+			name:     "several zones",
+			opts:     &vpc.SubnetsOptions{VPCID: "vpc-12345678", Zones: []string{"us-east-1a", "us-east-1b"}},
+			expected: map[string]string{"vpc-id": "vpc-12345678", "availability-zone": "us-east-1a,us-east-1b"},
+		},
+		{
+			name:     "all VPCs",
+			opts:     &vpc.SubnetsOptions{AllVPCs: true, State: "available"},
+			expected: map[string]string{"state": "available"},
+		},
+		{
+			name: "tags with zone",
+			opts: &vpc.SubnetsOptions{
+				VPCID: "vpc-12345678",
+				Zones: []string{"us-east-1a"},
+				Tags:  []vpc.TagFilter{{Key: "Tier", Values: []string{"private", "database"}}, {Key: "Environment", Values: []string{"prod"}}},
+			},
+			expected: map[string]string{
+				"vpc-id":            "vpc-12345678",
+				"availability-zone": "us-east-1a",
+				"tag:Tier":          "private,database",
+				"tag:Environment":   "prod",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := describeSubnetsInput(tt.opts)
+
+			filters := make(map[string]string)
+			for _, f := range input.Filters {
+				filters[aws.ToString(f.Name)] = strings.Join(f.Values, ",")
+			}
+			if len(filters) != len(tt.expected) {
+				t.Fatalf("filters = %v, want %v", filters, tt.expected)
+			}
+			for name, value := range tt.expected {
+				if filters[name] != value {
+					t.Errorf("filter %s = %q, want %q", name, filters[name], value)
+				}
+			}
+		})
+	}
+}
+
 func TestParseDeleteSubnetArgs(t *testing.T) {
 	tests := []struct {
-		name          string
-		args          []string
-		expectedID    string
-		expectedForce bool
+		name            string
+		args            []string
+		expectedID      string
+		expectedForce   bool
+		expectedVerbose bool
+		expectError     bool
 	}{
 		{
 			name:          "valid subnet id",
@@ -283,22 +351,53 @@ func TestParseDeleteSubnetArgs(t *testing.T) {
 			expectedID:    "",
 			expectedForce: false,
 		},
+		{
+			name:            "equals value with verbose shorthand",
+			args:            []string{"aws", "subnets", "delete", "--subnet-id=subnet-12345678", "-v"},
+			expectedID:      "subnet-12345678",
+			expectedVerbose: true,
+		},
+		{
+			name:          "detach dependencies with force",
+			args:          []string{"aws", "subnets", "delete", "--subnet-id", "subnet-12345678", "--force", "--detach-dependencies"},
+			expectedID:    "subnet-12345678",
+			expectedForce: true,
+		},
+		{
+			name:        "detach dependencies without force",
+			args:        []string{"aws", "subnets", "delete", "--subnet-id", "subnet-12345678", "--detach-dependencies"},
+			expectError: true,
+		},
+		{
+			name:        "unknown flag",
+			args:        []string{"aws", "subnets", "delete", "--subnet", "subnet-12345678"},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			subnetID, force, err := parseDeleteSubnetArgs(tt.args)
+			opts, err := parseDeleteSubnetArgs(tt.args)
 
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
 			if err != nil {
 				t.Errorf("Unexpected error: %v", err)
 				return
 			}
 
-			if subnetID != tt.expectedID {
-				t.Errorf("SubnetID = %v, want %v", subnetID, tt.expectedID)
+			if opts.SubnetID != tt.expectedID {
+				t.Errorf("SubnetID = %v, want %v", opts.SubnetID, tt.expectedID)
+			}
+			if opts.Force != tt.expectedForce {
+				t.Errorf("Force = %v, want %v", opts.Force, tt.expectedForce)
 			}
-			if force != tt.expectedForce {
-				t.Errorf("Force = %v, want %v", force, tt.expectedForce)
+			if opts.Verbose != tt.expectedVerbose {
+				t.Errorf("Verbose = %v, want %v", opts.Verbose, tt.expectedVerbose)
 			}
 		})
 	}
@@ -382,3 +481,196 @@ func TestDeleteSubnetErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePruneSubnetsArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected PruneSubnetsOptions
+	}{
+		{
+			name:     "vpc only",
+			args:     []string{"subnets", "prune", "--vpc", "vpc-12345678"},
+			expected: PruneSubnetsOptions{VPCID: "vpc-12345678"},
+		},
+		{
+			name:     "vpc with force",
+			args:     []string{"subnets", "prune", "--vpc", "vpc-12345678", "--force"},
+			expected: PruneSubnetsOptions{VPCID: "vpc-12345678", Force: true},
+		},
+		{
+			name:     "all flags",
+			args:     []string{"subnets", "prune", "--require-free-ips", "--force", "--vpc", "vpc-12345678"},
+			expected: PruneSubnetsOptions{VPCID: "vpc-12345678", Force: true, RequireFreeIPs: true},
+		},
+		{
+			name:     "verbose",
+			args:     []string{"subnets", "prune", "--vpc", "vpc-12345678", "-v"},
+			expected: PruneSubnetsOptions{VPCID: "vpc-12345678", Verbose: true},
+		},
+		{
+			name:     "no vpc",
+			args:     []string{"subnets", "prune"},
+			expected: PruneSubnetsOptions{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parsePruneSubnetsArgs(tt.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if *opts != tt.expected {
+				t.Errorf("parsePruneSubnetsArgs() = %+v, want %+v", *opts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubnetHasNoIPConsumers(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		available int32
+		expected  bool
+	}{
+		{
+			name:      "empty /24",
+			cidr:      "10.0.1.0/24",
+			available: 251,
+			expected:  true,
+		},
+		{
+			name:      "one address in use in /24",
+			cidr:      "10.0.1.0/24",
+			available: 250,
+			expected:  false,
+		},
+		{
+			name:      "empty /28",
+			cidr:      "10.0.1.16/28",
+			available: 11,
+			expected:  true,
+		},
+		{
+			name:      "invalid cidr",
+			cidr:      "not-a-cidr",
+			available: 251,
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subnet := types.Subnet{
+				CidrBlock:               aws.String(tt.cidr),
+				AvailableIpAddressCount: aws.Int32(tt.available),
+			}
+
+			if got := subnetHasNoIPConsumers(subnet); got != tt.expected {
+				t.Errorf("subnetHasNoIPConsumers() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckSubnetDependencies(t *testing.T) {
+	subnet := types.Subnet{SubnetId: aws.String("subnet-1")}
+
+	tests := []struct {
+		name      string
+		client    *fakeEC2
+		wantError string
+	}{
+		{
+			name:   "no dependencies",
+			client: &fakeEC2{},
+		},
+		{
+			name: "terminated instances do not block",
+			client: &fakeEC2{instances: []types.Instance{
+				{InstanceId: aws.String("i-old"), State: &types.InstanceState{Name: types.InstanceStateNameTerminated}},
+			}},
+		},
+		{
+			name: "running instance",
+			client: &fakeEC2{instances: []types.Instance{
+				{InstanceId: aws.String("i-123"), State: &types.InstanceState{Name: types.InstanceStateNameRunning}},
+			}},
+			wantError: "subnet has running EC2 instances:\n   i-123",
+		},
+		{
+			name: "instances are reported before other dependencies",
+			client: &fakeEC2{
+				instances: []types.Instance{{InstanceId: aws.String("i-123"), State: &types.InstanceState{Name: types.InstanceStateNameRunning}}},
+				endpoints: []types.VpcEndpoint{{VpcEndpointId: aws.String("vpce-1"), State: types.StateAvailable}},
+			},
+			wantError: "running EC2 instances",
+		},
+		{
+			name: "NLB network interface",
+			client: &fakeEC2{enis: []types.NetworkInterface{
+				{NetworkInterfaceId: aws.String("eni-1"), Description: aws.String("ELB net/k8s-default-web-abc123/def456")},
+			}},
+			wantError: "Network Load Balancer (NLB) network interfaces:\n   eni-1",
+		},
+		{
+			name: "deleted VPC endpoints do not block",
+			client: &fakeEC2{endpoints: []types.VpcEndpoint{
+				{VpcEndpointId: aws.String("vpce-1"), State: types.StateDeleted},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockers, err := checkSubnetDependencies(tt.client, subnet)
+			if err != nil {
+				t.Fatalf("checkSubnetDependencies() error = %v", err)
+			}
+			err = subnetBlockersError(blockers)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("subnetBlockersError() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("subnetBlockersError() = %v, want it to contain %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestDeleteDetachedNetworkInterfaces(t *testing.T) {
+	client := &fakeEC2{enis: []types.NetworkInterface{
+		{NetworkInterfaceId: aws.String("eni-available"), Description: aws.String("leftover")},
+		{NetworkInterfaceId: aws.String("eni-detached"), Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusDetached}},
+		{NetworkInterfaceId: aws.String("eni-attached"), Attachment: &types.NetworkInterfaceAttachment{Status: types.AttachmentStatusAttached, InstanceId: aws.String("i-123")}},
+		{NetworkInterfaceId: aws.String("eni-nlb"), Description: aws.String("ELB net/k8s-default-web-abc123/def456")},
+		{NetworkInterfaceId: aws.String("eni-nlb-type"), InterfaceType: types.NetworkInterfaceTypeNetworkLoadBalancer},
+		{NetworkInterfaceId: aws.String("eni-endpoint"), InterfaceType: types.NetworkInterfaceTypeVpcEndpoint},
+		{NetworkInterfaceId: aws.String("eni-managed"), RequesterManaged: aws.Bool(true)},
+	}}
+
+	var out strings.Builder
+	removed, err := deleteDetachedNetworkInterfaces(client, "subnet-1", &out)
+	if err != nil {
+		t.Fatalf("deleteDetachedNetworkInterfaces() error = %v", err)
+	}
+
+	want := []string{"eni-available", "eni-detached"}
+	if removed != len(want) || strings.Join(client.deletedENIs, ",") != strings.Join(want, ",") {
+		t.Errorf("deleteDetachedNetworkInterfaces() deleted %d %v, want %v", removed, client.deletedENIs, want)
+	}
+	for _, line := range []string{
+		"✓ Deleted detached network interface eni-available (leftover)",
+		"✓ Deleted detached network interface eni-detached\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("output missing %q:\n%s", line, out.String())
+		}
+	}
+}
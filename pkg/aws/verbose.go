@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// apiCallLogger counts and times AWS API calls for --verbose output
+type apiCallLogger struct {
+	out   io.Writer
+	start time.Time
+	calls int64
+}
+
+// loadAWSConfig loads the default AWS config. When verbose is set, the region in use is
+// logged to stderr and every AWS API call is counted and timed. The returned func logs the
+// totals and should be deferred by the caller; it is a no-op when verbose is off.
+func loadAWSConfig(ctx context.Context, verbose bool) (aws.Config, func(), error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, func() {}, err
+	}
+
+	if !verbose {
+		return cfg, func() {}, nil
+	}
+
+	logger := &apiCallLogger{out: os.Stderr, start: time.Now()}
+	cfg.APIOptions = append(cfg.APIOptions, logger.addMiddleware)
+
+	region := cfg.Region
+	if region == "" {
+		region = "(not set)"
+	}
+	fmt.Fprintf(logger.out, "[verbose] AWS region: %s\n", region)
+
+	return cfg, logger.summary, nil
+}
+
+// addMiddleware registers the timing middleware on an operation's stack
+func (l *apiCallLogger) addMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("VerboseAPICallLogger",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := time.Now()
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			l.record(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), time.Since(start), err)
+			return out, metadata, err
+		}), middleware.After)
+}
+
+// record logs a single completed API call
+func (l *apiCallLogger) record(service, operation string, elapsed time.Duration, err error) {
+	n := atomic.AddInt64(&l.calls, 1)
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	fmt.Fprintf(l.out, "[verbose] #%d %s.%s took %s (%s)\n", n, service, operation, elapsed.Round(time.Millisecond), status)
+}
+
+// summary logs the total number of API calls and the elapsed time
+func (l *apiCallLogger) summary() {
+	fmt.Fprintf(l.out, "[verbose] %d AWS API call(s) in %s\n", atomic.LoadInt64(&l.calls), time.Since(l.start).Round(time.Millisecond))
+}
@@ -0,0 +1,67 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestAPICallLoggerRecordAndSummary(t *testing.T) {
+	var out bytes.Buffer
+	logger := &apiCallLogger{out: &out, start: time.Now()}
+
+	logger.record("EC2", "DescribeSubnets", 120*time.Millisecond, nil)
+	logger.record("Elastic Load Balancing v2", "DescribeTags", 40*time.Millisecond, errors.New("throttled"))
+	logger.summary()
+
+	want := []string{
+		"[verbose] #1 EC2.DescribeSubnets took 120ms (ok)",
+		"[verbose] #2 Elastic Load Balancing v2.DescribeTags took 40ms (error)",
+		"[verbose] 2 AWS API call(s) in",
+	}
+	for _, w := range want {
+		if !strings.Contains(out.String(), w) {
+			t.Errorf("output missing %q, got:\n%s", w, out.String())
+		}
+	}
+}
+
+// failingHTTPClient fails every request without touching the network
+type failingHTTPClient struct{}
+
+func (failingHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, errors.New("no network in tests")
+}
+
+func TestAPICallLoggerMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	logger := &apiCallLogger{out: &out, start: time.Now()}
+
+	cfg := aws.Config{
+		Region:           "us-east-1",
+		Credentials:      aws.AnonymousCredentials{},
+		HTTPClient:       failingHTTPClient{},
+		RetryMaxAttempts: 1,
+		APIOptions:       []func(*middleware.Stack) error{logger.addMiddleware},
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	if _, err := client.DescribeSubnets(context.Background(), &ec2.DescribeSubnetsInput{}); err == nil {
+		t.Fatal("DescribeSubnets() error = nil, want transport error")
+	}
+
+	if !strings.Contains(out.String(), "#1 EC2.DescribeSubnets") {
+		t.Errorf("middleware did not log the call, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "(error)") {
+		t.Errorf("middleware did not log the failure, got:\n%s", out.String())
+	}
+}
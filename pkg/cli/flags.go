@@ -0,0 +1,29 @@
+// Package cli parses the command line flags of the gofr subcommands
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+)
+
+// NewFlagSet returns a flag set for a gofr subcommand. It accepts "--flag value",
+// "--flag=value" and single-letter shorthands such as "-v", and Parse reports unknown or
+// incomplete flags as errors instead of printing usage, since each command prints its
+// own help for -h/--help.
+func NewFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	return fs
+}
+
+// Parse parses args with fs. Non-flag arguments, such as the command names in
+// os.Args[1:] ("subnets", "delete"), are left as positional arguments.
+func Parse(fs *pflag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%s: %w (use --help for usage)", fs.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantVPC     string
+		wantForce   bool
+		wantVerbose bool
+		wantTimeout time.Duration
+		wantErr     string
+	}{
+		{
+			name:        "separate values and command names",
+			args:        []string{"subnets", "prune", "--vpc", "vpc-123", "--force"},
+			wantVPC:     "vpc-123",
+			wantForce:   true,
+			wantTimeout: time.Minute,
+		},
+		{
+			name:        "equals values",
+			args:        []string{"subnets", "prune", "--vpc=vpc-123", "--timeout=2m"},
+			wantVPC:     "vpc-123",
+			wantTimeout: 2 * time.Minute,
+		},
+		{
+			name:        "short flag",
+			args:        []string{"subnets", "prune", "-v", "--vpc", "vpc-123"},
+			wantVPC:     "vpc-123",
+			wantVerbose: true,
+			wantTimeout: time.Minute,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"subnets", "prune", "--vcp", "vpc-123"},
+			wantErr: "unknown flag: --vcp",
+		},
+		{
+			name:    "missing value",
+			args:    []string{"subnets", "prune", "--vpc"},
+			wantErr: "flag needs an argument: --vpc",
+		},
+		{
+			name:    "invalid value",
+			args:    []string{"subnets", "prune", "--timeout", "soon"},
+			wantErr: `invalid argument "soon" for "--timeout" flag`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vpc string
+			var force, verbose bool
+			var timeout time.Duration
+
+			fs := NewFlagSet("prune")
+			fs.StringVar(&vpc, "vpc", "", "VPC ID")
+			fs.BoolVar(&force, "force", false, "delete")
+			fs.BoolVarP(&verbose, "verbose", "v", false, "verbose")
+			fs.DurationVar(&timeout, "timeout", time.Minute, "timeout")
+
+			err := Parse(fs, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if !strings.HasPrefix(err.Error(), "prune: ") {
+					t.Errorf("Parse() error = %q, want it prefixed with the command name", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			if vpc != tt.wantVPC || force != tt.wantForce || verbose != tt.wantVerbose || timeout != tt.wantTimeout {
+				t.Errorf("Parse() = vpc %q force %v verbose %v timeout %v, want %q %v %v %v",
+					vpc, force, verbose, timeout, tt.wantVPC, tt.wantForce, tt.wantVerbose, tt.wantTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package container
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DigestRef combines the repository of a spec image with the digest from a container
+// status ImageID, e.g. "nginx:1.21" and "docker-pullable://nginx@sha256:abc" become
+// "nginx@sha256:abc". It returns false when the ImageID does not carry a repository
+// digest (empty, or a bare local image ID such as "sha256:abc").
+func DigestRef(image, imageID string) (string, bool) {
+	idx := strings.LastIndex(imageID, "@")
+	if idx == -1 {
+		return "", false
+	}
+	digest := imageID[idx+1:]
+	if !strings.Contains(digest, ":") {
+		return "", false
+	}
+
+	return imageRepository(image) + "@" + digest, true
+}
+
+// imageRepository strips any tag or digest from an image reference
+func imageRepository(image string) string {
+	name, _, _ := splitImageRef(image)
+	return name
+}
+
+// resolveImageDigests returns a copy of pods whose container images are replaced by the
+// digests the kubelet resolved at pull time. Containers without a usable status keep the
+// spec image, so pending pods still show what was requested.
+func resolveImageDigests(pods *corev1.PodList) *corev1.PodList {
+	resolved := pods.DeepCopy()
+
+	for i := range resolved.Items {
+		pod := &resolved.Items[i]
+
+		for j := range pod.Spec.Containers {
+			c := &pod.Spec.Containers[j]
+			c.Image = resolveContainerImage(c.Name, c.Image, pod.Status.ContainerStatuses)
+		}
+		for j := range pod.Spec.InitContainers {
+			c := &pod.Spec.InitContainers[j]
+			c.Image = resolveContainerImage(c.Name, c.Image, pod.Status.InitContainerStatuses)
+		}
+		for j := range pod.Spec.EphemeralContainers {
+			c := &pod.Spec.EphemeralContainers[j]
+			c.Image = resolveContainerImage(c.Name, c.Image, pod.Status.EphemeralContainerStatuses)
+		}
+	}
+
+	return resolved
+}
+
+// resolveContainerImage looks up the named container's status and returns its digest
+// reference, falling back to the spec image
+func resolveContainerImage(name, image string, statuses []corev1.ContainerStatus) string {
+	if image == "" {
+		return image
+	}
+	for _, status := range statuses {
+		if status.Name != name {
+			continue
+		}
+		if ref, ok := DigestRef(image, status.ImageID); ok {
+			return ref
+		}
+		break
+	}
+	return image
+}
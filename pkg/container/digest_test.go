@@ -0,0 +1,129 @@
+package container
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDigestRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		imageID  string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "docker-pullable image id",
+			image:    "nginx:1.21",
+			imageID:  "docker-pullable://nginx@sha256:abc123",
+			expected: "nginx@sha256:abc123",
+			ok:       true,
+		},
+		{
+			name:     "containerd image id",
+			image:    "registry.k8s.io/kube-proxy:v1.28.0",
+			imageID:  "registry.k8s.io/kube-proxy@sha256:def456",
+			expected: "registry.k8s.io/kube-proxy@sha256:def456",
+			ok:       true,
+		},
+		{
+			name:     "registry with port keeps the port",
+			image:    "registry.example.com:5000/team/app:v2",
+			imageID:  "registry.example.com:5000/team/app@sha256:0a1b2c",
+			expected: "registry.example.com:5000/team/app@sha256:0a1b2c",
+			ok:       true,
+		},
+		{
+			name:     "spec already pinned by digest",
+			image:    "busybox@sha256:old",
+			imageID:  "docker.io/library/busybox@sha256:new",
+			expected: "busybox@sha256:new",
+			ok:       true,
+		},
+		{
+			name:    "bare local image id",
+			image:   "app:dev",
+			imageID: "sha256:abc123",
+			ok:      false,
+		},
+		{
+			name:    "empty image id",
+			image:   "nginx:1.21",
+			imageID: "",
+			ok:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DigestRef(tt.image, tt.imageID)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("DigestRef(%q, %q) = (%q, %v), want (%q, %v)", tt.image, tt.imageID, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestResolveImageDigests(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.34"}},
+					Containers: []corev1.Container{
+						{Name: "app", Image: "nginx:1.21"},
+						{Name: "sidecar", Image: "envoy:v1.27"},
+					},
+				},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{Name: "init", ImageID: "docker.io/library/busybox@sha256:init"},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "app", ImageID: "docker.io/library/nginx@sha256:app"},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "nginx:1.21"}},
+				},
+			},
+		},
+	}
+
+	resolved := resolveImageDigests(pods)
+
+	expected := map[string][]string{
+		"web":     {"busybox@sha256:init", "nginx@sha256:app", "envoy:v1.27"},
+		"pending": {"nginx:1.21"},
+	}
+	for _, pod := range resolved.Items {
+		var images []string
+		for _, c := range pod.Spec.InitContainers {
+			images = append(images, c.Image)
+		}
+		for _, c := range pod.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		want := expected[pod.Name]
+		if len(images) != len(want) {
+			t.Fatalf("pod %s: got images %v, want %v", pod.Name, images, want)
+		}
+		for i := range want {
+			if images[i] != want[i] {
+				t.Errorf("pod %s: image %d = %q, want %q", pod.Name, i, images[i], want[i])
+			}
+		}
+	}
+
+	// The listed pods are left untouched
+	if pods.Items[0].Spec.Containers[0].Image != "nginx:1.21" {
+		t.Errorf("resolveImageDigests modified its input: %q", pods.Items[0].Spec.Containers[0].Image)
+	}
+}
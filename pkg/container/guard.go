@@ -0,0 +1,64 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AllNamespacesScanThreshold is the number of objects above which listing every namespace
+// without an explicit --all-namespaces is refused
+const AllNamespacesScanThreshold = 5000
+
+// checkImplicitAllNamespacesScan refuses an all-namespaces scan that was only implied by the
+// absence of --namespace when the cluster holds more than threshold objects. The estimate
+// comes from a single-item list, so the check is cheap even on large clusters.
+func checkImplicitAllNamespacesScan(ctx context.Context, clientset kubernetes.Interface, resource string, threshold int64) error {
+	count, known, err := estimateObjectCount(ctx, clientset, resource)
+	if err != nil {
+		return fmt.Errorf("count %s: %w", resource, err)
+	}
+	if !known || count <= threshold {
+		return nil
+	}
+
+	return fmt.Errorf("no namespace given and the cluster has about %d %s across all namespaces (more than %d); "+
+		"use --namespace NAMESPACE to narrow the scan or --all-namespaces to confirm a cluster-wide scan", count, resource, threshold)
+}
+
+// estimateObjectCount returns the number of pods or services in all namespaces using a list
+// limited to one item plus the server's remaining item count. known is false when the server
+// does not report a remaining count.
+func estimateObjectCount(ctx context.Context, clientset kubernetes.Interface, resource string) (count int64, known bool, err error) {
+	opts := metav1.ListOptions{Limit: 1}
+
+	var items int
+	var meta metav1.ListMeta
+	switch resource {
+	case "pods":
+		list, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+		if err != nil {
+			return 0, false, err
+		}
+		items, meta = len(list.Items), list.ListMeta
+	case "services":
+		list, err := clientset.CoreV1().Services(metav1.NamespaceAll).List(ctx, opts)
+		if err != nil {
+			return 0, false, err
+		}
+		items, meta = len(list.Items), list.ListMeta
+	default:
+		return 0, false, fmt.Errorf("unsupported resource %q", resource)
+	}
+
+	if meta.RemainingItemCount != nil {
+		return int64(items) + *meta.RemainingItemCount, true, nil
+	}
+	// Without a continue token the list was complete
+	if meta.Continue == "" {
+		return int64(items), true, nil
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,84 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckImplicitAllNamespacesScan(t *testing.T) {
+	objects := func(pods, services int) []runtime.Object {
+		var objs []runtime.Object
+		for i := 0; i < pods; i++ {
+			objs = append(objs, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: fmt.Sprintf("ns-%d", i%3)}})
+		}
+		for i := 0; i < services; i++ {
+			objs = append(objs, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("svc-%d", i), Namespace: "default"}})
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		resource  string
+		threshold int64
+		wantErr   string
+	}{
+		{
+			name:      "pods under threshold",
+			objects:   objects(3, 0),
+			resource:  "pods",
+			threshold: 3,
+		},
+		{
+			name:      "pods over threshold",
+			objects:   objects(4, 0),
+			resource:  "pods",
+			threshold: 3,
+			wantErr:   "about 4 pods across all namespaces (more than 3)",
+		},
+		{
+			name:      "services counted separately from pods",
+			objects:   objects(10, 2),
+			resource:  "services",
+			threshold: 3,
+		},
+		{
+			name:      "services over threshold",
+			objects:   objects(0, 5),
+			resource:  "services",
+			threshold: 3,
+			wantErr:   "--all-namespaces to confirm",
+		},
+		{
+			name:      "unsupported resource",
+			resource:  "nodes",
+			threshold: 3,
+			wantErr:   `unsupported resource "nodes"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.objects...)
+
+			err := checkImplicitAllNamespacesScan(context.Background(), clientset, tt.resource, tt.threshold)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkImplicitAllNamespacesScan() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkImplicitAllNamespacesScan() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
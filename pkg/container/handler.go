@@ -1,67 +1,99 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"gofr.dev/pkg/gofr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/pischarti/nix/pkg/cli"
 	"github.com/pischarti/nix/pkg/config"
+	"github.com/pischarti/nix/pkg/k8s"
 	"github.com/pischarti/nix/pkg/print"
 )
 
+// Output formats for kube images
+const (
+	// OutputWide selects one row per container, with its pod and restart count
+	OutputWide = "wide"
+	// OutputJSON prints the images as a JSON array
+	OutputJSON = "json"
+	// OutputYAML prints the images as a YAML list
+	OutputYAML = "yaml"
+)
+
 // ImagesOptions represents the parsed command line options for the images command
 type ImagesOptions struct {
 	Namespace     string
 	AllNamespaces bool
 	ByPod         bool
+	ByRegistry    bool
+	ByNamespace   bool
+	Digest        bool
+	Count         bool
+	Output        string
 	TableOutput   bool
 	TableStyle    string
+	Color         string
+	NoHeaders     bool
 	SortBy        string
+	Timeout       time.Duration
+	Watch         bool
+	Refresh       time.Duration
+	ListAttempts  int
+	// Filter keeps only images whose reference contains it, ignoring case
+	Filter string
+	// Save is the file the image inventory is written to, as sorted JSON
+	Save string
+	// Diff is a file saved with --save to compare the running images against
+	Diff string
+	// AllNamespacesImplied is set when all namespaces are scanned only because no
+	// --namespace was given, rather than by an explicit --all-namespaces
+	AllNamespacesImplied bool
 }
 
 // ParseImagesArgs parses command line arguments for the images command
 func ParseImagesArgs(args []string) (*ImagesOptions, error) {
-	opts := &ImagesOptions{
-		TableStyle: "colored",
-		SortBy:     "namespace",
-	}
-
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "--namespace", "-n":
-			if i+1 < len(args) {
-				i++
-				opts.Namespace = args[i]
-			}
-		case "--all-namespaces", "-A":
-			opts.AllNamespaces = true
-		case "--by-pod":
-			opts.ByPod = true
-		case "--table", "-t":
-			opts.TableOutput = true
-		case "--style":
-			if i+1 < len(args) {
-				i++
-				opts.TableStyle = args[i]
-			}
-		case "--sort":
-			if i+1 < len(args) {
-				i++
-				opts.SortBy = args[i]
-			}
-		}
+	opts := &ImagesOptions{}
+
+	fs := cli.NewFlagSet("images")
+	fs.StringVarP(&opts.Namespace, "namespace", "n", "", "namespace to list images from")
+	fs.BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "list images from all namespaces")
+	fs.BoolVar(&opts.ByPod, "by-pod", false, "group images by pod")
+	fs.BoolVar(&opts.ByRegistry, "by-registry", false, "group images by registry")
+	fs.BoolVar(&opts.ByNamespace, "by-namespace", false, "summarize distinct images and pods per namespace")
+	fs.BoolVar(&opts.Digest, "digest", false, "show the digests of running images")
+	fs.StringVar(&opts.Filter, "filter", "", "only show images whose reference contains this text")
+	fs.BoolVar(&opts.Count, "count", false, "show how many pods reference each image")
+	fs.StringVarP(&opts.Output, "output", "o", "", "output format: wide, json, yaml")
+	fs.BoolVarP(&opts.TableOutput, "table", "t", false, "display unique images in a table")
+	fs.StringVar(&opts.TableStyle, "style", "colored", "table style")
+	fs.StringVar(&opts.Color, "color", print.ColorAuto, "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.StringVar(&opts.SortBy, "sort", "namespace", "sort by: namespace, image, none")
+	fs.DurationVar(&opts.Timeout, "timeout", k8s.DefaultTimeout, "timeout for Kubernetes API calls")
+	fs.BoolVarP(&opts.Watch, "watch", "w", false, "refresh the output periodically")
+	fs.DurationVar(&opts.Refresh, "refresh", DefaultRefreshInterval, "refresh interval for --watch")
+	fs.IntVar(&opts.ListAttempts, "list-attempts", k8s.DefaultListAttempts, "maximum attempts for listing pods on transient API server errors")
+	fs.StringVar(&opts.Save, "save", "", "write the image inventory to a file as JSON")
+	fs.StringVar(&opts.Diff, "diff", "", "compare the running images to an inventory saved with --save")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
 	// Apply defaults
 	if opts.Namespace == "" && !opts.AllNamespaces {
 		opts.AllNamespaces = true
+		opts.AllNamespacesImplied = true
 	}
 
 	// Validate options
@@ -71,6 +103,32 @@ func ParseImagesArgs(args []string) (*ImagesOptions, error) {
 	if opts.TableOutput && opts.ByPod {
 		return nil, fmt.Errorf("cannot use --table with --by-pod (table output is only for unique images)")
 	}
+	if opts.ByRegistry && opts.ByPod {
+		return nil, fmt.Errorf("cannot use --by-registry with --by-pod")
+	}
+	if opts.ByNamespace && (opts.ByPod || opts.ByRegistry) {
+		return nil, fmt.Errorf("cannot use --by-namespace with --by-pod or --by-registry")
+	}
+	switch opts.Output {
+	case "", OutputWide, OutputJSON, OutputYAML:
+	default:
+		return nil, fmt.Errorf("invalid output option '%s'. Valid options: wide, json, yaml", opts.Output)
+	}
+	if opts.Output == OutputWide && (opts.ByPod || opts.ByRegistry || opts.ByNamespace) {
+		return nil, fmt.Errorf("cannot use --output wide with --by-pod, --by-registry or --by-namespace")
+	}
+	if (opts.Output == OutputJSON || opts.Output == OutputYAML) && (opts.ByRegistry || opts.ByNamespace || opts.TableOutput || opts.NoHeaders) {
+		return nil, fmt.Errorf("cannot use --output %s with --by-registry, --by-namespace, --table or --no-headers", opts.Output)
+	}
+	if opts.Count && (opts.ByPod || opts.ByRegistry || opts.ByNamespace || opts.Output != "") {
+		return nil, fmt.Errorf("cannot use --count with --by-pod, --by-registry, --by-namespace or --output")
+	}
+	if (opts.Save != "" || opts.Diff != "") && opts.Watch {
+		return nil, fmt.Errorf("cannot use --save or --diff with --watch")
+	}
+	if opts.Diff != "" && (opts.ByPod || opts.ByRegistry || opts.ByNamespace || opts.TableOutput || opts.Count || opts.Output != "") {
+		return nil, fmt.Errorf("cannot use --diff with --by-pod, --by-registry, --by-namespace, --table, --count or --output")
+	}
 
 	// Validate sort option
 	validSorts := map[string]bool{"namespace": true, "image": true, "none": true}
@@ -78,6 +136,16 @@ func ParseImagesArgs(args []string) (*ImagesOptions, error) {
 		return nil, fmt.Errorf("invalid sort option '%s'. Valid options: namespace, image, none", opts.SortBy)
 	}
 
+	if opts.Timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be greater than zero")
+	}
+	if opts.Refresh <= 0 {
+		return nil, fmt.Errorf("--refresh must be greater than zero")
+	}
+	if opts.ListAttempts < 1 {
+		return nil, fmt.Errorf("--list-attempts must be at least 1")
+	}
+
 	return opts, nil
 }
 
@@ -98,6 +166,10 @@ func ImagesHandler(ctx *gofr.Context) (any, error) {
 	if err != nil {
 		return nil, err
 	}
+	useColor, err := print.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get Kubernetes client
 	cfg, err := config.GetKubeConfig()
@@ -115,26 +187,158 @@ func ImagesHandler(ctx *gofr.Context) (any, error) {
 		ns = metav1.NamespaceAll
 	}
 
-	// List pods
-	pods, err := clientset.CoreV1().Pods(ns).List(ctx.Context, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("list pods: %w", err)
+	// Guard against accidental cluster-wide scans of large clusters
+	if opts.AllNamespacesImplied {
+		checkCtx, cancel := context.WithTimeout(ctx.Context, opts.Timeout)
+		err := k8s.RetryList(opts.ListAttempts, func() error {
+			return checkImplicitAllNamespacesScan(checkCtx, clientset, "pods", AllNamespacesScanThreshold)
+		})
+		cancel()
+		if err != nil {
+			return nil, k8s.WrapTimeoutError(checkCtx, err, opts.Timeout)
+		}
+	}
+
+	render := func(ctx context.Context) error {
+		// List pods
+		listCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		var pods *corev1.PodList
+		err := k8s.RetryList(opts.ListAttempts, func() error {
+			var err error
+			pods, err = clientset.CoreV1().Pods(ns).List(listCtx, metav1.ListOptions{})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("list pods: %w", k8s.WrapTimeoutError(listCtx, err, opts.Timeout))
+		}
+
+		_, err = renderImages(pods, opts, useColor)
+		return err
 	}
 
-	// Handle different output modes
-	if opts.ByPod {
-		return handleByPodOutput(pods, opts)
+	if opts.Watch {
+		return nil, watchLoop(ctx.Context, opts.Refresh, "kube images", render)
 	}
 
-	if opts.TableOutput && opts.AllNamespaces {
-		return handleTableWithNamespacesOutput(pods, opts)
+	return nil, render(ctx.Context)
+}
+
+// renderImages prints the pod images in the output mode selected by opts, coloring tables
+// when useColor is set. With --diff the comparison to the saved inventory replaces that
+// output, and with --save the inventory is also written to a file.
+func renderImages(pods *corev1.PodList, opts *ImagesOptions, useColor bool) (any, error) {
+	// Show what is actually running rather than the requested tag
+	if opts.Digest {
+		pods = resolveImageDigests(pods)
+	}
+	if opts.Filter != "" {
+		pods = filterPodImages(pods, opts.Filter)
+	}
+
+	// The snapshot is taken before any output mode reorders the pods
+	inventory := collectImageInventory(pods)
+	if opts.Diff != "" {
+		saved, err := loadImageInventory(opts.Diff)
+		if err != nil {
+			return nil, err
+		}
+		added, removed := diffImageInventory(saved, inventory)
+		printImageInventoryDiff(os.Stdout, opts.Diff, added, removed)
+	} else if err := renderImageOutput(pods, opts, useColor); err != nil {
+		return nil, err
+	}
+
+	if opts.Save != "" {
+		if err := saveImageInventory(opts.Save, inventory); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Saved %d image(s) to %s\n", len(inventory), opts.Save)
 	}
 
-	return handleStandardOutput(pods, opts)
+	return nil, nil
+}
+
+// filterPodImages returns a copy of pods keeping only the containers whose image contains
+// substr, ignoring case. Pods left without containers are dropped.
+func filterPodImages(pods *corev1.PodList, substr string) *corev1.PodList {
+	filtered := pods.DeepCopy()
+	substr = strings.ToLower(substr)
+	matches := func(image string) bool {
+		return strings.Contains(strings.ToLower(image), substr)
+	}
+
+	items := filtered.Items[:0]
+	for _, pod := range filtered.Items {
+		pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+			return !matches(c.Image)
+		})
+		pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, func(c corev1.Container) bool {
+			return !matches(c.Image)
+		})
+		pod.Spec.EphemeralContainers = slices.DeleteFunc(pod.Spec.EphemeralContainers, func(c corev1.EphemeralContainer) bool {
+			return !matches(c.Image)
+		})
+		if len(pod.Spec.Containers)+len(pod.Spec.InitContainers)+len(pod.Spec.EphemeralContainers) > 0 {
+			items = append(items, pod)
+		}
+	}
+	filtered.Items = items
+
+	return filtered
+}
+
+// renderImageOutput prints the pod images in the output mode selected by opts
+func renderImageOutput(pods *corev1.PodList, opts *ImagesOptions, useColor bool) error {
+	var err error
+	switch {
+	case opts.ByPod:
+		_, err = handleByPodOutput(pods, opts)
+	case opts.ByRegistry:
+		_, err = handleByRegistryOutput(pods, opts, useColor)
+	case opts.ByNamespace:
+		print.PrintNamespaceTable(summarizeImagesByNamespace(pods), opts.TableStyle, useColor, opts.NoHeaders)
+	case opts.Count:
+		print.PrintImagesCountTable(countImagePods(pods), opts.TableStyle, useColor, opts.NoHeaders)
+	case opts.Output == OutputWide:
+		print.PrintImagesWideTable(containerImages(pods), opts.TableStyle, opts.SortBy, useColor, opts.NoHeaders)
+	case opts.Output == OutputJSON:
+		err = print.PrintImagesJSON(os.Stdout, imageNamespaces(pods, opts.SortBy))
+	case opts.Output == OutputYAML:
+		err = print.PrintImagesYAML(os.Stdout, imageNamespaces(pods, opts.SortBy))
+	case opts.TableOutput && opts.AllNamespaces:
+		_, err = handleTableWithNamespacesOutput(pods, opts, useColor)
+	default:
+		_, err = handleStandardOutput(pods, opts, useColor)
+	}
+	return err
 }
 
 // handleByPodOutput handles the --by-pod output format
 func handleByPodOutput(pods *corev1.PodList, opts *ImagesOptions) (any, error) {
+	podImages := collectPodImages(pods)
+
+	switch opts.Output {
+	case OutputJSON:
+		return nil, print.PrintPodImagesJSON(os.Stdout, podImages)
+	case OutputYAML:
+		return nil, print.PrintPodImagesYAML(os.Stdout, podImages)
+	}
+
+	for _, pod := range podImages {
+		containers := make([]string, len(pod.Containers))
+		for i, c := range pod.Containers {
+			containers[i] = c.String()
+		}
+		fmt.Printf("%s/%s: %s\n", pod.Namespace, pod.Pod, strings.Join(containers, ", "))
+	}
+
+	return nil, nil
+}
+
+// collectPodImages lists the containers of each pod with their images and kinds, and the
+// distinct images, sorted by namespace then pod name. Pods without images are left out.
+func collectPodImages(pods *corev1.PodList) []print.PodImages {
 	// Sort pods by namespace then name
 	sort.Slice(pods.Items, func(i, j int) bool {
 		a := pods.Items[i]
@@ -145,49 +349,247 @@ func handleByPodOutput(pods *corev1.PodList, opts *ImagesOptions) (any, error) {
 		return a.Namespace < b.Namespace
 	})
 
+	var podImages []print.PodImages
 	for _, pod := range pods.Items {
-		var images []string
+		var containers []print.PodContainerImage
+		add := func(name, image, kind string) {
+			if image != "" {
+				containers = append(containers, print.PodContainerImage{Name: name, Image: image, Kind: kind})
+			}
+		}
 
 		// Collect container images
+		for _, c := range pod.Spec.Containers {
+			add(c.Name, c.Image, print.ContainerKindRegular)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			add(c.Name, c.Image, print.ContainerKindInit)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(c.Name, c.Image, print.ContainerKindEphemeral)
+		}
+
+		if len(containers) == 0 {
+			continue
+		}
+
+		// Remove duplicates
+		seen := map[string]struct{}{}
+		uniq := make([]string, 0, len(containers))
+		for _, c := range containers {
+			if _, ok := seen[c.Image]; ok {
+				continue
+			}
+			seen[c.Image] = struct{}{}
+			uniq = append(uniq, c.Image)
+		}
+
+		podImages = append(podImages, print.PodImages{
+			Namespace:  pod.Namespace,
+			Pod:        pod.Name,
+			Images:     uniq,
+			Containers: containers,
+		})
+	}
+
+	return podImages
+}
+
+// imageNamespaces lists each distinct pair of namespace and image, ordered by sortBy
+func imageNamespaces(pods *corev1.PodList, sortBy string) []print.ImageNamespace {
+	var images []print.ImageNamespace
+	seen := map[print.ImageNamespace]struct{}{}
+	add := func(namespace, image string) {
+		if image == "" {
+			return
+		}
+		key := print.ImageNamespace{Namespace: namespace, Image: image}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		images = append(images, key)
+	}
+
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			add(pod.Namespace, c.Image)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			add(pod.Namespace, c.Image)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(pod.Namespace, c.Image)
+		}
+	}
+
+	print.SortImageNamespaces(images, sortBy)
+	return images
+}
+
+// handleByRegistryOutput handles the registry breakdown summary
+func handleByRegistryOutput(pods *corev1.PodList, opts *ImagesOptions, useColor bool) (any, error) {
+	registryImages := make(map[string]map[string]struct{})
+	registryNamespaces := make(map[string]map[string]struct{})
+
+	addImage := func(image, namespace string) {
+		if image == "" {
+			return
+		}
+		host := RegistryHost(image)
+		if registryImages[host] == nil {
+			registryImages[host] = map[string]struct{}{}
+			registryNamespaces[host] = map[string]struct{}{}
+		}
+		registryImages[host][image] = struct{}{}
+		registryNamespaces[host][namespace] = struct{}{}
+	}
+
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			addImage(c.Image, pod.Namespace)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			addImage(c.Image, pod.Namespace)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			addImage(c.Image, pod.Namespace)
+		}
+	}
+
+	registries := make([]print.RegistryInfo, 0, len(registryImages))
+	for host, images := range registryImages {
+		namespaces := make([]string, 0, len(registryNamespaces[host]))
+		for ns := range registryNamespaces[host] {
+			namespaces = append(namespaces, ns)
+		}
+		registries = append(registries, print.RegistryInfo{
+			Registry:   host,
+			ImageCount: len(images),
+			Namespaces: namespaces,
+		})
+	}
+
+	print.PrintRegistryTable(registries, opts.TableStyle, useColor, opts.NoHeaders)
+	return nil, nil
+}
+
+// summarizeImagesByNamespace counts the distinct images and the pods in each namespace
+func summarizeImagesByNamespace(pods *corev1.PodList) []print.NamespaceImageInfo {
+	namespaceImages := make(map[string]map[string]struct{})
+	namespacePods := make(map[string]int)
+
+	for _, pod := range pods.Items {
+		if namespaceImages[pod.Namespace] == nil {
+			namespaceImages[pod.Namespace] = map[string]struct{}{}
+		}
+		namespacePods[pod.Namespace]++
+
 		for _, c := range pod.Spec.Containers {
 			if c.Image != "" {
-				images = append(images, c.Image)
+				namespaceImages[pod.Namespace][c.Image] = struct{}{}
 			}
 		}
 		for _, c := range pod.Spec.InitContainers {
 			if c.Image != "" {
-				images = append(images, c.Image)
+				namespaceImages[pod.Namespace][c.Image] = struct{}{}
 			}
 		}
 		for _, c := range pod.Spec.EphemeralContainers {
 			if c.Image != "" {
-				images = append(images, c.Image)
+				namespaceImages[pod.Namespace][c.Image] = struct{}{}
 			}
 		}
+	}
 
-		if len(images) == 0 {
-			continue
-		}
+	namespaces := make([]print.NamespaceImageInfo, 0, len(namespaceImages))
+	for ns, images := range namespaceImages {
+		namespaces = append(namespaces, print.NamespaceImageInfo{
+			Namespace:  ns,
+			ImageCount: len(images),
+			PodCount:   namespacePods[ns],
+		})
+	}
+	return namespaces
+}
 
-		// Remove duplicates
+// countImagePods counts the pods referencing each image. A pod running an image in
+// several containers counts once for it.
+func countImagePods(pods *corev1.PodList) []print.ImageCountInfo {
+	counts := make(map[string]int)
+	for _, pod := range pods.Items {
 		seen := map[string]struct{}{}
-		uniq := make([]string, 0, len(images))
-		for _, img := range images {
-			if _, ok := seen[img]; ok {
-				continue
+		add := func(image string) {
+			if image == "" {
+				return
 			}
-			seen[img] = struct{}{}
-			uniq = append(uniq, img)
+			if _, ok := seen[image]; ok {
+				return
+			}
+			seen[image] = struct{}{}
+			counts[image]++
+		}
+
+		for _, c := range pod.Spec.Containers {
+			add(c.Image)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			add(c.Image)
 		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(c.Image)
+		}
+	}
 
-		fmt.Printf("%s/%s: %s\n", pod.Namespace, pod.Name, strings.Join(uniq, ", "))
+	images := make([]print.ImageCountInfo, 0, len(counts))
+	for image, count := range counts {
+		images = append(images, print.ImageCountInfo{Image: image, Count: count})
 	}
+	return images
+}
 
-	return nil, nil
+// containerImages lists every container of every pod with its image and restart count,
+// without deduplicating, so each image can be traced to where it runs
+func containerImages(pods *corev1.PodList) []print.ContainerImageInfo {
+	var containers []print.ContainerImageInfo
+
+	add := func(pod corev1.Pod, name, image string, statuses []corev1.ContainerStatus) {
+		if image == "" {
+			return
+		}
+		var restarts int32
+		for _, status := range statuses {
+			if status.Name == name {
+				restarts = status.RestartCount
+				break
+			}
+		}
+		containers = append(containers, print.ContainerImageInfo{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: name,
+			Image:     image,
+			Restarts:  restarts,
+		})
+	}
+
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			add(pod, c.Name, c.Image, pod.Status.ContainerStatuses)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			add(pod, c.Name, c.Image, pod.Status.InitContainerStatuses)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(pod, c.Name, c.Image, pod.Status.EphemeralContainerStatuses)
+		}
+	}
+
+	return containers
 }
 
 // handleTableWithNamespacesOutput handles table output with namespace information
-func handleTableWithNamespacesOutput(pods *corev1.PodList, opts *ImagesOptions) (any, error) {
+func handleTableWithNamespacesOutput(pods *corev1.PodList, opts *ImagesOptions, useColor bool) (any, error) {
 	imageNamespaceMap := make(map[string]string)
 
 	for _, pod := range pods.Items {
@@ -209,12 +611,12 @@ func handleTableWithNamespacesOutput(pods *corev1.PodList, opts *ImagesOptions)
 		}
 	}
 
-	print.PrintImagesTableWithNamespaces(imageNamespaceMap, opts.TableStyle, opts.SortBy)
+	print.PrintImagesTableWithNamespaces(imageNamespaceMap, opts.TableStyle, opts.SortBy, useColor, opts.NoHeaders)
 	return nil, nil
 }
 
 // handleStandardOutput handles standard list or table output
-func handleStandardOutput(pods *corev1.PodList, opts *ImagesOptions) (any, error) {
+func handleStandardOutput(pods *corev1.PodList, opts *ImagesOptions, useColor bool) (any, error) {
 	imagesSet := map[string]struct{}{}
 
 	for _, pod := range pods.Items {
@@ -238,7 +640,7 @@ func handleStandardOutput(pods *corev1.PodList, opts *ImagesOptions) (any, error
 
 	// Output based on format
 	if opts.TableOutput {
-		print.PrintImagesTable(imagesSet, opts.Namespace, opts.AllNamespaces, opts.TableStyle, opts.SortBy)
+		print.PrintImagesTable(imagesSet, opts.Namespace, opts.AllNamespaces, opts.TableStyle, opts.SortBy, useColor, opts.NoHeaders)
 	} else {
 		print.PrintImagesList(imagesSet, opts.SortBy)
 	}
@@ -252,62 +654,98 @@ type ServicesOptions struct {
 	AllNamespaces   bool
 	TableOutput     bool
 	TableStyle      string
+	Color           string
+	NoHeaders       bool
 	SortBy          string
+	AnnotationSort  string
 	AnnotationValue string
+	Type            string
+	Selector        string
+	Orphaned        bool
+	Timeout         time.Duration
+	Watch           bool
+	Refresh         time.Duration
+	ListAttempts    int
+	// AllNamespacesImplied is set when all namespaces are scanned only because no
+	// --namespace was given, rather than by an explicit --all-namespaces
+	AllNamespacesImplied bool
+}
+
+// serviceTypes maps the lowercased --type values to the service types they select
+var serviceTypes = map[string]corev1.ServiceType{
+	"clusterip":    corev1.ServiceTypeClusterIP,
+	"nodeport":     corev1.ServiceTypeNodePort,
+	"loadbalancer": corev1.ServiceTypeLoadBalancer,
+	"externalname": corev1.ServiceTypeExternalName,
 }
 
 // ParseServicesArgs parses command line arguments for the services command
 func ParseServicesArgs(args []string) (*ServicesOptions, error) {
-	opts := &ServicesOptions{
-		TableStyle: "colored",
-		SortBy:     "namespace",
-	}
-
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "--namespace", "-n":
-			if i+1 < len(args) {
-				i++
-				opts.Namespace = args[i]
-			}
-		case "--all-namespaces", "-A":
-			opts.AllNamespaces = true
-		case "--table", "-t":
-			opts.TableOutput = true
-		case "--style":
-			if i+1 < len(args) {
-				i++
-				opts.TableStyle = args[i]
-			}
-		case "--sort":
-			if i+1 < len(args) {
-				i++
-				opts.SortBy = args[i]
-			}
-		case "--annotation-value":
-			if i+1 < len(args) {
-				i++
-				opts.AnnotationValue = args[i]
-			}
-		}
+	opts := &ServicesOptions{}
+
+	fs := cli.NewFlagSet("services")
+	fs.StringVarP(&opts.Namespace, "namespace", "n", "", "namespace to list services from")
+	fs.BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "list services from all namespaces")
+	fs.BoolVarP(&opts.TableOutput, "table", "t", false, "display services in a table")
+	fs.StringVar(&opts.TableStyle, "style", "colored", "table style")
+	fs.StringVar(&opts.Color, "color", print.ColorAuto, "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.StringVar(&opts.SortBy, "sort", "namespace", "sort by: namespace, name, none")
+	fs.StringVar(&opts.AnnotationSort, "sort-annotations", print.AnnotationSortKey, "order annotations by: key, value")
+	fs.StringVar(&opts.AnnotationValue, "annotation-value", "", "filter by annotation key or value containing this text")
+	fs.StringVar(&opts.Type, "type", "", "only show services of this type: ClusterIP, NodePort, LoadBalancer, ExternalName")
+	fs.StringVar(&opts.Selector, "selector", "", "only show services whose selector contains these key=value labels")
+	fs.BoolVar(&opts.Orphaned, "orphaned", false, "only show services whose selector matches no pods")
+	fs.DurationVar(&opts.Timeout, "timeout", k8s.DefaultTimeout, "timeout for Kubernetes API calls")
+	fs.BoolVarP(&opts.Watch, "watch", "w", false, "refresh the output periodically")
+	fs.DurationVar(&opts.Refresh, "refresh", DefaultRefreshInterval, "refresh interval for --watch")
+	fs.IntVar(&opts.ListAttempts, "list-attempts", k8s.DefaultListAttempts, "maximum attempts for listing services on transient API server errors")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
 	// Apply defaults
 	if opts.Namespace == "" && !opts.AllNamespaces {
 		opts.AllNamespaces = true
+		opts.AllNamespacesImplied = true
 	}
 
 	// Validate options
 	if opts.Namespace != "" && opts.AllNamespaces {
 		return nil, fmt.Errorf("cannot use --namespace and --all-namespaces together")
 	}
+	if opts.Orphaned && opts.TableOutput {
+		return nil, fmt.Errorf("cannot use --orphaned with --table (orphaned services are listed as deletion candidates)")
+	}
 
 	// Validate sort option
 	validSorts := map[string]bool{"namespace": true, "name": true, "none": true}
 	if !validSorts[opts.SortBy] {
 		return nil, fmt.Errorf("invalid sort option '%s'. Valid options: namespace, name, none", opts.SortBy)
 	}
+	if opts.AnnotationSort != print.AnnotationSortKey && opts.AnnotationSort != print.AnnotationSortValue {
+		return nil, fmt.Errorf("invalid sort-annotations option '%s'. Valid options: key, value", opts.AnnotationSort)
+	}
+	if opts.Type != "" {
+		serviceType, ok := serviceTypes[strings.ToLower(opts.Type)]
+		if !ok {
+			return nil, fmt.Errorf("invalid type '%s'. Valid options: ClusterIP, NodePort, LoadBalancer, ExternalName", opts.Type)
+		}
+		opts.Type = string(serviceType)
+	}
+	if _, err := labels.ConvertSelectorToLabelsMap(opts.Selector); err != nil {
+		return nil, fmt.Errorf("invalid --selector '%s', expected key=value[,key=value]: %w", opts.Selector, err)
+	}
+
+	if opts.Timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be greater than zero")
+	}
+	if opts.Refresh <= 0 {
+		return nil, fmt.Errorf("--refresh must be greater than zero")
+	}
+	if opts.ListAttempts < 1 {
+		return nil, fmt.Errorf("--list-attempts must be at least 1")
+	}
 
 	return opts, nil
 }
@@ -329,6 +767,10 @@ func ServicesHandler(ctx *gofr.Context) (any, error) {
 	if err != nil {
 		return nil, err
 	}
+	useColor, err := print.ResolveColor(opts.Color)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get Kubernetes client
 	cfg, err := config.GetKubeConfig()
@@ -346,28 +788,111 @@ func ServicesHandler(ctx *gofr.Context) (any, error) {
 		ns = metav1.NamespaceAll
 	}
 
-	// List services
-	services, err := clientset.CoreV1().Services(ns).List(ctx.Context, metav1.ListOptions{})
+	// Guard against accidental cluster-wide scans of large clusters
+	if opts.AllNamespacesImplied {
+		checkCtx, cancel := context.WithTimeout(ctx.Context, opts.Timeout)
+		err := k8s.RetryList(opts.ListAttempts, func() error {
+			return checkImplicitAllNamespacesScan(checkCtx, clientset, "services", AllNamespacesScanThreshold)
+		})
+		cancel()
+		if err != nil {
+			return nil, k8s.WrapTimeoutError(checkCtx, err, opts.Timeout)
+		}
+	}
+
+	// Already validated by ParseServicesArgs
+	selector, err := labels.ConvertSelectorToLabelsMap(opts.Selector)
 	if err != nil {
-		return nil, fmt.Errorf("list services: %w", err)
+		return nil, err
 	}
 
-	// Filter services with matching annotations
-	var filteredServices []corev1.Service
-	for _, service := range services.Items {
-		if hasMatchingAnnotation(service, opts.AnnotationValue) {
-			filteredServices = append(filteredServices, service)
+	render := func(ctx context.Context) error {
+		// List services
+		listCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		var services *corev1.ServiceList
+		err := k8s.RetryList(opts.ListAttempts, func() error {
+			var err error
+			services, err = clientset.CoreV1().Services(ns).List(listCtx, metav1.ListOptions{})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("list services: %w", k8s.WrapTimeoutError(listCtx, err, opts.Timeout))
+		}
+
+		if opts.Orphaned {
+			var pods *corev1.PodList
+			err := k8s.RetryList(opts.ListAttempts, func() error {
+				var err error
+				pods, err = clientset.CoreV1().Pods(ns).List(listCtx, metav1.ListOptions{})
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("list pods: %w", k8s.WrapTimeoutError(listCtx, err, opts.Timeout))
+			}
+
+			// Every service is a candidate unless an annotation, type or selector filter is given
+			var candidates []corev1.Service
+			for _, service := range services.Items {
+				if opts.AnnotationValue != "" && !hasMatchingAnnotation(service, opts.AnnotationValue) {
+					continue
+				}
+				if hasServiceType(service, opts.Type) && hasMatchingSelector(service, selector) {
+					candidates = append(candidates, service)
+				}
+			}
+
+			print.PrintOrphanedServices(findOrphanedServices(candidates, pods.Items), opts.SortBy)
+			return nil
+		}
+
+		// Filter services with matching annotations, then by type and selector
+		var filteredServices []corev1.Service
+		for _, service := range services.Items {
+			if hasMatchingAnnotation(service, opts.AnnotationValue) && hasServiceType(service, opts.Type) && hasMatchingSelector(service, selector) {
+				filteredServices = append(filteredServices, service)
+			}
+		}
+
+		// Handle output
+		if opts.TableOutput {
+			print.PrintServicesTable(filteredServices, opts.TableStyle, opts.SortBy, opts.AnnotationSort, useColor, opts.NoHeaders)
+		} else {
+			print.PrintServicesList(filteredServices, opts.SortBy, opts.AnnotationSort)
 		}
+
+		return nil
 	}
 
-	// Handle output
-	if opts.TableOutput {
-		print.PrintServicesTable(filteredServices, opts.TableStyle, opts.SortBy)
-	} else {
-		print.PrintServicesList(filteredServices, opts.SortBy)
+	if opts.Watch {
+		return nil, watchLoop(ctx.Context, opts.Refresh, "kube services", render)
 	}
 
-	return nil, nil
+	return nil, render(ctx.Context)
+}
+
+// hasServiceType reports whether the service is of serviceType, or always when serviceType
+// is empty. Services without a type count as ClusterIP, the API server default.
+func hasServiceType(service corev1.Service, serviceType string) bool {
+	if serviceType == "" {
+		return true
+	}
+	actual := service.Spec.Type
+	if actual == "" {
+		actual = corev1.ServiceTypeClusterIP
+	}
+	return string(actual) == serviceType
+}
+
+// hasMatchingSelector reports whether the service's selector contains every label in
+// selector with the same value. An empty selector matches every service.
+func hasMatchingSelector(service corev1.Service, selector labels.Set) bool {
+	for key, value := range selector {
+		if actual, ok := service.Spec.Selector[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
 }
 
 // hasMatchingAnnotation checks if a service has any annotation matching the specified value
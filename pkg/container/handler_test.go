@@ -1,11 +1,17 @@
 package container
 
 import (
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/pischarti/nix/pkg/print"
 )
 
 func TestParseImagesArgs(t *testing.T) {
@@ -28,6 +34,18 @@ func TestParseImagesArgs(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "table without headers",
+			args: []string{"images", "--table", "--no-headers"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				TableOutput:   true,
+				TableStyle:    "colored",
+				NoHeaders:     true,
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
 		{
 			name: "namespace flag",
 			args: []string{"images", "--namespace", "test"},
@@ -134,6 +152,162 @@ func TestParseImagesArgs(t *testing.T) {
 			args:          []string{"images", "--table", "--by-pod"},
 			expectedError: true,
 		},
+		{
+			name: "by-registry flag",
+			args: []string{"images", "--by-registry"},
+			expectedOpts: &ImagesOptions{
+				Namespace:     "",
+				AllNamespaces: true,
+				ByRegistry:    true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name: "by-namespace flag",
+			args: []string{"images", "--by-namespace"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				ByNamespace:   true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name: "digest flag",
+			args: []string{"images", "--digest", "--by-pod"},
+			expectedOpts: &ImagesOptions{
+				Namespace:     "",
+				AllNamespaces: true,
+				ByPod:         true,
+				Digest:        true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name: "equals values and short flags",
+			args: []string{"images", "-n", "test", "--style=box", "-t"},
+			expectedOpts: &ImagesOptions{
+				Namespace:     "test",
+				AllNamespaces: false,
+				TableOutput:   true,
+				TableStyle:    "box",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name:          "unknown flag",
+			args:          []string{"images", "--by-node"},
+			expectedError: true,
+		},
+		{
+			name:          "conflicting by-registry and by-pod flags",
+			args:          []string{"images", "--by-registry", "--by-pod"},
+			expectedError: true,
+		},
+		{
+			name:          "conflicting by-namespace and by-registry flags",
+			args:          []string{"images", "--by-namespace", "--by-registry"},
+			expectedError: true,
+		},
+		{
+			name: "wide output",
+			args: []string{"images", "-o", "wide", "--sort", "image"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				Output:        "wide",
+				TableStyle:    "colored",
+				SortBy:        "image",
+			},
+			expectedError: false,
+		},
+		{
+			name: "json output with by-pod",
+			args: []string{"images", "-o", "json", "--by-pod"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				ByPod:         true,
+				Output:        "json",
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name: "filter flag",
+			args: []string{"images", "--filter", "quay.io"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				Filter:        "quay.io",
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name: "count flag",
+			args: []string{"images", "--count", "--table"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				Count:         true,
+				TableOutput:   true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+			},
+			expectedError: false,
+		},
+		{
+			name:          "conflicting count and by-pod flags",
+			args:          []string{"images", "--count", "--by-pod"},
+			expectedError: true,
+		},
+		{
+			name:          "invalid output",
+			args:          []string{"images", "--output", "csv"},
+			expectedError: true,
+		},
+		{
+			name:          "conflicting yaml output and table flags",
+			args:          []string{"images", "--output", "yaml", "--table"},
+			expectedError: true,
+		},
+		{
+			name:          "conflicting json output and by-registry flags",
+			args:          []string{"images", "--output", "json", "--by-registry"},
+			expectedError: true,
+		},
+		{
+			name:          "conflicting wide output and by-pod flags",
+			args:          []string{"images", "--output", "wide", "--by-pod"},
+			expectedError: true,
+		},
+		{
+			name:          "save with watch",
+			args:          []string{"images", "--save", "images.json", "--watch"},
+			expectedError: true,
+		},
+		{
+			name:          "diff with by-pod",
+			args:          []string{"images", "--diff", "images.json", "--by-pod"},
+			expectedError: true,
+		},
+		{
+			name: "diff and save",
+			args: []string{"images", "--diff", "old.json", "--save", "new.json"},
+			expectedOpts: &ImagesOptions{
+				AllNamespaces: true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+				Save:          "new.json",
+				Diff:          "old.json",
+			},
+			expectedError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,6 +331,24 @@ func TestParseImagesArgs(t *testing.T) {
 				if opts.ByPod != tt.expectedOpts.ByPod {
 					t.Errorf("Expected byPod %v, got %v", tt.expectedOpts.ByPod, opts.ByPod)
 				}
+				if opts.ByRegistry != tt.expectedOpts.ByRegistry {
+					t.Errorf("Expected byRegistry %v, got %v", tt.expectedOpts.ByRegistry, opts.ByRegistry)
+				}
+				if opts.ByNamespace != tt.expectedOpts.ByNamespace {
+					t.Errorf("Expected byNamespace %v, got %v", tt.expectedOpts.ByNamespace, opts.ByNamespace)
+				}
+				if opts.Digest != tt.expectedOpts.Digest {
+					t.Errorf("Expected digest %v, got %v", tt.expectedOpts.Digest, opts.Digest)
+				}
+				if opts.Filter != tt.expectedOpts.Filter {
+					t.Errorf("Expected filter %q, got %q", tt.expectedOpts.Filter, opts.Filter)
+				}
+				if opts.Count != tt.expectedOpts.Count {
+					t.Errorf("Expected count %v, got %v", tt.expectedOpts.Count, opts.Count)
+				}
+				if opts.Output != tt.expectedOpts.Output {
+					t.Errorf("Expected output %v, got %v", tt.expectedOpts.Output, opts.Output)
+				}
 				if opts.TableOutput != tt.expectedOpts.TableOutput {
 					t.Errorf("Expected tableOutput %v, got %v", tt.expectedOpts.TableOutput, opts.TableOutput)
 				}
@@ -166,6 +358,365 @@ func TestParseImagesArgs(t *testing.T) {
 				if opts.SortBy != tt.expectedOpts.SortBy {
 					t.Errorf("Expected sortBy %v, got %v", tt.expectedOpts.SortBy, opts.SortBy)
 				}
+				if opts.NoHeaders != tt.expectedOpts.NoHeaders {
+					t.Errorf("Expected noHeaders %v, got %v", tt.expectedOpts.NoHeaders, opts.NoHeaders)
+				}
+				if opts.Save != tt.expectedOpts.Save || opts.Diff != tt.expectedOpts.Diff {
+					t.Errorf("Expected save %q and diff %q, got %q and %q", tt.expectedOpts.Save, tt.expectedOpts.Diff, opts.Save, opts.Diff)
+				}
+				wantImplied := tt.expectedOpts.AllNamespaces && !slices.Contains(tt.args, "--all-namespaces") && !slices.Contains(tt.args, "-A")
+				if opts.AllNamespacesImplied != wantImplied {
+					t.Errorf("Expected allNamespacesImplied %v, got %v", wantImplied, opts.AllNamespacesImplied)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeImagesByNamespace(t *testing.T) {
+	pod := func(namespace, name string, images ...string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Image: image})
+		}
+		return p
+	}
+
+	initPod := pod("default", "migrate")
+	initPod.Spec.InitContainers = []corev1.Container{{Image: "flyway:10"}}
+
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		pod("default", "web-1", "nginx:1.25", "envoy:1.30"),
+		pod("default", "web-2", "nginx:1.25", "envoy:1.30"),
+		initPod,
+		pod("kube-system", "coredns", "coredns:1.11"),
+	}}
+
+	summary := summarizeImagesByNamespace(pods)
+	slices.SortFunc(summary, func(a, b print.NamespaceImageInfo) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	expected := []print.NamespaceImageInfo{
+		{Namespace: "default", ImageCount: 3, PodCount: 3},
+		{Namespace: "kube-system", ImageCount: 1, PodCount: 1},
+	}
+	if !slices.Equal(summary, expected) {
+		t.Errorf("summarizeImagesByNamespace() = %+v, want %+v", summary, expected)
+	}
+}
+
+func TestCountImagePods(t *testing.T) {
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Image: "busybox:1.36"}},
+				Containers:     []corev1.Container{{Image: "nginx:1.25"}, {Image: "busybox:1.36"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-2"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.25"}}},
+		},
+	}}
+
+	got := countImagePods(pods)
+	slices.SortFunc(got, func(a, b print.ImageCountInfo) int {
+		return strings.Compare(a.Image, b.Image)
+	})
+
+	// busybox runs twice in web-1 but is referenced by one pod
+	expected := []print.ImageCountInfo{
+		{Image: "busybox:1.36", Count: 1},
+		{Image: "nginx:1.25", Count: 2},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("countImagePods() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestContainerImages(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "flyway:10"}},
+			Containers: []corev1.Container{
+				{Name: "app", Image: "nginx:1.25"},
+				{Name: "sidecar", Image: "envoy:1.30"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", RestartCount: 1},
+				{Name: "app", RestartCount: 3},
+			},
+		},
+	}
+	// A second replica running the same image is listed again, not deduplicated
+	replica := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-2"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25"}}},
+	}
+
+	got := containerImages(&corev1.PodList{Items: []corev1.Pod{pod, replica}})
+
+	expected := []print.ContainerImageInfo{
+		{Namespace: "default", Pod: "web-1", Container: "app", Image: "nginx:1.25", Restarts: 3},
+		{Namespace: "default", Pod: "web-1", Container: "sidecar", Image: "envoy:1.30", Restarts: 1},
+		{Namespace: "default", Pod: "web-1", Container: "migrate", Image: "flyway:10"},
+		{Namespace: "default", Pod: "web-2", Container: "app", Image: "nginx:1.25"},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("containerImages() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestCollectPodImages(t *testing.T) {
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-2"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "migrate", Image: "flyway:10"}},
+				Containers:     []corev1.Container{{Name: "app", Image: "nginx:1.25"}, {Name: "proxy", Image: "nginx:1.25"}},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox:1.36"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25"}}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "empty"}},
+	}}
+
+	got := collectPodImages(pods)
+
+	expected := []print.PodImages{
+		{
+			Namespace:  "default",
+			Pod:        "web-1",
+			Images:     []string{"nginx:1.25"},
+			Containers: []print.PodContainerImage{{Name: "app", Image: "nginx:1.25", Kind: print.ContainerKindRegular}},
+		},
+		{
+			Namespace: "default",
+			Pod:       "web-2",
+			Images:    []string{"nginx:1.25", "flyway:10", "busybox:1.36"},
+			Containers: []print.PodContainerImage{
+				{Name: "app", Image: "nginx:1.25", Kind: print.ContainerKindRegular},
+				{Name: "proxy", Image: "nginx:1.25", Kind: print.ContainerKindRegular},
+				{Name: "migrate", Image: "flyway:10", Kind: print.ContainerKindInit},
+				{Name: "debugger", Image: "busybox:1.36", Kind: print.ContainerKindEphemeral},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("collectPodImages() = %+v, want %+v", got, expected)
+	}
+
+	formatted := make([]string, len(got[1].Containers))
+	for i, c := range got[1].Containers {
+		formatted[i] = c.String()
+	}
+	expectedLine := "app=nginx:1.25, proxy=nginx:1.25, migrate=flyway:10 (init), debugger=busybox:1.36 (ephemeral)"
+	if line := strings.Join(formatted, ", "); line != expectedLine {
+		t.Errorf("formatted containers = %q, want %q", line, expectedLine)
+	}
+}
+
+func TestImageNamespaces(t *testing.T) {
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.25"}, {Image: "envoy:1.30"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-2"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.25"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "api"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.25"}}},
+		},
+	}}
+
+	got := imageNamespaces(pods, "namespace")
+
+	// Each namespace and image pair is listed once
+	expected := []print.ImageNamespace{
+		{Namespace: "apps", Image: "nginx:1.25"},
+		{Namespace: "default", Image: "envoy:1.30"},
+		{Namespace: "default", Image: "nginx:1.25"},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("imageNamespaces() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestFilterPodImages(t *testing.T) {
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "prometheus"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36"}},
+				Containers: []corev1.Container{
+					{Name: "prometheus", Image: "Quay.io/prometheus/prometheus:v2.50"},
+					{Name: "reloader", Image: "ghcr.io/jimmidyson/configmap-reload:v0.12"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25"}}},
+		},
+	}}
+
+	filtered := filterPodImages(pods, "quay.IO")
+
+	// Matching ignores case, and the pod without a match is dropped
+	got := imageNamespaces(filtered, "namespace")
+	expected := []print.ImageNamespace{
+		{Namespace: "monitoring", Image: "Quay.io/prometheus/prometheus:v2.50"},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("filterPodImages() kept %+v, want %+v", got, expected)
+	}
+
+	// The input is left untouched
+	if len(pods.Items) != 2 || len(pods.Items[0].Spec.Containers) != 2 || len(pods.Items[0].Spec.InitContainers) != 1 {
+		t.Errorf("filterPodImages() modified its input: %+v", pods.Items)
+	}
+}
+
+func TestParseTimeoutArgs(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedTimeout time.Duration
+		expectedError   bool
+	}{
+		{
+			name:            "default timeout",
+			args:            []string{"images"},
+			expectedTimeout: 30 * time.Second,
+		},
+		{
+			name:            "custom timeout",
+			args:            []string{"images", "--timeout", "10s"},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "minutes timeout",
+			args:            []string{"images", "--timeout", "2m"},
+			expectedTimeout: 2 * time.Minute,
+		},
+		{
+			name:          "invalid timeout",
+			args:          []string{"images", "--timeout", "soon"},
+			expectedError: true,
+		},
+		{
+			name:          "zero timeout",
+			args:          []string{"images", "--timeout", "0s"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imagesOpts, imagesErr := ParseImagesArgs(tt.args)
+			servicesOpts, servicesErr := ParseServicesArgs(tt.args)
+
+			if tt.expectedError {
+				if imagesErr == nil {
+					t.Error("Expected images error but got none")
+				}
+				if servicesErr == nil {
+					t.Error("Expected services error but got none")
+				}
+				return
+			}
+			if imagesErr != nil {
+				t.Fatalf("Expected no images error but got: %v", imagesErr)
+			}
+			if servicesErr != nil {
+				t.Fatalf("Expected no services error but got: %v", servicesErr)
+			}
+			if imagesOpts.Timeout != tt.expectedTimeout {
+				t.Errorf("Expected images timeout %v, got %v", tt.expectedTimeout, imagesOpts.Timeout)
+			}
+			if servicesOpts.Timeout != tt.expectedTimeout {
+				t.Errorf("Expected services timeout %v, got %v", tt.expectedTimeout, servicesOpts.Timeout)
+			}
+		})
+	}
+}
+
+func TestParseWatchArgs(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedWatch   bool
+		expectedRefresh time.Duration
+		expectedError   bool
+	}{
+		{
+			name:            "watch disabled by default",
+			args:            []string{"services"},
+			expectedWatch:   false,
+			expectedRefresh: 10 * time.Second,
+		},
+		{
+			name:            "watch with default refresh",
+			args:            []string{"services", "--watch"},
+			expectedWatch:   true,
+			expectedRefresh: 10 * time.Second,
+		},
+		{
+			name:            "short watch flag with custom refresh",
+			args:            []string{"services", "-w", "--refresh", "5s"},
+			expectedWatch:   true,
+			expectedRefresh: 5 * time.Second,
+		},
+		{
+			name:          "invalid refresh",
+			args:          []string{"services", "--watch", "--refresh", "often"},
+			expectedError: true,
+		},
+		{
+			name:          "negative refresh",
+			args:          []string{"services", "--watch", "--refresh", "-1s"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imagesOpts, imagesErr := ParseImagesArgs(tt.args)
+			servicesOpts, servicesErr := ParseServicesArgs(tt.args)
+
+			if tt.expectedError {
+				if imagesErr == nil {
+					t.Error("Expected images error but got none")
+				}
+				if servicesErr == nil {
+					t.Error("Expected services error but got none")
+				}
+				return
+			}
+			if imagesErr != nil {
+				t.Fatalf("Expected no images error but got: %v", imagesErr)
+			}
+			if servicesErr != nil {
+				t.Fatalf("Expected no services error but got: %v", servicesErr)
+			}
+			if imagesOpts.Watch != tt.expectedWatch || servicesOpts.Watch != tt.expectedWatch {
+				t.Errorf("Expected watch %v, got images %v, services %v", tt.expectedWatch, imagesOpts.Watch, servicesOpts.Watch)
+			}
+			if imagesOpts.Refresh != tt.expectedRefresh || servicesOpts.Refresh != tt.expectedRefresh {
+				t.Errorf("Expected refresh %v, got images %v, services %v", tt.expectedRefresh, imagesOpts.Refresh, servicesOpts.Refresh)
 			}
 		})
 	}
@@ -430,6 +981,70 @@ func TestParseServicesArgs(t *testing.T) {
 			args:          []string{"services", "--namespace", "test", "--all-namespaces"},
 			expectedError: true,
 		},
+		{
+			name: "orphaned flag",
+			args: []string{"services", "--orphaned", "-n", "staging"},
+			expectedOpts: &ServicesOptions{
+				Namespace:  "staging",
+				TableStyle: "colored",
+				SortBy:     "namespace",
+				Orphaned:   true,
+			},
+			expectedError: false,
+		},
+		{
+			name:          "orphaned with table",
+			args:          []string{"services", "--orphaned", "--table"},
+			expectedError: true,
+		},
+		{
+			name: "sort annotations by value",
+			args: []string{"services", "--sort-annotations", "value"},
+			expectedOpts: &ServicesOptions{
+				AllNamespaces:  true,
+				TableStyle:     "colored",
+				SortBy:         "namespace",
+				AnnotationSort: "value",
+			},
+			expectedError: false,
+		},
+		{
+			name:          "invalid sort annotations option",
+			args:          []string{"services", "--sort-annotations", "length"},
+			expectedError: true,
+		},
+		{
+			name: "type filter is case-insensitive",
+			args: []string{"services", "--type", "loadbalancer"},
+			expectedOpts: &ServicesOptions{
+				AllNamespaces: true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+				Type:          "LoadBalancer",
+			},
+			expectedError: false,
+		},
+		{
+			name:          "invalid type",
+			args:          []string{"services", "--type", "Headless"},
+			expectedError: true,
+		},
+		{
+			name: "selector filter",
+			args: []string{"services", "--selector", "app=web,tier=frontend"},
+			expectedOpts: &ServicesOptions{
+				AllNamespaces: true,
+				TableStyle:    "colored",
+				SortBy:        "namespace",
+				Selector:      "app=web,tier=frontend",
+			},
+			expectedError: false,
+		},
+		{
+			name:          "selector without value",
+			args:          []string{"services", "--selector", "app"},
+			expectedError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -462,6 +1077,18 @@ func TestParseServicesArgs(t *testing.T) {
 				if opts.AnnotationValue != tt.expectedOpts.AnnotationValue {
 					t.Errorf("Expected annotationValue %v, got %v", tt.expectedOpts.AnnotationValue, opts.AnnotationValue)
 				}
+				if tt.expectedOpts.AnnotationSort != "" && opts.AnnotationSort != tt.expectedOpts.AnnotationSort {
+					t.Errorf("Expected annotationSort %v, got %v", tt.expectedOpts.AnnotationSort, opts.AnnotationSort)
+				}
+				if opts.Selector != tt.expectedOpts.Selector {
+					t.Errorf("Expected selector %q, got %q", tt.expectedOpts.Selector, opts.Selector)
+				}
+				if opts.Type != tt.expectedOpts.Type {
+					t.Errorf("Expected type %q, got %q", tt.expectedOpts.Type, opts.Type)
+				}
+				if opts.Orphaned != tt.expectedOpts.Orphaned {
+					t.Errorf("Expected orphaned %v, got %v", tt.expectedOpts.Orphaned, opts.Orphaned)
+				}
 			}
 		})
 	}
@@ -498,6 +1125,83 @@ func TestServicesOptions(t *testing.T) {
 	}
 }
 
+func TestHasServiceType(t *testing.T) {
+	loadBalancer := corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}}
+	untyped := corev1.Service{}
+
+	if !hasServiceType(loadBalancer, "") {
+		t.Error("hasServiceType() with no type should match every service")
+	}
+	if !hasServiceType(loadBalancer, "LoadBalancer") {
+		t.Error("hasServiceType() should match a LoadBalancer service")
+	}
+	if hasServiceType(loadBalancer, "NodePort") {
+		t.Error("hasServiceType() should not match a LoadBalancer service as NodePort")
+	}
+	if !hasServiceType(untyped, "ClusterIP") {
+		t.Error("hasServiceType() should treat a service without a type as ClusterIP")
+	}
+}
+
+func TestHasMatchingSelector(t *testing.T) {
+	service := corev1.Service{
+		Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "web", "tier": "frontend"}},
+	}
+
+	tests := []struct {
+		name     string
+		service  corev1.Service
+		selector labels.Set
+		expected bool
+	}{
+		{
+			name:     "no filter",
+			service:  service,
+			selector: labels.Set{},
+			expected: true,
+		},
+		{
+			name:     "matching label",
+			service:  service,
+			selector: labels.Set{"app": "web"},
+			expected: true,
+		},
+		{
+			name:     "all labels match",
+			service:  service,
+			selector: labels.Set{"app": "web", "tier": "frontend"},
+			expected: true,
+		},
+		{
+			name:     "different value",
+			service:  service,
+			selector: labels.Set{"app": "api"},
+			expected: false,
+		},
+		{
+			name:     "one label missing",
+			service:  service,
+			selector: labels.Set{"app": "web", "version": "v2"},
+			expected: false,
+		},
+		{
+			name:     "service without selector",
+			service:  corev1.Service{},
+			selector: labels.Set{"app": "web"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := hasMatchingSelector(tt.service, tt.selector)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestHasMatchingAnnotation(t *testing.T) {
 	tests := []struct {
 		name            string
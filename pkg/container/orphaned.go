@@ -0,0 +1,40 @@
+package container
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// findOrphanedServices returns the services whose selector matches none of the given pods.
+// Services without a selector, such as ExternalName services or services with manually
+// managed endpoints, are never reported since they are not expected to match pods.
+func findOrphanedServices(services []corev1.Service, pods []corev1.Pod) []corev1.Service {
+	var orphaned []corev1.Service
+	for _, service := range services {
+		if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if !serviceHasMatchingPods(service, pods) {
+			orphaned = append(orphaned, service)
+		}
+	}
+	return orphaned
+}
+
+// serviceHasMatchingPods reports whether any pod in the service's namespace matches its
+// selector. Pods that have already completed or failed never receive traffic and are ignored.
+func serviceHasMatchingPods(service corev1.Service, pods []corev1.Pod) bool {
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	for _, pod := range pods {
+		if pod.Namespace != service.Namespace {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package container
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindOrphanedServices(t *testing.T) {
+	service := func(namespace, name string, serviceType corev1.ServiceType, selector map[string]string) corev1.Service {
+		return corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       corev1.ServiceSpec{Type: serviceType, Selector: selector},
+		}
+	}
+	pod := func(namespace string, phase corev1.PodPhase, podLabels map[string]string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Labels: podLabels},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	services := []corev1.Service{
+		service("default", "web", corev1.ServiceTypeClusterIP, map[string]string{"app": "web"}),
+		service("default", "api", corev1.ServiceTypeLoadBalancer, map[string]string{"app": "api", "tier": "backend"}),
+		service("default", "legacy", corev1.ServiceTypeClusterIP, map[string]string{"app": "legacy"}),
+		service("default", "batch", corev1.ServiceTypeClusterIP, map[string]string{"app": "batch"}),
+		service("staging", "web", corev1.ServiceTypeClusterIP, map[string]string{"app": "web"}),
+		service("default", "external-db", corev1.ServiceTypeExternalName, nil),
+		service("default", "manual-endpoints", corev1.ServiceTypeClusterIP, nil),
+	}
+	pods := []corev1.Pod{
+		pod("default", corev1.PodRunning, map[string]string{"app": "web", "version": "v2"}),
+		// Matches only part of the api selector
+		pod("default", corev1.PodRunning, map[string]string{"app": "api"}),
+		pod("default", corev1.PodSucceeded, map[string]string{"app": "batch"}),
+	}
+
+	var got []string
+	for _, s := range findOrphanedServices(services, pods) {
+		got = append(got, s.Namespace+"/"+s.Name)
+	}
+
+	want := []string{"default/api", "default/legacy", "default/batch", "staging/web"}
+	if !slices.Equal(got, want) {
+		t.Errorf("findOrphanedServices() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package container
+
+import "strings"
+
+// DefaultRegistry is the registry host used when an image reference does not name one
+const DefaultRegistry = "docker.io"
+
+// DefaultTag is the tag the container runtime pulls when a reference has neither a tag nor a digest
+const DefaultTag = "latest"
+
+// ParseImageRef splits an image reference into its registry host, repository, tag and digest,
+// applying the defaults the container runtime uses. References without a registry component
+// resolve to docker.io, and Docker Hub aliases are normalized to docker.io. Single-component
+// Docker Hub repositories gain the "library/" namespace, so "nginx" and
+// "docker.io/library/nginx" parse the same. The tag defaults to "latest" unless the reference
+// is pinned by digest; digest is empty when the reference has none.
+func ParseImageRef(ref string) (registry, repository, tag, digest string) {
+	name, tag, digest := splitImageRef(ref)
+
+	registry = DefaultRegistry
+	repository = name
+	if slash := strings.Index(name, "/"); slash != -1 {
+		// The first component is a registry host only if it looks like one:
+		// it contains a dot (domain), a colon (port), or is localhost
+		first := name[:slash]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			registry = strings.ToLower(first)
+			repository = name[slash+1:]
+		}
+	}
+
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		registry = DefaultRegistry
+	}
+	if registry == DefaultRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	if tag == "" && digest == "" {
+		tag = DefaultTag
+	}
+
+	return registry, repository, tag, digest
+}
+
+// RegistryHost returns the registry host an image reference is pulled from.
+// References without a registry component (e.g. "nginx:1.21" or "library/nginx")
+// resolve to Docker Hub, and Docker Hub aliases are normalized to docker.io.
+func RegistryHost(image string) string {
+	registry, _, _, _ := ParseImageRef(image)
+	return registry
+}
+
+// splitImageRef splits an image reference as written into its name, tag and digest,
+// without applying any defaults
+func splitImageRef(ref string) (name, tag, digest string) {
+	name = ref
+	// Drop the digest first so "@sha256:..." is never mistaken for a tag or port
+	if idx := strings.Index(name, "@"); idx != -1 {
+		name, digest = name[:idx], name[idx+1:]
+	}
+	// A colon after the last slash separates the tag; earlier colons belong to a registry port
+	if idx := strings.LastIndex(name, ":"); idx > strings.LastIndex(name, "/") {
+		name, tag = name[:idx], name[idx+1:]
+	}
+	return name, tag, digest
+}
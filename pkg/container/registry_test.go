@@ -0,0 +1,177 @@
+package container
+
+import "testing"
+
+func TestRegistryHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		expected string
+	}{
+		{
+			name:     "official image without tag",
+			image:    "nginx",
+			expected: "docker.io",
+		},
+		{
+			name:     "official image with tag",
+			image:    "nginx:1.21",
+			expected: "docker.io",
+		},
+		{
+			name:     "library namespace",
+			image:    "library/nginx:1.21",
+			expected: "docker.io",
+		},
+		{
+			name:     "docker hub user repository",
+			image:    "bitnami/redis:7.0",
+			expected: "docker.io",
+		},
+		{
+			name:     "explicit docker hub",
+			image:    "docker.io/library/busybox:1.34",
+			expected: "docker.io",
+		},
+		{
+			name:     "docker hub alias",
+			image:    "index.docker.io/library/busybox",
+			expected: "docker.io",
+		},
+		{
+			name:     "quay",
+			image:    "quay.io/prometheus/node-exporter:v1.6.0",
+			expected: "quay.io",
+		},
+		{
+			name:     "ecr",
+			image:    "123456789012.dkr.ecr.us-east-1.amazonaws.com/app:latest",
+			expected: "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+		},
+		{
+			name:     "registry with port",
+			image:    "registry.example.com:5000/team/app:v2",
+			expected: "registry.example.com:5000",
+		},
+		{
+			name:     "localhost with port",
+			image:    "localhost:5000/app",
+			expected: "localhost:5000",
+		},
+		{
+			name:     "localhost without port",
+			image:    "localhost/app:dev",
+			expected: "localhost",
+		},
+		{
+			name:     "digest only",
+			image:    "nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			expected: "docker.io",
+		},
+		{
+			name:     "registry with tag and digest",
+			image:    "registry.k8s.io/kube-proxy:v1.28.2@sha256:8f7f3e3b6c1e8d6d2f6a0f8e1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+			expected: "registry.k8s.io",
+		},
+		{
+			name:     "uppercase host",
+			image:    "GHCR.IO/org/app:1.0",
+			expected: "ghcr.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RegistryHost(tt.image); got != tt.expected {
+				t.Errorf("RegistryHost(%q) = %q, want %q", tt.image, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		name           string
+		ref            string
+		wantRegistry   string
+		wantRepository string
+		wantTag        string
+		wantDigest     string
+	}{
+		{
+			name:           "official image without tag",
+			ref:            "nginx",
+			wantRegistry:   "docker.io",
+			wantRepository: "library/nginx",
+			wantTag:        "latest",
+		},
+		{
+			name:           "official image with tag",
+			ref:            "nginx:1.21",
+			wantRegistry:   "docker.io",
+			wantRepository: "library/nginx",
+			wantTag:        "1.21",
+		},
+		{
+			name:           "docker hub user image",
+			ref:            "bitnami/redis:7.0",
+			wantRegistry:   "docker.io",
+			wantRepository: "bitnami/redis",
+			wantTag:        "7.0",
+		},
+		{
+			name:           "docker hub alias",
+			ref:            "index.docker.io/library/nginx:1.21",
+			wantRegistry:   "docker.io",
+			wantRepository: "library/nginx",
+			wantTag:        "1.21",
+		},
+		{
+			name:           "registry with port",
+			ref:            "registry.local:5000/team/app:v2",
+			wantRegistry:   "registry.local:5000",
+			wantRepository: "team/app",
+			wantTag:        "v2",
+		},
+		{
+			name:           "registry with port and no tag",
+			ref:            "localhost:5000/app",
+			wantRegistry:   "localhost:5000",
+			wantRepository: "app",
+			wantTag:        "latest",
+		},
+		{
+			name:           "digest only",
+			ref:            "123456789012.dkr.ecr.us-east-1.amazonaws.com/api@sha256:abc123",
+			wantRegistry:   "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			wantRepository: "api",
+			wantDigest:     "sha256:abc123",
+		},
+		{
+			name:           "tag and digest",
+			ref:            "ghcr.io/org/tool:1.0@sha256:def456",
+			wantRegistry:   "ghcr.io",
+			wantRepository: "org/tool",
+			wantTag:        "1.0",
+			wantDigest:     "sha256:def456",
+		},
+		{
+			name:           "uppercase registry host",
+			ref:            "Quay.IO/coreos/etcd:v3.5",
+			wantRegistry:   "quay.io",
+			wantRepository: "coreos/etcd",
+			wantTag:        "v3.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, repository, tag, digest := ParseImageRef(tt.ref)
+			if registry != tt.wantRegistry || repository != tt.wantRepository || tag != tt.wantTag || digest != tt.wantDigest {
+				t.Errorf("ParseImageRef(%q) = (%q, %q, %q, %q), want (%q, %q, %q, %q)",
+					tt.ref, registry, repository, tag, digest,
+					tt.wantRegistry, tt.wantRepository, tt.wantTag, tt.wantDigest)
+			}
+		})
+	}
+}
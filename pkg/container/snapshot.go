@@ -0,0 +1,132 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImageInventoryEntry is an image running in the cluster and the namespaces running it
+type ImageInventoryEntry struct {
+	Image      string   `json:"image"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// collectImageInventory lists the distinct images of the pods, including init and ephemeral
+// containers, sorted by image with sorted namespaces so that snapshots diff cleanly
+func collectImageInventory(pods *corev1.PodList) []ImageInventoryEntry {
+	imageNamespaces := make(map[string]map[string]struct{})
+
+	add := func(image, namespace string) {
+		if image == "" {
+			return
+		}
+		if imageNamespaces[image] == nil {
+			imageNamespaces[image] = map[string]struct{}{}
+		}
+		imageNamespaces[image][namespace] = struct{}{}
+	}
+
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			add(c.Image, pod.Namespace)
+		}
+		for _, c := range pod.Spec.InitContainers {
+			add(c.Image, pod.Namespace)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(c.Image, pod.Namespace)
+		}
+	}
+
+	inventory := make([]ImageInventoryEntry, 0, len(imageNamespaces))
+	for image, namespaces := range imageNamespaces {
+		entry := ImageInventoryEntry{Image: image, Namespaces: make([]string, 0, len(namespaces))}
+		for ns := range namespaces {
+			entry.Namespaces = append(entry.Namespaces, ns)
+		}
+		sort.Strings(entry.Namespaces)
+		inventory = append(inventory, entry)
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Image < inventory[j].Image
+	})
+
+	return inventory
+}
+
+// saveImageInventory writes the inventory to path as indented JSON
+func saveImageInventory(path string, inventory []ImageInventoryEntry) error {
+	data, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode image inventory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("save image inventory: %w", err)
+	}
+	return nil
+}
+
+// loadImageInventory reads an inventory written by saveImageInventory
+func loadImageInventory(path string) ([]ImageInventoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read image inventory: %w", err)
+	}
+
+	var inventory []ImageInventoryEntry
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		return nil, fmt.Errorf("parse image inventory %s: %w", path, err)
+	}
+	return inventory, nil
+}
+
+// diffImageInventory compares the current inventory to a saved one and returns the entries
+// that are only running now and those that are no longer running, both sorted by image
+func diffImageInventory(saved, current []ImageInventoryEntry) (added, removed []ImageInventoryEntry) {
+	savedImages := make(map[string]struct{}, len(saved))
+	for _, entry := range saved {
+		savedImages[entry.Image] = struct{}{}
+	}
+	currentImages := make(map[string]struct{}, len(current))
+	for _, entry := range current {
+		currentImages[entry.Image] = struct{}{}
+		if _, ok := savedImages[entry.Image]; !ok {
+			added = append(added, entry)
+		}
+	}
+	for _, entry := range saved {
+		if _, ok := currentImages[entry.Image]; !ok {
+			removed = append(removed, entry)
+		}
+	}
+
+	byImage := func(entries []ImageInventoryEntry) {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Image < entries[j].Image })
+	}
+	byImage(added)
+	byImage(removed)
+	return added, removed
+}
+
+// printImageInventoryDiff prints added images with a "+" and removed images with a "-",
+// followed by a count of each
+func printImageInventoryDiff(w io.Writer, path string, added, removed []ImageInventoryEntry) {
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintf(w, "No image changes since %s\n", path)
+		return
+	}
+
+	for _, entry := range added {
+		fmt.Fprintf(w, "+ %s (%s)\n", entry.Image, strings.Join(entry.Namespaces, ", "))
+	}
+	for _, entry := range removed {
+		fmt.Fprintf(w, "- %s (%s)\n", entry.Image, strings.Join(entry.Namespaces, ", "))
+	}
+	fmt.Fprintf(w, "\n%d added, %d removed since %s\n", len(added), len(removed), path)
+}
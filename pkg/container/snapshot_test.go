@@ -0,0 +1,109 @@
+package container
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCollectImageInventory(t *testing.T) {
+	pod := func(namespace, name string, images ...string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		for _, image := range images {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Image: image})
+		}
+		return p
+	}
+
+	initPod := pod("default", "migrate")
+	initPod.Spec.InitContainers = []corev1.Container{{Image: "flyway:10"}}
+
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		pod("web", "web-1", "nginx:1.25", "envoy:1.30"),
+		pod("default", "proxy", "nginx:1.25"),
+		initPod,
+	}}
+
+	expected := []ImageInventoryEntry{
+		{Image: "envoy:1.30", Namespaces: []string{"web"}},
+		{Image: "flyway:10", Namespaces: []string{"default"}},
+		{Image: "nginx:1.25", Namespaces: []string{"default", "web"}},
+	}
+	if got := collectImageInventory(pods); !reflect.DeepEqual(got, expected) {
+		t.Errorf("collectImageInventory() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestImageInventorySaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images.json")
+	inventory := []ImageInventoryEntry{
+		{Image: "nginx:1.25", Namespaces: []string{"default", "web"}},
+	}
+
+	if err := saveImageInventory(path, inventory); err != nil {
+		t.Fatalf("saveImageInventory() error = %v", err)
+	}
+	loaded, err := loadImageInventory(path)
+	if err != nil {
+		t.Fatalf("loadImageInventory() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, inventory) {
+		t.Errorf("loadImageInventory() = %+v, want %+v", loaded, inventory)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadImageInventory(path); err == nil {
+		t.Error("loadImageInventory() of invalid JSON succeeded, want an error")
+	}
+	if _, err := loadImageInventory(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loadImageInventory() of a missing file succeeded, want an error")
+	}
+}
+
+func TestDiffImageInventory(t *testing.T) {
+	saved := []ImageInventoryEntry{
+		{Image: "envoy:1.29", Namespaces: []string{"web"}},
+		{Image: "nginx:1.25", Namespaces: []string{"web"}},
+	}
+	current := []ImageInventoryEntry{
+		{Image: "nginx:1.25", Namespaces: []string{"default", "web"}},
+		{Image: "envoy:1.30", Namespaces: []string{"web"}},
+		{Image: "coredns:1.11", Namespaces: []string{"kube-system"}},
+	}
+
+	added, removed := diffImageInventory(saved, current)
+
+	wantAdded := []ImageInventoryEntry{
+		{Image: "coredns:1.11", Namespaces: []string{"kube-system"}},
+		{Image: "envoy:1.30", Namespaces: []string{"web"}},
+	}
+	wantRemoved := []ImageInventoryEntry{{Image: "envoy:1.29", Namespaces: []string{"web"}}}
+	if !reflect.DeepEqual(added, wantAdded) {
+		t.Errorf("added = %+v, want %+v", added, wantAdded)
+	}
+	if !reflect.DeepEqual(removed, wantRemoved) {
+		t.Errorf("removed = %+v, want %+v", removed, wantRemoved)
+	}
+
+	var buf bytes.Buffer
+	printImageInventoryDiff(&buf, "images.json", added, removed)
+	for _, want := range []string{"+ coredns:1.11 (kube-system)\n", "- envoy:1.29 (web)\n", "2 added, 1 removed since images.json"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("diff output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	printImageInventoryDiff(&buf, "images.json", nil, nil)
+	if !strings.Contains(buf.String(), "No image changes") {
+		t.Errorf("diff output = %q, want no changes", buf.String())
+	}
+}
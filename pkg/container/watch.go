@@ -0,0 +1,42 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// DefaultRefreshInterval is the default interval between renders in --watch mode
+const DefaultRefreshInterval = 10 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchLoop re-runs render every refresh interval, clearing the screen between renders.
+// Render errors are shown and retried on the next tick; the loop returns nil once ctx is
+// cancelled or the process receives SIGINT.
+func watchLoop(ctx context.Context, refresh time.Duration, title string, render func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	for {
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: %s    %s\n\n", refresh, title, time.Now().Format("2006-01-02 15:04:05"))
+
+		if err := render(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
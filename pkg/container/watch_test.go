@@ -0,0 +1,57 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatchLoop(t *testing.T) {
+	tests := []struct {
+		name      string
+		renderErr error
+	}{
+		{
+			name: "renders until cancelled",
+		},
+		{
+			name:      "keeps going after render errors",
+			renderErr: errors.New("list pods: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			renders := 0
+			render := func(context.Context) error {
+				renders++
+				if renders == 3 {
+					cancel()
+				}
+				return tt.renderErr
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- watchLoop(ctx, time.Millisecond, "test", render)
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("watchLoop() error = %v, want nil", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("watchLoop() did not return after cancellation")
+			}
+
+			if renders != 3 {
+				t.Errorf("render called %d times, want 3", renders)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultBreakdownSize is how many namespaces and reasons an event breakdown lists
+const DefaultBreakdownSize = 5
+
+// EventCount is the number of events sharing a namespace or reason
+type EventCount struct {
+	Name  string
+	Count int
+}
+
+// EventCounts is a list of event counts, most events first
+type EventCounts []EventCount
+
+// String returns the counts as "name=count" pairs in order
+func (c EventCounts) String() string {
+	pairs := make([]string, 0, len(c))
+	for _, count := range c {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", count.Name, count.Count))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// EventBreakdown shows where in the cluster a set of matching events is concentrated
+type EventBreakdown struct {
+	Total      int
+	Namespaces EventCounts
+	Reasons    EventCounts
+}
+
+// BreakdownEvents counts the events per namespace and per reason and keeps the top entries
+// of each, most events first with ties broken by name. A non-positive top keeps them all.
+func BreakdownEvents(events []corev1.Event, top int) EventBreakdown {
+	namespaces := make(map[string]int)
+	reasons := make(map[string]int)
+	for _, event := range events {
+		namespaces[event.Namespace]++
+		reasons[event.Reason]++
+	}
+
+	return EventBreakdown{
+		Total:      len(events),
+		Namespaces: topEventCounts(namespaces, top),
+		Reasons:    topEventCounts(reasons, top),
+	}
+}
+
+// topEventCounts sorts the counts, most events first, and keeps the first top of them
+func topEventCounts(counts map[string]int, top int) EventCounts {
+	sorted := make(EventCounts, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, EventCount{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	if top > 0 && len(sorted) > top {
+		sorted = sorted[:top]
+	}
+	return sorted
+}
@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBreakdownEvents(t *testing.T) {
+	event := func(namespace, reason string) corev1.Event {
+		return corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}, Reason: reason}
+	}
+	events := []corev1.Event{
+		event("payments", "FailedCreatePodSandBox"),
+		event("payments", "FailedCreatePodSandBox"),
+		event("payments", "BackOff"),
+		event("web", "FailedCreatePodSandBox"),
+		event("batch", "BackOff"),
+		event("api", "Failed"),
+	}
+
+	breakdown := BreakdownEvents(events, 2)
+
+	if breakdown.Total != 6 {
+		t.Errorf("Total = %d, want 6", breakdown.Total)
+	}
+	wantNamespaces := EventCounts{{Name: "payments", Count: 3}, {Name: "api", Count: 1}}
+	if !reflect.DeepEqual(breakdown.Namespaces, wantNamespaces) {
+		t.Errorf("Namespaces = %v, want %v", breakdown.Namespaces, wantNamespaces)
+	}
+	wantReasons := EventCounts{{Name: "FailedCreatePodSandBox", Count: 3}, {Name: "BackOff", Count: 2}}
+	if !reflect.DeepEqual(breakdown.Reasons, wantReasons) {
+		t.Errorf("Reasons = %v, want %v", breakdown.Reasons, wantReasons)
+	}
+	if got := breakdown.Reasons.String(); got != "FailedCreatePodSandBox=3, BackOff=2" {
+		t.Errorf("Reasons.String() = %q", got)
+	}
+
+	if all := BreakdownEvents(events, 0); len(all.Namespaces) != 4 || len(all.Reasons) != 3 {
+		t.Errorf("BreakdownEvents(events, 0) = %+v, want every namespace and reason", all)
+	}
+}
@@ -0,0 +1,133 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HeadroomReport compares the resources requested by pods on a set of nodes that are about
+// to be removed against the free allocatable capacity on the remaining schedulable nodes
+type HeadroomReport struct {
+	TargetNodes      int
+	OtherNodes       int
+	RequiredMilliCPU int64
+	RequiredMemory   int64
+	FreeMilliCPU     int64
+	FreeMemory       int64
+}
+
+// Sufficient reports whether the free capacity covers the required capacity multiplied by
+// threshold (e.g. 1.2 demands 20% spare) for both CPU and memory
+func (r *HeadroomReport) Sufficient(threshold float64) bool {
+	return float64(r.FreeMilliCPU) >= float64(r.RequiredMilliCPU)*threshold &&
+		float64(r.FreeMemory) >= float64(r.RequiredMemory)*threshold
+}
+
+// String summarizes the report for display
+func (r *HeadroomReport) String() string {
+	return fmt.Sprintf("pods on %d node(s) request %dm CPU / %dMi memory; %d other node(s) have %dm CPU / %dMi memory free",
+		r.TargetNodes, r.RequiredMilliCPU, r.RequiredMemory/(1024*1024),
+		r.OtherNodes, r.FreeMilliCPU, r.FreeMemory/(1024*1024))
+}
+
+// CheckHeadroom measures whether the cluster can absorb the pods running on the nodes backed
+// by instanceIDs. DaemonSet pods are ignored since they are not rescheduled elsewhere, and
+// only Ready, schedulable nodes outside the target set count toward free capacity.
+func CheckHeadroom(ctx context.Context, clientset kubernetes.Interface, instanceIDs []string) (*HeadroomReport, error) {
+	targets := make(map[string]bool, len(instanceIDs))
+	for _, id := range instanceIDs {
+		targets[id] = true
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Sum the requests of active pods per node, and separately of the pods that would
+	// have to move if their node went away
+	requestedCPU := make(map[string]int64)
+	requestedMemory := make(map[string]int64)
+	movableCPU := make(map[string]int64)
+	movableMemory := make(map[string]int64)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		cpu, memory := podRequests(&pod)
+		requestedCPU[pod.Spec.NodeName] += cpu
+		requestedMemory[pod.Spec.NodeName] += memory
+		if !isDaemonSetPod(&pod) {
+			movableCPU[pod.Spec.NodeName] += cpu
+			movableMemory[pod.Spec.NodeName] += memory
+		}
+	}
+
+	report := &HeadroomReport{}
+	for _, node := range nodes.Items {
+		if targets[extractInstanceIDFromProviderID(node.Spec.ProviderID)] {
+			report.TargetNodes++
+			report.RequiredMilliCPU += movableCPU[node.Name]
+			report.RequiredMemory += movableMemory[node.Name]
+			continue
+		}
+
+		if node.Spec.Unschedulable || !isNodeReady(&node) {
+			continue
+		}
+
+		report.OtherNodes++
+		if free := node.Status.Allocatable.Cpu().MilliValue() - requestedCPU[node.Name]; free > 0 {
+			report.FreeMilliCPU += free
+		}
+		if free := node.Status.Allocatable.Memory().Value() - requestedMemory[node.Name]; free > 0 {
+			report.FreeMemory += free
+		}
+	}
+
+	return report, nil
+}
+
+// podRequests returns the effective CPU (millicores) and memory (bytes) requests of a pod:
+// the sum of its containers, or the largest init container if that is higher
+func podRequests(pod *corev1.Pod) (int64, int64) {
+	var cpu, memory int64
+	for _, c := range pod.Spec.Containers {
+		cpu += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+	}
+	for _, c := range pod.Spec.InitContainers {
+		cpu = max(cpu, c.Resources.Requests.Cpu().MilliValue())
+		memory = max(memory, c.Resources.Requests.Memory().Value())
+	}
+	return cpu, memory
+}
+
+// isDaemonSetPod reports whether the pod is owned by a DaemonSet
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeReady reports whether the node's Ready condition is true
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
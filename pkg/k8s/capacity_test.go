@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckHeadroom(t *testing.T) {
+	node := func(name, instanceID, cpu, memory string, ready, unschedulable bool) *corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.NodeSpec{
+				ProviderID:    "aws:///us-east-1a/" + instanceID,
+				Unschedulable: unschedulable,
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			},
+		}
+	}
+	pod := func(name, nodeName, cpu, memory string, owner string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					}},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: owner, Name: "owner"}}
+		}
+		return p
+	}
+
+	objects := []runtime.Object{
+		node("target-1", "i-target1", "2", "4Gi", true, false),
+		node("other-1", "i-other1", "2", "4Gi", true, false),
+		node("other-2", "i-other2", "2", "4Gi", true, false),
+		node("cordoned", "i-cordoned", "8", "16Gi", true, true),
+		node("not-ready", "i-notready", "8", "16Gi", false, false),
+		pod("web", "target-1", "1", "1Gi", "ReplicaSet"),
+		pod("logger", "target-1", "500m", "512Mi", "DaemonSet"),
+		pod("api", "other-1", "1500m", "3Gi", "ReplicaSet"),
+	}
+
+	clientset := fake.NewSimpleClientset(objects...)
+	report, err := CheckHeadroom(context.Background(), clientset, []string{"i-target1"})
+	if err != nil {
+		t.Fatalf("CheckHeadroom() error = %v", err)
+	}
+
+	expected := HeadroomReport{
+		TargetNodes:      1,
+		OtherNodes:       2,
+		RequiredMilliCPU: 1000,
+		RequiredMemory:   1024 * 1024 * 1024,
+		FreeMilliCPU:     500 + 2000,
+		FreeMemory:       1024*1024*1024 + 4*1024*1024*1024,
+	}
+	if *report != expected {
+		t.Errorf("CheckHeadroom() = %+v, want %+v", *report, expected)
+	}
+
+	if !report.Sufficient(1.0) {
+		t.Errorf("Sufficient(1.0) = false, want true for %s", report)
+	}
+	if report.Sufficient(3.0) {
+		t.Errorf("Sufficient(3.0) = true, want false for %s", report)
+	}
+}
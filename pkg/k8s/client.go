@@ -1,8 +1,11 @@
 package k8s
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 	"k8s.io/client-go/kubernetes"
@@ -10,6 +13,9 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
+// DefaultTimeout is the default deadline for calls to the Kubernetes API server
+const DefaultTimeout = 30 * time.Second
+
 // Client wraps a Kubernetes clientset with additional functionality
 type Client struct {
 	Clientset *kubernetes.Clientset
@@ -39,3 +45,15 @@ func NewClient() (*Client, error) {
 
 	return &Client{Clientset: clientset}, nil
 }
+
+// WrapTimeoutError returns a clear "timed out contacting cluster" error when err was caused
+// by ctx exceeding its deadline, and err unchanged otherwise
+func WrapTimeoutError(ctx context.Context, err error, timeout time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out contacting cluster after %s: %w", timeout, err)
+	}
+	return err
+}
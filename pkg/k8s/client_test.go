@@ -1,7 +1,11 @@
 package k8s
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEventQueryOptions(t *testing.T) {
@@ -37,9 +41,65 @@ func TestEventQueryOptions(t *testing.T) {
 	}
 }
 
+func TestWrapTimeoutError(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-expired.Done()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		err         error
+		wantNil     bool
+		wantTimeout bool
+	}{
+		{
+			name:    "nil error",
+			ctx:     context.Background(),
+			err:     nil,
+			wantNil: true,
+		},
+		{
+			name:        "deadline exceeded error",
+			ctx:         context.Background(),
+			err:         context.DeadlineExceeded,
+			wantTimeout: true,
+		},
+		{
+			name:        "expired context with opaque error",
+			ctx:         expired,
+			err:         errors.New("client rate limiter Wait returned an error"),
+			wantTimeout: true,
+		},
+		{
+			name: "unrelated error",
+			ctx:  context.Background(),
+			err:  errors.New("forbidden"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapTimeoutError(tt.ctx, tt.err, 5*time.Second)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("WrapTimeoutError() = %v, want nil", got)
+				}
+				return
+			}
+			isTimeout := strings.Contains(got.Error(), "timed out contacting cluster after 5s")
+			if isTimeout != tt.wantTimeout {
+				t.Errorf("WrapTimeoutError() = %q, want timeout message: %v", got.Error(), tt.wantTimeout)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("WrapTimeoutError() does not wrap original error %v", tt.err)
+			}
+		})
+	}
+}
+
 // Note: Testing NewClient and QueryEvents would require either:
 // 1. A real Kubernetes cluster (integration test)
 // 2. Mock Kubernetes client (using interfaces)
 // 3. Fake clientset from k8s.io/client-go/kubernetes/fake
 // These are typically done in integration tests rather than unit tests
-
@@ -0,0 +1,283 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Drain defaults
+const (
+	// DefaultEvictionRetryInterval is how long to wait before retrying an eviction refused
+	// by a PodDisruptionBudget
+	DefaultEvictionRetryInterval = 5 * time.Second
+	// DefaultDrainTimeout bounds how long a drain keeps retrying evictions blocked by
+	// PodDisruptionBudgets
+	DefaultDrainTimeout = 5 * time.Minute
+)
+
+// DrainOptions controls how DrainNode evicts pods
+type DrainOptions struct {
+	// GracePeriod overrides each pod's termination grace period; zero keeps the pod's own
+	GracePeriod time.Duration
+	// Timeout bounds the retries of evictions blocked by a PodDisruptionBudget and the wait
+	// for evicted pods to terminate; zero selects DefaultDrainTimeout
+	Timeout time.Duration
+	// RetryInterval is the wait between those retries and between checks that evicted pods
+	// are gone; zero selects DefaultEvictionRetryInterval
+	RetryInterval time.Duration
+	// Deadline, when set, ends the drain instead of Timeout, so that the drains of several
+	// nodes can share one budget
+	Deadline time.Time
+}
+
+// PodDrainStatus is the outcome of draining a single pod
+type PodDrainStatus string
+
+const (
+	// PodEvicted means the pod was evicted and has terminated, or was already gone
+	PodEvicted PodDrainStatus = "evicted"
+	// PodSkipped means the pod is left on the node: a DaemonSet or mirror pod, or one that
+	// has already finished
+	PodSkipped PodDrainStatus = "skipped"
+	// PodFailed means the pod could not be evicted, for example because a
+	// PodDisruptionBudget kept refusing until the drain timed out, or was evicted but did not
+	// terminate in time
+	PodFailed PodDrainStatus = "failed"
+)
+
+// PodDrainResult reports what DrainNode did with one pod
+type PodDrainResult struct {
+	Namespace string
+	Name      string
+	Status    PodDrainStatus
+	// Reason explains a skipped pod
+	Reason string
+	// Err is the eviction error of a failed pod
+	Err error
+}
+
+// DrainResults is the per-pod outcome of a drain
+type DrainResults []PodDrainResult
+
+// Failed returns the pods that could not be evicted
+func (r DrainResults) Failed() DrainResults {
+	var failed DrainResults
+	for _, result := range r {
+		if result.Status == PodFailed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Count returns how many pods ended with the given status
+func (r DrainResults) Count(status PodDrainStatus) int {
+	count := 0
+	for _, result := range r {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// CordonNode marks the node unschedulable so no new pods land on it. A node that is
+// already cordoned is left unchanged.
+func CordonNode(ctx context.Context, clientset kubernetes.Interface, nodeName string) error {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	node.Spec.Unschedulable = true
+	if _, err := clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+	}
+	return nil
+}
+
+// DrainNode cordons the node, then evicts its pods through the eviction API so that
+// PodDisruptionBudgets are respected. DaemonSet pods, mirror pods and finished pods are
+// skipped. Evictions refused by a budget are retried until opts.Timeout, or opts.Deadline
+// when set, while the other pods are still evicted. Like kubectl drain, DrainNode then waits
+// until the evicted pods are gone, within the same time. The error is only set when the
+// node could not be cordoned or its pods listed; per-pod failures are reported in the results.
+func DrainNode(ctx context.Context, clientset kubernetes.Interface, nodeName string, opts DrainOptions) (DrainResults, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultDrainTimeout
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultEvictionRetryInterval
+	}
+
+	if err := CordonNode(ctx, clientset, nodeName); err != nil {
+		return nil, err
+	}
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+	}
+
+	deadline := opts.Deadline
+	if deadline.IsZero() {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	results := make(DrainResults, 0, len(pods.Items))
+	var pending []int
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		result := PodDrainResult{Namespace: pod.Namespace, Name: pod.Name}
+		if reason := drainSkipReason(pod); reason != "" {
+			result.Status = PodSkipped
+			result.Reason = reason
+		} else {
+			pending = append(pending, i)
+		}
+		results = append(results, result)
+	}
+
+	// Evict in rounds, so a pod refused by a PodDisruptionBudget does not hold up the others
+	evicted := make(map[int]*corev1.Pod)
+	for len(pending) > 0 {
+		var blocked []int
+		for _, i := range pending {
+			pod := &pods.Items[i]
+			err := evictPod(ctx, clientset, pod, opts)
+			switch {
+			case err == nil:
+				results[i].Status = PodEvicted
+				evicted[i] = pod
+			case apierrors.IsTooManyRequests(err):
+				results[i].Err = err
+				blocked = append(blocked, i)
+			default:
+				results[i].Status = PodFailed
+				results[i].Err = fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+		pending = blocked
+		if len(pending) == 0 {
+			break
+		}
+
+		var stopped string
+		if time.Now().Add(opts.RetryInterval).After(deadline) {
+			stopped = "still blocked by a disruption budget when the drain timed out"
+		} else {
+			select {
+			case <-ctx.Done():
+				stopped = "interrupted while blocked by a disruption budget"
+			case <-time.After(opts.RetryInterval):
+			}
+		}
+		if stopped != "" {
+			for _, i := range pending {
+				results[i].Status = PodFailed
+				results[i].Err = fmt.Errorf("eviction of pod %s/%s %s: %w", results[i].Namespace, results[i].Name, stopped, results[i].Err)
+			}
+			break
+		}
+	}
+
+	// An accepted eviction only starts the pod's termination
+	for i, pod := range evicted {
+		if err := waitForPodDeletion(ctx, clientset, pod, opts, deadline); err != nil {
+			results[i].Status = PodFailed
+			results[i].Err = err
+		}
+	}
+
+	return results, nil
+}
+
+// drainSkipReason returns why a pod is left on a drained node, or "" when it is evicted
+func drainSkipReason(pod *corev1.Pod) string {
+	switch {
+	case pod.Annotations[corev1.MirrorPodAnnotationKey] != "":
+		return "mirror pod"
+	case isDaemonSetPod(pod):
+		return "DaemonSet pod"
+	case pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+		return "pod has finished"
+	}
+	return ""
+}
+
+// evictPod requests the eviction of a pod once. A pod that no longer exists counts as
+// evicted; a refusal by a PodDisruptionBudget is returned as 429 Too Many Requests.
+func evictPod(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, opts DrainOptions) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+	}
+	if opts.GracePeriod > 0 {
+		seconds := int64(opts.GracePeriod.Seconds())
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &seconds}
+	}
+
+	err := clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// waitForPodDeletion waits until the pod no longer exists, or has been replaced by a pod of
+// the same name with another UID, checking every opts.RetryInterval until the deadline
+func waitForPodDeletion(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, opts DrainOptions, deadline time.Time) error {
+	for {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to check that pod %s/%s is gone: %w", pod.Namespace, pod.Name, err)
+		case current.UID != pod.UID:
+			return nil
+		case time.Now().Add(opts.RetryInterval).After(deadline):
+			return fmt.Errorf("pod %s/%s was evicted but was still terminating when the drain timed out", pod.Namespace, pod.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.RetryInterval):
+		}
+	}
+}
+
+// NodeNamesForInstances returns the names of the nodes backed by the given EC2 instances,
+// matched by the instance ID in each node's provider ID
+func NodeNamesForInstances(ctx context.Context, clientset kubernetes.Interface, instanceIDs []string) ([]string, error) {
+	targets := make(map[string]bool, len(instanceIDs))
+	for _, id := range instanceIDs {
+		targets[id] = true
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var names []string
+	for _, node := range nodes.Items {
+		if targets[extractInstanceIDFromProviderID(node.Spec.ProviderID)] {
+			names = append(names, node.Name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,272 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newDrainClientset returns a fake clientset whose eviction subresource deletes the pod,
+// except for the pods in blocked, which a PodDisruptionBudget refuses to evict
+func newDrainClientset(blocked map[string]bool, objects ...runtime.Object) (*fake.Clientset, *[]string) {
+	clientset := fake.NewSimpleClientset(objects...)
+	var evicted []string
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if blocked[eviction.Name] {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		evicted = append(evicted, eviction.Name)
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		return true, nil, clientset.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+	})
+	return clientset, &evicted
+}
+
+func TestDrainNode(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	// The fake clientset ignores the spec.nodeName field selector, so every pod is on node-1
+	pod := func(name, nodeName string, mutate func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+
+	clientset, evicted := newDrainClientset(map[string]bool{"guarded": true},
+		node,
+		pod("web", "node-1", nil),
+		pod("guarded", "node-1", nil),
+		pod("logging", "node-1", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluent-bit"}}
+		}),
+		pod("static", "node-1", func(p *corev1.Pod) {
+			p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+		}),
+		pod("job", "node-1", func(p *corev1.Pod) { p.Status.Phase = corev1.PodSucceeded }),
+	)
+
+	results, err := DrainNode(context.Background(), clientset, "node-1", DrainOptions{
+		Timeout:       20 * time.Millisecond,
+		RetryInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+
+	got, err := clientset.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !got.Spec.Unschedulable {
+		t.Error("node was not cordoned")
+	}
+
+	want := map[string]PodDrainStatus{
+		"web":     PodEvicted,
+		"guarded": PodFailed,
+		"logging": PodSkipped,
+		"static":  PodSkipped,
+		"job":     PodSkipped,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("DrainNode() returned %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for _, result := range results {
+		if result.Status != want[result.Name] {
+			t.Errorf("pod %s status = %s, want %s", result.Name, result.Status, want[result.Name])
+		}
+		if result.Status == PodFailed && !apierrors.IsTooManyRequests(result.Err) {
+			t.Errorf("pod %s error = %v, want a disruption budget refusal", result.Name, result.Err)
+		}
+	}
+
+	if len(*evicted) != 1 || (*evicted)[0] != "web" {
+		t.Errorf("evicted pods = %v, want [web]", *evicted)
+	}
+	if failed := results.Failed(); len(failed) != 1 || failed[0].Name != "guarded" {
+		t.Errorf("Failed() = %+v, want only guarded", failed)
+	}
+	if count := results.Count(PodSkipped); count != 3 {
+		t.Errorf("Count(PodSkipped) = %d, want 3", count)
+	}
+}
+
+func TestDrainNodeGracePeriod(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+	)
+	var gracePeriod *int64
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		eviction, ok := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if !ok {
+			return false, nil, nil
+		}
+		if eviction.DeleteOptions != nil {
+			gracePeriod = eviction.DeleteOptions.GracePeriodSeconds
+		}
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		return true, nil, clientset.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+	})
+
+	if _, err := DrainNode(context.Background(), clientset, "node-1", DrainOptions{GracePeriod: 30 * time.Second}); err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+	if gracePeriod == nil || *gracePeriod != 30 {
+		t.Errorf("eviction grace period = %v, want 30", gracePeriod)
+	}
+}
+
+func TestDrainNodeWaitsForTermination(t *testing.T) {
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-1")},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}, pod("stuck"), pod("db-0"))
+
+	// Evicting stuck is accepted but the pod never terminates, while db-0 is replaced by its
+	// StatefulSet under the same name
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		eviction, ok := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if !ok || eviction.Name != "db-0" {
+			return ok, nil, nil
+		}
+		replacement := pod("db-0")
+		replacement.UID = "db-0-2"
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		return true, nil, clientset.Tracker().Update(gvr, replacement, eviction.Namespace)
+	})
+
+	results, err := DrainNode(context.Background(), clientset, "node-1", DrainOptions{
+		Timeout:       20 * time.Millisecond,
+		RetryInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+
+	want := map[string]PodDrainStatus{"stuck": PodFailed, "db-0": PodEvicted}
+	for _, result := range results {
+		if result.Status != want[result.Name] {
+			t.Errorf("pod %s status = %s (%v), want %s", result.Name, result.Status, result.Err, want[result.Name])
+		}
+	}
+	if failed := results.Failed(); len(failed) != 1 || !strings.Contains(failed[0].Err.Error(), "still terminating") {
+		t.Errorf("Failed() = %+v, want stuck still terminating", failed)
+	}
+}
+
+func TestDrainNodeRetriesBlockedPodsInRounds(t *testing.T) {
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}, pod("guarded"), pod("web"))
+
+	// The budget refuses guarded twice before letting it go
+	refusals := 2
+	var evicted []string
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		eviction, ok := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if !ok {
+			return false, nil, nil
+		}
+		if eviction.Name == "guarded" && refusals > 0 {
+			refusals--
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		evicted = append(evicted, eviction.Name)
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		return true, nil, clientset.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+	})
+
+	results, err := DrainNode(context.Background(), clientset, "node-1", DrainOptions{
+		Timeout:       time.Second,
+		RetryInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+	if count := results.Count(PodEvicted); count != 2 {
+		t.Errorf("Count(PodEvicted) = %d, want 2: %+v", count, results)
+	}
+	// web is evicted in the first round instead of waiting behind guarded
+	if want := []string{"web", "guarded"}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("eviction order = %v, want %v", evicted, want)
+	}
+}
+
+func TestDrainNodeDeadline(t *testing.T) {
+	clientset, _ := newDrainClientset(map[string]bool{"guarded": true},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "guarded", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+	)
+
+	// A deadline shared with earlier nodes that has run out stops retries at once, whatever
+	// the timeout
+	start := time.Now()
+	results, err := DrainNode(context.Background(), clientset, "node-1", DrainOptions{
+		Timeout:       time.Hour,
+		RetryInterval: time.Millisecond,
+		Deadline:      start,
+	})
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+	if failed := results.Failed(); len(failed) != 1 || !strings.Contains(failed[0].Err.Error(), "timed out") {
+		t.Errorf("Failed() = %+v, want guarded timed out", failed)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DrainNode() took %s past the deadline", elapsed)
+	}
+}
+
+func TestDrainNodeMissingNode(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	if _, err := DrainNode(context.Background(), clientset, "missing", DrainOptions{}); err == nil {
+		t.Error("DrainNode() on a missing node succeeded, want an error")
+	}
+}
+
+func TestNodeNamesForInstances(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-aaa"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1b/i-bbb"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1c/i-ccc"}},
+	)
+
+	names, err := NodeNamesForInstances(context.Background(), clientset, []string{"i-aaa", "i-ccc", "i-gone"})
+	if err != nil {
+		t.Fatalf("NodeNamesForInstances() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "node-a" || names[1] != "node-c" {
+		t.Errorf("NodeNamesForInstances() = %v, want [node-a node-c]", names)
+	}
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceNameTag is the AWS tag the Kubernetes cloud provider sets on load balancers it
+// creates, with a "namespace/name" value identifying the owning service
+const ServiceNameTag = "kubernetes.io/service-name"
+
+// ServiceEndpointStatus summarizes the endpoints currently backing a Kubernetes service
+type ServiceEndpointStatus struct {
+	Namespace      string
+	Name           string
+	Found          bool
+	ReadyEndpoints int
+	TotalEndpoints int
+}
+
+// HasActiveEndpoints reports whether the service exists and has at least one ready endpoint
+func (s *ServiceEndpointStatus) HasActiveEndpoints() bool {
+	return s.Found && s.ReadyEndpoints > 0
+}
+
+// ParseServiceNameTag splits a "namespace/name" service tag value into its parts
+func ParseServiceNameTag(value string) (namespace, name string, ok bool) {
+	namespace, name, found := strings.Cut(value, "/")
+	if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	return namespace, name, true
+}
+
+// GetServiceEndpointStatus looks up a service and counts the endpoints across its EndpointSlices.
+// A missing service is not an error; it is reported with Found set to false.
+func GetServiceEndpointStatus(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (*ServiceEndpointStatus, error) {
+	status := &ServiceEndpointStatus{
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	_, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, name, err)
+	}
+	status.Found = true
+
+	slices, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint slices for service %s/%s: %w", namespace, name, err)
+	}
+
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			status.TotalEndpoints++
+			// A nil ready condition is interpreted as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				status.ReadyEndpoints++
+			}
+		}
+	}
+
+	return status, nil
+}
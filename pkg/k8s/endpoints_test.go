@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseServiceNameTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantNamespace string
+		wantName      string
+		wantOK        bool
+	}{
+		{
+			name:          "namespace and name",
+			value:         "default/my-service",
+			wantNamespace: "default",
+			wantName:      "my-service",
+			wantOK:        true,
+		},
+		{
+			name:   "missing namespace",
+			value:  "my-service",
+			wantOK: false,
+		},
+		{
+			name:   "empty name",
+			value:  "default/",
+			wantOK: false,
+		},
+		{
+			name:   "too many parts",
+			value:  "default/my-service/extra",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, name, ok := ParseServiceNameTag(tt.value)
+			if ok != tt.wantOK || namespace != tt.wantNamespace || name != tt.wantName {
+				t.Errorf("ParseServiceNameTag(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.value, namespace, name, ok, tt.wantNamespace, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetServiceEndpointStatus(t *testing.T) {
+	ready := true
+	notReady := false
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+
+	endpointSlice := func(name, service string, conditions ...*bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+		}
+		for _, c := range conditions {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: c},
+			})
+		}
+		return slice
+	}
+
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		wantFound  bool
+		wantReady  int
+		wantTotal  int
+		wantActive bool
+	}{
+		{
+			name:      "service not found",
+			objects:   nil,
+			wantFound: false,
+		},
+		{
+			name:      "service without endpoints",
+			objects:   []runtime.Object{service},
+			wantFound: true,
+		},
+		{
+			name: "service with ready endpoints across slices",
+			objects: []runtime.Object{
+				service,
+				endpointSlice("web-abc", "web", &ready, nil),
+				endpointSlice("web-def", "web", &notReady),
+				endpointSlice("other-abc", "other", &ready),
+			},
+			wantFound:  true,
+			wantReady:  2,
+			wantTotal:  3,
+			wantActive: true,
+		},
+		{
+			name: "service with only unready endpoints",
+			objects: []runtime.Object{
+				service,
+				endpointSlice("web-abc", "web", &notReady, &notReady),
+			},
+			wantFound: true,
+			wantReady: 0,
+			wantTotal: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.objects...)
+
+			status, err := GetServiceEndpointStatus(context.Background(), clientset, "default", "web")
+			if err != nil {
+				t.Fatalf("GetServiceEndpointStatus() error = %v", err)
+			}
+
+			if status.Found != tt.wantFound {
+				t.Errorf("Found = %v, want %v", status.Found, tt.wantFound)
+			}
+			if status.ReadyEndpoints != tt.wantReady {
+				t.Errorf("ReadyEndpoints = %d, want %d", status.ReadyEndpoints, tt.wantReady)
+			}
+			if status.TotalEndpoints != tt.wantTotal {
+				t.Errorf("TotalEndpoints = %d, want %d", status.TotalEndpoints, tt.wantTotal)
+			}
+			if status.HasActiveEndpoints() != tt.wantActive {
+				t.Errorf("HasActiveEndpoints() = %v, want %v", status.HasActiveEndpoints(), tt.wantActive)
+			}
+		})
+	}
+}
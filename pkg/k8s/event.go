@@ -3,14 +3,24 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 // EventQueryOptions contains options for querying events
 type EventQueryOptions struct {
 	Namespace string
+	// ListAttempts bounds retries of the list call on transient API server errors;
+	// zero selects DefaultListAttempts
+	ListAttempts int
+	// Type restricts the query to events of this type, such as corev1.EventTypeWarning,
+	// with a server-side field selector; empty returns events of every type
+	Type string
 }
 
 // EventWithNode combines an event with node information for pods
@@ -22,7 +32,17 @@ type EventWithNode struct {
 
 // QueryEvents retrieves Kubernetes events based on the provided options
 func (c *Client) QueryEvents(ctx context.Context, opts EventQueryOptions) ([]corev1.Event, error) {
-	eventList, err := c.Clientset.CoreV1().Events(opts.Namespace).List(ctx, metav1.ListOptions{})
+	listOpts := metav1.ListOptions{}
+	if opts.Type != "" {
+		listOpts.FieldSelector = fields.OneTermEqualSelector("type", opts.Type).String()
+	}
+
+	var eventList *corev1.EventList
+	err := RetryList(opts.ListAttempts, func() error {
+		var err error
+		eventList, err = c.Clientset.CoreV1().Events(opts.Namespace).List(ctx, listOpts)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
@@ -147,12 +167,49 @@ func getInstanceIDFromNode(node *corev1.Node) string {
 	return "N/A"
 }
 
+// EventMatchField selects the event fields a search term is matched against
+type EventMatchField string
+
+// Event fields a search term can be matched against
+const (
+	// MatchMessage matches the free-form event message
+	MatchMessage EventMatchField = "message"
+	// MatchReason matches the event reason, such as FailedCreatePodSandBox, which is stable
+	// across Kubernetes versions and runtimes
+	MatchReason EventMatchField = "reason"
+	// MatchBoth matches an event when either its message or its reason contains the term
+	MatchBoth EventMatchField = "both"
+)
+
+// SupportedMatchFields lists the accepted --match-field values
+var SupportedMatchFields = []string{string(MatchMessage), string(MatchReason), string(MatchBoth)}
+
+// ParseEventMatchField validates a --match-field value, matching it case-insensitively.
+// An empty value selects MatchMessage.
+func ParseEventMatchField(value string) (EventMatchField, error) {
+	if strings.TrimSpace(value) == "" {
+		return MatchMessage, nil
+	}
+	for _, supported := range SupportedMatchFields {
+		if strings.EqualFold(strings.TrimSpace(value), supported) {
+			return EventMatchField(supported), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported match field: %s (supported: %s)", value, strings.Join(SupportedMatchFields, ", "))
+}
+
 // FilterEvents filters events by search term in the message field
 func FilterEvents(events []corev1.Event, searchTerm string) []corev1.Event {
+	return FilterEventsByField(events, searchTerm, MatchMessage)
+}
+
+// FilterEventsByField filters events by search term in the given field. An empty field
+// matches the message, as FilterEvents does.
+func FilterEventsByField(events []corev1.Event, searchTerm string, field EventMatchField) []corev1.Event {
 	matchingEvents := []corev1.Event{}
 
 	for _, event := range events {
-		if contains(event.Message, searchTerm) {
+		if eventMatches(event, searchTerm, field) {
 			matchingEvents = append(matchingEvents, event)
 		}
 	}
@@ -160,6 +217,85 @@ func FilterEvents(events []corev1.Event, searchTerm string) []corev1.Event {
 	return matchingEvents
 }
 
+// FilterEventsByTerms returns the events whose field contains any of the search terms, each
+// event once and in input order
+func FilterEventsByTerms(events []corev1.Event, searchTerms []string, field EventMatchField) []corev1.Event {
+	matchingEvents := []corev1.Event{}
+
+	for _, event := range events {
+		for _, searchTerm := range searchTerms {
+			if eventMatches(event, searchTerm, field) {
+				matchingEvents = append(matchingEvents, event)
+				break
+			}
+		}
+	}
+
+	return matchingEvents
+}
+
+// eventMatches reports whether the event's field contains the search term
+func eventMatches(event corev1.Event, searchTerm string, field EventMatchField) bool {
+	switch field {
+	case MatchReason:
+		return contains(event.Reason, searchTerm)
+	case MatchBoth:
+		return contains(event.Message, searchTerm) || contains(event.Reason, searchTerm)
+	default:
+		return contains(event.Message, searchTerm)
+	}
+}
+
+// FilterEventsByType returns the events of the given type, such as corev1.EventTypeWarning.
+// An empty eventType matches every event.
+func FilterEventsByType(events []corev1.Event, eventType string) []corev1.Event {
+	if eventType == "" {
+		return events
+	}
+
+	matchingEvents := []corev1.Event{}
+	for _, event := range events {
+		if event.Type == eventType {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+
+	return matchingEvents
+}
+
+// FilterEventsSince returns the events last seen at or after since. An event without a
+// LastTimestamp, as recorded through the events.k8s.io API, is dated by its EventTime;
+// events with neither are dropped.
+func FilterEventsSince(events []corev1.Event, since time.Time) []corev1.Event {
+	matchingEvents := []corev1.Event{}
+	for _, event := range events {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if !lastSeen.IsZero() && !lastSeen.Before(since) {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+
+	return matchingEvents
+}
+
+// TailEvents returns the n most recent events, ordered by LastTimestamp descending.
+// A non-positive n keeps every event, still ordered newest first. The input slice is not modified.
+func TailEvents(events []corev1.Event, n int) []corev1.Event {
+	sorted := make([]corev1.Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].LastTimestamp.Before(&sorted[i].LastTimestamp)
+	})
+
+	if n > 0 && n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
 // contains checks if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) &&
@@ -144,6 +144,148 @@ func TestFilterEvents_EmptyList(t *testing.T) {
 	}
 }
 
+func TestFilterEventsByType(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "warning-1"}, Type: corev1.EventTypeWarning},
+		{ObjectMeta: metav1.ObjectMeta{Name: "normal-1"}, Type: corev1.EventTypeNormal},
+		{ObjectMeta: metav1.ObjectMeta{Name: "warning-2"}, Type: corev1.EventTypeWarning},
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		wantNames []string
+	}{
+		{name: "warning only", eventType: corev1.EventTypeWarning, wantNames: []string{"warning-1", "warning-2"}},
+		{name: "normal only", eventType: corev1.EventTypeNormal, wantNames: []string{"normal-1"}},
+		{name: "empty type keeps all", eventType: "", wantNames: []string{"warning-1", "normal-1", "warning-2"}},
+		{name: "no matches", eventType: "Unknown", wantNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterEventsByType(events, tt.eventType)
+			if len(result) != len(tt.wantNames) {
+				t.Fatalf("FilterEventsByType() returned %d events, want %d", len(result), len(tt.wantNames))
+			}
+			for i, event := range result {
+				if event.Name != tt.wantNames[i] {
+					t.Errorf("FilterEventsByType()[%d] = %s, want %s", i, event.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterEventsByTerms(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}, Reason: "FailedCreatePodSandBox", Message: "failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "backoff"}, Reason: "BackOff", Message: "Back-off pulling image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "both"}, Reason: "Failed", Message: "failed to get sandbox image: Back-off pulling image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pulled"}, Reason: "Pulled", Message: "Successfully pulled image"},
+	}
+
+	tests := []struct {
+		name        string
+		searchTerms []string
+		field       EventMatchField
+		wantNames   []string
+	}{
+		{name: "single term", searchTerms: []string{"sandbox"}, wantNames: []string{"sandbox", "both"}},
+		{name: "any term matches, each event once", searchTerms: []string{"sandbox", "Back-off"}, wantNames: []string{"sandbox", "backoff", "both"}},
+		{name: "reason field", searchTerms: []string{"BackOff", "Pulled"}, field: MatchReason, wantNames: []string{"backoff", "pulled"}},
+		{name: "no terms", searchTerms: nil, wantNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterEventsByTerms(events, tt.searchTerms, tt.field)
+			if len(result) != len(tt.wantNames) {
+				t.Fatalf("FilterEventsByTerms() returned %d events, want %d", len(result), len(tt.wantNames))
+			}
+			for i, event := range result {
+				if event.Name != tt.wantNames[i] {
+					t.Errorf("FilterEventsByTerms()[%d] = %s, want %s", i, event.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterEventsSince(t *testing.T) {
+	base := time.Date(2024, 10, 14, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time { return metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)) }
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "old"}, LastTimestamp: at(0)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "at-cutoff"}, LastTimestamp: at(30)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "recent"}, LastTimestamp: at(50)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "event-time-only"}, EventTime: metav1.NewMicroTime(at(45).Time)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "undated"}},
+	}
+
+	tests := []struct {
+		name      string
+		since     time.Time
+		wantNames []string
+	}{
+		{name: "window keeps events at or after the cutoff", since: at(30).Time, wantNames: []string{"at-cutoff", "recent", "event-time-only"}},
+		{name: "window covering everything dated", since: at(-60).Time, wantNames: []string{"old", "at-cutoff", "recent", "event-time-only"}},
+		{name: "window after every event", since: at(60).Time, wantNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterEventsSince(events, tt.since)
+			if len(result) != len(tt.wantNames) {
+				t.Fatalf("FilterEventsSince() returned %d events, want %d", len(result), len(tt.wantNames))
+			}
+			for i, event := range result {
+				if event.Name != tt.wantNames[i] {
+					t.Errorf("FilterEventsSince()[%d] = %s, want %s", i, event.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTailEvents(t *testing.T) {
+	base := time.Date(2024, 10, 14, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time { return metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)) }
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "oldest"}, LastTimestamp: at(0)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "newest"}, LastTimestamp: at(30)},
+		{ObjectMeta: metav1.ObjectMeta{Name: "middle"}, LastTimestamp: at(15)},
+	}
+
+	tests := []struct {
+		name      string
+		n         int
+		wantNames []string
+	}{
+		{name: "zero keeps all, newest first", n: 0, wantNames: []string{"newest", "middle", "oldest"}},
+		{name: "tail two", n: 2, wantNames: []string{"newest", "middle"}},
+		{name: "tail larger than list", n: 10, wantNames: []string{"newest", "middle", "oldest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TailEvents(events, tt.n)
+			if len(result) != len(tt.wantNames) {
+				t.Fatalf("TailEvents() returned %d events, want %d", len(result), len(tt.wantNames))
+			}
+			for i, event := range result {
+				if event.Name != tt.wantNames[i] {
+					t.Errorf("TailEvents()[%d] = %s, want %s", i, event.Name, tt.wantNames[i])
+				}
+			}
+		})
+	}
+
+	if events[0].Name != "oldest" {
+		t.Errorf("TailEvents() reordered its input: events[0] = %s", events[0].Name)
+	}
+}
+
 func TestFilterEvents_RealWorldExample(t *testing.T) {
 	now := metav1.NewTime(time.Now())
 
@@ -261,3 +403,91 @@ func TestContains(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkEventsProcessed(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Namespace: "default"}, Message: "failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pull", Namespace: "default"}, Message: "ImagePullBackOff: failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pulled", Namespace: "default"}, Message: "Successfully pulled image"},
+	}
+	processedEvents := make(map[string]metav1.Time)
+
+	// An event matching several search terms is only counted once
+	marked := MarkEventsProcessed(events, []string{"failed to get sandbox image", "ImagePullBackOff"}, MatchMessage, processedEvents)
+	if marked != 2 {
+		t.Errorf("MarkEventsProcessed() = %d, want 2", marked)
+	}
+	if len(processedEvents) != 2 {
+		t.Errorf("processedEvents has %d entries, want 2", len(processedEvents))
+	}
+
+	// Marked events are skipped; unrelated events still come through
+	recent := FilterRecentEvents(events, processedEvents)
+	if len(recent) != 1 || recent[0].Name != "pulled" {
+		t.Errorf("FilterRecentEvents() after marking = %v, want only the unmarked event", recent)
+	}
+}
+
+func TestFilterEventsByField(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}, Reason: "FailedCreatePodSandBox", Message: "failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mentions"}, Reason: "BackOff", Message: "retrying after FailedCreatePodSandBox"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pulled"}, Reason: "Pulled", Message: "Successfully pulled image"},
+	}
+
+	tests := []struct {
+		name  string
+		term  string
+		field EventMatchField
+		want  []string
+	}{
+		{name: "message", term: "FailedCreatePodSandBox", field: MatchMessage, want: []string{"mentions"}},
+		{name: "empty field matches message", term: "FailedCreatePodSandBox", field: "", want: []string{"mentions"}},
+		{name: "reason", term: "FailedCreatePodSandBox", field: MatchReason, want: []string{"sandbox"}},
+		{name: "both", term: "FailedCreatePodSandBox", field: MatchBoth, want: []string{"sandbox", "mentions"}},
+		{name: "reason ignores message", term: "sandbox image", field: MatchReason, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, event := range FilterEventsByField(events, tt.term, tt.field) {
+				got = append(got, event.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterEventsByField() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterEventsByField() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEventMatchField(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    EventMatchField
+		wantErr bool
+	}{
+		{value: "", want: MatchMessage},
+		{value: "message", want: MatchMessage},
+		{value: "Reason", want: MatchReason},
+		{value: "both", want: MatchBoth},
+		{value: "type", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseEventMatchField(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEventMatchField(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEventMatchField(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
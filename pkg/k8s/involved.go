@@ -0,0 +1,239 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Involved object kinds that can be followed to the nodes their pods run on
+const (
+	KindPod         = "Pod"
+	KindNode        = "Node"
+	KindDeployment  = "Deployment"
+	KindReplicaSet  = "ReplicaSet"
+	KindDaemonSet   = "DaemonSet"
+	KindStatefulSet = "StatefulSet"
+	KindJob         = "Job"
+)
+
+// SupportedWatchResources lists the involved object kinds that can be mapped to node groups
+var SupportedWatchResources = []string{KindPod, KindNode, KindDeployment, KindReplicaSet, KindDaemonSet, KindStatefulSet, KindJob}
+
+// DefaultWatchResources is used when no involved object kinds are configured
+var DefaultWatchResources = []string{KindPod}
+
+// ParseWatchResources validates involved object kinds, matching them case-insensitively and
+// returning their canonical names without duplicates. No kinds selects DefaultWatchResources.
+func ParseWatchResources(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return DefaultWatchResources, nil
+	}
+
+	var kinds []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		kind := ""
+		for _, supported := range SupportedWatchResources {
+			if strings.EqualFold(strings.TrimSpace(value), supported) {
+				kind = supported
+				break
+			}
+		}
+		if kind == "" {
+			return nil, fmt.Errorf("unsupported watch resource: %s (supported: %s)", value, strings.Join(SupportedWatchResources, ", "))
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+// WatchesResource reports whether events on objects of kind are considered. No kinds means
+// DefaultWatchResources.
+func WatchesResource(kinds []string, kind string) bool {
+	if len(kinds) == 0 {
+		kinds = DefaultWatchResources
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// podSource looks up the pods and ReplicaSets an involved object is resolved through
+type podSource interface {
+	getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error)
+	listPods(ctx context.Context, namespace string) ([]corev1.Pod, error)
+	listReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error)
+}
+
+// cachedPodSource reads through a controller-runtime client, such as the manager's cache
+type cachedPodSource struct {
+	reader client.Reader
+}
+
+func (s cachedPodSource) getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	var pod corev1.Pod
+	if err := s.reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pod); err != nil {
+		return nil, err
+	}
+	return &pod, nil
+}
+
+func (s cachedPodSource) listPods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := s.reader.List(ctx, &pods, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func (s cachedPodSource) listReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error) {
+	var replicaSets appsv1.ReplicaSetList
+	if err := s.reader.List(ctx, &replicaSets, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return replicaSets.Items, nil
+}
+
+// clientsetPodSource reads directly from the API server
+type clientsetPodSource struct {
+	clientset kubernetes.Interface
+}
+
+func (s clientsetPodSource) getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	return s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (s clientsetPodSource) listPods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func (s clientsetPodSource) listReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error) {
+	replicaSets, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return replicaSets.Items, nil
+}
+
+// nodeNamesForObject returns the sorted names of the nodes an involved object runs on: the
+// node itself for a Node, the pod's node for a Pod, and the nodes of the pods owned by a
+// workload. Deployments are followed through their ReplicaSets. Unscheduled pods are skipped.
+func nodeNamesForObject(ctx context.Context, src podSource, ref corev1.ObjectReference) ([]string, error) {
+	var pods []corev1.Pod
+	switch ref.Kind {
+	case KindNode:
+		return []string{ref.Name}, nil
+	case KindPod:
+		pod, err := src.getPod(ctx, ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		pods = []corev1.Pod{*pod}
+	case KindReplicaSet, KindDaemonSet, KindStatefulSet, KindJob:
+		all, err := src.listPods(ctx, ref.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", ref.Namespace, err)
+		}
+		for _, pod := range all {
+			if ownedBy(pod.OwnerReferences, ref) {
+				pods = append(pods, pod)
+			}
+		}
+	case KindDeployment:
+		replicaSets, err := src.listReplicaSets(ctx, ref.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list replicasets in namespace %s: %w", ref.Namespace, err)
+		}
+		var owners []corev1.ObjectReference
+		for _, rs := range replicaSets {
+			if ownedBy(rs.OwnerReferences, ref) {
+				owners = append(owners, corev1.ObjectReference{Kind: KindReplicaSet, Name: rs.Name, UID: rs.UID})
+			}
+		}
+		if len(owners) == 0 {
+			return nil, nil
+		}
+
+		all, err := src.listPods(ctx, ref.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", ref.Namespace, err)
+		}
+		for _, pod := range all {
+			for _, owner := range owners {
+				if ownedBy(pod.OwnerReferences, owner) {
+					pods = append(pods, pod)
+					break
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported involved object kind: %s", ref.Kind)
+	}
+
+	seen := make(map[string]bool)
+	var nodeNames []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && !seen[pod.Spec.NodeName] {
+			seen[pod.Spec.NodeName] = true
+			nodeNames = append(nodeNames, pod.Spec.NodeName)
+		}
+	}
+	sort.Strings(nodeNames)
+	return nodeNames, nil
+}
+
+// ownedBy reports whether an owner reference points at ref, by UID when the reference has
+// one and by kind and name otherwise
+func ownedBy(owners []metav1.OwnerReference, ref corev1.ObjectReference) bool {
+	for _, owner := range owners {
+		if ref.UID != "" {
+			if owner.UID == ref.UID {
+				return true
+			}
+			continue
+		}
+		if owner.Kind == ref.Kind && owner.Name == ref.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// InstanceIDsForObject returns the EC2 instance IDs of the nodes an event's involved object
+// runs on, following workloads down to their pods
+func (c *Client) InstanceIDsForObject(ctx context.Context, ref corev1.ObjectReference) ([]string, error) {
+	nodeNames, err := nodeNamesForObject(ctx, clientsetPodSource{clientset: c.Clientset}, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceIDs []string
+	for _, nodeName := range nodeNames {
+		node, err := c.Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if instanceID := getInstanceIDFromNode(node); instanceID != "" && instanceID != "N/A" {
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+	}
+	return instanceIDs, nil
+}
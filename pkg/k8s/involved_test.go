@@ -0,0 +1,183 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakePodSource serves pods and ReplicaSets from memory
+type fakePodSource struct {
+	pods        []corev1.Pod
+	replicaSets []appsv1.ReplicaSet
+}
+
+func (s fakePodSource) getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	for i := range s.pods {
+		if s.pods[i].Namespace == namespace && s.pods[i].Name == name {
+			return &s.pods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("pod %s/%s not found", namespace, name)
+}
+
+func (s fakePodSource) listPods(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	for _, pod := range s.pods {
+		if pod.Namespace == namespace {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+func (s fakePodSource) listReplicaSets(ctx context.Context, namespace string) ([]appsv1.ReplicaSet, error) {
+	var replicaSets []appsv1.ReplicaSet
+	for _, rs := range s.replicaSets {
+		if rs.Namespace == namespace {
+			replicaSets = append(replicaSets, rs)
+		}
+	}
+	return replicaSets, nil
+}
+
+func testPod(name, nodeName string, owner metav1.OwnerReference) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, OwnerReferences: []metav1.OwnerReference{owner}},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func TestNodeNamesForObject(t *testing.T) {
+	rsOwner := metav1.OwnerReference{Kind: KindReplicaSet, Name: "web-abc", UID: types.UID("rs-uid")}
+	dsOwner := metav1.OwnerReference{Kind: KindDaemonSet, Name: "agent", UID: types.UID("ds-uid")}
+	src := fakePodSource{
+		pods: []corev1.Pod{
+			testPod("web-abc-1", "node-b", rsOwner),
+			testPod("web-abc-2", "node-a", rsOwner),
+			testPod("web-abc-3", "node-a", rsOwner),
+			testPod("web-abc-4", "", rsOwner),
+			testPod("agent-1", "node-c", dsOwner),
+		},
+		replicaSets: []appsv1.ReplicaSet{
+			{ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				Name:            "web-abc",
+				UID:             types.UID("rs-uid"),
+				OwnerReferences: []metav1.OwnerReference{{Kind: KindDeployment, Name: "web", UID: types.UID("deploy-uid")}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		ref         corev1.ObjectReference
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "pod",
+			ref:      corev1.ObjectReference{Kind: KindPod, Namespace: "default", Name: "web-abc-1"},
+			expected: []string{"node-b"},
+		},
+		{
+			name:     "node",
+			ref:      corev1.ObjectReference{Kind: KindNode, Name: "node-z"},
+			expected: []string{"node-z"},
+		},
+		{
+			name:     "deployment through its replicaset",
+			ref:      corev1.ObjectReference{Kind: KindDeployment, Namespace: "default", Name: "web", UID: types.UID("deploy-uid")},
+			expected: []string{"node-a", "node-b"},
+		},
+		{
+			name:     "daemonset matched by name without UID",
+			ref:      corev1.ObjectReference{Kind: KindDaemonSet, Namespace: "default", Name: "agent"},
+			expected: []string{"node-c"},
+		},
+		{
+			name: "deployment without replicasets",
+			ref:  corev1.ObjectReference{Kind: KindDeployment, Namespace: "default", Name: "api"},
+		},
+		{
+			name:        "missing pod",
+			ref:         corev1.ObjectReference{Kind: KindPod, Namespace: "default", Name: "gone"},
+			expectError: true,
+		},
+		{
+			name:        "unsupported kind",
+			ref:         corev1.ObjectReference{Kind: "CronJob", Namespace: "default", Name: "nightly"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeNames, err := nodeNamesForObject(context.Background(), src, tt.ref)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(nodeNames, tt.expected) {
+				t.Errorf("nodeNamesForObject() = %v, want %v", nodeNames, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseWatchResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    []string
+		expectError bool
+	}{
+		{name: "default", values: nil, expected: []string{KindPod}},
+		{name: "case-insensitive and deduplicated", values: []string{"pod", "deployment", "Pod", "DAEMONSET"}, expected: []string{KindPod, KindDeployment, KindDaemonSet}},
+		{name: "unsupported kind", values: []string{"CronJob"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kinds, err := ParseWatchResources(tt.values)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(kinds, tt.expected) {
+				t.Errorf("ParseWatchResources() = %v, want %v", kinds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWatchesResource(t *testing.T) {
+	if !WatchesResource(nil, KindPod) {
+		t.Error("Pod should be watched by default")
+	}
+	if WatchesResource(nil, KindDeployment) {
+		t.Error("Deployment should not be watched by default")
+	}
+	if !WatchesResource([]string{KindDeployment}, KindDeployment) {
+		t.Error("Deployment should be watched when configured")
+	}
+	if WatchesResource([]string{KindDeployment}, KindPod) {
+		t.Error("Pod should not be watched when only Deployment is configured")
+	}
+}
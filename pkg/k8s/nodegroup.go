@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// NodeGroupKind identifies what manages a group of nodes, which decides how it is recycled
+type NodeGroupKind string
+
+const (
+	// NodeGroupKindASG is an EKS managed or eksctl node group backed by an Auto Scaling Group,
+	// recycled by scaling the group to zero and back
+	NodeGroupKindASG NodeGroupKind = "asg"
+	// NodeGroupKindKarpenter is a Karpenter NodePool (or legacy Provisioner), recycled by
+	// deleting its nodes and letting Karpenter replace them
+	NodeGroupKindKarpenter NodeGroupKind = "karpenter"
+)
+
+// Instance tags identifying the node group an instance belongs to, in lookup order
+const (
+	TagEKSNodeGroup         = "eks:nodegroup-name"
+	TagEksctlNodeGroup      = "alpha.eksctl.io/nodegroup-name"
+	TagKarpenterNodePool    = "karpenter.sh/nodepool"
+	TagKarpenterProvisioner = "karpenter.sh/provisioner-name"
+)
+
+// NodeGroup is a named group of nodes together with what manages it
+type NodeGroup struct {
+	Name string
+	Kind NodeGroupKind
+}
+
+// String returns the group name, prefixed with "karpenter/" for Karpenter node pools so the
+// two kinds never collide when used as a map key or shown to users
+func (g NodeGroup) String() string {
+	if g.Kind == NodeGroupKindKarpenter {
+		return "karpenter/" + g.Name
+	}
+	return g.Name
+}
+
+// NodeGroupFromTags identifies the node group from an instance's tags. EKS and eksctl node
+// group tags take precedence over Karpenter tags.
+func NodeGroupFromTags(tags map[string]string) (NodeGroup, bool) {
+	for _, key := range []string{TagEKSNodeGroup, TagEksctlNodeGroup} {
+		if name := tags[key]; name != "" {
+			return NodeGroup{Name: name, Kind: NodeGroupKindASG}, true
+		}
+	}
+	for _, key := range []string{TagKarpenterNodePool, TagKarpenterProvisioner} {
+		if name := tags[key]; name != "" {
+			return NodeGroup{Name: name, Kind: NodeGroupKindKarpenter}, true
+		}
+	}
+	return NodeGroup{}, false
+}
+
+// NodeGroupFromEC2Tags identifies the node group from EC2 instance tags
+func NodeGroupFromEC2Tags(tags []ec2types.Tag) (NodeGroup, bool) {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		if tag.Key == nil || tag.Value == nil {
+			continue
+		}
+		tagMap[*tag.Key] = *tag.Value
+	}
+	return NodeGroupFromTags(tagMap)
+}
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestNodeGroupFromTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     map[string]string
+		expected NodeGroup
+		ok       bool
+	}{
+		{
+			name:     "eks managed node group",
+			tags:     map[string]string{TagEKSNodeGroup: "ng-workers-1"},
+			expected: NodeGroup{Name: "ng-workers-1", Kind: NodeGroupKindASG},
+			ok:       true,
+		},
+		{
+			name:     "eksctl node group",
+			tags:     map[string]string{TagEksctlNodeGroup: "ng-eksctl"},
+			expected: NodeGroup{Name: "ng-eksctl", Kind: NodeGroupKindASG},
+			ok:       true,
+		},
+		{
+			name:     "karpenter node pool",
+			tags:     map[string]string{TagKarpenterNodePool: "default", "karpenter.sh/nodeclaim": "default-abc12"},
+			expected: NodeGroup{Name: "default", Kind: NodeGroupKindKarpenter},
+			ok:       true,
+		},
+		{
+			name:     "legacy karpenter provisioner",
+			tags:     map[string]string{TagKarpenterProvisioner: "spot"},
+			expected: NodeGroup{Name: "spot", Kind: NodeGroupKindKarpenter},
+			ok:       true,
+		},
+		{
+			name:     "eks tag wins over karpenter tag",
+			tags:     map[string]string{TagKarpenterNodePool: "default", TagEKSNodeGroup: "ng-workers-1"},
+			expected: NodeGroup{Name: "ng-workers-1", Kind: NodeGroupKindASG},
+			ok:       true,
+		},
+		{
+			name: "no node group tags",
+			tags: map[string]string{"Name": "bastion"},
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NodeGroupFromTags(tt.tags)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("NodeGroupFromTags() = (%+v, %v), want (%+v, %v)", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNodeGroupFromEC2Tags(t *testing.T) {
+	tags := []ec2types.Tag{
+		{Key: aws.String("Name"), Value: aws.String("karpenter-node")},
+		{Key: nil, Value: aws.String("ignored")},
+		{Key: aws.String(TagKarpenterNodePool), Value: aws.String("general")},
+	}
+
+	got, ok := NodeGroupFromEC2Tags(tags)
+	if !ok || got != (NodeGroup{Name: "general", Kind: NodeGroupKindKarpenter}) {
+		t.Errorf("NodeGroupFromEC2Tags() = (%+v, %v), want karpenter node pool general", got, ok)
+	}
+}
+
+func TestNodeGroupEventCountsByName(t *testing.T) {
+	counts := NodeGroupEventCounts{
+		{Name: "ng-workers-1", Kind: NodeGroupKindASG}:  3,
+		{Name: "default", Kind: NodeGroupKindKarpenter}: 5,
+	}
+
+	byName := counts.ByName()
+	expected := map[string]int{"ng-workers-1": 3, "karpenter/default": 5}
+	if len(byName) != len(expected) {
+		t.Fatalf("ByName() = %v, want %v", byName, expected)
+	}
+	for name, count := range expected {
+		if byName[name] != count {
+			t.Errorf("ByName()[%q] = %d, want %d", name, byName[name], count)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -17,14 +18,37 @@ type RecyclerConfig struct {
 	SearchTerms []string
 	Threshold   int
 	DryRun      bool
+	// ListAttempts bounds retries of the event list on transient API server errors;
+	// zero selects DefaultListAttempts
+	ListAttempts int
+	// EventType restricts matching to events of this type, such as corev1.EventTypeWarning;
+	// empty matches every type
+	EventType string
+	// WatchResources lists the involved object kinds whose events are mapped to node groups;
+	// empty selects DefaultWatchResources
+	WatchResources []string
+	// Detail logs the top namespaces and reasons of each search term's matching events
+	Detail bool
+	// MatchField selects the event fields the search terms are matched against; empty
+	// matches the message
+	MatchField EventMatchField
 }
 
-// NodeGroupEventCounts maps node group names to event counts
-type NodeGroupEventCounts map[string]int
+// NodeGroupEventCounts maps node groups to event counts
+type NodeGroupEventCounts map[NodeGroup]int
+
+// ByName returns the counts keyed by node group display name, as stored in status
+func (c NodeGroupEventCounts) ByName() map[string]int {
+	byName := make(map[string]int, len(c))
+	for ng, count := range c {
+		byName[ng.String()] = count
+	}
+	return byName
+}
 
 // RecyclerStatus holds the status information to be updated
 type RecyclerStatus struct {
-	EventCounts   NodeGroupEventCounts
+	EventCounts   map[string]int
 	LastCheckTime metav1.Time
 }
 
@@ -42,7 +66,7 @@ func CheckAndRecycleWithStatus(
 	}
 
 	status := RecyclerStatus{
-		EventCounts:   nodeGroupCounts,
+		EventCounts:   nodeGroupCounts.ByName(),
 		LastCheckTime: metav1.Now(),
 	}
 
@@ -61,18 +85,22 @@ func CheckAndRecycle(
 
 	// List all events using the client's cached informer
 	eventList := &corev1.EventList{}
-	if err := kubeClient.List(ctx, eventList); err != nil {
+	if err := RetryList(config.ListAttempts, func() error { return kubeClient.List(ctx, eventList) }); err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
 
 	log.Info("Checking events", "total", len(eventList.Items))
 
-	// Track node groups that need recycling
+	// The cached client cannot apply field selectors without an index, so filter by type here
+	events := FilterEventsByType(eventList.Items, config.EventType)
+
+	// Track node groups that need recycling, and matching events that cannot be attributed to one
 	nodeGroupCounts := make(NodeGroupEventCounts)
+	unmapped := make(UnmappedEventCounts)
 
 	// Check each search term
 	for _, searchTerm := range config.SearchTerms {
-		matchingEvents := FilterEvents(eventList.Items, searchTerm)
+		matchingEvents := FilterEventsByField(events, searchTerm, config.MatchField)
 
 		if len(matchingEvents) == 0 {
 			continue
@@ -86,70 +114,53 @@ func CheckAndRecycle(
 		}
 
 		log.Info("Found matching events", "searchTerm", searchTerm, "count", len(recentEvents))
+		if config.Detail {
+			breakdown := BreakdownEvents(recentEvents, DefaultBreakdownSize)
+			log.Info("Matching event breakdown", "searchTerm", searchTerm,
+				"topNamespaces", breakdown.Namespaces.String(), "topReasons", breakdown.Reasons.String())
+		}
 
-		// For each event, try to identify the node group
+		// For each event, try to identify the node groups it ran on
 		for _, event := range recentEvents {
-			if event.InvolvedObject.Kind != "Pod" {
-				continue
-			}
-
-			// Get pod to find node
-			var pod corev1.Pod
-			podKey := client.ObjectKey{
-				Namespace: event.InvolvedObject.Namespace,
-				Name:      event.InvolvedObject.Name,
-			}
-
-			// Use cached pod lookup (thread-safe via informer cache)
-			if err := kubeClient.Get(ctx, podKey, &pod); err != nil {
-				log.V(1).Info("Could not get pod", "pod", event.InvolvedObject.Name, "error", err)
-				continue
-			}
-
-			if pod.Spec.NodeName == "" {
-				continue
-			}
-
-			// Get node to find instance ID
-			var node corev1.Node
-			nodeKey := client.ObjectKey{Name: pod.Spec.NodeName}
-
-			// Use cached node lookup (thread-safe via informer cache)
-			if err := kubeClient.Get(ctx, nodeKey, &node); err != nil {
-				log.V(1).Info("Could not get node", "node", pod.Spec.NodeName, "error", err)
+			// Events on kinds left out of WatchResources are ignored on purpose, not unmapped
+			if !WatchesResource(config.WatchResources, event.InvolvedObject.Kind) {
+				log.V(1).Info("Skipping event on unwatched kind", "kind", event.InvolvedObject.Kind, "event", event.Namespace+"/"+event.Name)
 				continue
 			}
 
-			// Extract instance ID and find node group
-			instanceID := extractInstanceIDFromProviderID(node.Spec.ProviderID)
-			if instanceID == "" || instanceID == "N/A" {
+			// Follow the involved object to its nodes (thread-safe via informer cache)
+			nodeNames, err := nodeNamesForObject(ctx, cachedPodSource{reader: kubeClient}, event.InvolvedObject)
+			if err != nil {
+				log.V(1).Info("Could not resolve involved object", "kind", event.InvolvedObject.Kind, "name", event.InvolvedObject.Name, "error", err)
+				unmapped.Add(UnmappedNoInstanceID)
 				continue
 			}
 
-			// Find node group from instance tags
-			nodeGroups, err := findNodeGroupByInstanceID(ctx, ec2Client, instanceID)
-			if err != nil {
-				log.V(1).Info("Could not find node group", "instance", instanceID, "error", err)
+			eventNodeGroups, reason := nodeGroupsForNodes(ctx, kubeClient, ec2Client, nodeNames)
+			if len(eventNodeGroups) == 0 {
+				unmapped.Add(reason)
 				continue
 			}
 
-			for _, ng := range nodeGroups {
-				if ng != "" && ng != "Unknown" {
-					nodeGroupCounts[ng]++
-				}
+			for ng := range eventNodeGroups {
+				nodeGroupCounts[ng]++
 			}
 		}
 	}
 
+	if unmapped.Total() > 0 {
+		log.V(1).Info("Matching events could not be mapped to a node group", "total", unmapped.Total(), "reasons", unmapped.String())
+	}
+
 	// Log node groups that meet or exceed threshold
 	for ng, count := range nodeGroupCounts {
 		if count >= config.Threshold {
-			log.Info("Node group exceeds threshold", "nodeGroup", ng, "count", count, "threshold", config.Threshold)
+			log.Info("Node group exceeds threshold", "nodeGroup", ng.String(), "kind", ng.Kind, "count", count, "threshold", config.Threshold)
 
 			if config.DryRun {
-				log.Info("[DRY RUN] Would recycle node group", "nodeGroup", ng)
+				log.Info("[DRY RUN] Would recycle node group", "nodeGroup", ng.String())
 			} else {
-				log.Info("Node group ready for recycling", "nodeGroup", ng)
+				log.Info("Node group ready for recycling", "nodeGroup", ng.String())
 				// Note: Actual recycling is done by the caller
 			}
 		}
@@ -158,6 +169,48 @@ func CheckAndRecycle(
 	return nodeGroupCounts, nil
 }
 
+// RecyclePlan splits the node groups at or over the threshold in one check into those to
+// recycle now and those deferred by the per-cycle limit
+type RecyclePlan struct {
+	Recycle  []NodeGroup
+	Deferred []NodeGroup
+}
+
+// PlanRecycles picks the node groups at or over threshold to recycle this cycle, most events
+// first with ties broken by name. The counts of node groups deferred by an earlier cycle are
+// added to counts first, since their events are already marked processed and would not be
+// counted again. With a positive limit at most limit node groups are recycled; the rest are
+// stored in deferred, replacing its previous contents, for the next cycle.
+func PlanRecycles(counts, deferred NodeGroupEventCounts, threshold, limit int) RecyclePlan {
+	for ng, count := range deferred {
+		counts[ng] += count
+		delete(deferred, ng)
+	}
+
+	var candidates []NodeGroup
+	for ng, count := range counts {
+		if count >= threshold {
+			candidates = append(candidates, ng)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if counts[candidates[i]] != counts[candidates[j]] {
+			return counts[candidates[i]] > counts[candidates[j]]
+		}
+		return candidates[i].String() < candidates[j].String()
+	})
+
+	if limit <= 0 || len(candidates) <= limit {
+		return RecyclePlan{Recycle: candidates}
+	}
+
+	plan := RecyclePlan{Recycle: candidates[:limit], Deferred: candidates[limit:]}
+	for _, ng := range plan.Deferred {
+		deferred[ng] = counts[ng]
+	}
+	return plan
+}
+
 // FilterRecentEvents filters out events that have been processed recently
 // It marks new events as processed and cleans up old entries (>2 hours)
 func FilterRecentEvents(events []corev1.Event, processedEvents map[string]metav1.Time) []corev1.Event {
@@ -189,9 +242,90 @@ func FilterRecentEvents(events []corev1.Event, processedEvents map[string]metav1
 	return recentEvents
 }
 
-// findNodeGroupByInstanceID queries AWS EC2 to find the node group name for a given instance ID
-// It looks for standard EKS node group tags on the instance
-func findNodeGroupByInstanceID(ctx context.Context, ec2Client *ec2.Client, instanceID string) ([]string, error) {
+// MarkExistingEventsProcessed lists the current events and marks those matching the search
+// terms as processed, so that a replica which has just started (or just become leader) does
+// not act on events a previous replica may already have handled. It returns the number of
+// events marked.
+func MarkExistingEventsProcessed(
+	ctx context.Context,
+	kubeClient client.Client,
+	config RecyclerConfig,
+	processedEvents map[string]metav1.Time,
+) (int, error) {
+	eventList := &corev1.EventList{}
+	if err := RetryList(config.ListAttempts, func() error { return kubeClient.List(ctx, eventList) }); err != nil {
+		return 0, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	events := FilterEventsByType(eventList.Items, config.EventType)
+	return MarkEventsProcessed(events, config.SearchTerms, config.MatchField, processedEvents), nil
+}
+
+// MarkEventsProcessed records every event matching one of the search terms in the given field
+// as processed now, so FilterRecentEvents skips it for the next hour. It returns the number of
+// events marked.
+func MarkEventsProcessed(events []corev1.Event, searchTerms []string, field EventMatchField, processedEvents map[string]metav1.Time) int {
+	now := metav1.Now()
+	marked := 0
+	for _, searchTerm := range searchTerms {
+		for _, event := range FilterEventsByField(events, searchTerm, field) {
+			eventKey := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+			if _, found := processedEvents[eventKey]; !found {
+				marked++
+			}
+			processedEvents[eventKey] = now
+		}
+	}
+	return marked
+}
+
+// nodeGroupsForNodes returns the distinct node groups of the given nodes. When none can be
+// resolved it also returns the reason the last node failed, for the unmapped event count.
+func nodeGroupsForNodes(ctx context.Context, kubeClient client.Client, ec2Client *ec2.Client, nodeNames []string) (map[NodeGroup]bool, UnmappedReason) {
+	log := log.FromContext(ctx)
+
+	nodeGroups := make(map[NodeGroup]bool)
+	reason := UnmappedNoInstanceID
+	for _, nodeName := range nodeNames {
+		// Use cached node lookup (thread-safe via informer cache)
+		var node corev1.Node
+		if err := kubeClient.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+			log.V(1).Info("Could not get node", "node", nodeName, "error", err)
+			reason = UnmappedNoInstanceID
+			continue
+		}
+
+		// Extract instance ID and find node group
+		instanceID := extractInstanceIDFromProviderID(node.Spec.ProviderID)
+		if instanceID == "" || instanceID == "N/A" {
+			log.V(1).Info("Matched event with unresolvable node group", "reason", UnmappedNoInstanceID, "node", node.Name)
+			reason = UnmappedNoInstanceID
+			continue
+		}
+
+		// Find node group from instance tags
+		found, err := findNodeGroupByInstanceID(ctx, ec2Client, instanceID)
+		if err != nil {
+			log.V(1).Info("Matched event with unresolvable node group", "reason", UnmappedDescribeError, "instance", instanceID, "error", err)
+			reason = UnmappedDescribeError
+			continue
+		}
+		if len(found) == 0 {
+			log.V(1).Info("Matched event with unresolvable node group", "reason", UnmappedNoNodeGroupTag, "instance", instanceID)
+			reason = UnmappedNoNodeGroupTag
+			continue
+		}
+
+		for _, ng := range found {
+			nodeGroups[ng] = true
+		}
+	}
+	return nodeGroups, reason
+}
+
+// findNodeGroupByInstanceID queries AWS EC2 to find the node group for a given instance ID
+// It looks for EKS, eksctl and Karpenter node group tags on the instance
+func findNodeGroupByInstanceID(ctx context.Context, ec2Client *ec2.Client, instanceID string) ([]NodeGroup, error) {
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []string{instanceID},
 	}
@@ -201,21 +335,12 @@ func findNodeGroupByInstanceID(ctx context.Context, ec2Client *ec2.Client, insta
 		return nil, fmt.Errorf("failed to describe instance %s: %w", instanceID, err)
 	}
 
-	nodeGroups := []string{}
+	nodeGroups := []NodeGroup{}
 
 	for _, reservation := range result.Reservations {
 		for _, instance := range reservation.Instances {
-			for _, tag := range instance.Tags {
-				if tag.Key == nil || tag.Value == nil {
-					continue
-				}
-
-				// Check for EKS node group tags
-				// eks:nodegroup-name is used by EKS
-				// alpha.eksctl.io/nodegroup-name is used by eksctl
-				if *tag.Key == "eks:nodegroup-name" || *tag.Key == "alpha.eksctl.io/nodegroup-name" {
-					nodeGroups = append(nodeGroups, *tag.Value)
-				}
+			if ng, ok := NodeGroupFromEC2Tags(instance.Tags); ok {
+				nodeGroups = append(nodeGroups, ng)
 			}
 		}
 	}
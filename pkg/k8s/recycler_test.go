@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlanRecycles(t *testing.T) {
+	busy := NodeGroup{Name: "ng-busy", Kind: NodeGroupKindASG}
+	alsoBusy := NodeGroup{Name: "ng-also-busy", Kind: NodeGroupKindASG}
+	quiet := NodeGroup{Name: "ng-quiet", Kind: NodeGroupKindASG}
+	pool := NodeGroup{Name: "pool-a", Kind: NodeGroupKindKarpenter}
+
+	counts := func() NodeGroupEventCounts {
+		return NodeGroupEventCounts{busy: 6, alsoBusy: 6, quiet: 2, pool: 9}
+	}
+
+	tests := []struct {
+		name         string
+		deferred     NodeGroupEventCounts
+		limit        int
+		wantRecycle  []NodeGroup
+		wantDeferred NodeGroupEventCounts
+	}{
+		{
+			name:         "no limit",
+			deferred:     NodeGroupEventCounts{},
+			wantRecycle:  []NodeGroup{pool, alsoBusy, busy},
+			wantDeferred: NodeGroupEventCounts{},
+		},
+		{
+			name:         "limit defers the rest, most events first",
+			deferred:     NodeGroupEventCounts{},
+			limit:        1,
+			wantRecycle:  []NodeGroup{pool},
+			wantDeferred: NodeGroupEventCounts{alsoBusy: 6, busy: 6},
+		},
+		{
+			name:         "limit above candidates",
+			deferred:     NodeGroupEventCounts{},
+			limit:        5,
+			wantRecycle:  []NodeGroup{pool, alsoBusy, busy},
+			wantDeferred: NodeGroupEventCounts{},
+		},
+		{
+			name:         "deferred counts carry over",
+			deferred:     NodeGroupEventCounts{busy: 6, quiet: 4},
+			limit:        2,
+			wantRecycle:  []NodeGroup{busy, pool},
+			wantDeferred: NodeGroupEventCounts{alsoBusy: 6, quiet: 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanRecycles(counts(), tt.deferred, 5, tt.limit)
+			if !reflect.DeepEqual(plan.Recycle, tt.wantRecycle) {
+				t.Errorf("Recycle = %v, want %v", plan.Recycle, tt.wantRecycle)
+			}
+			if len(plan.Deferred) != len(tt.wantDeferred) {
+				t.Errorf("Deferred = %v, want %d node groups", plan.Deferred, len(tt.wantDeferred))
+			}
+			if !reflect.DeepEqual(tt.deferred, tt.wantDeferred) {
+				t.Errorf("deferred counts = %v, want %v", tt.deferred, tt.wantDeferred)
+			}
+		})
+	}
+}
+
+func TestCheckAndRecycleSkipsUnwatchedKinds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "node-event", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: KindNode, Name: "ip-10-0-0-1"},
+		Type:           corev1.EventTypeWarning,
+		Message:        "failed to get sandbox image",
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(event).Build()
+
+	before := testutil.ToFloat64(UnmappedEventsTotal.WithLabelValues(string(UnmappedNoInstanceID)))
+	counts, err := CheckAndRecycle(context.Background(), kubeClient, nil, RecyclerConfig{
+		SearchTerms: []string{"failed to get sandbox image"},
+		Threshold:   1,
+	}, map[string]metav1.Time{})
+	if err != nil {
+		t.Fatalf("CheckAndRecycle() error = %v", err)
+	}
+
+	// Only Pod events are watched by default, so the Node event is neither counted nor unmapped
+	if len(counts) != 0 {
+		t.Errorf("CheckAndRecycle() counts = %v, want none", counts)
+	}
+	if after := testutil.ToFloat64(UnmappedEventsTotal.WithLabelValues(string(UnmappedNoInstanceID))); after != before {
+		t.Errorf("kaws_unmapped_events_total{reason=no_instance_id} went from %v to %v, want unchanged", before, after)
+	}
+}
@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultListAttempts is the default number of attempts for a List call that fails with a
+// transient API server error
+const DefaultListAttempts = 3
+
+// IsRetryableError reports whether err is a transient API server error worth retrying:
+// server-side timeouts, throttling (429), and an unavailable server
+func IsRetryableError(err error) bool {
+	return apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err)
+}
+
+// RetryList calls list up to attempts times, backing off exponentially between attempts,
+// for as long as it fails with a retryable error. Other errors, including an expired
+// context, are returned immediately. attempts below one selects DefaultListAttempts.
+func RetryList(attempts int, list func() error) error {
+	if attempts < 1 {
+		attempts = DefaultListAttempts
+	}
+
+	// retry.OnError is not used since it reports a context deadline as success
+	backoff := retry.DefaultBackoff
+	backoff.Steps = attempts
+	for {
+		err := list()
+		if err == nil || !IsRetryableError(err) || backoff.Steps <= 1 {
+			return err
+		}
+		time.Sleep(backoff.Step())
+	}
+}
@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRetryList(t *testing.T) {
+	podsResource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name         string
+		attempts     int
+		failures     []error
+		wantCalls    int
+		wantErr      bool
+		wantRetryErr bool
+	}{
+		{
+			name:      "succeeds first time",
+			attempts:  3,
+			wantCalls: 1,
+		},
+		{
+			name:      "retries server timeout and throttling",
+			attempts:  3,
+			failures:  []error{apierrors.NewServerTimeout(podsResource, "list", 1), apierrors.NewTooManyRequests("slow down", 1)},
+			wantCalls: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			attempts:     2,
+			failures:     []error{apierrors.NewServiceUnavailable("down"), apierrors.NewServiceUnavailable("down"), apierrors.NewServiceUnavailable("down")},
+			wantCalls:    2,
+			wantErr:      true,
+			wantRetryErr: true,
+		},
+		{
+			name:      "does not retry forbidden",
+			attempts:  3,
+			failures:  []error{apierrors.NewForbidden(podsResource, "", errors.New("rbac"))},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "does not retry context deadline",
+			attempts:  3,
+			failures:  []error{context.DeadlineExceeded},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "zero attempts uses default",
+			attempts:  0,
+			failures:  []error{apierrors.NewTimeoutError("slow", 1), apierrors.NewTimeoutError("slow", 1)},
+			wantCalls: DefaultListAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+
+			calls := 0
+			clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= len(tt.failures) {
+					return true, nil, tt.failures[calls-1]
+				}
+				return false, nil, nil
+			})
+
+			var pods *corev1.PodList
+			err := RetryList(tt.attempts, func() error {
+				var err error
+				pods, err = clientset.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+				return err
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("list called %d time(s), want %d", calls, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RetryList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if IsRetryableError(err) != tt.wantRetryErr {
+					t.Errorf("RetryList() returned %v, want the last list error", err)
+				}
+				return
+			}
+			if len(pods.Items) != 1 {
+				t.Errorf("got %d pods, want 1", len(pods.Items))
+			}
+		})
+	}
+}
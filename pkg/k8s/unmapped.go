@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnmappedReason explains why a matching event could not be attributed to a node group
+type UnmappedReason string
+
+const (
+	// UnmappedNoInstanceID means the event's pod, node or provider ID did not lead to an EC2 instance
+	UnmappedNoInstanceID UnmappedReason = "no_instance_id"
+	// UnmappedDescribeError means the EC2 instance could not be described
+	UnmappedDescribeError UnmappedReason = "describe_error"
+	// UnmappedNoNodeGroupTag means the instance carries no EKS, eksctl or Karpenter node group tag
+	UnmappedNoNodeGroupTag UnmappedReason = "no_nodegroup_tag"
+)
+
+// UnmappedEventsTotal counts matching events that were dropped because their node group
+// could not be resolved, by reason. The CRD operator registers it with the manager's metrics
+// registry; the standalone operator serves no metrics, so there it is only counted.
+var UnmappedEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kaws_unmapped_events_total",
+	Help: "Events matching a search term whose node group could not be resolved, by reason",
+}, []string{"reason"})
+
+// UnmappedEventCounts counts the matching events of one check that could not be mapped to a
+// node group, by reason
+type UnmappedEventCounts map[UnmappedReason]int
+
+// Add counts one unmapped event and increments UnmappedEventsTotal
+func (c UnmappedEventCounts) Add(reason UnmappedReason) {
+	c[reason]++
+	UnmappedEventsTotal.WithLabelValues(string(reason)).Inc()
+}
+
+// Total returns the number of unmapped events across all reasons
+func (c UnmappedEventCounts) Total() int {
+	total := 0
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+// String returns the counts as "reason=count" pairs sorted by reason
+func (c UnmappedEventCounts) String() string {
+	pairs := make([]string, 0, len(c))
+	for reason, count := range c {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", reason, count))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,31 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestUnmappedEventCounts(t *testing.T) {
+	before := testutil.ToFloat64(UnmappedEventsTotal.WithLabelValues(string(UnmappedNoNodeGroupTag)))
+
+	counts := make(UnmappedEventCounts)
+	counts.Add(UnmappedNoNodeGroupTag)
+	counts.Add(UnmappedNoNodeGroupTag)
+	counts.Add(UnmappedDescribeError)
+	counts.Add(UnmappedNoInstanceID)
+
+	if got := counts.Total(); got != 4 {
+		t.Errorf("Total() = %d, want 4", got)
+	}
+
+	want := "describe_error=1, no_instance_id=1, no_nodegroup_tag=2"
+	if got := counts.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	after := testutil.ToFloat64(UnmappedEventsTotal.WithLabelValues(string(UnmappedNoNodeGroupTag)))
+	if after-before != 2 {
+		t.Errorf("kaws_unmapped_events_total{reason=%q} increased by %v, want 2", UnmappedNoNodeGroupTag, after-before)
+	}
+}
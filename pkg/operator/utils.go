@@ -3,7 +3,9 @@ package operator
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"text/tabwriter"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
@@ -18,31 +20,128 @@ type OperatorConfig struct {
 	SearchTerms      []string
 	RecycleThreshold int
 	DryRun           bool
-	ProcessedEvents  map[string]time.Time
+	QueryTimeout     time.Duration
+	ListAttempts     int
+	// NodeGroups restricts detection and recycling to the named node groups; empty means all
+	NodeGroups []string
+	// EventType restricts detection to events of this type, such as corev1.EventTypeWarning;
+	// empty matches every type
+	EventType string
+	// WatchResources lists the involved object kinds whose events are mapped to node groups;
+	// empty selects k8s.DefaultWatchResources
+	WatchResources []string
+	// MaxRecyclesPerCycle caps the node groups recycled in one check; zero means no limit.
+	// Node groups over the cap are deferred to later checks, most events first.
+	MaxRecyclesPerCycle int
+	// DeferredRecycles carries the event counts of node groups deferred by MaxRecyclesPerCycle
+	// into the next check; nil is allocated on first use
+	DeferredRecycles k8s.NodeGroupEventCounts
+	// Detail prints the top namespaces and reasons of each search term's matching events,
+	// as verbose output does
+	Detail bool
+	// MatchField selects the event fields the search terms are matched against; empty
+	// matches the message
+	MatchField k8s.EventMatchField
+	// Recycle recycles an ASG-backed node group when DryRun is off; nil leaves the node
+	// groups over the threshold to manual intervention
+	Recycle func(ctx context.Context, ng k8s.NodeGroup) error
+	// RecycleCooldown is the minimum time between two recycles of the same node group; zero
+	// disables the cooldown
+	RecycleCooldown time.Duration
+	// LastRecycled records when each node group was last handed to Recycle, for the
+	// cooldown; nil is allocated on first use
+	LastRecycled    map[k8s.NodeGroup]time.Time
+	ProcessedEvents map[string]time.Time
+}
+
+// Validate checks that the configuration can drive the watch loop
+func (c *OperatorConfig) Validate() error {
+	if c.WatchInterval <= 0 {
+		return fmt.Errorf("watch interval must be greater than zero")
+	}
+	if len(c.SearchTerms) == 0 {
+		return fmt.Errorf("at least one search term is required")
+	}
+	for _, term := range c.SearchTerms {
+		if term == "" {
+			return fmt.Errorf("search terms must not be empty")
+		}
+	}
+	if c.RecycleThreshold < 1 {
+		return fmt.Errorf("threshold must be at least 1")
+	}
+	if c.QueryTimeout <= 0 {
+		return fmt.Errorf("timeout must be greater than zero")
+	}
+	if c.ListAttempts < 1 {
+		return fmt.Errorf("list attempts must be at least 1")
+	}
+	if c.MaxRecyclesPerCycle < 0 {
+		return fmt.Errorf("max recycles per cycle must not be negative")
+	}
+	if c.RecycleCooldown < 0 {
+		return fmt.Errorf("recycle cooldown must not be negative")
+	}
+	return nil
+}
+
+// InScope reports whether the operator acts on ng. Karpenter node pools match either their
+// bare name or their "karpenter/<name>" display name.
+func (c *OperatorConfig) InScope(ng k8s.NodeGroup) bool {
+	if len(c.NodeGroups) == 0 {
+		return true
+	}
+	for _, name := range c.NodeGroups {
+		if name == ng.Name || name == ng.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckResult summarizes the node groups a check found over the threshold
+type CheckResult struct {
+	// OverThreshold lists the node groups whose events reached the threshold, including those
+	// deferred by MaxRecyclesPerCycle
+	OverThreshold []k8s.NodeGroup
+	// Recycled lists the node groups a recycle was performed for
+	Recycled []k8s.NodeGroup
 }
 
 // CheckAndRecycle checks for error events and recycles affected node groups
-func CheckAndRecycle(ctx context.Context, k8sClient *k8s.Client, ec2Client *ec2.Client, asgClient *autoscaling.Client, opConfig *OperatorConfig, verbose bool) error {
+func CheckAndRecycle(ctx context.Context, k8sClient *k8s.Client, ec2Client *ec2.Client, asgClient *autoscaling.Client, opConfig *OperatorConfig, verbose bool) (CheckResult, error) {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
 
 	if verbose {
 		fmt.Printf("[%s] Checking for error events...\n", timestamp)
 	}
 
-	// Query all events
-	events, err := k8sClient.QueryEvents(ctx, k8s.EventQueryOptions{
-		Namespace: "", // All namespaces
+	// Query all events, bounded so an unreachable API server cannot stall the watch loop
+	queryTimeout := opConfig.QueryTimeout
+	if queryTimeout <= 0 {
+		queryTimeout = k8s.DefaultTimeout
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	events, err := k8sClient.QueryEvents(queryCtx, k8s.EventQueryOptions{
+		Namespace:    "", // All namespaces
+		ListAttempts: opConfig.ListAttempts,
+		Type:         opConfig.EventType,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to query events: %w", err)
+		return CheckResult{}, fmt.Errorf("failed to query events: %w", k8s.WrapTimeoutError(queryCtx, err, queryTimeout))
 	}
 
-	// Track node groups that need recycling
-	nodeGroupsToRecycle := make(map[string]int) // key: nodeGroupName, value: event count
+	// Guard against API servers that ignore the field selector
+	events = k8s.FilterEventsByType(events, opConfig.EventType)
+
+	// Track node groups that need recycling, and matching events that cannot be attributed to one
+	nodeGroupsToRecycle := make(k8s.NodeGroupEventCounts)
+	unmapped := make(k8s.UnmappedEventCounts)
 
 	// Check each search term
 	for _, searchTerm := range opConfig.SearchTerms {
-		matchingEvents := k8s.FilterEvents(events, searchTerm)
+		matchingEvents := k8s.FilterEventsByField(events, searchTerm, opConfig.MatchField)
 
 		if len(matchingEvents) == 0 {
 			continue
@@ -56,6 +155,9 @@ func CheckAndRecycle(ctx context.Context, k8sClient *k8s.Client, ec2Client *ec2.
 		}
 
 		fmt.Printf("[%s] Found %d recent event(s) matching %q\n", timestamp, len(recentEvents), searchTerm)
+		if verbose || opConfig.Detail {
+			printEventBreakdown(os.Stdout, k8s.BreakdownEvents(recentEvents, k8s.DefaultBreakdownSize))
+		}
 
 		// Enrich with node information
 		enrichedEvents, err := k8sClient.EnrichEventsWithNodeInfo(ctx, recentEvents, true)
@@ -66,41 +168,65 @@ func CheckAndRecycle(ctx context.Context, k8sClient *k8s.Client, ec2Client *ec2.
 
 		// Find affected node groups
 		for _, enriched := range enrichedEvents {
-			if enriched.InstanceID != "" && enriched.InstanceID != "N/A" {
-				// Query node group for this instance
-				nodeGroups, err := FindNodeGroupForInstance(ctx, ec2Client, enriched.InstanceID)
-				if err != nil {
-					if verbose {
-						fmt.Fprintf(os.Stderr, "  Warning: Could not find node group for instance %s: %v\n", enriched.InstanceID, err)
-					}
-					continue
+			ref := enriched.Event.InvolvedObject
+			// Events on kinds left out of WatchResources are ignored on purpose, not unmapped
+			if !k8s.WatchesResource(opConfig.WatchResources, ref.Kind) {
+				if verbose {
+					fmt.Printf("  Skipped event %s/%s on %s, which is not a watched resource\n", enriched.Event.Namespace, enriched.Event.Name, ref.Kind)
 				}
+				continue
+			}
+
+			// Pod events are enriched already; other kinds are followed to their pods' nodes
+			instanceIDs := []string{enriched.InstanceID}
+			if ref.Kind != k8s.KindPod {
+				instanceIDs, err = k8sClient.InstanceIDsForObject(ctx, ref)
+				if err != nil && verbose {
+					fmt.Fprintf(os.Stderr, "  Warning: Could not resolve %s %s/%s: %v\n", ref.Kind, ref.Namespace, ref.Name, err)
+				}
+			}
+
+			eventNodeGroups, reason := nodeGroupsForInstances(ctx, ec2Client, instanceIDs, verbose)
+			if len(eventNodeGroups) == 0 {
+				if reason == k8s.UnmappedNoInstanceID && verbose {
+					fmt.Printf("  Matched event %s/%s with unresolvable node group (%s)\n", enriched.Event.Namespace, enriched.Event.Name, k8s.UnmappedNoInstanceID)
+				}
+				unmapped.Add(reason)
+				continue
+			}
 
-				for _, ng := range nodeGroups {
-					if ng != "" && ng != "Unknown" {
-						nodeGroupsToRecycle[ng]++
+			for ng := range eventNodeGroups {
+				if !opConfig.InScope(ng) {
+					if verbose {
+						fmt.Printf("  Ignoring event on node group %s (not in --node-group scope)\n", ng)
 					}
+					continue
 				}
+				nodeGroupsToRecycle[ng]++
 			}
 		}
 	}
 
+	if unmapped.Total() > 0 && verbose {
+		fmt.Printf("[%s] ⚠️  %d matching event(s) could not be mapped to a node group (%s)\n", timestamp, unmapped.Total(), unmapped)
+	}
+
+	// Pick the node groups over the threshold, deferring any beyond the per-cycle cap
+	plan := planRecycles(nodeGroupsToRecycle, opConfig, timestamp)
+	result := CheckResult{OverThreshold: append(append([]k8s.NodeGroup{}, plan.Recycle...), plan.Deferred...)}
+
 	// Recycle node groups that exceed threshold
-	for ngName, count := range nodeGroupsToRecycle {
-		if count >= opConfig.RecycleThreshold {
-			fmt.Printf("[%s] 🔄 Node group %s has %d problematic events (threshold: %d)\n",
-				timestamp, ngName, count, opConfig.RecycleThreshold)
-
-			if opConfig.DryRun {
-				fmt.Printf("  [DRY RUN] Would recycle node group: %s\n", ngName)
-			} else {
-				fmt.Printf("  Recycling node group: %s\n", ngName)
-				// Note: Implement recycling logic here or call the recycle function
-				fmt.Printf("  ⚠️  Automated recycling not yet implemented - manual intervention required\n")
+	result.Recycled = recycleNodeGroups(ctx, plan.Recycle, nodeGroupsToRecycle, opConfig, timestamp)
+	for _, ng := range plan.Deferred {
+		fmt.Printf("[%s] ⏸️  Deferred recycle of node group %s with %d problematic events (limit of %d per cycle reached)\n",
+			timestamp, ng, nodeGroupsToRecycle[ng], opConfig.MaxRecyclesPerCycle)
+	}
+	if verbose {
+		for ng, count := range nodeGroupsToRecycle {
+			if count < opConfig.RecycleThreshold {
+				fmt.Printf("[%s] Node group %s has %d events (below threshold of %d)\n",
+					timestamp, ng, count, opConfig.RecycleThreshold)
 			}
-		} else if verbose {
-			fmt.Printf("[%s] Node group %s has %d events (below threshold of %d)\n",
-				timestamp, ngName, count, opConfig.RecycleThreshold)
 		}
 	}
 
@@ -108,7 +234,132 @@ func CheckAndRecycle(ctx context.Context, k8sClient *k8s.Client, ec2Client *ec2.
 		fmt.Printf("[%s] ✓ No problematic node groups detected\n", timestamp)
 	}
 
-	return nil
+	return result, nil
+}
+
+// recycleNodeGroups recycles each node group with opConfig.Recycle, or only reports it in dry
+// run, and returns those recycled. A failed recycle is reported and does not stop the others,
+// unless ctx was cancelled.
+// Each attempt is recorded in opConfig.LastRecycled for the cooldown.
+func recycleNodeGroups(ctx context.Context, nodeGroups []k8s.NodeGroup, counts k8s.NodeGroupEventCounts, opConfig *OperatorConfig, timestamp string) []k8s.NodeGroup {
+	var recycled []k8s.NodeGroup
+	for _, ng := range nodeGroups {
+		ngName := ng.String()
+		fmt.Printf("[%s] 🔄 Node group %s has %d problematic events (threshold: %d)\n",
+			timestamp, ngName, counts[ng], opConfig.RecycleThreshold)
+
+		switch {
+		case opConfig.DryRun:
+			fmt.Printf("  [DRY RUN] Would recycle node group: %s\n", ngName)
+		case ng.Kind == k8s.NodeGroupKindKarpenter:
+			// Karpenter nodes are recycled by deleting the nodes, not by scaling an ASG
+			fmt.Printf("  ⚠️  %s is a Karpenter node pool - recycle it by deleting its nodes\n", ng.Name)
+		case opConfig.Recycle == nil:
+			fmt.Printf("  ⚠️  No recycler configured for %s - manual intervention required\n", ngName)
+		default:
+			fmt.Printf("  Recycling node group: %s\n", ngName)
+			// A failed recycle may have replaced some nodes already, so it starts the
+			// cooldown too
+			if opConfig.LastRecycled == nil {
+				opConfig.LastRecycled = make(map[k8s.NodeGroup]time.Time)
+			}
+			opConfig.LastRecycled[ng] = time.Now()
+			if err := opConfig.Recycle(ctx, ng); err != nil {
+				fmt.Fprintf(os.Stderr, "  ❌ Failed to recycle node group %s: %v\n", ngName, err)
+				// A cancelled context would fail every remaining recycle the same way
+				if ctx.Err() != nil {
+					fmt.Fprintln(os.Stderr, "  🛑 Shutting down; remaining node groups are not recycled")
+					return recycled
+				}
+				continue
+			}
+			fmt.Printf("  ✓ Recycled node group: %s\n", ngName)
+			recycled = append(recycled, ng)
+		}
+	}
+	return recycled
+}
+
+// planRecycles picks the node groups to recycle in this check with k8s.PlanRecycles. Node
+// groups still in their recycle cooldown are dropped first, along with any deferred count, so
+// they cannot take a MaxRecyclesPerCycle slot from node groups that can be recycled now.
+func planRecycles(counts k8s.NodeGroupEventCounts, opConfig *OperatorConfig, timestamp string) k8s.RecyclePlan {
+	if opConfig.DeferredRecycles == nil {
+		opConfig.DeferredRecycles = make(k8s.NodeGroupEventCounts)
+	}
+
+	now := time.Now()
+	for ng, last := range opConfig.LastRecycled {
+		if !inRecycleCooldown(opConfig, ng, now) {
+			continue
+		}
+		if count := counts[ng] + opConfig.DeferredRecycles[ng]; count >= opConfig.RecycleThreshold {
+			fmt.Printf("[%s] ⏳ Skipping node group %s with %d problematic events: recycled %s ago (cooldown: %s)\n",
+				timestamp, ng, count, now.Sub(last).Round(time.Second), opConfig.RecycleCooldown)
+		}
+		delete(counts, ng)
+		delete(opConfig.DeferredRecycles, ng)
+	}
+
+	return k8s.PlanRecycles(counts, opConfig.DeferredRecycles, opConfig.RecycleThreshold, opConfig.MaxRecyclesPerCycle)
+}
+
+// inRecycleCooldown reports whether ng was handed to the recycler less than the configured
+// cooldown before now
+func inRecycleCooldown(opConfig *OperatorConfig, ng k8s.NodeGroup, now time.Time) bool {
+	last, ok := opConfig.LastRecycled[ng]
+	return ok && opConfig.RecycleCooldown > 0 && now.Sub(last) < opConfig.RecycleCooldown
+}
+
+// printEventBreakdown prints the top namespaces and reasons of the matching events as two
+// small indented tables
+func printEventBreakdown(w io.Writer, breakdown k8s.EventBreakdown) {
+	printTable := func(header string, counts k8s.EventCounts) {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "  %s\tEVENTS\n", header)
+		for _, count := range counts {
+			fmt.Fprintf(tw, "  %s\t%d\n", count.Name, count.Count)
+		}
+		_ = tw.Flush()
+	}
+
+	printTable("NAMESPACE", breakdown.Namespaces)
+	printTable("REASON", breakdown.Reasons)
+}
+
+// nodeGroupsForInstances returns the distinct node groups of the given instances. When none
+// can be resolved it also returns the reason the last instance failed, for the unmapped count.
+func nodeGroupsForInstances(ctx context.Context, ec2Client *ec2.Client, instanceIDs []string, verbose bool) (map[k8s.NodeGroup]bool, k8s.UnmappedReason) {
+	nodeGroups := make(map[k8s.NodeGroup]bool)
+	reason := k8s.UnmappedNoInstanceID
+	for _, instanceID := range instanceIDs {
+		if instanceID == "" || instanceID == "N/A" {
+			reason = k8s.UnmappedNoInstanceID
+			continue
+		}
+
+		// Query node group for this instance
+		found, err := FindNodeGroupForInstance(ctx, ec2Client, instanceID)
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "  Warning: Could not find node group for instance %s: %v\n", instanceID, err)
+			}
+			reason = k8s.UnmappedDescribeError
+			continue
+		}
+		if len(found) == 0 {
+			if verbose {
+				fmt.Printf("  Matched event on instance %s with unresolvable node group (%s)\n", instanceID, k8s.UnmappedNoNodeGroupTag)
+			}
+			reason = k8s.UnmappedNoNodeGroupTag
+			continue
+		}
+
+		for _, ng := range found {
+			nodeGroups[ng] = true
+		}
+	}
+	return nodeGroups, reason
 }
 
 // FilterRecentEvents filters out events that have been processed recently
@@ -145,8 +396,50 @@ func FilterRecentEvents(events []corev1.Event, opConfig *OperatorConfig) []corev
 	return recentEvents
 }
 
-// FindNodeGroupForInstance queries AWS to find node group for an instance
-func FindNodeGroupForInstance(ctx context.Context, ec2Client *ec2.Client, instanceID string) ([]string, error) {
+// MarkExistingEventsProcessed queries the current events and marks those matching the search
+// terms as processed, so a restarted operator does not act on events it may already have
+// handled before the restart. It returns the number of events marked.
+func MarkExistingEventsProcessed(ctx context.Context, k8sClient *k8s.Client, opConfig *OperatorConfig) (int, error) {
+	queryTimeout := opConfig.QueryTimeout
+	if queryTimeout <= 0 {
+		queryTimeout = k8s.DefaultTimeout
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	events, err := k8sClient.QueryEvents(queryCtx, k8s.EventQueryOptions{
+		Namespace:    "", // All namespaces
+		ListAttempts: opConfig.ListAttempts,
+		Type:         opConfig.EventType,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query events: %w", k8s.WrapTimeoutError(queryCtx, err, queryTimeout))
+	}
+
+	return markEventsProcessed(events, opConfig), nil
+}
+
+// markEventsProcessed records every event matching one of the search terms as processed now,
+// so FilterRecentEvents skips it for the next hour. It returns the number of events marked.
+func markEventsProcessed(events []corev1.Event, opConfig *OperatorConfig) int {
+	events = k8s.FilterEventsByType(events, opConfig.EventType)
+
+	now := time.Now()
+	marked := 0
+	for _, searchTerm := range opConfig.SearchTerms {
+		for _, event := range k8s.FilterEventsByField(events, searchTerm, opConfig.MatchField) {
+			eventKey := fmt.Sprintf("%s/%s", event.Namespace, event.Name)
+			if _, found := opConfig.ProcessedEvents[eventKey]; !found {
+				marked++
+			}
+			opConfig.ProcessedEvents[eventKey] = now
+		}
+	}
+	return marked
+}
+
+// FindNodeGroupForInstance queries AWS to find node group for an instance, recognizing
+// EKS, eksctl and Karpenter instance tags
+func FindNodeGroupForInstance(ctx context.Context, ec2Client *ec2.Client, instanceID string) ([]k8s.NodeGroup, error) {
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []string{instanceID},
 	}
@@ -156,19 +449,13 @@ func FindNodeGroupForInstance(ctx context.Context, ec2Client *ec2.Client, instan
 		return nil, err
 	}
 
-	nodeGroups := []string{}
+	nodeGroups := []k8s.NodeGroup{}
 
 	for _, reservation := range result.Reservations {
 		for _, instance := range reservation.Instances {
 			// Extract node group from tags
-			for _, tag := range instance.Tags {
-				if tag.Key == nil || tag.Value == nil {
-					continue
-				}
-
-				if *tag.Key == "eks:nodegroup-name" || *tag.Key == "alpha.eksctl.io/nodegroup-name" {
-					nodeGroups = append(nodeGroups, *tag.Value)
-				}
+			if ng, ok := k8s.NodeGroupFromEC2Tags(instance.Tags); ok {
+				nodeGroups = append(nodeGroups, ng)
 			}
 		}
 	}
@@ -0,0 +1,260 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pischarti/nix/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOperatorConfigInScope(t *testing.T) {
+	asg := k8s.NodeGroup{Name: "ng-risky", Kind: k8s.NodeGroupKindASG}
+	other := k8s.NodeGroup{Name: "ng-workers", Kind: k8s.NodeGroupKindASG}
+	karpenter := k8s.NodeGroup{Name: "default", Kind: k8s.NodeGroupKindKarpenter}
+
+	tests := []struct {
+		name       string
+		nodeGroups []string
+		ng         k8s.NodeGroup
+		want       bool
+	}{
+		{name: "no scope matches everything", ng: other, want: true},
+		{name: "named group", nodeGroups: []string{"ng-risky"}, ng: asg, want: true},
+		{name: "other group", nodeGroups: []string{"ng-risky"}, ng: other, want: false},
+		{name: "karpenter by name", nodeGroups: []string{"default"}, ng: karpenter, want: true},
+		{name: "karpenter by display name", nodeGroups: []string{"karpenter/default"}, ng: karpenter, want: true},
+		{name: "karpenter prefix does not match asg", nodeGroups: []string{"karpenter/ng-risky"}, ng: asg, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &OperatorConfig{NodeGroups: tt.nodeGroups}
+			if got := config.InScope(tt.ng); got != tt.want {
+				t.Errorf("InScope(%s) = %v, want %v", tt.ng, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperatorConfigValidate(t *testing.T) {
+	valid := func() *OperatorConfig {
+		return &OperatorConfig{
+			WatchInterval:    time.Minute,
+			SearchTerms:      []string{"failed to get sandbox image"},
+			RecycleThreshold: 5,
+			QueryTimeout:     30 * time.Second,
+			ListAttempts:     3,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *OperatorConfig)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(c *OperatorConfig) {}},
+		{name: "zero watch interval", modify: func(c *OperatorConfig) { c.WatchInterval = 0 }, wantErr: true},
+		{name: "no search terms", modify: func(c *OperatorConfig) { c.SearchTerms = nil }, wantErr: true},
+		{name: "empty search term", modify: func(c *OperatorConfig) { c.SearchTerms = []string{""} }, wantErr: true},
+		{name: "zero threshold", modify: func(c *OperatorConfig) { c.RecycleThreshold = 0 }, wantErr: true},
+		{name: "zero timeout", modify: func(c *OperatorConfig) { c.QueryTimeout = 0 }, wantErr: true},
+		{name: "zero list attempts", modify: func(c *OperatorConfig) { c.ListAttempts = 0 }, wantErr: true},
+		{name: "recycle limit", modify: func(c *OperatorConfig) { c.MaxRecyclesPerCycle = 2 }},
+		{name: "negative recycle limit", modify: func(c *OperatorConfig) { c.MaxRecyclesPerCycle = -1 }, wantErr: true},
+		{name: "recycle cooldown", modify: func(c *OperatorConfig) { c.RecycleCooldown = 30 * time.Minute }},
+		{name: "negative recycle cooldown", modify: func(c *OperatorConfig) { c.RecycleCooldown = -time.Minute }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarkEventsProcessed(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Namespace: "default"}, Message: "failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pulled", Namespace: "default"}, Message: "Successfully pulled image"},
+	}
+	opConfig := &OperatorConfig{
+		SearchTerms:     []string{"failed to get sandbox image"},
+		ProcessedEvents: make(map[string]time.Time),
+	}
+
+	if marked := markEventsProcessed(events, opConfig); marked != 1 {
+		t.Errorf("markEventsProcessed() = %d, want 1", marked)
+	}
+	if _, found := opConfig.ProcessedEvents["default/sandbox"]; !found {
+		t.Error("matching event was not marked as processed")
+	}
+
+	// Marked events are skipped by the regular dedup filter
+	recent := FilterRecentEvents(events, opConfig)
+	if len(recent) != 1 || recent[0].Name != "pulled" {
+		t.Errorf("FilterRecentEvents() after marking = %v, want only the unmarked event", recent)
+	}
+}
+
+func TestMarkEventsProcessedByReason(t *testing.T) {
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Namespace: "default"}, Reason: "FailedCreatePodSandBox", Message: "failed to get sandbox image"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mentions", Namespace: "default"}, Reason: "BackOff", Message: "after FailedCreatePodSandBox"},
+	}
+	opConfig := &OperatorConfig{
+		SearchTerms:     []string{"FailedCreatePodSandBox"},
+		MatchField:      k8s.MatchReason,
+		ProcessedEvents: make(map[string]time.Time),
+	}
+
+	if marked := markEventsProcessed(events, opConfig); marked != 1 {
+		t.Errorf("markEventsProcessed() = %d, want 1", marked)
+	}
+	if _, found := opConfig.ProcessedEvents["default/sandbox"]; !found {
+		t.Error("event with the matching reason was not marked as processed")
+	}
+}
+
+func TestPrintEventBreakdown(t *testing.T) {
+	var out bytes.Buffer
+	printEventBreakdown(&out, k8s.EventBreakdown{
+		Total:      4,
+		Namespaces: k8s.EventCounts{{Name: "payments", Count: 3}, {Name: "web", Count: 1}},
+		Reasons:    k8s.EventCounts{{Name: "FailedCreatePodSandBox", Count: 4}},
+	})
+
+	want := `  NAMESPACE  EVENTS
+  payments   3
+  web        1
+  REASON                  EVENTS
+  FailedCreatePodSandBox  4
+`
+	if out.String() != want {
+		t.Errorf("printEventBreakdown() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestRecycleNodeGroups(t *testing.T) {
+	ok := k8s.NodeGroup{Name: "ng-workers", Kind: k8s.NodeGroupKindASG}
+	failing := k8s.NodeGroup{Name: "ng-broken", Kind: k8s.NodeGroupKindASG}
+	karpenter := k8s.NodeGroup{Name: "default", Kind: k8s.NodeGroupKindKarpenter}
+	nodeGroups := []k8s.NodeGroup{ok, failing, karpenter}
+	counts := k8s.NodeGroupEventCounts{ok: 5, failing: 6, karpenter: 7}
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		noRecycler   bool
+		cancelled    bool
+		wantCalls    []k8s.NodeGroup
+		wantRecycled []k8s.NodeGroup
+	}{
+		{
+			name:         "recycles ASG node groups",
+			wantCalls:    []k8s.NodeGroup{ok, failing},
+			wantRecycled: []k8s.NodeGroup{ok},
+		},
+		{
+			name:   "dry run only reports",
+			dryRun: true,
+		},
+		{
+			name:       "no recycler configured",
+			noRecycler: true,
+		},
+		{
+			name:      "shutdown stops after the interrupted recycle",
+			cancelled: true,
+			wantCalls: []k8s.NodeGroup{ok},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []k8s.NodeGroup
+			config := &OperatorConfig{RecycleThreshold: 5, DryRun: tt.dryRun}
+			if !tt.noRecycler {
+				config.Recycle = func(ctx context.Context, ng k8s.NodeGroup) error {
+					calls = append(calls, ng)
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					if ng == failing {
+						return errors.New("ASG not found")
+					}
+					return nil
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			recycled := recycleNodeGroups(ctx, nodeGroups, counts, config, "now")
+
+			// Karpenter node pools are never passed to the recycler
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("Recycle called with %v, want %v", calls, tt.wantCalls)
+			}
+			if !slices.Equal(recycled, tt.wantRecycled) {
+				t.Errorf("recycleNodeGroups() = %v, want %v", recycled, tt.wantRecycled)
+			}
+		})
+	}
+}
+
+func TestPlanRecyclesCooldown(t *testing.T) {
+	busy := k8s.NodeGroup{Name: "ng-busy", Kind: k8s.NodeGroupKindASG}
+	other := k8s.NodeGroup{Name: "ng-other", Kind: k8s.NodeGroupKindASG}
+
+	var calls []k8s.NodeGroup
+	config := &OperatorConfig{
+		RecycleThreshold:    5,
+		MaxRecyclesPerCycle: 1,
+		RecycleCooldown:     30 * time.Minute,
+		Recycle: func(ctx context.Context, ng k8s.NodeGroup) error {
+			calls = append(calls, ng)
+			return nil
+		},
+	}
+
+	// The first check recycles the busiest node group and defers the other one
+	first := k8s.NodeGroupEventCounts{busy: 10, other: 5}
+	plan := planRecycles(first, config, "first")
+	if want := []k8s.NodeGroup{busy}; !slices.Equal(plan.Recycle, want) {
+		t.Fatalf("first check plans %v, want %v", plan.Recycle, want)
+	}
+	recycleNodeGroups(context.Background(), plan.Recycle, first, config, "first")
+
+	// The next check sees the busy node group again, but it is in its cooldown, so the
+	// deferred node group gets the only slot instead of waiting behind it
+	second := k8s.NodeGroupEventCounts{busy: 10}
+	plan = planRecycles(second, config, "second")
+	if want := []k8s.NodeGroup{other}; !slices.Equal(plan.Recycle, want) || len(plan.Deferred) != 0 {
+		t.Fatalf("second check plans %v (deferred %v), want %v", plan.Recycle, plan.Deferred, want)
+	}
+	recycleNodeGroups(context.Background(), plan.Recycle, second, config, "second")
+
+	if want := []k8s.NodeGroup{busy, other}; !slices.Equal(calls, want) {
+		t.Errorf("Recycle called with %v, want %v", calls, want)
+	}
+
+	// Once the cooldown has passed the node group is planned again
+	config.LastRecycled[busy] = time.Now().Add(-config.RecycleCooldown)
+	plan = planRecycles(k8s.NodeGroupEventCounts{busy: 10}, config, "third")
+	if want := []k8s.NodeGroup{busy}; !slices.Equal(plan.Recycle, want) {
+		t.Errorf("check after the cooldown plans %v, want %v", plan.Recycle, want)
+	}
+}
@@ -0,0 +1,90 @@
+package print
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []CustomColumn
+		wantErr bool
+	}{
+		{
+			name: "several columns",
+			spec: "NAMESPACE:.metadata.namespace,REASON:.reason,MSG:.message",
+			want: []CustomColumn{
+				{Header: "NAMESPACE", Path: []string{"metadata", "namespace"}},
+				{Header: "REASON", Path: []string{"reason"}},
+				{Header: "MSG", Path: []string{"message"}},
+			},
+		},
+		{
+			name: "braced path",
+			spec: "KIND:{.involvedObject.kind}",
+			want: []CustomColumn{{Header: "KIND", Path: []string{"involvedObject", "kind"}}},
+		},
+		{name: "empty spec", spec: "", wantErr: true},
+		{name: "missing path", spec: "REASON", wantErr: true},
+		{name: "missing header", spec: ":.reason", wantErr: true},
+		{name: "path without leading dot", spec: "REASON:reason", wantErr: true},
+		{name: "array index", spec: "OWNER:.metadata.ownerReferences[0].name", wantErr: true},
+		{name: "empty field", spec: "NAME:.metadata..name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCustomColumns(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCustomColumns(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCustomColumns(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsCustomColumns(t *testing.T) {
+	events := []corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "my-pod.abc123", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "my-pod"},
+			Reason:         "FailedCreatePodSandBox",
+			Message:        "failed to get sandbox image",
+			Count:          5,
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "node-1.def456"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"},
+			Reason:         "NodeNotReady",
+		},
+	}
+
+	columns, err := ParseCustomColumns("NAMESPACE:.metadata.namespace,KIND:.involvedObject.kind,COUNT:.count,MSG:.message")
+	if err != nil {
+		t.Fatalf("ParseCustomColumns() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := EventsCustomColumns(&out, events, columns); err != nil {
+		t.Fatalf("EventsCustomColumns() error = %v", err)
+	}
+
+	want := []string{
+		"NAMESPACE   KIND   COUNT    MSG",
+		"default     Pod    5        failed to get sandbox image",
+		"<none>      Node   <none>   <none>",
+	}
+	got := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EventsCustomColumns() output:\n%s\nwant:\n%s", out.String(), strings.Join(want, "\n"))
+	}
+}
@@ -2,6 +2,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -174,3 +175,11 @@ func EventsYAML(events []corev1.Event) error {
 	fmt.Println(string(data))
 	return nil
 }
+
+// EventsJSON writes events to w as an indented JSON array
+func EventsJSON(w io.Writer, events []corev1.Event) error {
+	if events == nil {
+		events = []corev1.Event{}
+	}
+	return writeJSON(w, events, "events")
+}
@@ -1,6 +1,8 @@
 package print
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -47,6 +49,38 @@ func TestEventsYAML_EmptyList(t *testing.T) {
 	}
 }
 
+func TestEventsJSON(t *testing.T) {
+	events := []corev1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod.abc123", Namespace: "default"},
+			Message:    "Failed to create pod sandbox: failed to get sandbox image",
+			Reason:     "FailedCreatePodSandBox",
+			Count:      5,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := EventsJSON(&buf, events); err != nil {
+		t.Fatalf("EventsJSON() returned error: %v", err)
+	}
+	var got []corev1.Event
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("EventsJSON() wrote invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 1 || got[0].Name != "my-pod.abc123" || got[0].Reason != "FailedCreatePodSandBox" || got[0].Count != 5 {
+		t.Errorf("EventsJSON() round trip = %+v, want the input event", got)
+	}
+
+	// An empty result is an empty array, not null
+	buf.Reset()
+	if err := EventsJSON(&buf, nil); err != nil {
+		t.Fatalf("EventsJSON() with no events returned error: %v", err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("EventsJSON() with no events = %q, want %q", got, "[]\n")
+	}
+}
+
 func TestEventsTable_EmptyList(t *testing.T) {
 	events := []corev1.Event{}
 
@@ -1,17 +1,21 @@
 package print
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
 )
 
 // PrintImagesTable prints images in a table format with namespace information
-func PrintImagesTable(imagesSet map[string]struct{}, namespace string, allNamespaces bool, style string, sortBy string) {
+func PrintImagesTable(imagesSet map[string]struct{}, namespace string, allNamespaces bool, style string, sortBy string, color, noHeaders bool) {
 	images := make([]string, 0, len(imagesSet))
 	for img := range imagesSet {
 		images = append(images, img)
@@ -36,21 +40,12 @@ func PrintImagesTable(imagesSet map[string]struct{}, namespace string, allNamesp
 	t.SetOutputMirror(os.Stdout)
 
 	// Set table style based on parameter
-	switch style {
-	case "simple":
-		t.SetStyle(table.StyleDefault)
-	case "box":
-		t.SetStyle(table.StyleDouble)
-	case "rounded":
-		t.SetStyle(table.StyleRounded)
-	case "colored", "color":
-		t.SetStyle(table.StyleColoredBright)
-	default:
-		t.SetStyle(table.StyleColoredBright)
-	}
+	setTableStyle(t, style, color, noHeaders)
 
 	// Add headers
-	t.AppendHeader(table.Row{"NAMESPACE", "IMAGE"})
+	if !noHeaders {
+		t.AppendHeader(table.Row{"NAMESPACE", "IMAGE"})
+	}
 
 	// Determine namespace display
 	nsDisplay := "all"
@@ -69,39 +64,208 @@ func PrintImagesTable(imagesSet map[string]struct{}, namespace string, allNamesp
 
 // ImageNamespace represents an image with its namespace
 type ImageNamespace struct {
-	Image     string
-	Namespace string
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+}
+
+// SortImageNamespaces orders images by namespace then image, or by image alone for
+// sortBy "image"; "none" keeps the given order
+func SortImageNamespaces(images []ImageNamespace, sortBy string) {
+	switch sortBy {
+	case "image":
+		sort.SliceStable(images, func(i, j int) bool {
+			return images[i].Image < images[j].Image
+		})
+	case "none":
+		// No sorting
+	default:
+		sort.Slice(images, func(i, j int) bool {
+			if images[i].Namespace == images[j].Namespace {
+				return images[i].Image < images[j].Image
+			}
+			return images[i].Namespace < images[j].Namespace
+		})
+	}
 }
 
 // PrintImagesTableWithNamespaces prints images in a table format showing actual namespace values
-func PrintImagesTableWithNamespaces(imageNamespaceMap map[string]string, style string, sortBy string) {
+func PrintImagesTableWithNamespaces(imageNamespaceMap map[string]string, style string, sortBy string, color, noHeaders bool) {
 	// Convert map to slice of structs for sorting
 	var imageNsList []ImageNamespace
 	for img, ns := range imageNamespaceMap {
 		imageNsList = append(imageNsList, ImageNamespace{Image: img, Namespace: ns})
 	}
+	SortImageNamespaces(imageNsList, sortBy)
+
+	// Create table
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	// Set table style based on parameter
+	setTableStyle(t, style, color, noHeaders)
+
+	// Add headers
+	if !noHeaders {
+		t.AppendHeader(table.Row{"NAMESPACE", "IMAGE"})
+	}
+
+	// Add rows with actual namespace values
+	for _, item := range imageNsList {
+		t.AppendRow(table.Row{item.Namespace, item.Image})
+	}
+
+	// Render table
+	t.Render()
+}
+
+// RegistryInfo represents a registry host with the images and namespaces that pull from it
+type RegistryInfo struct {
+	Registry   string
+	ImageCount int
+	Namespaces []string
+}
+
+// PrintRegistryTable prints a registry breakdown table, busiest registries first
+func PrintRegistryTable(registries []RegistryInfo, style string, color, noHeaders bool) {
+	sort.Slice(registries, func(i, j int) bool {
+		if registries[i].ImageCount == registries[j].ImageCount {
+			return registries[i].Registry < registries[j].Registry
+		}
+		return registries[i].ImageCount > registries[j].ImageCount
+	})
+
+	// Create table
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	// Set table style based on parameter
+	setTableStyle(t, style, color, noHeaders)
+
+	// Add headers
+	if !noHeaders {
+		t.AppendHeader(table.Row{"REGISTRY", "IMAGE COUNT", "NAMESPACES"})
+	}
+
+	// Add rows with namespaces listed one per line, or comma-separated without headers
+	for _, reg := range registries {
+		namespaces := append([]string(nil), reg.Namespaces...)
+		sort.Strings(namespaces)
+		row := table.Row{reg.Registry, reg.ImageCount, strings.Join(namespaces, "\n")}
+		if noHeaders {
+			row = flattenRow(row)
+		}
+		t.AppendRow(row)
+	}
+
+	// Render table
+	t.Render()
+}
+
+// NamespaceImageInfo represents a namespace with the number of distinct images and pods it runs
+type NamespaceImageInfo struct {
+	Namespace  string
+	ImageCount int
+	PodCount   int
+}
+
+// PrintNamespaceTable prints a per-namespace image summary, namespaces with the most
+// distinct images first
+func PrintNamespaceTable(namespaces []NamespaceImageInfo, style string, color, noHeaders bool) {
+	sort.Slice(namespaces, func(i, j int) bool {
+		if namespaces[i].ImageCount == namespaces[j].ImageCount {
+			return namespaces[i].Namespace < namespaces[j].Namespace
+		}
+		return namespaces[i].ImageCount > namespaces[j].ImageCount
+	})
+
+	// Create table
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	// Set table style based on parameter
+	setTableStyle(t, style, color, noHeaders)
+
+	// Add headers
+	if !noHeaders {
+		t.AppendHeader(table.Row{"NAMESPACE", "DISTINCT IMAGES", "PODS"})
+	}
+
+	// Add rows
+	for _, ns := range namespaces {
+		t.AppendRow(table.Row{ns.Namespace, ns.ImageCount, ns.PodCount})
+	}
+
+	// Render table
+	t.Render()
+}
+
+// ImageCountInfo represents an image with the number of pods that reference it
+type ImageCountInfo struct {
+	Image string
+	Count int
+}
+
+// PrintImagesCountTable prints each image with the number of pods referencing it, most
+// referenced first
+func PrintImagesCountTable(images []ImageCountInfo, style string, color, noHeaders bool) {
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Count == images[j].Count {
+			return images[i].Image < images[j].Image
+		}
+		return images[i].Count > images[j].Count
+	})
+
+	// Create table
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	// Set table style based on parameter
+	setTableStyle(t, style, color, noHeaders)
+
+	// Add headers
+	if !noHeaders {
+		t.AppendHeader(table.Row{"IMAGE", "COUNT"})
+	}
+
+	// Add rows
+	for _, image := range images {
+		t.AppendRow(table.Row{image.Image, image.Count})
+	}
+
+	// Render table
+	t.Render()
+}
+
+// ContainerImageInfo represents a single container with the image it runs
+type ContainerImageInfo struct {
+	Namespace string
+	Pod       string
+	Container string
+	Image     string
+	Restarts  int32
+}
 
+// PrintImagesWideTable prints one row per container with its namespace, pod, image and
+// restart count, without deduplicating images
+func PrintImagesWideTable(containers []ContainerImageInfo, style string, sortBy string, color, noHeaders bool) {
 	// Sort based on sortBy parameter
 	switch sortBy {
 	case "image":
-		sort.Slice(imageNsList, func(i, j int) bool {
-			return imageNsList[i].Image < imageNsList[j].Image
-		})
-	case "namespace":
-		sort.Slice(imageNsList, func(i, j int) bool {
-			if imageNsList[i].Namespace == imageNsList[j].Namespace {
-				return imageNsList[i].Image < imageNsList[j].Image
-			}
-			return imageNsList[i].Namespace < imageNsList[j].Namespace
+		sort.SliceStable(containers, func(i, j int) bool {
+			return containers[i].Image < containers[j].Image
 		})
 	case "none":
 		// No sorting
 	default:
-		sort.Slice(imageNsList, func(i, j int) bool {
-			if imageNsList[i].Namespace == imageNsList[j].Namespace {
-				return imageNsList[i].Image < imageNsList[j].Image
+		sort.SliceStable(containers, func(i, j int) bool {
+			a, b := containers[i], containers[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
 			}
-			return imageNsList[i].Namespace < imageNsList[j].Namespace
+			if a.Pod != b.Pod {
+				return a.Pod < b.Pod
+			}
+			return a.Container < b.Container
 		})
 	}
 
@@ -110,25 +274,16 @@ func PrintImagesTableWithNamespaces(imageNamespaceMap map[string]string, style s
 	t.SetOutputMirror(os.Stdout)
 
 	// Set table style based on parameter
-	switch style {
-	case "simple":
-		t.SetStyle(table.StyleDefault)
-	case "box":
-		t.SetStyle(table.StyleDouble)
-	case "rounded":
-		t.SetStyle(table.StyleRounded)
-	case "colored", "color":
-		t.SetStyle(table.StyleColoredBright)
-	default:
-		t.SetStyle(table.StyleColoredBright)
-	}
+	setTableStyle(t, style, color, noHeaders)
 
 	// Add headers
-	t.AppendHeader(table.Row{"NAMESPACE", "IMAGE"})
+	if !noHeaders {
+		t.AppendHeader(table.Row{"NAMESPACE", "POD", "CONTAINER", "IMAGE", "RESTARTS"})
+	}
 
-	// Add rows with actual namespace values
-	for _, item := range imageNsList {
-		t.AppendRow(table.Row{item.Namespace, item.Image})
+	// Add rows
+	for _, c := range containers {
+		t.AppendRow(table.Row{c.Namespace, c.Pod, c.Container, c.Image, c.Restarts})
 	}
 
 	// Render table
@@ -160,47 +315,237 @@ func PrintImagesList(imagesSet map[string]struct{}, sortBy string) {
 	}
 }
 
+// Kinds of container a pod image can run in
+const (
+	ContainerKindRegular   = "regular"
+	ContainerKindInit      = "init"
+	ContainerKindEphemeral = "ephemeral"
+)
+
+// PodContainerImage represents a container of a pod with the image it runs and its kind:
+// regular, init or ephemeral
+type PodContainerImage struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Kind  string `json:"kind"`
+}
+
+// String formats the container as name=image, marking init and ephemeral containers
+func (c PodContainerImage) String() string {
+	if c.Kind == ContainerKindRegular {
+		return c.Name + "=" + c.Image
+	}
+	return fmt.Sprintf("%s=%s (%s)", c.Name, c.Image, c.Kind)
+}
+
+// PodImages represents the distinct images of a pod, in container order, and the
+// containers running them
+type PodImages struct {
+	Namespace  string              `json:"namespace"`
+	Pod        string              `json:"pod"`
+	Images     []string            `json:"images"`
+	Containers []PodContainerImage `json:"containers"`
+}
+
+// PrintImagesJSON writes the images to w as an indented JSON array of namespace and image objects
+func PrintImagesJSON(w io.Writer, images []ImageNamespace) error {
+	if images == nil {
+		images = []ImageNamespace{}
+	}
+	return writeJSON(w, images, "images")
+}
+
+// PrintImagesYAML writes the images to w as a YAML list of namespace and image objects
+func PrintImagesYAML(w io.Writer, images []ImageNamespace) error {
+	if images == nil {
+		images = []ImageNamespace{}
+	}
+	return writeYAML(w, images, "images")
+}
+
+// PrintPodImagesJSON writes the images of each pod to w as an indented JSON array
+func PrintPodImagesJSON(w io.Writer, pods []PodImages) error {
+	if pods == nil {
+		pods = []PodImages{}
+	}
+	return writeJSON(w, pods, "pod images")
+}
+
+// PrintPodImagesYAML writes the images of each pod to w as a YAML list
+func PrintPodImagesYAML(w io.Writer, pods []PodImages) error {
+	if pods == nil {
+		pods = []PodImages{}
+	}
+	return writeYAML(w, pods, "pod images")
+}
+
+// writeJSON encodes v to w as indented JSON, naming what in the error
+func writeJSON(w io.Writer, v any, what string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal %s to JSON: %w", what, err)
+	}
+	return nil
+}
+
+// writeYAML encodes v to w as YAML, naming what in the error
+func writeYAML(w io.Writer, v any, what string) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s to YAML: %w", what, err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
 // PrintImagesHelp prints the help information for the images command
 func PrintImagesHelp() {
-	fmt.Println("Usage: kube images [--namespace NAMESPACE | --all-namespaces] [--by-pod] [--table] [--style STYLE] [--sort SORT]")
+	fmt.Println("Usage: kube images [--namespace NAMESPACE | --all-namespaces] [--by-pod] [--by-registry] [--by-namespace] [--digest] [--filter SUBSTR] [--count] [--output wide|json|yaml] [--table] [--style STYLE] [--color WHEN] [--no-headers] [--sort SORT] [--timeout DURATION] [--list-attempts N] [--watch [--refresh DURATION]] [--save FILE] [--diff FILE]")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --namespace, -n    Query a specific namespace")
-	fmt.Println("  --all-namespaces, -A  Query across all namespaces (default)")
-	fmt.Println("  --by-pod          Show images grouped by pod")
+	fmt.Println("  --all-namespaces, -A  Query across all namespaces (default; required when the cluster has more than 5000 pods)")
+	fmt.Println("  --by-pod          Show the containers of each pod as name=image, marking (init) and (ephemeral) ones")
+	fmt.Println("  --by-registry     Show a summary of images per registry host")
+	fmt.Println("  --by-namespace    Show the distinct image and pod count per namespace")
+	fmt.Println("  --digest          Show the running image digest (image@sha256:...) from pod status")
+	fmt.Println("  --filter SUBSTR   Only show images whose reference contains SUBSTR, ignoring case (e.g. quay.io)")
+	fmt.Println("  --count           Show how many pods reference each image, most referenced first")
+	fmt.Println("  --output, -o      Output format: wide lists every container with its namespace, pod and restart count;")
+	fmt.Println("                    json and yaml print namespace and image objects, or namespace, pod and images with --by-pod")
 	fmt.Println("  --table, -t       Display output in table format")
 	fmt.Println("  --style           Table style: simple, box, rounded, colored (default)")
+	fmt.Println("  --color           Color tables: auto (default, only when stdout is a terminal), always, never")
+	fmt.Println("  --no-headers      Omit the header row and borders from table output, for piping into awk or cut")
 	fmt.Println("  --sort            Sort order: namespace (default), image, none")
+	fmt.Println("  --timeout         Deadline for cluster calls, e.g. 10s, 2m (default: 30s)")
+	fmt.Println("  --list-attempts   Maximum attempts for list calls failing with transient API server errors (default: 3)")
+	fmt.Println("  --watch, -w       Re-query and re-render on an interval until Ctrl+C")
+	fmt.Println("  --refresh         Interval between renders in watch mode (default: 10s)")
+	fmt.Println("  --save FILE       Also write the image inventory with its namespaces to FILE as sorted JSON")
+	fmt.Println("  --diff FILE       Print the images added and removed since the inventory saved in FILE")
 	fmt.Println("  --help, -h        Show this help message")
 }
 
 // ServiceInfo represents a service with its key information
 type ServiceInfo struct {
-	Namespace   string
-	Name        string
-	Type        string
+	Namespace string
+	Name      string
+	Type      string
+	// External is the load balancer hostnames or IPs and external IPs, or "-" when there are none
+	External string
+	Ports    string
+	// Selector is the pod selector as sorted key=value pairs, or "-" when there is none
+	Selector    string
 	Annotations []string
 }
 
-// PrintServicesTable prints services in a table format
-func PrintServicesTable(services []corev1.Service, style string, sortBy string) {
-	// Convert services to ServiceInfo structs
-	var serviceInfos []ServiceInfo
-	for _, service := range services {
-		var allAnnotations []string
-		for key, value := range service.Annotations {
-			// Exclude last-applied-configuration annotations
-			if !strings.Contains(strings.ToLower(key), "last-applied-configuration") {
-				allAnnotations = append(allAnnotations, fmt.Sprintf("%s=%s", key, value))
+// newServiceInfo collects the columns shown for a service, with annotations ordered by annotationSort
+func newServiceInfo(service corev1.Service, annotationSort string) ServiceInfo {
+	return ServiceInfo{
+		Namespace:   service.Namespace,
+		Name:        service.Name,
+		Type:        string(service.Spec.Type),
+		External:    serviceExternal(service),
+		Ports:       servicePorts(service),
+		Selector:    serviceSelector(service),
+		Annotations: serviceAnnotations(service, annotationSort),
+	}
+}
+
+// serviceExternal lists the addresses a service is reachable at from outside the cluster:
+// the load balancer ingress hostnames or IPs, then any external IPs. A LoadBalancer service
+// still waiting for its load balancer shows "<pending>", other services without one "-".
+func serviceExternal(service corev1.Service) string {
+	var addresses []string
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			addresses = append(addresses, ingress.Hostname)
+		} else if ingress.IP != "" {
+			addresses = append(addresses, ingress.IP)
+		}
+	}
+	addresses = append(addresses, service.Spec.ExternalIPs...)
+
+	if len(addresses) > 0 {
+		return strings.Join(addresses, ",")
+	}
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		return "<pending>"
+	}
+	return "-"
+}
+
+// serviceSelector formats the service's pod selector as comma-separated key=value pairs
+// sorted by key, or "-" for services without a selector
+func serviceSelector(service corev1.Service) string {
+	if len(service.Spec.Selector) == 0 {
+		return "-"
+	}
+	return labels.SelectorFromSet(service.Spec.Selector).String()
+}
+
+// servicePorts formats the service's ports like kubectl, as port[:nodePort]/protocol
+func servicePorts(service corev1.Service) string {
+	if len(service.Spec.Ports) == 0 {
+		return "-"
+	}
+	ports := make([]string, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		if port.NodePort != 0 {
+			ports = append(ports, fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, protocol))
+		} else {
+			ports = append(ports, fmt.Sprintf("%d/%s", port.Port, protocol))
+		}
+	}
+	return strings.Join(ports, ",")
+}
+
+// Annotation orders accepted by --sort-annotations
+const (
+	AnnotationSortKey   = "key"
+	AnnotationSortValue = "value"
+)
+
+// serviceAnnotations returns the service's annotations as "key=value" strings, excluding
+// last-applied-configuration. They are ordered by key, or by value then key with
+// AnnotationSortValue, so output is the same on every run.
+func serviceAnnotations(service corev1.Service, annotationSort string) []string {
+	keys := make([]string, 0, len(service.Annotations))
+	for key := range service.Annotations {
+		if !strings.Contains(strings.ToLower(key), "last-applied-configuration") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if annotationSort == AnnotationSortValue {
+			vi, vj := service.Annotations[keys[i]], service.Annotations[keys[j]]
+			if vi != vj {
+				return vi < vj
 			}
 		}
+		return keys[i] < keys[j]
+	})
 
-		serviceInfos = append(serviceInfos, ServiceInfo{
-			Namespace:   service.Namespace,
-			Name:        service.Name,
-			Type:        string(service.Spec.Type),
-			Annotations: allAnnotations,
-		})
+	var annotations []string
+	for _, key := range keys {
+		annotations = append(annotations, fmt.Sprintf("%s=%s", key, service.Annotations[key]))
+	}
+	return annotations
+}
+
+// PrintServicesTable prints services in a table format, with annotations ordered by annotationSort
+func PrintServicesTable(services []corev1.Service, style string, sortBy string, annotationSort string, color, noHeaders bool) {
+	// Convert services to ServiceInfo structs
+	var serviceInfos []ServiceInfo
+	for _, service := range services {
+		serviceInfos = append(serviceInfos, newServiceInfo(service, annotationSort))
 	}
 
 	// Sort services based on sortBy parameter
@@ -232,34 +577,28 @@ func PrintServicesTable(services []corev1.Service, style string, sortBy string)
 	t.SetOutputMirror(os.Stdout)
 
 	// Set table style based on parameter
-	switch style {
-	case "simple":
-		t.SetStyle(table.StyleDefault)
-	case "box":
-		t.SetStyle(table.StyleDouble)
-	case "rounded":
-		t.SetStyle(table.StyleRounded)
-	case "colored", "color":
-		t.SetStyle(table.StyleColoredBright)
-	default:
-		t.SetStyle(table.StyleColoredBright)
-	}
+	setTableStyle(t, style, color, noHeaders)
 
 	// Add headers
-	t.AppendHeader(table.Row{"NAMESPACE", "NAME", "TYPE", "ANNOTATIONS"})
+	if !noHeaders {
+		t.AppendHeader(table.Row{"NAMESPACE", "NAME", "TYPE", "EXTERNAL", "PORTS", "SELECTOR", "ANNOTATIONS"})
+	}
 
 	// Add rows
 	for _, info := range serviceInfos {
 		if len(info.Annotations) == 0 {
-			t.AppendRow(table.Row{info.Namespace, info.Name, info.Type, "-"})
+			t.AppendRow(table.Row{info.Namespace, info.Name, info.Type, info.External, info.Ports, info.Selector, "-"})
 		} else {
 			for i, annotation := range info.Annotations {
 				if i == 0 {
-					// First annotation includes namespace, name, and type
-					t.AppendRow(table.Row{info.Namespace, info.Name, info.Type, annotation})
+					// First annotation includes the service columns
+					t.AppendRow(table.Row{info.Namespace, info.Name, info.Type, info.External, info.Ports, info.Selector, annotation})
+				} else if noHeaders {
+					// Without headers every line stands alone, so repeat the service columns
+					t.AppendRow(table.Row{info.Namespace, info.Name, info.Type, info.External, info.Ports, info.Selector, annotation})
 				} else {
-					// Subsequent annotations have empty cells for namespace, name, type
-					t.AppendRow(table.Row{"", "", "", annotation})
+					// Subsequent annotations have empty cells for the service columns
+					t.AppendRow(table.Row{"", "", "", "", "", "", annotation})
 				}
 			}
 		}
@@ -269,25 +608,12 @@ func PrintServicesTable(services []corev1.Service, style string, sortBy string)
 	t.Render()
 }
 
-// PrintServicesList prints services in a simple list format
-func PrintServicesList(services []corev1.Service, sortBy string) {
+// PrintServicesList prints services in a simple list format, with annotations ordered by annotationSort
+func PrintServicesList(services []corev1.Service, sortBy string, annotationSort string) {
 	// Convert services to ServiceInfo structs for sorting
 	var serviceInfos []ServiceInfo
 	for _, service := range services {
-		var allAnnotations []string
-		for key, value := range service.Annotations {
-			// Exclude last-applied-configuration annotations
-			if !strings.Contains(strings.ToLower(key), "last-applied-configuration") {
-				allAnnotations = append(allAnnotations, fmt.Sprintf("%s=%s", key, value))
-			}
-		}
-
-		serviceInfos = append(serviceInfos, ServiceInfo{
-			Namespace:   service.Namespace,
-			Name:        service.Name,
-			Type:        string(service.Spec.Type),
-			Annotations: allAnnotations,
-		})
+		serviceInfos = append(serviceInfos, newServiceInfo(service, annotationSort))
 	}
 
 	// Sort services based on sortBy parameter
@@ -317,9 +643,9 @@ func PrintServicesList(services []corev1.Service, sortBy string) {
 	// Print services
 	for _, info := range serviceInfos {
 		if len(info.Annotations) == 0 {
-			fmt.Printf("%s/%s (%s): -\n", info.Namespace, info.Name, info.Type)
+			fmt.Printf("%s/%s (%s) external=%s ports=%s selector=%s: -\n", info.Namespace, info.Name, info.Type, info.External, info.Ports, info.Selector)
 		} else {
-			fmt.Printf("%s/%s (%s):\n", info.Namespace, info.Name, info.Type)
+			fmt.Printf("%s/%s (%s) external=%s ports=%s selector=%s:\n", info.Namespace, info.Name, info.Type, info.External, info.Ports, info.Selector)
 			for _, annotation := range info.Annotations {
 				fmt.Printf("  %s\n", annotation)
 			}
@@ -327,23 +653,61 @@ func PrintServicesList(services []corev1.Service, sortBy string) {
 	}
 }
 
+// PrintOrphanedServices prints services whose selector matches no pods as deletion candidates
+func PrintOrphanedServices(services []corev1.Service, sortBy string) {
+	if len(services) == 0 {
+		fmt.Println("No orphaned services found.")
+		return
+	}
+
+	if sortBy != "none" {
+		sort.Slice(services, func(i, j int) bool {
+			if sortBy == "name" || services[i].Namespace == services[j].Namespace {
+				return services[i].Name < services[j].Name
+			}
+			return services[i].Namespace < services[j].Namespace
+		})
+	}
+
+	fmt.Printf("Found %d service(s) whose selector matches no pods:\n", len(services))
+	for _, service := range services {
+		fmt.Printf("%s/%s (%s): selector %s\n", service.Namespace, service.Name, service.Spec.Type, labels.SelectorFromSet(service.Spec.Selector))
+	}
+	fmt.Println()
+	fmt.Println("Review each candidate, then delete it with: kubectl delete service NAME -n NAMESPACE")
+}
+
 // PrintServicesHelp prints the help information for the services command
 func PrintServicesHelp() {
-	fmt.Println("Usage: kube services [--namespace NAMESPACE | --all-namespaces] [--table] [--style STYLE] [--sort SORT] [--annotation-value VALUE]")
+	fmt.Println("Usage: kube services [--namespace NAMESPACE | --all-namespaces] [--table] [--style STYLE] [--color WHEN] [--no-headers] [--sort SORT] [--sort-annotations ORDER] [--annotation-value VALUE] [--type TYPE] [--selector KEY=VALUE] [--orphaned] [--timeout DURATION] [--list-attempts N] [--watch [--refresh DURATION]]")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --namespace, -n    Query a specific namespace")
-	fmt.Println("  --all-namespaces, -A  Query across all namespaces (default)")
+	fmt.Println("  --all-namespaces, -A  Query across all namespaces (default; required when the cluster has more than 5000 services)")
 	fmt.Println("  --table, -t       Display output in table format")
 	fmt.Println("  --style           Table style: simple, box, rounded, colored (default)")
+	fmt.Println("  --color           Color tables: auto (default, only when stdout is a terminal), always, never")
+	fmt.Println("  --no-headers      Omit the header row and borders from table output, for piping into awk or cut")
 	fmt.Println("  --sort            Sort order: namespace (default), name, none")
+	fmt.Println("  --sort-annotations  Order each service's annotations by: key (default), value")
 	fmt.Println("  --annotation-value  Filter by annotation key or value containing this text (case-insensitive)")
+	fmt.Println("  --type            Only show services of this type: ClusterIP, NodePort, LoadBalancer, ExternalName")
+	fmt.Println("  --selector        Only show services whose selector contains these labels, e.g. app=web or app=web,tier=api")
+	fmt.Println("  --orphaned        List services whose selector matches no pods, as deletion candidates (services without a selector are skipped)")
+	fmt.Println("  --timeout         Deadline for cluster calls, e.g. 10s, 2m (default: 30s)")
+	fmt.Println("  --list-attempts   Maximum attempts for list calls failing with transient API server errors (default: 3)")
+	fmt.Println("  --watch, -w       Re-query and re-render on an interval until Ctrl+C")
+	fmt.Println("  --refresh         Interval between renders in watch mode (default: 10s)")
 	fmt.Println("  --help, -h        Show this help message")
 	fmt.Println()
+	fmt.Println("Each service shows its external address (load balancer hostname or IP, <pending> while one is")
+	fmt.Println("provisioned, - for none), its ports as port[:nodePort]/protocol, and its pod selector.")
 	fmt.Println("Note: last-applied-configuration annotations are automatically excluded from output.")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  ./kube services                                    # Show all services with annotations")
 	fmt.Println("  ./kube services --annotation-value aws-load-balancer  # Filter by annotation containing 'aws-load-balancer'")
 	fmt.Println("  ./kube services --annotation-value nlb             # Filter by annotation containing 'nlb'")
+	fmt.Println("  ./kube services --type LoadBalancer                # Services that provision cloud load balancers")
+	fmt.Println("  ./kube services --orphaned -n staging              # Find services with no matching pods")
 }
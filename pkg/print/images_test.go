@@ -3,9 +3,13 @@ package print
 import (
 	"bytes"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPrintImagesTable(t *testing.T) {
@@ -53,7 +57,7 @@ func TestPrintImagesTable(t *testing.T) {
 			os.Stdout = w
 
 			// Call the function
-			PrintImagesTable(tt.imagesSet, tt.namespace, tt.allNamespaces, tt.style, tt.sortBy)
+			PrintImagesTable(tt.imagesSet, tt.namespace, tt.allNamespaces, tt.style, tt.sortBy, true, false)
 
 			// Close the write end and restore stdout
 			w.Close()
@@ -114,7 +118,7 @@ func TestPrintImagesTableWithNamespaces(t *testing.T) {
 			os.Stdout = w
 
 			// Call the function
-			PrintImagesTableWithNamespaces(tt.imageNamespaceMap, tt.style, tt.sortBy)
+			PrintImagesTableWithNamespaces(tt.imageNamespaceMap, tt.style, tt.sortBy, true, false)
 
 			// Close the write end and restore stdout
 			w.Close()
@@ -135,6 +139,243 @@ func TestPrintImagesTableWithNamespaces(t *testing.T) {
 	}
 }
 
+func TestPrintRegistryTable(t *testing.T) {
+	registries := []RegistryInfo{
+		{Registry: "quay.io", ImageCount: 1, Namespaces: []string{"monitoring"}},
+		{Registry: "docker.io", ImageCount: 3, Namespaces: []string{"kube-system", "default"}},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrintRegistryTable(registries, "simple", true, false)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	for _, expected := range []string{"REGISTRY", "IMAGE COUNT", "NAMESPACES", "docker.io", "quay.io", "default", "kube-system", "monitoring"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %s, got: %s", expected, output)
+		}
+	}
+
+	// Registries with more images are listed first
+	if strings.Index(output, "docker.io") > strings.Index(output, "quay.io") {
+		t.Errorf("Expected docker.io before quay.io, got: %s", output)
+	}
+}
+
+func TestPrintNamespaceTable(t *testing.T) {
+	namespaces := []NamespaceImageInfo{
+		{Namespace: "monitoring", ImageCount: 2, PodCount: 4},
+		{Namespace: "default", ImageCount: 5, PodCount: 12},
+		{Namespace: "apps", ImageCount: 2, PodCount: 2},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrintNamespaceTable(namespaces, "colored", true, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	// Most distinct images first, ties broken by namespace name
+	expected := "default    5 12\napps       2  2\nmonitoring 2  4\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestPrintImagesCountTable(t *testing.T) {
+	images := []ImageCountInfo{
+		{Image: "redis:7.0", Count: 2},
+		{Image: "nginx:1.25", Count: 12},
+		{Image: "envoy:1.30", Count: 2},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrintImagesCountTable(images, "colored", true, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	// Most referenced first, ties broken by image name
+	expected := "nginx:1.25 12\nenvoy:1.30  2\nredis:7.0   2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestPrintImagesWideTable(t *testing.T) {
+	containers := []ContainerImageInfo{
+		{Namespace: "default", Pod: "web-2", Container: "app", Image: "nginx:1.25", Restarts: 0},
+		{Namespace: "default", Pod: "web-1", Container: "app", Image: "nginx:1.25", Restarts: 3},
+		{Namespace: "apps", Pod: "api", Container: "app", Image: "api:2.0", Restarts: 12},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrintImagesWideTable(containers, "colored", "namespace", true, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	// Sorted by namespace, then pod; every container keeps its own row
+	expected := "apps    api   app api:2.0    12\ndefault web-1 app nginx:1.25  3\ndefault web-2 app nginx:1.25  0\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestPrintImagesJSONAndYAML(t *testing.T) {
+	images := []ImageNamespace{
+		{Namespace: "apps", Image: "api:2.0"},
+		{Namespace: "default", Image: "nginx:1.25"},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintImagesJSON(&buf, images); err != nil {
+		t.Fatalf("PrintImagesJSON() error = %v", err)
+	}
+	expectedJSON := `[
+  {
+    "namespace": "apps",
+    "image": "api:2.0"
+  },
+  {
+    "namespace": "default",
+    "image": "nginx:1.25"
+  }
+]
+`
+	if buf.String() != expectedJSON {
+		t.Errorf("PrintImagesJSON() = %q, want %q", buf.String(), expectedJSON)
+	}
+
+	buf.Reset()
+	if err := PrintImagesYAML(&buf, images); err != nil {
+		t.Fatalf("PrintImagesYAML() error = %v", err)
+	}
+	expectedYAML := "- image: api:2.0\n  namespace: apps\n- image: nginx:1.25\n  namespace: default\n"
+	if buf.String() != expectedYAML {
+		t.Errorf("PrintImagesYAML() = %q, want %q", buf.String(), expectedYAML)
+	}
+
+	// An empty result is still a list
+	buf.Reset()
+	if err := PrintImagesJSON(&buf, nil); err != nil {
+		t.Fatalf("PrintImagesJSON() error = %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("PrintImagesJSON(nil) = %q, want %q", buf.String(), "[]\n")
+	}
+}
+
+func TestPrintPodImagesJSONAndYAML(t *testing.T) {
+	pods := []PodImages{
+		{
+			Namespace: "default",
+			Pod:       "web-1",
+			Images:    []string{"nginx:1.25", "flyway:10"},
+			Containers: []PodContainerImage{
+				{Name: "app", Image: "nginx:1.25", Kind: ContainerKindRegular},
+				{Name: "migrate", Image: "flyway:10", Kind: ContainerKindInit},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintPodImagesJSON(&buf, pods); err != nil {
+		t.Fatalf("PrintPodImagesJSON() error = %v", err)
+	}
+	expectedJSON := `[
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "images": [
+      "nginx:1.25",
+      "flyway:10"
+    ],
+    "containers": [
+      {
+        "name": "app",
+        "image": "nginx:1.25",
+        "kind": "regular"
+      },
+      {
+        "name": "migrate",
+        "image": "flyway:10",
+        "kind": "init"
+      }
+    ]
+  }
+]
+`
+	if buf.String() != expectedJSON {
+		t.Errorf("PrintPodImagesJSON() = %q, want %q", buf.String(), expectedJSON)
+	}
+
+	buf.Reset()
+	if err := PrintPodImagesYAML(&buf, pods); err != nil {
+		t.Fatalf("PrintPodImagesYAML() error = %v", err)
+	}
+	expectedYAML := "- containers:\n  - image: nginx:1.25\n    kind: regular\n    name: app\n  - image: flyway:10\n    kind: init\n    name: migrate\n" +
+		"  images:\n  - nginx:1.25\n  - flyway:10\n  namespace: default\n  pod: web-1\n"
+	if buf.String() != expectedYAML {
+		t.Errorf("PrintPodImagesYAML() = %q, want %q", buf.String(), expectedYAML)
+	}
+}
+
+func TestPrintRegistryTableNoHeaders(t *testing.T) {
+	registries := []RegistryInfo{
+		{Registry: "quay.io", ImageCount: 1, Namespaces: []string{"monitoring"}},
+		{Registry: "docker.io", ImageCount: 3, Namespaces: []string{"kube-system", "default"}},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	PrintRegistryTable(registries, "colored", true, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	expected := "docker.io 3 default,kube-system\nquay.io   1 monitoring\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, buf.String())
+	}
+}
+
 func TestPrintImagesList(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -305,3 +546,133 @@ func TestSortingLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAnnotations(t *testing.T) {
+	service := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internet-facing",
+				"external-dns.alpha.kubernetes.io/hostname":             "www.example.com",
+				"kubectl.kubernetes.io/last-applied-configuration":      "{}",
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "false",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		annotationSort string
+		want           []string
+	}{
+		{
+			name:           "by key",
+			annotationSort: AnnotationSortKey,
+			want: []string{
+				"external-dns.alpha.kubernetes.io/hostname=www.example.com",
+				"service.beta.kubernetes.io/aws-load-balancer-internal=false",
+				"service.beta.kubernetes.io/aws-load-balancer-scheme=internet-facing",
+				"service.beta.kubernetes.io/aws-load-balancer-type=nlb",
+			},
+		},
+		{
+			name:           "by value",
+			annotationSort: AnnotationSortValue,
+			want: []string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal=false",
+				"service.beta.kubernetes.io/aws-load-balancer-scheme=internet-facing",
+				"service.beta.kubernetes.io/aws-load-balancer-type=nlb",
+				"external-dns.alpha.kubernetes.io/hostname=www.example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map iteration order varies, so repeat to catch nondeterminism
+			for i := 0; i < 10; i++ {
+				got := serviceAnnotations(service, tt.annotationSort)
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("serviceAnnotations() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceColumns(t *testing.T) {
+	tests := []struct {
+		name         string
+		service      corev1.Service
+		wantExternal string
+		wantPorts    string
+		wantSelector string
+	}{
+		{
+			name: "load balancer with hostname",
+			service: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:     corev1.ServiceTypeLoadBalancer,
+					Selector: map[string]string{"tier": "frontend", "app": "web"},
+					Ports: []corev1.ServicePort{
+						{Port: 80, NodePort: 30080, Protocol: corev1.ProtocolTCP},
+						{Port: 443, NodePort: 30443, Protocol: corev1.ProtocolTCP},
+					},
+				},
+				Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{Hostname: "web-1234.elb.us-east-1.amazonaws.com"}},
+				}},
+			},
+			wantExternal: "web-1234.elb.us-east-1.amazonaws.com",
+			wantPorts:    "80:30080/TCP,443:30443/TCP",
+			wantSelector: "app=web,tier=frontend",
+		},
+		{
+			name: "load balancer still provisioning",
+			service: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{{Port: 53, NodePort: 31053, Protocol: corev1.ProtocolUDP}},
+				},
+			},
+			wantExternal: "<pending>",
+			wantPorts:    "53:31053/UDP",
+			wantSelector: "-",
+		},
+		{
+			name: "cluster IP",
+			service: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{{Port: 8080}},
+				},
+			},
+			wantExternal: "-",
+			wantPorts:    "8080/TCP",
+			wantSelector: "-",
+		},
+		{
+			name: "external name",
+			service: corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+			},
+			wantExternal: "-",
+			wantPorts:    "-",
+			wantSelector: "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceExternal(tt.service); got != tt.wantExternal {
+				t.Errorf("serviceExternal() = %q, want %q", got, tt.wantExternal)
+			}
+			if got := servicePorts(tt.service); got != tt.wantPorts {
+				t.Errorf("servicePorts() = %q, want %q", got, tt.wantPorts)
+			}
+			if got := serviceSelector(tt.service); got != tt.wantSelector {
+				t.Errorf("serviceSelector() = %q, want %q", got, tt.wantSelector)
+			}
+		})
+	}
+}
@@ -1,35 +1,61 @@
 package print
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/pischarti/nix/pkg/vpc"
+	"sigs.k8s.io/yaml"
 )
 
-// PrintNLBTable prints NLBs in a table format
-func PrintNLBTable(nlbs []vpc.NLBInfo) {
+// PrintNLBTable prints NLBs in a table format. With noHeaders it prints only the data rows,
+// without the header, borders or summary line.
+func PrintNLBTable(nlbs []vpc.NLBInfo, color, noHeaders bool) {
+	printNLBTable(nlbs, false, color, noHeaders)
+}
+
+// PrintNLBTableWithDetails prints NLBs in a table format with CrossZone and AccessLogs columns
+func PrintNLBTableWithDetails(nlbs []vpc.NLBInfo, color, noHeaders bool) {
+	printNLBTable(nlbs, true, color, noHeaders)
+}
+
+// printNLBTable renders the NLB table, optionally including the attribute detail columns
+func printNLBTable(nlbs []vpc.NLBInfo, withDetails, color, noHeaders bool) {
 	if len(nlbs) == 0 {
-		fmt.Println("No Network Load Balancers found.")
+		if !noHeaders {
+			fmt.Println("No Network Load Balancers found.")
+		}
 		return
 	}
 
 	// Create table
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleColoredBright)
+	if noHeaders {
+		SetNoHeadersStyle(t)
+	} else {
+		SetColoredStyle(t, color)
+	}
 
 	// Set table headers
-	t.AppendHeader(table.Row{
+	header := table.Row{
 		"Name",
 		"State",
 		"Scheme",
 		"AZ / Subnet",
 		"Created Time",
 		"Tags",
-	})
+	}
+	if withDetails {
+		header = append(header, "CrossZone", "AccessLogs")
+	}
+	if !noHeaders {
+		t.AppendHeader(header)
+	}
 
 	// Add rows
 	for _, nlb := range nlbs {
@@ -58,21 +84,34 @@ func PrintNLBTable(nlbs []vpc.NLBInfo) {
 
 		// Format tags - show first few tags, truncate if too many
 		tags := nlb.Tags
-		if tags != "" {
+		if tags != "" && !noHeaders {
 			tagLines := strings.Split(tags, "\n")
 			if len(tagLines) > 3 {
 				tags = strings.Join(tagLines[:3], "\n") + "\n..."
 			}
 		}
 
-		t.AppendRow(table.Row{
+		row := table.Row{
 			name,
 			nlb.State,
 			nlb.Scheme,
 			azs,
 			createdTime,
 			tags,
-		})
+		}
+		if withDetails {
+			row = append(row, nlb.CrossZone, formatAccessLogs(nlb.AccessLogs, nlb.AccessLogsBucket))
+		}
+		if noHeaders {
+			row = flattenRow(row)
+		}
+		t.AppendRow(row)
+	}
+
+	if noHeaders {
+		// Keep full values on one line per NLB for scripts
+		t.Render()
+		return
 	}
 
 	// Configure table options
@@ -84,6 +123,8 @@ func PrintNLBTable(nlbs []vpc.NLBInfo) {
 		{Number: 4, WidthMax: 50}, // AZ / Subnet
 		{Number: 5, WidthMax: 19}, // Created Time
 		{Number: 6, WidthMax: 30}, // Tags
+		{Number: 7, WidthMax: 10}, // CrossZone
+		{Number: 8, WidthMax: 40}, // AccessLogs
 	})
 
 	// Render table
@@ -93,6 +134,85 @@ func PrintNLBTable(nlbs []vpc.NLBInfo) {
 	fmt.Printf("\nFound %d Network Load Balancer(s)\n", len(nlbs))
 }
 
+// nlbRecord is the YAML and JSON form of an NLBInfo, with the zones, subnets and tag keys
+// that the table joins into one cell split back into lists
+type nlbRecord struct {
+	LoadBalancerArn   string   `json:"loadBalancerArn"`
+	Name              string   `json:"name"`
+	DNSName           string   `json:"dnsName"`
+	State             string   `json:"state"`
+	Type              string   `json:"type"`
+	Scheme            string   `json:"scheme"`
+	VPCID             string   `json:"vpcId"`
+	AvailabilityZones []string `json:"availabilityZones"`
+	Subnets           []string `json:"subnets"`
+	CreatedTime       string   `json:"createdTime"`
+	Tags              []string `json:"tags"`
+	CrossZone         string   `json:"crossZone,omitempty"`
+	AccessLogs        string   `json:"accessLogs,omitempty"`
+	AccessLogsBucket  string   `json:"accessLogsBucket,omitempty"`
+}
+
+// nlbRecords converts NLBs to their YAML and JSON form
+func nlbRecords(nlbs []vpc.NLBInfo) []nlbRecord {
+	records := make([]nlbRecord, 0, len(nlbs))
+	for _, nlb := range nlbs {
+		records = append(records, nlbRecord{
+			LoadBalancerArn:   nlb.LoadBalancerArn,
+			Name:              nlb.Name,
+			DNSName:           nlb.DNSName,
+			State:             nlb.State,
+			Type:              nlb.Type,
+			Scheme:            nlb.Scheme,
+			VPCID:             nlb.VPCID,
+			AvailabilityZones: splitList(nlb.AvailabilityZones, ", "),
+			Subnets:           splitList(nlb.Subnets, ", "),
+			CreatedTime:       nlb.CreatedTime,
+			Tags:              splitList(nlb.Tags, "\n"),
+			CrossZone:         nlb.CrossZone,
+			AccessLogs:        nlb.AccessLogs,
+			AccessLogsBucket:  nlb.AccessLogsBucket,
+		})
+	}
+	return records
+}
+
+// splitList splits a joined cell value into its items; an empty value is an empty list
+func splitList(value, sep string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, sep)
+}
+
+// PrintNLBYAML writes the NLBs to w as a YAML list, with the same keys as the JSON output
+func PrintNLBYAML(w io.Writer, nlbs []vpc.NLBInfo) error {
+	data, err := yaml.Marshal(nlbRecords(nlbs))
+	if err != nil {
+		return fmt.Errorf("failed to marshal load balancers to YAML: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// PrintNLBJSON writes the NLBs to w as an indented JSON array
+func PrintNLBJSON(w io.Writer, nlbs []vpc.NLBInfo) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(nlbRecords(nlbs)); err != nil {
+		return fmt.Errorf("failed to marshal load balancers to JSON: %w", err)
+	}
+	return nil
+}
+
+// formatAccessLogs shows the log bucket under the access log state when logging is enabled
+func formatAccessLogs(state, bucket string) string {
+	if bucket == "" || bucket == "-" {
+		return state
+	}
+	return state + "\ns3://" + bucket
+}
+
 // formatAZSubnetPairs formats availability zones and subnets to show matching pairs on separate lines
 func formatAZSubnetPairs(azs, subnets string) string {
 	if azs == "" || subnets == "" {
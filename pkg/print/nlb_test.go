@@ -1,6 +1,10 @@
 package print
 
 import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/pischarti/nix/pkg/vpc"
@@ -9,7 +13,7 @@ import (
 func TestPrintNLBTable(t *testing.T) {
 	// Test with empty slice
 	nlbs := []vpc.NLBInfo{}
-	PrintNLBTable(nlbs) // Should not panic and should print "No Network Load Balancers found."
+	PrintNLBTable(nlbs, true, false) // Should not panic and should print "No Network Load Balancers found."
 
 	// Test with sample data
 	nlbs = []vpc.NLBInfo{
@@ -56,7 +60,58 @@ func TestPrintNLBTable(t *testing.T) {
 
 	// This test mainly ensures the function doesn't panic
 	// In a real test environment, you might want to capture stdout
-	PrintNLBTable(nlbs)
+	PrintNLBTable(nlbs, true, false)
+	PrintNLBTable(nlbs, true, true)
+}
+
+func TestPrintNLBTableWithDetails(t *testing.T) {
+	nlbs := []vpc.NLBInfo{
+		{
+			LoadBalancerArn:   "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-nlb/1234567890123456",
+			Name:              "test-nlb",
+			State:             "active",
+			Scheme:            "internal",
+			AvailabilityZones: "us-east-1a",
+			Subnets:           "subnet-12345678",
+			CreatedTime:       "2024-01-01T12:00:00Z",
+			CrossZone:         "enabled",
+			AccessLogs:        "enabled",
+			AccessLogsBucket:  "nlb-logs",
+		},
+		{
+			LoadBalancerArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-nlb-2/1234567890123457",
+			Name:            "test-nlb-2",
+			State:           "active",
+			Scheme:          "internet-facing",
+			CrossZone:       "disabled",
+			AccessLogs:      "disabled",
+		},
+	}
+
+	// This test mainly ensures the detail columns render without panicking
+	PrintNLBTableWithDetails(nlbs, true, false)
+	PrintNLBTableWithDetails(nlbs, true, true)
+}
+
+func TestFormatAccessLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    string
+		bucket   string
+		expected string
+	}{
+		{name: "enabled with bucket", state: "enabled", bucket: "nlb-logs", expected: "enabled\ns3://nlb-logs"},
+		{name: "disabled", state: "disabled", bucket: "", expected: "disabled"},
+		{name: "enabled without bucket", state: "enabled", bucket: "-", expected: "enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAccessLogs(tt.state, tt.bucket); got != tt.expected {
+				t.Errorf("formatAccessLogs(%q, %q) = %q, want %q", tt.state, tt.bucket, got, tt.expected)
+			}
+		})
+	}
 }
 
 func TestNLBInfoFields(t *testing.T) {
@@ -106,3 +161,60 @@ func TestNLBInfoFields(t *testing.T) {
 		t.Error("CreatedTime should not be empty")
 	}
 }
+
+func TestPrintNLBYAMLAndJSON(t *testing.T) {
+	nlbs := []vpc.NLBInfo{
+		{
+			LoadBalancerArn:   "arn:nlb/1",
+			Name:              "web-nlb",
+			State:             "active",
+			Type:              "network",
+			VPCID:             "vpc-1",
+			AvailabilityZones: "us-east-1a, us-east-1b",
+			Subnets:           "subnet-a, subnet-b",
+			Tags:              "kubernetes.io/service-name\nkubernetes.io/cluster/prod",
+		},
+	}
+
+	var yamlOut bytes.Buffer
+	if err := PrintNLBYAML(&yamlOut, nlbs); err != nil {
+		t.Fatalf("PrintNLBYAML() error = %v", err)
+	}
+	for _, want := range []string{"name: web-nlb", "vpcId: vpc-1", "- us-east-1b", "- subnet-a"} {
+		if !strings.Contains(yamlOut.String(), want) {
+			t.Errorf("Expected YAML to contain %q, got:\n%s", want, yamlOut.String())
+		}
+	}
+	if strings.Contains(yamlOut.String(), "crossZone") {
+		t.Errorf("Expected attribute fields to be omitted when unset, got:\n%s", yamlOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := PrintNLBJSON(&jsonOut, nlbs); err != nil {
+		t.Fatalf("PrintNLBJSON() error = %v", err)
+	}
+	var decoded []struct {
+		AvailabilityZones []string `json:"availabilityZones"`
+		Subnets           []string `json:"subnets"`
+		Tags              []string `json:"tags"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("PrintNLBJSON() output is not valid JSON: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("decoded %d NLBs, want 1", len(decoded))
+	}
+	if !slices.Equal(decoded[0].AvailabilityZones, []string{"us-east-1a", "us-east-1b"}) ||
+		!slices.Equal(decoded[0].Subnets, []string{"subnet-a", "subnet-b"}) ||
+		!slices.Equal(decoded[0].Tags, []string{"kubernetes.io/service-name", "kubernetes.io/cluster/prod"}) {
+		t.Errorf("decoded = %+v, want zones, subnets and tags split into lists", decoded[0])
+	}
+
+	jsonOut.Reset()
+	if err := PrintNLBJSON(&jsonOut, nil); err != nil {
+		t.Fatalf("PrintNLBJSON() error = %v", err)
+	}
+	if strings.TrimSpace(jsonOut.String()) != "[]" {
+		t.Errorf("Expected an empty JSON array, got %q", jsonOut.String())
+	}
+}
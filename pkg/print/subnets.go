@@ -1,39 +1,110 @@
 package print
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"slices"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/pischarti/nix/pkg/vpc"
+	"sigs.k8s.io/yaml"
 )
 
-// PrintSubnetsTable prints subnets in a formatted table
-func PrintSubnetsTable(subnets []vpc.SubnetInfo) {
+// PrintSubnetsTable prints subnets in a formatted table. With noHeaders it prints only the
+// data rows, one line per subnet, without the header or borders.
+func PrintSubnetsTable(subnets []vpc.SubnetInfo, color, noHeaders bool) {
+	printSubnetsTable(subnets, false, false, color, noHeaders)
+}
+
+// PrintSubnetsTableWithRouting prints subnets in a formatted table with Route Table and
+// Routing columns
+func PrintSubnetsTableWithRouting(subnets []vpc.SubnetInfo, color, noHeaders bool) {
+	printSubnetsTable(subnets, false, true, color, noHeaders)
+}
+
+// PrintSubnetsTableAllVPCs prints subnets from several VPCs in a formatted table with a VPC
+// ID column after the subnet ID, and the routing columns when withRouting is set
+func PrintSubnetsTableAllVPCs(subnets []vpc.SubnetInfo, withRouting, color, noHeaders bool) {
+	printSubnetsTable(subnets, true, withRouting, color, noHeaders)
+}
+
+// printSubnetsTable renders the subnets table, optionally including the VPC and routing
+// columns
+func printSubnetsTable(subnets []vpc.SubnetInfo, withVPC, withRouting, color, noHeaders bool) {
 	// Create table
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleColoredBright)
-
-	// Add headers
-	t.AppendHeader(table.Row{"Subnet ID", "CIDR Block", "AZ", "Name", "State", "Type", "Tags"})
+	if noHeaders {
+		SetNoHeadersStyle(t)
+	} else {
+		SetColoredStyle(t, color)
+		header := table.Row{"Subnet ID", "CIDR Block", "AZ", "Name", "State", "Type", "Available IPs", "Tags"}
+		if withVPC {
+			header = slices.Insert(header, 1, any("VPC ID"))
+		}
+		if withRouting {
+			header = append(header, "Route Table", "Routing")
+		}
+		t.AppendHeader(header)
+	}
 
 	// Add rows
 	for _, subnet := range subnets {
-		t.AppendRow(table.Row{
+		row := table.Row{
 			subnet.SubnetID,
 			subnet.CIDRBlock,
 			subnet.AZ,
 			subnet.Name,
 			subnet.State,
 			subnet.Type,
+			subnet.AvailableIPs,
 			subnet.Tags,
-		})
+		}
+		if withVPC {
+			row = slices.Insert(row, 1, any(subnet.VPCID))
+		}
+		if withRouting {
+			row = append(row, subnet.RouteTableID, subnet.Routing)
+		}
+		if noHeaders {
+			row = flattenRow(row)
+		}
+		t.AppendRow(row)
 	}
 
 	// Render table
 	t.Render()
 }
 
+// PrintSubnetsYAML writes the subnets to w as a YAML list, with the same keys as the JSON
+// output
+func PrintSubnetsYAML(w io.Writer, subnets []vpc.SubnetInfo) error {
+	if subnets == nil {
+		subnets = []vpc.SubnetInfo{}
+	}
+	data, err := yaml.Marshal(subnets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subnets to YAML: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// PrintSubnetsJSON writes the subnets to w as an indented JSON array
+func PrintSubnetsJSON(w io.Writer, subnets []vpc.SubnetInfo) error {
+	if subnets == nil {
+		subnets = []vpc.SubnetInfo{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(subnets); err != nil {
+		return fmt.Errorf("failed to marshal subnets to JSON: %w", err)
+	}
+	return nil
+}
+
 // PrintSubnetsTableString returns the table as a string instead of printing to stdout
 func PrintSubnetsTableString(subnets []vpc.SubnetInfo) string {
 	// Create table
@@ -41,7 +112,7 @@ func PrintSubnetsTableString(subnets []vpc.SubnetInfo) string {
 	t.SetStyle(table.StyleColoredDark)
 
 	// Add headers
-	t.AppendHeader(table.Row{"Subnet ID", "CIDR Block", "AZ", "Name", "State", "Type", "Tags"})
+	t.AppendHeader(table.Row{"Subnet ID", "CIDR Block", "AZ", "Name", "State", "Type", "Available IPs", "Tags"})
 
 	// Add rows
 	for _, subnet := range subnets {
@@ -52,6 +123,7 @@ func PrintSubnetsTableString(subnets []vpc.SubnetInfo) string {
 			subnet.Name,
 			subnet.State,
 			subnet.Type,
+			subnet.AvailableIPs,
 			subnet.Tags,
 		})
 	}
@@ -1,6 +1,8 @@
 package print
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -236,3 +238,76 @@ func TestPrintSubnetsTableStringWithSpecialCharacters(t *testing.T) {
 		t.Error("Expected result to contain AWS tag")
 	}
 }
+
+func TestPrintSubnetsTableStringAvailableIPs(t *testing.T) {
+	subnets := []vpc.SubnetInfo{
+		{
+			SubnetID:     "subnet-test",
+			CIDRBlock:    "10.0.0.0/24",
+			AZ:           "us-east-1a",
+			State:        "available",
+			Type:         "private",
+			AvailableIPs: 4091,
+		},
+	}
+
+	result := PrintSubnetsTableString(subnets)
+
+	if !strings.Contains(result, "AVAILABLE IPS") {
+		t.Error("Expected result to contain 'AVAILABLE IPS' header")
+	}
+	if !strings.Contains(result, "4091") {
+		t.Error("Expected result to contain the available IP count")
+	}
+}
+
+func TestPrintSubnetsTableAllVPCs(t *testing.T) {
+	subnets := []vpc.SubnetInfo{
+		{SubnetID: "subnet-a", VPCID: "vpc-1", CIDRBlock: "10.0.0.0/24", AZ: "us-east-1a", RouteTableID: "rtb-1", Routing: vpc.RoutingPrivate},
+		{SubnetID: "subnet-b", VPCID: "vpc-2", CIDRBlock: "10.0.0.0/24", AZ: "us-east-1b"},
+	}
+
+	// This test mainly ensures the function doesn't panic
+	PrintSubnetsTableAllVPCs(subnets, false, false, false)
+	PrintSubnetsTableAllVPCs(subnets, true, false, false)
+	PrintSubnetsTableAllVPCs(subnets, true, false, true)
+}
+
+func TestPrintSubnetsYAMLAndJSON(t *testing.T) {
+	subnets := []vpc.SubnetInfo{
+		{SubnetID: "subnet-a", VPCID: "vpc-1", CIDRBlock: "10.0.0.0/24", AZ: "us-east-1a", State: "available", AvailableIPs: 251},
+	}
+
+	var yamlOut bytes.Buffer
+	if err := PrintSubnetsYAML(&yamlOut, subnets); err != nil {
+		t.Fatalf("PrintSubnetsYAML() error = %v", err)
+	}
+	for _, want := range []string{"subnetId: subnet-a", "vpcId: vpc-1", "availableIps: 251"} {
+		if !strings.Contains(yamlOut.String(), want) {
+			t.Errorf("Expected YAML to contain %q, got:\n%s", want, yamlOut.String())
+		}
+	}
+	if strings.Contains(yamlOut.String(), "routing") {
+		t.Errorf("Expected routing fields to be omitted when unset, got:\n%s", yamlOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := PrintSubnetsJSON(&jsonOut, subnets); err != nil {
+		t.Fatalf("PrintSubnetsJSON() error = %v", err)
+	}
+	var decoded []vpc.SubnetInfo
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("PrintSubnetsJSON() output is not valid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0] != subnets[0] {
+		t.Errorf("decoded = %+v, want %+v", decoded, subnets)
+	}
+
+	jsonOut.Reset()
+	if err := PrintSubnetsJSON(&jsonOut, nil); err != nil {
+		t.Fatalf("PrintSubnetsJSON() error = %v", err)
+	}
+	if strings.TrimSpace(jsonOut.String()) != "[]" {
+		t.Errorf("Expected an empty JSON array, got %q", jsonOut.String())
+	}
+}
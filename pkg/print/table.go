@@ -0,0 +1,95 @@
+package print
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"golang.org/x/term"
+)
+
+// Color modes accepted by the --color flag
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ResolveColor decides whether tables should be colored for a --color mode. "auto" colors
+// only when stdout is a terminal, so ANSI codes don't end up in redirected files or pipes.
+func ResolveColor(mode string) (bool, error) {
+	return resolveColor(mode, term.IsTerminal(int(os.Stdout.Fd())))
+}
+
+// resolveColor implements ResolveColor for a known terminal state
+func resolveColor(mode string, isTerminal bool) (bool, error) {
+	switch mode {
+	case ColorAuto, "":
+		return isTerminal, nil
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid color option '%s'. Valid options: auto, always, never", mode)
+	}
+}
+
+// SetColoredStyle applies the bright colored table style, or the plain default style when
+// color is disabled
+func SetColoredStyle(t table.Writer, color bool) {
+	if color {
+		t.SetStyle(table.StyleColoredBright)
+	} else {
+		t.SetStyle(table.StyleDefault)
+	}
+}
+
+// SetNoHeadersStyle configures t for --no-headers output: no borders, separators or cell
+// padding, with columns separated by a single space, so rows can be piped into awk or cut.
+// Callers skip AppendHeader themselves.
+func SetNoHeadersStyle(t table.Writer) {
+	style := table.StyleDefault
+	style.Name = "NoHeaders"
+	style.Options = table.OptionsNoBordersAndSeparators
+	style.Options.SeparateColumns = true
+	style.Box.PaddingLeft = ""
+	style.Box.PaddingRight = ""
+	style.Box.MiddleVertical = " "
+	t.SetStyle(style)
+	t.SuppressTrailingSpaces()
+}
+
+// setTableStyle applies a --style value to t, or the no-headers style when noHeaders is set.
+// The colored style falls back to the plain default style when color is disabled.
+func setTableStyle(t table.Writer, style string, color, noHeaders bool) {
+	if noHeaders {
+		SetNoHeadersStyle(t)
+		return
+	}
+
+	switch style {
+	case "simple":
+		t.SetStyle(table.StyleDefault)
+	case "box":
+		t.SetStyle(table.StyleDouble)
+	case "rounded":
+		t.SetStyle(table.StyleRounded)
+	default:
+		// "colored", "color" and unknown styles
+		SetColoredStyle(t, color)
+	}
+}
+
+// flattenRow joins multi-line cells with commas so the row renders on a single line
+func flattenRow(row table.Row) table.Row {
+	flat := make(table.Row, len(row))
+	for i, cell := range row {
+		if s, ok := cell.(string); ok {
+			cell = strings.ReplaceAll(s, "\n", ",")
+		}
+		flat[i] = cell
+	}
+	return flat
+}
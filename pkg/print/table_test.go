@@ -0,0 +1,69 @@
+package print
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func TestSetNoHeadersStyle(t *testing.T) {
+	tw := table.NewWriter()
+	SetNoHeadersStyle(tw)
+	tw.AppendRow(table.Row{"default", "nginx:1.21"})
+	tw.AppendRow(table.Row{"kube-system", "coredns:1.11"})
+
+	want := "default     nginx:1.21\nkube-system coredns:1.11"
+	if got := tw.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestFlattenRow(t *testing.T) {
+	row := table.Row{"us-east-1a / subnet-1\nus-east-1b / subnet-2", 3, "Env=prod"}
+	want := table.Row{"us-east-1a / subnet-1,us-east-1b / subnet-2", 3, "Env=prod"}
+
+	if got := flattenRow(row); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenRow() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveColor(t *testing.T) {
+	tests := []struct {
+		mode       string
+		isTerminal bool
+		want       bool
+		wantErr    bool
+	}{
+		{mode: "auto", isTerminal: true, want: true},
+		{mode: "auto", isTerminal: false, want: false},
+		{mode: "always", isTerminal: false, want: true},
+		{mode: "never", isTerminal: true, want: false},
+		{mode: "yes", isTerminal: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveColor(tt.mode, tt.isTerminal)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveColor(%q, %v) error = %v, wantErr %v", tt.mode, tt.isTerminal, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveColor(%q, %v) = %v, want %v", tt.mode, tt.isTerminal, got, tt.want)
+		}
+	}
+}
+
+func TestSetTableStyleColor(t *testing.T) {
+	for _, color := range []bool{true, false} {
+		tw := table.NewWriter()
+		setTableStyle(tw, "colored", color, false)
+		tw.AppendHeader(table.Row{"Image"})
+		tw.AppendRow(table.Row{"nginx:1.21"})
+
+		if hasANSI := strings.Contains(tw.Render(), "\x1b["); hasANSI != color {
+			t.Errorf("setTableStyle(color=%v) rendered ANSI codes = %v", color, hasANSI)
+		}
+	}
+}
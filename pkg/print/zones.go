@@ -0,0 +1,65 @@
+package print
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pischarti/nix/pkg/vpc"
+)
+
+// PrintSubnetZoneSummary prints the subnet count and available IPs of each zone with a total
+// row. With noHeaders it prints only the per-zone rows.
+func PrintSubnetZoneSummary(summaries []vpc.SubnetZoneSummary, color, noHeaders bool) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if noHeaders {
+		SetNoHeadersStyle(t)
+	} else {
+		SetColoredStyle(t, color)
+		t.AppendHeader(table.Row{"AZ", "Subnets", "Available IPs"})
+	}
+
+	totalSubnets, totalIPs := 0, 0
+	for _, summary := range summaries {
+		t.AppendRow(table.Row{summary.Zone, summary.Subnets, summary.AvailableIPs})
+		totalSubnets += summary.Subnets
+		totalIPs += summary.AvailableIPs
+	}
+	if !noHeaders {
+		t.AppendFooter(table.Row{"Total", totalSubnets, totalIPs})
+	}
+
+	t.Render()
+}
+
+// PrintNLBZoneTable prints the NLBs enabled in each zone, one NLB per line within a zone's
+// row. With noHeaders the names are comma-separated on a single line per zone.
+func PrintNLBZoneTable(coverage []vpc.NLBZoneCoverage, color, noHeaders bool) {
+	if len(coverage) == 0 {
+		if !noHeaders {
+			fmt.Println("No Network Load Balancers found.")
+		}
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	if noHeaders {
+		SetNoHeadersStyle(t)
+	} else {
+		SetColoredStyle(t, color)
+		t.AppendHeader(table.Row{"AZ", "NLBs", "Names"})
+	}
+
+	for _, zone := range coverage {
+		row := table.Row{zone.Zone, len(zone.NLBs), strings.Join(zone.NLBs, "\n")}
+		if noHeaders {
+			row = flattenRow(row)
+		}
+		t.AppendRow(row)
+	}
+
+	t.Render()
+}
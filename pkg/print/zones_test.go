@@ -0,0 +1,31 @@
+package print
+
+import (
+	"testing"
+
+	"github.com/pischarti/nix/pkg/vpc"
+)
+
+func TestPrintSubnetZoneSummary(t *testing.T) {
+	summaries := []vpc.SubnetZoneSummary{
+		{Zone: "us-east-1a", Subnets: 2, AvailableIPs: 500},
+		{Zone: "us-east-1b", Subnets: 1, AvailableIPs: 12},
+	}
+
+	// This test mainly ensures the function doesn't panic
+	PrintSubnetZoneSummary(summaries, false, false)
+	PrintSubnetZoneSummary(summaries, false, true)
+	PrintSubnetZoneSummary(nil, false, false)
+}
+
+func TestPrintNLBZoneTable(t *testing.T) {
+	coverage := []vpc.NLBZoneCoverage{
+		{Zone: "us-east-1a", NLBs: []string{"api", "web"}},
+		{Zone: "us-east-1b", NLBs: []string{"web"}},
+	}
+
+	// This test mainly ensures the function doesn't panic
+	PrintNLBZoneTable(coverage, false, false)
+	PrintNLBZoneTable(coverage, false, true)
+	PrintNLBZoneTable(nil, false, false) // Should print "No Network Load Balancers found."
+}
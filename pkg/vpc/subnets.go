@@ -8,69 +8,123 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/pischarti/nix/pkg/cli"
 )
 
 // ParseSubnetsArgs parses command line arguments for the subnets command
 func ParseSubnetsArgs(args []string) (*SubnetsOptions, error) {
-	opts := &SubnetsOptions{
-		SortBy: "cidr", // Default sort by CIDR
-	}
+	opts := &SubnetsOptions{}
+	var tags []string
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "--vpc":
-			if i+1 < len(args) {
-				i++
-				opts.VPCID = args[i]
-			}
-		case "--zone":
-			if i+1 < len(args) {
-				i++
-				opts.Zone = args[i]
-			}
-		case "--sort":
-			if i+1 < len(args) {
-				i++
-				opts.SortBy = args[i]
-			}
-		}
+	fs := cli.NewFlagSet("subnets")
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID to list subnets for")
+	fs.StringArrayVar(&opts.Zones, "zone", nil, "filter by availability zone (can specify multiple)")
+	fs.StringArrayVar(&tags, "tag", nil, "filter by tag KEY=VALUE (can specify multiple)")
+	fs.StringVar(&opts.State, "state", "", "filter by subnet state")
+	fs.StringVar(&opts.SortBy, "sort", "cidr", "sort by: cidr, az, name, type, available, vpc")
+	fs.StringVar(&opts.Color, "color", "auto", "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.BoolVar(&opts.CheckOverlap, "check-overlap", false, "report subnets with overlapping CIDR blocks")
+	fs.BoolVar(&opts.WithRouting, "with-routing", false, "add route table and public/private/isolated columns")
+	fs.BoolVar(&opts.ByZone, "by-zone", false, "summarize subnet counts and available IPs per zone")
+	fs.BoolVar(&opts.AllVPCs, "all", false, "list subnets in every VPC in the region")
+	fs.StringVar(&opts.OutputFormat, "output", "table", "output format: table, yaml, json")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
 	// Validate sort option
-	validSorts := map[string]bool{"cidr": true, "az": true, "name": true, "type": true}
+	validSorts := map[string]bool{"cidr": true, "az": true, "name": true, "type": true, "available": true, "vpc": true}
 	if !validSorts[opts.SortBy] {
-		return nil, fmt.Errorf("invalid sort option '%s'. Valid options: cidr, az, name, type", opts.SortBy)
+		return nil, fmt.Errorf("invalid sort option '%s'. Valid options: cidr, az, name, type, available, vpc", opts.SortBy)
+	}
+
+	if opts.AllVPCs && opts.VPCID != "" {
+		return nil, fmt.Errorf("--vpc cannot be combined with --all")
+	}
+	// Group the subnets of each VPC together unless another order is asked for
+	if opts.AllVPCs && !fs.Changed("sort") {
+		opts.SortBy = "vpc"
+	}
+
+	if opts.ByZone && opts.WithRouting {
+		return nil, fmt.Errorf("--by-zone cannot be combined with --with-routing")
 	}
 
+	switch opts.OutputFormat {
+	case "table":
+	case "yaml", "json":
+		if opts.ByZone || opts.NoHeaders || opts.CheckOverlap {
+			return nil, fmt.Errorf("--output %s cannot be combined with --by-zone, --no-headers or --check-overlap", opts.OutputFormat)
+		}
+	default:
+		return nil, fmt.Errorf("invalid output format '%s'. Valid options: table, yaml, json", opts.OutputFormat)
+	}
+
+	if err := validateState(opts.State, types.SubnetState("").Values()); err != nil {
+		return nil, err
+	}
+
+	filters, err := ParseTagFilters(tags)
+	if err != nil {
+		return nil, err
+	}
+	opts.Tags = filters
+
 	return opts, nil
 }
 
+// ParseTagFilters parses --tag KEY=VALUE values into one filter per key, in the order the
+// keys first appear. Repeating a key adds a value that also matches.
+func ParseTagFilters(tags []string) ([]TagFilter, error) {
+	var filters []TagFilter
+	index := make(map[string]int)
+	for _, tag := range tags {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag filter '%s': expected KEY=VALUE", tag)
+		}
+		if i, seen := index[key]; seen {
+			filters[i].Values = append(filters[i].Values, value)
+			continue
+		}
+		index[key] = len(filters)
+		filters = append(filters, TagFilter{Key: key, Values: []string{value}})
+	}
+	return filters, nil
+}
+
 // ParseNLBArgs parses command line arguments for the nlb command
 func ParseNLBArgs(args []string) (*NLBOptions, error) {
-	opts := &NLBOptions{
-		SortBy: "name", // Default sort by name
-	}
+	return parseLoadBalancerArgs("nlb", args)
+}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		switch arg {
-		case "--vpc":
-			if i+1 < len(args) {
-				i++
-				opts.VPCID = args[i]
-			}
-		case "--zone":
-			if i+1 < len(args) {
-				i++
-				opts.Zone = args[i]
-			}
-		case "--sort":
-			if i+1 < len(args) {
-				i++
-				opts.SortBy = args[i]
-			}
-		}
+// ParseALBArgs parses command line arguments for the alb command, which takes the same
+// options as the nlb command
+func ParseALBArgs(args []string) (*NLBOptions, error) {
+	return parseLoadBalancerArgs("alb", args)
+}
+
+// parseLoadBalancerArgs parses the load balancer listing options of the command name
+func parseLoadBalancerArgs(name string, args []string) (*NLBOptions, error) {
+	opts := &NLBOptions{}
+
+	fs := cli.NewFlagSet(name)
+	fs.StringVar(&opts.VPCID, "vpc", "", "VPC ID to list NLBs for")
+	fs.StringArrayVar(&opts.Zones, "zone", nil, "filter by availability zone (can specify multiple)")
+	fs.StringVar(&opts.State, "state", "", "filter by load balancer state")
+	fs.StringVar(&opts.SortBy, "sort", "name", "sort by: name, state, type, scheme, created")
+	fs.BoolVar(&opts.WithDetails, "with-details", false, "add cross-zone and access log columns")
+	fs.BoolVar(&opts.NoTags, "no-tags", false, "skip fetching tags")
+	fs.BoolVar(&opts.ByZone, "by-zone", false, "list the NLBs covering each zone")
+	fs.StringVar(&opts.Color, "color", "auto", "color table output: auto, always, never")
+	fs.BoolVar(&opts.NoHeaders, "no-headers", false, "print table rows without headers or borders")
+	fs.StringVar(&opts.OutputFormat, "output", "table", "output format: table, yaml, json")
+	fs.BoolVarP(&opts.Verbose, "verbose", "v", false, "log AWS API calls")
+	if err := cli.Parse(fs, args); err != nil {
+		return nil, err
 	}
 
 	// Validate sort option
@@ -79,9 +133,44 @@ func ParseNLBArgs(args []string) (*NLBOptions, error) {
 		return nil, fmt.Errorf("invalid sort option '%s'. Valid options: name, state, type, scheme, created", opts.SortBy)
 	}
 
+	if opts.ByZone && opts.WithDetails {
+		return nil, fmt.Errorf("--by-zone cannot be combined with --with-details")
+	}
+
+	switch opts.OutputFormat {
+	case "table":
+	case "yaml", "json":
+		if opts.ByZone || opts.NoHeaders {
+			return nil, fmt.Errorf("--output %s cannot be combined with --by-zone or --no-headers", opts.OutputFormat)
+		}
+	default:
+		return nil, fmt.Errorf("invalid output format '%s'. Valid options: table, yaml, json", opts.OutputFormat)
+	}
+
+	if err := validateState(opts.State, elbv2types.LoadBalancerStateEnum("").Values()); err != nil {
+		return nil, err
+	}
+
 	return opts, nil
 }
 
+// validateState checks a --state value against the states AWS reports for the resource.
+// An empty state means no filter.
+func validateState[T ~string](state string, valid []T) error {
+	if state == "" {
+		return nil
+	}
+
+	names := make([]string, len(valid))
+	for i, v := range valid {
+		if string(v) == state {
+			return nil
+		}
+		names[i] = string(v)
+	}
+	return fmt.Errorf("invalid state '%s'. Valid states: %s", state, strings.Join(names, ", "))
+}
+
 // SortSubnets sorts a slice of SubnetInfo based on the specified sort criteria
 func SortSubnets(subnets []SubnetInfo, sortBy string) {
 	switch sortBy {
@@ -101,6 +190,19 @@ func SortSubnets(subnets []SubnetInfo, sortBy string) {
 		sort.Slice(subnets, func(i, j int) bool {
 			return subnets[i].Type < subnets[j].Type
 		})
+	case "vpc":
+		// By VPC, then in network order within each VPC
+		sort.Slice(subnets, func(i, j int) bool {
+			if subnets[i].VPCID != subnets[j].VPCID {
+				return subnets[i].VPCID < subnets[j].VPCID
+			}
+			return CompareCIDRBlocks(subnets[i].CIDRBlock, subnets[j].CIDRBlock) < 0
+		})
+	case "available":
+		// Most free addresses first
+		sort.Slice(subnets, func(i, j int) bool {
+			return subnets[i].AvailableIPs > subnets[j].AvailableIPs
+		})
 	}
 }
 
@@ -149,6 +251,34 @@ func CompareCIDRBlocks(cidr1, cidr2 string) int {
 	return 0
 }
 
+// FindOverlappingSubnets returns every pair of subnets in the same VPC whose CIDR blocks
+// overlap. Subnets whose CIDR block cannot be parsed are skipped.
+func FindOverlappingSubnets(subnets []SubnetInfo) []SubnetOverlap {
+	networks := make([]*net.IPNet, len(subnets))
+	for i, subnet := range subnets {
+		if _, ipNet, err := net.ParseCIDR(subnet.CIDRBlock); err == nil {
+			networks[i] = ipNet
+		}
+	}
+
+	var overlaps []SubnetOverlap
+	for i := range subnets {
+		for j := i + 1; j < len(subnets); j++ {
+			a, b := networks[i], networks[j]
+			// Separate VPCs are separate address spaces
+			if a == nil || b == nil || subnets[i].VPCID != subnets[j].VPCID {
+				continue
+			}
+			// Two CIDR blocks overlap exactly when one contains the other's network address
+			if a.Contains(b.IP) || b.Contains(a.IP) {
+				overlaps = append(overlaps, SubnetOverlap{First: subnets[i], Second: subnets[j]})
+			}
+		}
+	}
+
+	return overlaps
+}
+
 // ConvertEC2SubnetsToSubnetInfo converts AWS EC2 subnet types to SubnetInfo structs
 func ConvertEC2SubnetsToSubnetInfo(ec2Subnets []types.Subnet) []SubnetInfo {
 	var subnets []SubnetInfo
@@ -185,13 +315,15 @@ func ConvertEC2SubnetsToSubnetInfo(ec2Subnets []types.Subnet) []SubnetInfo {
 		tagsStr := strings.Join(relevantTags, "\n")
 
 		subnetInfo := SubnetInfo{
-			SubnetID:  aws.ToString(subnet.SubnetId),
-			CIDRBlock: aws.ToString(subnet.CidrBlock),
-			AZ:        aws.ToString(subnet.AvailabilityZone),
-			Name:      name,
-			State:     string(subnet.State),
-			Type:      subnetType,
-			Tags:      tagsStr,
+			SubnetID:     aws.ToString(subnet.SubnetId),
+			VPCID:        aws.ToString(subnet.VpcId),
+			CIDRBlock:    aws.ToString(subnet.CidrBlock),
+			AZ:           aws.ToString(subnet.AvailabilityZone),
+			Name:         name,
+			State:        string(subnet.State),
+			Type:         subnetType,
+			Tags:         tagsStr,
+			AvailableIPs: aws.ToInt32(subnet.AvailableIpAddressCount),
 		}
 		subnets = append(subnets, subnetInfo)
 	}
@@ -199,6 +331,47 @@ func ConvertEC2SubnetsToSubnetInfo(ec2Subnets []types.Subnet) []SubnetInfo {
 	return subnets
 }
 
+// Subnet routing classifications, derived from the default route of the subnet's route table
+const (
+	// RoutingPublic subnets send their default route to an internet gateway
+	RoutingPublic = "Public"
+	// RoutingPrivate subnets send their default route elsewhere, typically a NAT gateway
+	RoutingPrivate = "Private"
+	// RoutingIsolated subnets have no usable default route
+	RoutingIsolated = "Isolated"
+)
+
+// ClassifyRouteTable returns RoutingPublic, RoutingPrivate or RoutingIsolated from the target
+// of the route table's IPv4 (or, failing that, IPv6) default route. Blackholed routes are ignored.
+func ClassifyRouteTable(routeTable types.RouteTable) string {
+	var ipv4, ipv6 *types.Route
+	for i, route := range routeTable.Routes {
+		if route.State == types.RouteStateBlackhole {
+			continue
+		}
+		switch {
+		case aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0":
+			ipv4 = &routeTable.Routes[i]
+		case aws.ToString(route.DestinationIpv6CidrBlock) == "::/0":
+			ipv6 = &routeTable.Routes[i]
+		}
+	}
+
+	defaultRoute := ipv4
+	if defaultRoute == nil {
+		defaultRoute = ipv6
+	}
+	if defaultRoute == nil {
+		return RoutingIsolated
+	}
+
+	// Egress-only internet gateways use the "eigw-" prefix and only allow outbound traffic
+	if strings.HasPrefix(aws.ToString(defaultRoute.GatewayId), "igw-") {
+		return RoutingPublic
+	}
+	return RoutingPrivate
+}
+
 // SortNLBs sorts a slice of NLBInfo based on the specified sort criteria
 func SortNLBs(nlbs []NLBInfo, sortBy string) {
 	switch sortBy {
@@ -1,6 +1,8 @@
 package vpc
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,17 +21,46 @@ func TestParseSubnetsArgs(t *testing.T) {
 			args: []string{"--vpc", "vpc-12345678"},
 			expected: &SubnetsOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "",
 				SortBy: "cidr",
 			},
 			expectError: false,
 		},
+		{
+			name: "no headers",
+			args: []string{"--vpc", "vpc-12345678", "--no-headers"},
+			expected: &SubnetsOptions{
+				VPCID:     "vpc-12345678",
+				SortBy:    "cidr",
+				NoHeaders: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "check overlap",
+			args: []string{"--vpc", "vpc-12345678", "--check-overlap"},
+			expected: &SubnetsOptions{
+				VPCID:        "vpc-12345678",
+				SortBy:       "cidr",
+				CheckOverlap: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "with routing",
+			args: []string{"--vpc", "vpc-12345678", "--with-routing"},
+			expected: &SubnetsOptions{
+				VPCID:       "vpc-12345678",
+				SortBy:      "cidr",
+				WithRouting: true,
+			},
+			expectError: false,
+		},
 		{
 			name: "valid args with vpc and zone",
 			args: []string{"--vpc", "vpc-12345678", "--zone", "us-east-1a"},
 			expected: &SubnetsOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "us-east-1a",
+				Zones:  []string{"us-east-1a"},
 				SortBy: "cidr",
 			},
 			expectError: false,
@@ -39,7 +70,7 @@ func TestParseSubnetsArgs(t *testing.T) {
 			args: []string{"--vpc", "vpc-12345678", "--zone", "us-east-1a", "--sort", "az"},
 			expected: &SubnetsOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "us-east-1a",
+				Zones:  []string{"us-east-1a"},
 				SortBy: "az",
 			},
 			expectError: false,
@@ -49,7 +80,6 @@ func TestParseSubnetsArgs(t *testing.T) {
 			args: []string{"--vpc", "vpc-12345678", "--sort", "name"},
 			expected: &SubnetsOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "",
 				SortBy: "name",
 			},
 			expectError: false,
@@ -59,23 +89,113 @@ func TestParseSubnetsArgs(t *testing.T) {
 			args: []string{"--vpc", "vpc-12345678", "--sort", "type"},
 			expected: &SubnetsOptions{
 				VPCID:  "vpc-12345678",
-				Zone:   "",
 				SortBy: "type",
 			},
 			expectError: false,
 		},
+		{
+			name: "all VPCs sorts by VPC by default",
+			args: []string{"--all"},
+			expected: &SubnetsOptions{
+				AllVPCs: true,
+				SortBy:  "vpc",
+			},
+			expectError: false,
+		},
+		{
+			name: "all VPCs keeps an explicit sort",
+			args: []string{"--all", "--sort", "available"},
+			expected: &SubnetsOptions{
+				AllVPCs: true,
+				SortBy:  "available",
+			},
+			expectError: false,
+		},
+		{
+			name:        "vpc with all",
+			args:        []string{"--vpc", "vpc-12345678", "--all"},
+			expected:    nil,
+			expectError: true,
+		},
+		{
+			name: "valid sort options - available",
+			args: []string{"--vpc", "vpc-12345678", "--sort", "available"},
+			expected: &SubnetsOptions{
+				VPCID:  "vpc-12345678",
+				SortBy: "available",
+			},
+			expectError: false,
+		},
+		{
+			name: "repeated zones",
+			args: []string{"--vpc", "vpc-12345678", "--zone", "us-east-1a", "--zone", "us-east-1c"},
+			expected: &SubnetsOptions{
+				VPCID:  "vpc-12345678",
+				Zones:  []string{"us-east-1a", "us-east-1c"},
+				SortBy: "cidr",
+			},
+			expectError: false,
+		},
+		{
+			name: "by zone",
+			args: []string{"--vpc", "vpc-12345678", "--by-zone"},
+			expected: &SubnetsOptions{
+				VPCID:  "vpc-12345678",
+				SortBy: "cidr",
+				ByZone: true,
+			},
+			expectError: false,
+		},
+		{
+			name:        "by zone with routing",
+			args:        []string{"--vpc", "vpc-12345678", "--by-zone", "--with-routing"},
+			expected:    nil,
+			expectError: true,
+		},
 		{
 			name:        "invalid sort option",
 			args:        []string{"--vpc", "vpc-12345678", "--sort", "invalid"},
 			expected:    nil,
 			expectError: true,
 		},
+		{
+			name: "state filter",
+			args: []string{"--vpc", "vpc-12345678", "--state", "pending"},
+			expected: &SubnetsOptions{
+				VPCID:  "vpc-12345678",
+				State:  "pending",
+				SortBy: "cidr",
+			},
+			expectError: false,
+		},
+		{
+			name:        "invalid state",
+			args:        []string{"--vpc", "vpc-12345678", "--state", "active"},
+			expected:    nil,
+			expectError: true,
+		},
+		{
+			name: "tag filters with zone",
+			args: []string{"--vpc", "vpc-12345678", "--tag", "Tier=private", "--zone", "us-east-1a", "--tag", "Tier=database", "--tag", "Env="},
+			expected: &SubnetsOptions{
+				VPCID:  "vpc-12345678",
+				Zones:  []string{"us-east-1a"},
+				Tags:   []TagFilter{{Key: "Tier", Values: []string{"private", "database"}}, {Key: "Env", Values: []string{""}}},
+				SortBy: "cidr",
+			},
+			expectError: false,
+		},
+		{
+			name:        "tag without value",
+			args:        []string{"--vpc", "vpc-12345678", "--tag", "Tier"},
+			expected:    nil,
+			expectError: true,
+		},
 		{
 			name: "empty args",
 			args: []string{},
 			expected: &SubnetsOptions{
 				VPCID:  "",
-				Zone:   "",
 				SortBy: "cidr",
 			},
 			expectError: false,
@@ -101,12 +221,100 @@ func TestParseSubnetsArgs(t *testing.T) {
 			if result.VPCID != tt.expected.VPCID {
 				t.Errorf("VPCID = %v, want %v", result.VPCID, tt.expected.VPCID)
 			}
-			if result.Zone != tt.expected.Zone {
-				t.Errorf("Zone = %v, want %v", result.Zone, tt.expected.Zone)
+			if !slices.Equal(result.Zones, tt.expected.Zones) {
+				t.Errorf("Zones = %v, want %v", result.Zones, tt.expected.Zones)
+			}
+			if !reflect.DeepEqual(result.Tags, tt.expected.Tags) {
+				t.Errorf("Tags = %v, want %v", result.Tags, tt.expected.Tags)
+			}
+			if result.State != tt.expected.State {
+				t.Errorf("State = %v, want %v", result.State, tt.expected.State)
 			}
 			if result.SortBy != tt.expected.SortBy {
 				t.Errorf("SortBy = %v, want %v", result.SortBy, tt.expected.SortBy)
 			}
+			if result.NoHeaders != tt.expected.NoHeaders {
+				t.Errorf("NoHeaders = %v, want %v", result.NoHeaders, tt.expected.NoHeaders)
+			}
+			if result.CheckOverlap != tt.expected.CheckOverlap {
+				t.Errorf("CheckOverlap = %v, want %v", result.CheckOverlap, tt.expected.CheckOverlap)
+			}
+			if result.AllVPCs != tt.expected.AllVPCs {
+				t.Errorf("AllVPCs = %v, want %v", result.AllVPCs, tt.expected.AllVPCs)
+			}
+			if result.ByZone != tt.expected.ByZone {
+				t.Errorf("ByZone = %v, want %v", result.ByZone, tt.expected.ByZone)
+			}
+		})
+	}
+}
+
+func TestParseSubnetsArgsOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectError bool
+	}{
+		{name: "default table", args: []string{"--vpc", "vpc-12345678"}, expected: "table"},
+		{name: "yaml", args: []string{"--vpc", "vpc-12345678", "--output", "yaml"}, expected: "yaml"},
+		{name: "json with routing", args: []string{"--vpc", "vpc-12345678", "--output", "json", "--with-routing"}, expected: "json"},
+		{name: "invalid format", args: []string{"--vpc", "vpc-12345678", "--output", "xml"}, expectError: true},
+		{name: "json with by zone", args: []string{"--vpc", "vpc-12345678", "--output", "json", "--by-zone"}, expectError: true},
+		{name: "yaml with check overlap", args: []string{"--vpc", "vpc-12345678", "--output", "yaml", "--check-overlap"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSubnetsArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.OutputFormat != tt.expected {
+				t.Errorf("OutputFormat = %v, want %v", result.OutputFormat, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseNLBArgsOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectError bool
+	}{
+		{name: "default table", args: []string{"--vpc", "vpc-12345678"}, expected: "table"},
+		{name: "json", args: []string{"--vpc", "vpc-12345678", "--output", "json"}, expected: "json"},
+		{name: "yaml with details", args: []string{"--vpc", "vpc-12345678", "--output", "yaml", "--with-details"}, expected: "yaml"},
+		{name: "invalid format", args: []string{"--vpc", "vpc-12345678", "--output", "csv"}, expectError: true},
+		{name: "json with by zone", args: []string{"--vpc", "vpc-12345678", "--output", "json", "--by-zone"}, expectError: true},
+		{name: "yaml with no headers", args: []string{"--vpc", "vpc-12345678", "--output", "yaml", "--no-headers"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseNLBArgs(tt.args)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.OutputFormat != tt.expected {
+				t.Errorf("OutputFormat = %v, want %v", result.OutputFormat, tt.expected)
+			}
 		})
 	}
 }
@@ -174,6 +382,34 @@ func TestSortSubnets(t *testing.T) {
 				{CIDRBlock: "10.0.2.0/24", AZ: "us-east-1b", Name: "subnet2", Type: "public", Tags: ""},
 			},
 		},
+		{
+			name: "sort by VPC then CIDR",
+			subnets: []SubnetInfo{
+				{CIDRBlock: "10.0.2.0/24", AZ: "us-east-1b", VPCID: "vpc-b"},
+				{CIDRBlock: "10.0.10.0/24", AZ: "us-east-1a", VPCID: "vpc-a"},
+				{CIDRBlock: "10.0.9.0/24", AZ: "us-east-1c", VPCID: "vpc-a"},
+			},
+			sortBy: "vpc",
+			expected: []SubnetInfo{
+				{CIDRBlock: "10.0.9.0/24", AZ: "us-east-1c", VPCID: "vpc-a"},
+				{CIDRBlock: "10.0.10.0/24", AZ: "us-east-1a", VPCID: "vpc-a"},
+				{CIDRBlock: "10.0.2.0/24", AZ: "us-east-1b", VPCID: "vpc-b"},
+			},
+		},
+		{
+			name: "sort by available IPs",
+			subnets: []SubnetInfo{
+				{CIDRBlock: "10.0.1.0/24", AZ: "us-east-1a", AvailableIPs: 12},
+				{CIDRBlock: "10.0.2.0/24", AZ: "us-east-1b", AvailableIPs: 250},
+				{CIDRBlock: "10.0.3.0/24", AZ: "us-east-1c", AvailableIPs: 0},
+			},
+			sortBy: "available",
+			expected: []SubnetInfo{
+				{CIDRBlock: "10.0.2.0/24", AZ: "us-east-1b", AvailableIPs: 250},
+				{CIDRBlock: "10.0.1.0/24", AZ: "us-east-1a", AvailableIPs: 12},
+				{CIDRBlock: "10.0.3.0/24", AZ: "us-east-1c", AvailableIPs: 0},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -210,6 +446,128 @@ func TestSortSubnets(t *testing.T) {
 	}
 }
 
+func TestFindOverlappingSubnets(t *testing.T) {
+	tests := []struct {
+		name     string
+		subnets  []SubnetInfo
+		expected [][2]string
+	}{
+		{
+			name: "no overlap",
+			subnets: []SubnetInfo{
+				{SubnetID: "subnet-a", CIDRBlock: "10.0.0.0/24"},
+				{SubnetID: "subnet-b", CIDRBlock: "10.0.1.0/24"},
+			},
+		},
+		{
+			name: "duplicate CIDR",
+			subnets: []SubnetInfo{
+				{SubnetID: "subnet-a", CIDRBlock: "10.0.0.0/24"},
+				{SubnetID: "subnet-b", CIDRBlock: "10.0.0.0/24"},
+			},
+			expected: [][2]string{{"subnet-a", "subnet-b"}},
+		},
+		{
+			name: "larger block contains smaller",
+			subnets: []SubnetInfo{
+				{SubnetID: "subnet-a", CIDRBlock: "10.0.2.128/25"},
+				{SubnetID: "subnet-b", CIDRBlock: "10.0.1.0/24"},
+				{SubnetID: "subnet-c", CIDRBlock: "10.0.0.0/22"},
+			},
+			expected: [][2]string{{"subnet-a", "subnet-c"}, {"subnet-b", "subnet-c"}},
+		},
+		{
+			name: "same CIDR in different VPCs",
+			subnets: []SubnetInfo{
+				{SubnetID: "subnet-a", VPCID: "vpc-1", CIDRBlock: "10.0.0.0/24"},
+				{SubnetID: "subnet-b", VPCID: "vpc-2", CIDRBlock: "10.0.0.0/24"},
+			},
+		},
+		{
+			name: "invalid CIDR is skipped",
+			subnets: []SubnetInfo{
+				{SubnetID: "subnet-a", CIDRBlock: "invalid"},
+				{SubnetID: "subnet-b", CIDRBlock: "10.0.0.0/24"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlaps := FindOverlappingSubnets(tt.subnets)
+			if len(overlaps) != len(tt.expected) {
+				t.Fatalf("FindOverlappingSubnets() returned %d overlaps, want %d: %+v", len(overlaps), len(tt.expected), overlaps)
+			}
+			for i, overlap := range overlaps {
+				if overlap.First.SubnetID != tt.expected[i][0] || overlap.Second.SubnetID != tt.expected[i][1] {
+					t.Errorf("overlap %d = %s/%s, want %s/%s", i, overlap.First.SubnetID, overlap.Second.SubnetID, tt.expected[i][0], tt.expected[i][1])
+				}
+			}
+		})
+	}
+}
+
+func TestClassifyRouteTable(t *testing.T) {
+	local := types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), State: types.RouteStateActive}
+
+	tests := []struct {
+		name   string
+		routes []types.Route
+		want   string
+	}{
+		{
+			name: "internet gateway",
+			routes: []types.Route{local,
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-0abc"), State: types.RouteStateActive}},
+			want: RoutingPublic,
+		},
+		{
+			name: "NAT gateway",
+			routes: []types.Route{local,
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-0abc"), State: types.RouteStateActive}},
+			want: RoutingPrivate,
+		},
+		{
+			name: "transit gateway",
+			routes: []types.Route{local,
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), TransitGatewayId: aws.String("tgw-0abc"), State: types.RouteStateActive}},
+			want: RoutingPrivate,
+		},
+		{
+			name:   "local only",
+			routes: []types.Route{local},
+			want:   RoutingIsolated,
+		},
+		{
+			name: "blackholed NAT route",
+			routes: []types.Route{local,
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-0gone"), State: types.RouteStateBlackhole}},
+			want: RoutingIsolated,
+		},
+		{
+			name: "IPv6-only internet gateway",
+			routes: []types.Route{local,
+				{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-0abc"), State: types.RouteStateActive}},
+			want: RoutingPublic,
+		},
+		{
+			name: "egress-only internet gateway",
+			routes: []types.Route{local,
+				{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-0abc"), State: types.RouteStateActive}},
+			want: RoutingPrivate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyRouteTable(types.RouteTable{Routes: tt.routes})
+			if got != tt.want {
+				t.Errorf("ClassifyRouteTable() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCompareCIDRBlocks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -281,11 +639,12 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 			name: "basic conversion with tags",
 			ec2Subnets: []types.Subnet{
 				{
-					SubnetId:         aws.String("subnet-12345678"),
-					VpcId:            aws.String("vpc-12345678"),
-					CidrBlock:        aws.String("10.0.1.0/24"),
-					AvailabilityZone: aws.String("us-east-1a"),
-					State:            types.SubnetStateAvailable,
+					SubnetId:                aws.String("subnet-12345678"),
+					VpcId:                   aws.String("vpc-12345678"),
+					CidrBlock:               aws.String("10.0.1.0/24"),
+					AvailabilityZone:        aws.String("us-east-1a"),
+					State:                   types.SubnetStateAvailable,
+					AvailableIpAddressCount: aws.Int32(247),
 					Tags: []types.Tag{
 						{Key: aws.String("Name"), Value: aws.String("test-subnet")},
 						{Key: aws.String("Type"), Value: aws.String("private")},
@@ -296,13 +655,15 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 			},
 			expected: []SubnetInfo{
 				{
-					SubnetID:  "subnet-12345678",
-					CIDRBlock: "10.0.1.0/24",
-					AZ:        "us-east-1a",
-					Name:      "test-subnet",
-					State:     "available",
-					Type:      "private",
-					Tags:      "kubernetes.io/role/elb\nEnvironment",
+					SubnetID:     "subnet-12345678",
+					VPCID:        "vpc-12345678",
+					CIDRBlock:    "10.0.1.0/24",
+					AZ:           "us-east-1a",
+					Name:         "test-subnet",
+					State:        "available",
+					Type:         "private",
+					Tags:         "kubernetes.io/role/elb\nEnvironment",
+					AvailableIPs: 247,
 				},
 			},
 		},
@@ -321,6 +682,7 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 			expected: []SubnetInfo{
 				{
 					SubnetID:  "subnet-87654321",
+					VPCID:     "vpc-87654321",
 					CIDRBlock: "10.0.2.0/24",
 					AZ:        "us-east-1b",
 					Name:      "",
@@ -349,6 +711,7 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 			expected: []SubnetInfo{
 				{
 					SubnetID:  "subnet-11111111",
+					VPCID:     "vpc-11111111",
 					CIDRBlock: "10.0.3.0/24",
 					AZ:        "us-east-1c",
 					Name:      "named-subnet",
@@ -379,6 +742,9 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 				if subnet.SubnetID != expected.SubnetID {
 					t.Errorf("SubnetID[%d] = %v, want %v", i, subnet.SubnetID, expected.SubnetID)
 				}
+				if subnet.VPCID != expected.VPCID {
+					t.Errorf("VPCID[%d] = %v, want %v", i, subnet.VPCID, expected.VPCID)
+				}
 				if subnet.CIDRBlock != expected.CIDRBlock {
 					t.Errorf("CIDRBlock[%d] = %v, want %v", i, subnet.CIDRBlock, expected.CIDRBlock)
 				}
@@ -397,6 +763,9 @@ func TestConvertEC2SubnetsToSubnetInfo(t *testing.T) {
 				if subnet.Tags != expected.Tags {
 					t.Errorf("Tags[%d] = %v, want %v", i, subnet.Tags, expected.Tags)
 				}
+				if subnet.AvailableIPs != expected.AvailableIPs {
+					t.Errorf("AvailableIPs[%d] = %v, want %v", i, subnet.AvailableIPs, expected.AvailableIPs)
+				}
 			}
 		})
 	}
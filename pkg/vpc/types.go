@@ -1,21 +1,56 @@
 package vpc
 
-// SubnetInfo represents information about an AWS subnet
+// SubnetInfo represents information about an AWS subnet. The JSON keys are also used for
+// YAML output.
 type SubnetInfo struct {
-	SubnetID  string
-	CIDRBlock string
-	AZ        string
-	Name      string
-	State     string
-	Type      string
-	Tags      string
+	SubnetID  string `json:"subnetId"`
+	VPCID     string `json:"vpcId"`
+	CIDRBlock string `json:"cidrBlock"`
+	AZ        string `json:"availabilityZone"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Type      string `json:"type"`
+	Tags      string `json:"tags"`
+	// AvailableIPs is the number of unused private IPv4 addresses in the subnet
+	AvailableIPs int32 `json:"availableIps"`
+	// RouteTableID and Routing are only set when the listing resolves routing
+	RouteTableID string `json:"routeTableId,omitempty"`
+	Routing      string `json:"routing,omitempty"`
+}
+
+// SubnetOverlap is a pair of subnets whose CIDR blocks overlap
+type SubnetOverlap struct {
+	First  SubnetInfo
+	Second SubnetInfo
+}
+
+// TagFilter matches resources whose tag Key has one of Values
+type TagFilter struct {
+	Key    string
+	Values []string
 }
 
 // SubnetsOptions represents the parsed command line options for the subnets command
 type SubnetsOptions struct {
-	VPCID  string
-	Zone   string
-	SortBy string
+	VPCID string
+	// Zones filters by availability zone; a subnet in any of them is listed
+	Zones []string
+	// Tags filters by tag; a subnet must match every key, with any of that key's values
+	Tags         []TagFilter
+	State        string
+	SortBy       string
+	Color        string
+	NoHeaders    bool
+	CheckOverlap bool
+	// WithRouting resolves each subnet's route table and classifies it by its default route
+	WithRouting bool
+	// ByZone prints a per-zone summary instead of the subnet table
+	ByZone bool
+	// AllVPCs lists the subnets of every VPC in the region instead of those of VPCID
+	AllVPCs bool
+	// OutputFormat is table, yaml or json
+	OutputFormat string
+	Verbose      bool
 }
 
 // NLBInfo represents information about an AWS Network Load Balancer
@@ -31,11 +66,40 @@ type NLBInfo struct {
 	Subnets           string
 	CreatedTime       string
 	Tags              string
+	CrossZone         string
+	AccessLogs        string
+	AccessLogsBucket  string
 }
 
 // NLBOptions represents the parsed command line options for the nlb command
 type NLBOptions struct {
-	VPCID  string
-	Zone   string
-	SortBy string
+	VPCID string
+	// Zones filters by availability zone; an NLB enabled in any of them is listed
+	Zones       []string
+	State       string
+	SortBy      string
+	WithDetails bool
+	// NoTags skips DescribeTags, leaving the Name tag and Tags column unset
+	NoTags bool
+	// ByZone prints which NLBs cover each zone instead of the NLB table
+	ByZone    bool
+	Color     string
+	NoHeaders bool
+	// OutputFormat is table, yaml or json
+	OutputFormat string
+	Verbose      bool
+}
+
+// SubnetZoneSummary is the number of subnets in an availability zone and their combined
+// available IP addresses
+type SubnetZoneSummary struct {
+	Zone         string
+	Subnets      int
+	AvailableIPs int
+}
+
+// NLBZoneCoverage lists the NLBs enabled in an availability zone
+type NLBZoneCoverage struct {
+	Zone string
+	NLBs []string
 }
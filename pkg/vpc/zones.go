@@ -0,0 +1,67 @@
+package vpc
+
+import (
+	"sort"
+	"strings"
+)
+
+// SummarizeSubnetsByZone groups subnets by availability zone, counting the subnets and
+// totalling their available IPs, sorted by zone
+func SummarizeSubnetsByZone(subnets []SubnetInfo) []SubnetZoneSummary {
+	byZone := make(map[string]*SubnetZoneSummary)
+	var summaries []*SubnetZoneSummary
+	for _, subnet := range subnets {
+		summary, ok := byZone[subnet.AZ]
+		if !ok {
+			summary = &SubnetZoneSummary{Zone: subnet.AZ}
+			byZone[subnet.AZ] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Subnets++
+		summary.AvailableIPs += int(subnet.AvailableIPs)
+	}
+
+	result := make([]SubnetZoneSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Zone < result[j].Zone
+	})
+	return result
+}
+
+// GroupNLBsByZone lists the NLBs enabled in each availability zone, sorted by zone and then
+// by name. NLBs without a Name are listed by ARN. When zones is set, only those zones are
+// included.
+func GroupNLBsByZone(nlbs []NLBInfo, zones []string) []NLBZoneCoverage {
+	wanted := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		wanted[zone] = true
+	}
+
+	byZone := make(map[string][]string)
+	for _, nlb := range nlbs {
+		name := nlb.Name
+		if name == "" {
+			name = nlb.LoadBalancerArn
+		}
+		for _, zone := range strings.Split(nlb.AvailabilityZones, ", ") {
+			zone = strings.TrimSpace(zone)
+			if zone == "" || (len(wanted) > 0 && !wanted[zone]) {
+				continue
+			}
+			byZone[zone] = append(byZone[zone], name)
+		}
+	}
+
+	coverage := make([]NLBZoneCoverage, 0, len(byZone))
+	for zone, names := range byZone {
+		sort.Strings(names)
+		coverage = append(coverage, NLBZoneCoverage{Zone: zone, NLBs: names})
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Zone < coverage[j].Zone
+	})
+	return coverage
+}
@@ -0,0 +1,66 @@
+package vpc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeSubnetsByZone(t *testing.T) {
+	subnets := []SubnetInfo{
+		{SubnetID: "subnet-1", AZ: "us-east-1b", AvailableIPs: 100},
+		{SubnetID: "subnet-2", AZ: "us-east-1a", AvailableIPs: 250},
+		{SubnetID: "subnet-3", AZ: "us-east-1b", AvailableIPs: 20},
+	}
+
+	want := []SubnetZoneSummary{
+		{Zone: "us-east-1a", Subnets: 1, AvailableIPs: 250},
+		{Zone: "us-east-1b", Subnets: 2, AvailableIPs: 120},
+	}
+	if got := SummarizeSubnetsByZone(subnets); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeSubnetsByZone() = %+v, want %+v", got, want)
+	}
+
+	if got := SummarizeSubnetsByZone(nil); len(got) != 0 {
+		t.Errorf("SummarizeSubnetsByZone(nil) = %+v, want empty", got)
+	}
+}
+
+func TestGroupNLBsByZone(t *testing.T) {
+	nlbs := []NLBInfo{
+		{Name: "web", AvailabilityZones: "us-east-1a, us-east-1b"},
+		{Name: "api", AvailabilityZones: "us-east-1b, us-east-1c"},
+		{LoadBalancerArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/internal/abc", AvailabilityZones: "us-east-1a"},
+		{Name: "pending", AvailabilityZones: ""},
+	}
+
+	tests := []struct {
+		name  string
+		zones []string
+		want  []NLBZoneCoverage
+	}{
+		{
+			name: "all zones",
+			want: []NLBZoneCoverage{
+				{Zone: "us-east-1a", NLBs: []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/internal/abc", "web"}},
+				{Zone: "us-east-1b", NLBs: []string{"api", "web"}},
+				{Zone: "us-east-1c", NLBs: []string{"api"}},
+			},
+		},
+		{
+			name:  "filtered zones",
+			zones: []string{"us-east-1c", "us-east-1b"},
+			want: []NLBZoneCoverage{
+				{Zone: "us-east-1b", NLBs: []string{"api", "web"}},
+				{Zone: "us-east-1c", NLBs: []string{"api"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupNLBsByZone(nlbs, tt.zones); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupNLBsByZone() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}